@@ -0,0 +1,88 @@
+package bitbucket
+
+import (
+	"context"
+	"io"
+
+	"bitbucket-cli/internal/domain"
+)
+
+// BitbucketAPI is the set of calls the TUI makes against Bitbucket. Client
+// implements it over HTTP; the fake package provides an in-memory version.
+type BitbucketAPI interface {
+	ListProjects(ctx context.Context) (string, []domain.Project, error)
+	GetCurrentUser(ctx context.Context) (domain.User, error)
+	GetWorkspace(ctx context.Context) (domain.Workspace, error)
+	ListWorkspaceMembers(ctx context.Context) ([]domain.User, error)
+	ListRepositories(ctx context.Context) ([]domain.Repository, error)
+	GetRepository(ctx context.Context, repoSlug string) (domain.Repository, error)
+	ListRepositoryPage(ctx context.Context, pageNumber int) ([]domain.Repository, bool, error)
+	ListProjectRepositories(ctx context.Context, repositoriesURL string) ([]domain.Repository, error)
+	ListBranches(ctx context.Context, repoSlug string) ([]domain.Branch, error)
+	GetBranch(ctx context.Context, repoSlug, name string) (domain.Branch, error)
+	CreateBranch(ctx context.Context, repoSlug, name, targetHash string) (domain.Branch, error)
+	DeleteBranch(ctx context.Context, repoSlug, name string) error
+	ListTags(ctx context.Context, repoSlug string) ([]domain.Tag, error)
+	CreateTag(ctx context.Context, repoSlug, name, targetHash string) (domain.Tag, error)
+	DeleteTag(ctx context.Context, repoSlug, name string) error
+	ListPullRequests(ctx context.Context, repoSlug string) ([]domain.PullRequest, error)
+	ListPullRequestsByState(ctx context.Context, repoSlug, state string) ([]domain.PullRequest, error)
+	ListUserPullRequests(ctx context.Context, userUUID string) ([]domain.PullRequest, error)
+	ListReviewPullRequests(ctx context.Context, repoSlug, userUUID string) ([]domain.PullRequest, error)
+	ListRecentPullRequests(ctx context.Context, repoSlug string) ([]domain.PullRequest, error)
+	ListApprovedPullRequests(ctx context.Context, repoSlug, userUUID string) ([]domain.Approval, error)
+	GetPullRequest(ctx context.Context, repoSlug string, pullRequestID int) (domain.PullRequest, error)
+	ListBranchRestrictions(ctx context.Context, repoSlug string) ([]domain.BranchRestriction, error)
+	ListPullRequestStatuses(ctx context.Context, repoSlug string, pullRequestID int) ([]domain.BuildStatus, error)
+	ListCommitStatuses(ctx context.Context, repoSlug, commitHash string) ([]domain.BuildStatus, error)
+	ListPullRequestActivity(ctx context.Context, repoSlug string, pullRequestID int) ([]domain.PullRequestActivity, error)
+	CreatePullRequest(ctx context.Context, repoSlug string, input domain.NewPullRequest) (domain.PullRequest, error)
+	ListPullRequestComments(ctx context.Context, repoSlug string, pullRequestID int) ([]domain.Comment, error)
+	CreatePullRequestComment(ctx context.Context, repoSlug string, pullRequestID int, content string) (domain.Comment, error)
+	ListPullRequestTasks(ctx context.Context, repoSlug string, pullRequestID int) ([]domain.Task, error)
+	CreatePullRequestTask(ctx context.Context, repoSlug string, pullRequestID int, content string, commentID int) (domain.Task, error)
+	SetPullRequestTaskResolved(ctx context.Context, repoSlug string, pullRequestID, taskID int, resolved bool) (domain.Task, error)
+	CreatePullRequestInlineComment(ctx context.Context, repoSlug string, pullRequestID int, content string, anchor domain.CommentAnchor) (domain.Comment, error)
+	ApprovePullRequest(ctx context.Context, repoSlug string, pullRequestID int) error
+	UnapprovePullRequest(ctx context.Context, repoSlug string, pullRequestID int) error
+	MergePullRequest(ctx context.Context, repoSlug string, pullRequestID int, strategy string) (domain.PullRequest, error)
+	DeclinePullRequest(ctx context.Context, repoSlug string, pullRequestID int) (domain.PullRequest, error)
+	ReopenPullRequest(ctx context.Context, repoSlug string, pullRequestID int) (domain.PullRequest, error)
+	ListPullRequestCommits(ctx context.Context, repoSlug string, pullRequestID int) ([]domain.Commit, error)
+	ListBranchCommits(ctx context.Context, repoSlug, branch string, limit int) ([]domain.Commit, error)
+	ListCommitsBetween(ctx context.Context, repoSlug, include, exclude string, limit int) ([]domain.Commit, bool, error)
+	GetCommit(ctx context.Context, repoSlug, commitHash string) (domain.Commit, error)
+	ListCommitChanges(ctx context.Context, repoSlug, commitHash string) ([]domain.CommitChange, error)
+	GetFileContent(ctx context.Context, repoSlug, commitHash, path string) ([]byte, error)
+	CreateCommit(ctx context.Context, repoSlug string, input domain.NewCommit) error
+	GetCommitDiff(ctx context.Context, repoSlug, commitHash string) (string, error)
+	GetPullRequestDiff(ctx context.Context, repoSlug string, pullRequestID int) (string, error)
+	ListPipelines(ctx context.Context, repoSlug string) ([]domain.Pipeline, error)
+	GetPipeline(ctx context.Context, repoSlug, pipelineUUID string) (domain.Pipeline, error)
+	RerunPipeline(ctx context.Context, repoSlug, pipelineUUID string) (domain.Pipeline, error)
+	ListPipelineSteps(ctx context.Context, repoSlug, pipelineUUID string) ([]domain.PipelineStep, error)
+	GetPipelineStepLog(ctx context.Context, repoSlug, pipelineUUID, stepUUID string) (string, error)
+	GetPipelineStepLogFrom(ctx context.Context, repoSlug, pipelineUUID, stepUUID string, offset int64) (string, error)
+	ListPipelineArtifacts(ctx context.Context, repoSlug, pipelineUUID, stepUUID string) ([]domain.PipelineArtifact, error)
+	DownloadPipelineArtifact(ctx context.Context, repoSlug, pipelineUUID, stepUUID, artifactUUID string, w io.Writer) error
+	ListPipelineSchedules(ctx context.Context, repoSlug string) ([]domain.PipelineSchedule, error)
+	CreatePipelineSchedule(ctx context.Context, repoSlug string, schedule domain.PipelineSchedule) (domain.PipelineSchedule, error)
+	SetPipelineScheduleEnabled(ctx context.Context, repoSlug, scheduleUUID string, enabled bool) (domain.PipelineSchedule, error)
+	DeletePipelineSchedule(ctx context.Context, repoSlug, scheduleUUID string) error
+	ListEnvironments(ctx context.Context, repoSlug string) ([]domain.Environment, error)
+	ListDeployments(ctx context.Context, repoSlug string) ([]domain.Deployment, error)
+	ListIssues(ctx context.Context, repoSlug string) ([]domain.Issue, error)
+	ListIssueComments(ctx context.Context, repoSlug string, issueID int) ([]domain.Comment, error)
+	CreateIssue(ctx context.Context, repoSlug string, input domain.NewIssue) (domain.Issue, error)
+	UpdateIssue(ctx context.Context, repoSlug string, issueID int, update domain.IssueUpdate) (domain.Issue, error)
+	ListDownloads(ctx context.Context, repoSlug string) ([]domain.Download, error)
+	GetDownload(ctx context.Context, repoSlug, name string, w io.Writer) error
+	UploadDownload(ctx context.Context, repoSlug, name string, content []byte) error
+
+	// RateLimit reports the most recent rate limit headers seen.
+	RateLimit() RateLimit
+	// RecentRequests returns the request log shown by the inspector.
+	RecentRequests() []RequestRecord
+}
+
+var _ BitbucketAPI = (*Client)(nil)
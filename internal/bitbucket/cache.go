@@ -0,0 +1,95 @@
+package bitbucket
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+type noCacheKey struct{}
+
+// WithoutCache returns a context whose requests skip the response cache and
+// store the fresh response instead, as an explicit refresh should.
+func WithoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noCacheKey{}, true)
+}
+
+func cacheBypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(noCacheKey{}).(bool)
+	return bypass
+}
+
+type cacheEntry struct {
+	body         []byte
+	expires      time.Time
+	etag         string
+	lastModified string
+}
+
+// responseCache keeps GET responses keyed by URL. Fresh entries are served
+// as-is; stale ones are kept for revalidation with their ETag.
+type responseCache struct {
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+func newResponseCache() *responseCache {
+	return &responseCache{entries: make(map[string]cacheEntry)}
+}
+
+func (r *responseCache) lookup(key string) (cacheEntry, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entry, ok := r.entries[key]
+	return entry, ok
+}
+
+// put stores entry unless it can neither be served (zero TTL) nor
+// revalidated (no validators).
+func (r *responseCache) put(key string, entry cacheEntry) {
+	if !entry.expires.After(time.Now()) && entry.etag == "" && entry.lastModified == "" {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries[key] = entry
+}
+
+// clear drops every entry. Mutating requests call it since they may change
+// any listing.
+func (r *responseCache) clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = make(map[string]cacheEntry)
+}
+
+// cacheTTL returns how long a GET response for url may be reused. Content
+// addressed by commit hash never changes; pipelines change the fastest and
+// step logs are always revalidated.
+func cacheTTL(url string) time.Duration {
+	path := url
+	if index := strings.Index(path, "?"); index >= 0 {
+		path = path[:index]
+	}
+
+	switch {
+	case strings.Contains(path, "/steps/") && strings.HasSuffix(path, "/log"):
+		return 0
+	case strings.Contains(path, "/pipelines"):
+		return 10 * time.Second
+	case strings.Contains(path, "/commit/") && strings.HasSuffix(path, "/statuses"):
+		return 10 * time.Second
+	case strings.Contains(path, "/pullrequests"):
+		return 30 * time.Second
+	case strings.Contains(path, "/diff/"), strings.Contains(path, "/diffstat/"):
+		return 10 * time.Minute
+	case strings.Contains(path, "/refs/"):
+		return time.Minute
+	case strings.HasSuffix(path, "/user"):
+		return 10 * time.Minute
+	default:
+		return 2 * time.Minute
+	}
+}
@@ -1,13 +1,18 @@
 package bitbucket
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
 	neturl "net/url"
 	"sort"
 	"strings"
+	"time"
 
 	"bitbucket-cli/internal/config"
 	"bitbucket-cli/internal/domain"
@@ -16,10 +21,12 @@ import (
 type Client struct {
 	httpClient *http.Client
 	config     config.Config
-}
-
-type projectsResponse struct {
-	Values []apiProject `json:"values"`
+	rateLimit  rateLimitState
+	cache      *responseCache
+	requests   requestLog
+	inflight   requestGroup
+	// oauth is nil unless the profile signs in with an OAuth consumer.
+	oauth *oauthTokens
 }
 
 type apiProject struct {
@@ -33,9 +40,10 @@ type apiProject struct {
 	} `json:"links"`
 }
 
-type repositoriesResponse struct {
-	Values []apiRepository `json:"values"`
-	Next   string          `json:"next"`
+type apiWorkspace struct {
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+	UUID string `json:"uuid"`
 }
 
 type apiRepository struct {
@@ -46,11 +54,9 @@ type apiRepository struct {
 	Mainbranch struct {
 		Name string `json:"name"`
 	} `json:"mainbranch"`
-}
-
-type branchesResponse struct {
-	Values []apiBranch `json:"values"`
-	Next   string      `json:"next"`
+	Project struct {
+		Key string `json:"key"`
+	} `json:"project"`
 }
 
 type apiBranch struct {
@@ -61,19 +67,23 @@ type apiBranch struct {
 	} `json:"target"`
 }
 
-type pullRequestsResponse struct {
-	Values []apiPullRequest `json:"values"`
-	Next   string           `json:"next"`
-}
-
-type commitsResponse struct {
-	Values []apiCommit `json:"values"`
-	Next   string      `json:"next"`
+type apiTag struct {
+	Name    string `json:"name"`
+	Message string `json:"message"`
+	Date    string `json:"date"`
+	Target  struct {
+		Hash string `json:"hash"`
+		Date string `json:"date"`
+	} `json:"target"`
 }
 
-type diffstatResponse struct {
-	Values []apiDiffstat `json:"values"`
-	Next   string        `json:"next"`
+func mapAPITag(item apiTag) domain.Tag {
+	return domain.Tag{
+		Name:    item.Name,
+		Target:  domain.BranchTarget{Hash: item.Target.Hash, Date: item.Target.Date},
+		Message: strings.TrimSpace(item.Message),
+		Date:    item.Date,
+	}
 }
 
 type apiPullRequest struct {
@@ -84,16 +94,23 @@ type apiPullRequest struct {
 	Draft       bool   `json:"draft"`
 	Author      struct {
 		DisplayName string `json:"display_name"`
+		UUID        string `json:"uuid"`
 	} `json:"author"`
 	Source struct {
 		Branch struct {
 			Name string `json:"name"`
 		} `json:"branch"`
+		Commit struct {
+			Hash string `json:"hash"`
+		} `json:"commit"`
 	} `json:"source"`
 	Destination struct {
 		Branch struct {
 			Name string `json:"name"`
 		} `json:"branch"`
+		Repository struct {
+			FullName string `json:"full_name"`
+		} `json:"repository"`
 	} `json:"destination"`
 	CreatedOn string `json:"created_on"`
 	UpdatedOn string `json:"updated_on"`
@@ -106,11 +123,94 @@ type apiPullRequest struct {
 		} `json:"html"`
 	} `json:"links"`
 	Participants []struct {
-		Approved bool `json:"approved"`
-		User     struct {
+		Approved       bool   `json:"approved"`
+		ParticipatedOn string `json:"participated_on"`
+		State          string `json:"state"`
+		Role           string `json:"role"`
+		User           struct {
 			DisplayName string `json:"display_name"`
+			UUID        string `json:"uuid"`
 		} `json:"user"`
 	} `json:"participants"`
+	Reviewers []struct {
+		DisplayName string `json:"display_name"`
+		UUID        string `json:"uuid"`
+	} `json:"reviewers"`
+	TaskCount   int `json:"task_count"`
+	MergeCommit struct {
+		Hash string `json:"hash"`
+	} `json:"merge_commit"`
+}
+
+type apiBranchRestriction struct {
+	Kind            string `json:"kind"`
+	BranchMatchKind string `json:"branch_match_kind"`
+	Pattern         string `json:"pattern"`
+	BranchType      string `json:"branch_type"`
+	Value           int    `json:"value"`
+}
+
+type apiBuildStatus struct {
+	Key   string `json:"key"`
+	Name  string `json:"name"`
+	State string `json:"state"`
+	URL   string `json:"url"`
+}
+
+type apiComment struct {
+	ID      int `json:"id"`
+	Content struct {
+		Raw string `json:"raw"`
+	} `json:"content"`
+	User struct {
+		DisplayName string `json:"display_name"`
+	} `json:"user"`
+	CreatedOn string `json:"created_on"`
+	Parent    *struct {
+		ID int `json:"id"`
+	} `json:"parent"`
+	Deleted bool       `json:"deleted"`
+	Inline  *apiInline `json:"inline"`
+}
+
+// apiInline anchors a comment to a line of the diff. Bitbucket sends null for
+// the side a line is not on.
+type apiInline struct {
+	Path string `json:"path"`
+	From int    `json:"from,omitempty"`
+	To   int    `json:"to,omitempty"`
+}
+
+type apiIssue struct {
+	ID       int    `json:"id"`
+	Title    string `json:"title"`
+	State    string `json:"state"`
+	Kind     string `json:"kind"`
+	Priority string `json:"priority"`
+	Content  struct {
+		Raw string `json:"raw"`
+	} `json:"content"`
+	Reporter struct {
+		DisplayName string `json:"display_name"`
+	} `json:"reporter"`
+	Assignee *struct {
+		DisplayName string `json:"display_name"`
+		AccountID   string `json:"account_id"`
+	} `json:"assignee"`
+	CreatedOn string `json:"created_on"`
+	UpdatedOn string `json:"updated_on"`
+	Links     struct {
+		HTML struct {
+			Href string `json:"href"`
+		} `json:"html"`
+	} `json:"links"`
+}
+
+type apiUser struct {
+	UUID        string `json:"uuid"`
+	AccountID   string `json:"account_id"`
+	Nickname    string `json:"nickname"`
+	DisplayName string `json:"display_name"`
 }
 
 type apiCommit struct {
@@ -123,6 +223,9 @@ type apiCommit struct {
 			DisplayName string `json:"display_name"`
 		} `json:"user"`
 	} `json:"author"`
+	Parents []struct {
+		Hash string `json:"hash"`
+	} `json:"parents"`
 }
 
 type apiDiffstat struct {
@@ -137,11 +240,6 @@ type apiDiffstat struct {
 	} `json:"new"`
 }
 
-type pipelinesResponse struct {
-	Values []apiPipeline `json:"values"`
-	Next   string        `json:"next"`
-}
-
 type apiPipeline struct {
 	UUID        string `json:"uuid"`
 	BuildNumber int    `json:"build_number"`
@@ -149,7 +247,17 @@ type apiPipeline struct {
 	CompletedOn string `json:"completed_on"`
 	Target      struct {
 		RefName string `json:"ref_name"`
+		Commit  struct {
+			Hash    string `json:"hash"`
+			Message string `json:"message"`
+		} `json:"commit"`
 	} `json:"target"`
+	Trigger struct {
+		Name string `json:"name"`
+	} `json:"trigger"`
+	Creator struct {
+		DisplayName string `json:"display_name"`
+	} `json:"creator"`
 	State struct {
 		Name  string `json:"name"`
 		Stage struct {
@@ -162,8 +270,109 @@ type apiPipeline struct {
 	} `json:"state"`
 }
 
-type pipelineStepsResponse struct {
-	Values []apiPipelineStep `json:"values"`
+// apiPipelineTarget is the part of a pipeline's target needed to run it
+// again: a branch or tag with an optional custom selector, a bare commit, or
+// a pull request.
+type apiPipelineTarget struct {
+	Type     string           `json:"type"`
+	RefType  string           `json:"ref_type,omitempty"`
+	RefName  string           `json:"ref_name,omitempty"`
+	Commit   *apiTargetCommit `json:"commit,omitempty"`
+	Selector *struct {
+		Type    string `json:"type"`
+		Pattern string `json:"pattern,omitempty"`
+	} `json:"selector,omitempty"`
+	Source            string           `json:"source,omitempty"`
+	Destination       string           `json:"destination,omitempty"`
+	DestinationCommit *apiTargetCommit `json:"destination_commit,omitempty"`
+	PullRequest       *struct {
+		ID int `json:"id"`
+	} `json:"pull_request,omitempty"`
+}
+
+type apiTargetCommit struct {
+	Type string `json:"type,omitempty"`
+	Hash string `json:"hash"`
+}
+
+type apiDownload struct {
+	Name      string `json:"name"`
+	Size      int64  `json:"size"`
+	CreatedOn string `json:"created_on"`
+	Downloads int    `json:"downloads"`
+	User      struct {
+		DisplayName string `json:"display_name"`
+	} `json:"user"`
+}
+
+type apiSchedule struct {
+	UUID        string            `json:"uuid"`
+	Enabled     bool              `json:"enabled"`
+	CronPattern string            `json:"cron_pattern"`
+	Target      apiPipelineTarget `json:"target"`
+	CreatedOn   string            `json:"created_on"`
+	UpdatedOn   string            `json:"updated_on"`
+}
+
+type createScheduleRequest struct {
+	Type        string `json:"type"`
+	Enabled     bool   `json:"enabled"`
+	CronPattern string `json:"cron_pattern"`
+	Target      struct {
+		Type     string `json:"type"`
+		RefType  string `json:"ref_type"`
+		RefName  string `json:"ref_name"`
+		Selector struct {
+			Type    string `json:"type"`
+			Pattern string `json:"pattern"`
+		} `json:"selector"`
+	} `json:"target"`
+}
+
+type apiArtifact struct {
+	UUID      string `json:"uuid"`
+	Path      string `json:"path"`
+	Size      int64  `json:"file_size_bytes"`
+	CreatedOn string `json:"created_on"`
+}
+
+type apiEnvironment struct {
+	UUID            string `json:"uuid"`
+	Name            string `json:"name"`
+	Rank            int    `json:"rank"`
+	EnvironmentType struct {
+		Name string `json:"name"`
+	} `json:"environment_type"`
+}
+
+type apiDeployment struct {
+	UUID  string `json:"uuid"`
+	State struct {
+		Name   string `json:"name"`
+		Status struct {
+			Name string `json:"name"`
+		} `json:"status"`
+		StartedOn   string `json:"started_on"`
+		CompletedOn string `json:"completed_on"`
+	} `json:"state"`
+	Environment struct {
+		UUID string `json:"uuid"`
+	} `json:"environment"`
+	Step struct {
+		UUID string `json:"uuid"`
+	} `json:"step"`
+	Release struct {
+		Name   string `json:"name"`
+		Commit struct {
+			Hash string `json:"hash"`
+		} `json:"commit"`
+		CreatedOn string `json:"created_on"`
+	} `json:"release"`
+	Deployable struct {
+		Pipeline struct {
+			UUID string `json:"uuid"`
+		} `json:"pipeline"`
+	} `json:"deployable"`
 }
 
 type apiPipelineStep struct {
@@ -177,258 +386,514 @@ type apiPipelineStep struct {
 			Name string `json:"name"`
 		} `json:"result"`
 	} `json:"state"`
+	ScriptCommands []struct {
+		Name    string `json:"name"`
+		Command string `json:"command"`
+	} `json:"script_commands"`
 }
 
 func NewClient(cfg config.Config) *Client {
-	return &Client{
-		httpClient: &http.Client{Timeout: cfg.Timeout},
+	c := &Client{
+		// Timeouts are set per request, see requestTimeout.
+		httpClient: &http.Client{Transport: newGzipTransport(http.DefaultTransport)},
 		config:     cfg,
+		cache:      newResponseCache(),
+	}
+	if cfg.OAuth != nil {
+		c.oauth = newOAuthTokens(*cfg.OAuth, &http.Client{Timeout: cfg.ListTimeout})
 	}
+	return c
 }
 
-func (c *Client) ListProjects() (string, []domain.Project, error) {
-	url := c.config.ProjectsURL(c.config.Workspace)
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+func (c *Client) ListProjects(ctx context.Context) (string, []domain.Project, error) {
+	url := c.config.ProjectsURL(c.config.Workspace) + "?pagelen=100"
+	projects, err := listAll(ctx, c, url, "projects", func(item apiProject) domain.Project {
+		return domain.Project{
+			Key:             item.Key,
+			Name:            item.Name,
+			UUID:            item.UUID,
+			RepositoriesURL: item.Links.Repositories.Href,
+		}
+	})
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return fmt.Sprintf("%d %s", apiErr.StatusCode, http.StatusText(apiErr.StatusCode)), nil, err
+	}
 	if err != nil {
-		return "", nil, err
+		return "", nil, fmt.Errorf("request failed for URL %s: %w", url, err)
 	}
+	return fmt.Sprintf("%d %s", http.StatusOK, http.StatusText(http.StatusOK)), projects, nil
+}
+
+// ListProjectRepositories follows a project's repositories link, listing
+// the repositories in the project, most recently updated first.
+func (c *Client) ListProjectRepositories(ctx context.Context, repositoriesURL string) ([]domain.Repository, error) {
+	if repositoriesURL == "" {
+		return nil, errors.New("project has no repositories link")
+	}
+	separator := "?"
+	if strings.Contains(repositoriesURL, "?") {
+		separator = "&"
+	}
+	url := fmt.Sprintf("%s%spagelen=100&sort=-updated_on&%s", repositoriesURL, separator, pageFields(repositoryFields))
+	return listAll(ctx, c, url, "repositories", mapAPIRepository)
+}
 
-	setJSONHeaders(req, c.config.BasicAuth)
+func (c *Client) GetCurrentUser(ctx context.Context) (domain.User, error) {
+	url := c.config.BaseURL() + "/user"
 
-	resp, err := c.httpClient.Do(req)
+	body, err := c.doRequest(ctx, http.MethodGet, url, acceptJSON, nil)
 	if err != nil {
-		return "", nil, fmt.Errorf("request failed for URL %s: %w", url, err)
+		return domain.User{}, err
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return resp.Status, nil, err
+	var decoded apiUser
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return domain.User{}, fmt.Errorf("unable to decode user response: %w", err)
 	}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return resp.Status, nil, fmt.Errorf("non-success status code: %d for URL %s, response: %s", resp.StatusCode, url, string(body))
+	return domain.User{
+		UUID:        decoded.UUID,
+		AccountID:   decoded.AccountID,
+		Nickname:    decoded.Nickname,
+		DisplayName: decoded.DisplayName,
+	}, nil
+}
+
+// GetWorkspace returns the metadata of the configured workspace.
+func (c *Client) GetWorkspace(ctx context.Context) (domain.Workspace, error) {
+	url := fmt.Sprintf("%s/workspaces/%s?fields=slug,name,uuid", c.config.BaseURL(), c.config.Workspace)
+
+	body, err := c.doRequest(ctx, http.MethodGet, url, acceptJSON, nil)
+	if err != nil {
+		return domain.Workspace{}, err
 	}
 
-	var decoded projectsResponse
+	var decoded apiWorkspace
 	if err := json.Unmarshal(body, &decoded); err != nil {
-		return resp.Status, nil, fmt.Errorf("unable to decode projects response: %w", err)
+		return domain.Workspace{}, fmt.Errorf("unable to decode workspace response: %w", err)
 	}
 
-	projects := make([]domain.Project, 0, len(decoded.Values))
-	for _, item := range decoded.Values {
-		projects = append(projects, domain.Project{
-			Key:             item.Key,
-			Name:            item.Name,
-			UUID:            item.UUID,
-			RepositoriesURL: item.Links.Repositories.Href,
-		})
+	return domain.Workspace{Slug: decoded.Slug, Name: decoded.Name, UUID: decoded.UUID}, nil
+}
+
+type apiWorkspaceMembership struct {
+	User apiUser `json:"user"`
+}
+
+// ListWorkspaceMembers returns the users of the configured workspace.
+func (c *Client) ListWorkspaceMembers(ctx context.Context) ([]domain.User, error) {
+	url := fmt.Sprintf("%s/workspaces/%s/members?pagelen=100&%s", c.config.BaseURL(), c.config.Workspace, pageFields(memberFields))
+	return listAll(ctx, c, url, "workspace members", func(item apiWorkspaceMembership) domain.User {
+		return domain.User{
+			UUID:        item.User.UUID,
+			AccountID:   item.User.AccountID,
+			Nickname:    item.User.Nickname,
+			DisplayName: item.User.DisplayName,
+		}
+	})
+}
+
+// GetRepository returns a single repository of the workspace.
+func (c *Client) GetRepository(ctx context.Context, repoSlug string) (domain.Repository, error) {
+	url := fmt.Sprintf("%s/repositories/%s/%s?fields=%s", c.config.BaseURL(), c.config.Workspace, repoSlug, strings.Join(repositoryFields, ","))
+
+	body, err := c.doRequest(ctx, http.MethodGet, url, acceptJSON, nil)
+	if err != nil {
+		return domain.Repository{}, err
 	}
 
-	return resp.Status, projects, nil
+	var decoded apiRepository
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return domain.Repository{}, fmt.Errorf("unable to decode repository response: %w", err)
+	}
+
+	return mapAPIRepository(decoded), nil
 }
 
-func (c *Client) ListRepositories() ([]domain.Repository, error) {
-	var allRepos []domain.Repository
-	url := fmt.Sprintf("https://api.bitbucket.org/2.0/repositories/%s?pagelen=100", c.config.Workspace)
+// ListRepositoryPage returns one page (starting at 1) of the workspace's
+// repositories, most recently updated first, and whether more pages follow.
+// It lets the UI show the first repositories before the rest have loaded.
+func (c *Client) ListRepositoryPage(ctx context.Context, pageNumber int) ([]domain.Repository, bool, error) {
+	url := fmt.Sprintf(
+		"%s/repositories/%s?pagelen=100&sort=-updated_on&page=%d&%s",
+		c.config.BaseURL(),
+		c.config.Workspace,
+		pageNumber,
+		pageFields(repositoryFields),
+	)
 
-	for url != "" {
-		req, err := http.NewRequest(http.MethodGet, url, nil)
-		if err != nil {
-			return nil, err
-		}
+	body, err := c.doRequest(ctx, http.MethodGet, url, acceptJSON, nil)
+	if err != nil {
+		return nil, false, err
+	}
 
-		setJSONHeaders(req, c.config.BasicAuth)
+	var decoded page[apiRepository]
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return nil, false, fmt.Errorf("unable to decode repositories response: %w", err)
+	}
 
-		resp, err := c.httpClient.Do(req)
-		if err != nil {
-			return nil, fmt.Errorf("request failed: %w", err)
-		}
-		defer resp.Body.Close()
+	repos := make([]domain.Repository, 0, len(decoded.Values))
+	for _, item := range decoded.Values {
+		repos = append(repos, mapAPIRepository(item))
+	}
+	return repos, decoded.Next != "", nil
+}
 
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+func (c *Client) ListRepositories(ctx context.Context) ([]domain.Repository, error) {
+	url := fmt.Sprintf("%s/repositories/%s?pagelen=100&%s", c.config.BaseURL(), c.config.Workspace, pageFields(repositoryFields))
+	repos, err := listAll(ctx, c, url, "repositories", mapAPIRepository)
+	if err != nil {
+		return nil, err
+	}
 
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("non-success status code: %d, response: %s", resp.StatusCode, string(body))
-		}
+	sortByUpdatedOn(repos)
 
-		var decoded repositoriesResponse
-		if err := json.Unmarshal(body, &decoded); err != nil {
-			return nil, fmt.Errorf("unable to decode repositories response: %w", err)
-		}
+	return repos, nil
+}
 
-		for _, item := range decoded.Values {
-			allRepos = append(allRepos, domain.Repository{
-				Name:       item.Name,
-				Slug:       item.Slug,
-				UUID:       item.UUID,
-				Mainbranch: item.Mainbranch.Name,
-				UpdatedOn:  item.UpdatedOn,
-			})
+func (c *Client) ListBranches(ctx context.Context, repoSlug string) ([]domain.Branch, error) {
+	url := fmt.Sprintf("%s/repositories/%s/%s/refs/branches?pagelen=100&%s", c.config.BaseURL(), c.config.Workspace, repoSlug, pageFields(branchFields))
+	return listAll(ctx, c, url, "branches", func(item apiBranch) domain.Branch {
+		return domain.Branch{
+			Name: item.Name,
+			Target: domain.BranchTarget{
+				Hash: item.Target.Hash,
+				Date: item.Target.Date,
+			},
 		}
+	})
+}
 
-		url = decoded.Next
+// GetBranch returns a single branch, or ErrNotFound when it does not exist.
+func (c *Client) GetBranch(ctx context.Context, repoSlug, name string) (domain.Branch, error) {
+	url := fmt.Sprintf("%s/repositories/%s/%s/refs/branches/%s?fields=%s", c.config.BaseURL(), c.config.Workspace, repoSlug, neturl.PathEscape(name), strings.Join(branchFields, ","))
+
+	body, err := c.doRequest(ctx, http.MethodGet, url, acceptJSON, nil)
+	if err != nil {
+		return domain.Branch{}, err
 	}
 
-	sortByUpdatedOn(allRepos)
+	var decoded apiBranch
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return domain.Branch{}, fmt.Errorf("unable to decode branch response: %w", err)
+	}
+	return domain.Branch{Name: decoded.Name, Target: domain.BranchTarget{Hash: decoded.Target.Hash, Date: decoded.Target.Date}}, nil
+}
 
-	return allRepos, nil
+type createBranchRequest struct {
+	Name   string `json:"name"`
+	Target struct {
+		Hash string `json:"hash"`
+	} `json:"target"`
 }
 
-func (c *Client) ListBranches(repoSlug string) ([]domain.Branch, error) {
-	var allBranches []domain.Branch
-	url := fmt.Sprintf("https://api.bitbucket.org/2.0/repositories/%s/%s/refs/branches?pagelen=100", c.config.Workspace, repoSlug)
+// CreateBranch creates a branch pointing at targetHash, which may also name
+// an existing branch to start from its head.
+func (c *Client) CreateBranch(ctx context.Context, repoSlug, name, targetHash string) (domain.Branch, error) {
+	url := fmt.Sprintf("%s/repositories/%s/%s/refs/branches", c.config.BaseURL(), c.config.Workspace, repoSlug)
 
-	for url != "" {
-		req, err := http.NewRequest(http.MethodGet, url, nil)
-		if err != nil {
-			return nil, err
-		}
+	request := createBranchRequest{Name: name}
+	request.Target.Hash = targetHash
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return domain.Branch{}, err
+	}
 
-		setJSONHeaders(req, c.config.BasicAuth)
+	body, err := c.doRequest(ctx, http.MethodPost, url, acceptJSON, payload)
+	if err != nil {
+		return domain.Branch{}, err
+	}
 
-		resp, err := c.httpClient.Do(req)
-		if err != nil {
-			return nil, fmt.Errorf("request failed: %w", err)
-		}
-		defer resp.Body.Close()
+	var decoded apiBranch
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return domain.Branch{}, fmt.Errorf("unable to decode branch response: %w", err)
+	}
+	return domain.Branch{Name: decoded.Name, Target: domain.BranchTarget{Hash: decoded.Target.Hash, Date: decoded.Target.Date}}, nil
+}
 
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+// DeleteBranch deletes a branch. Bitbucket refuses to delete the main branch.
+func (c *Client) DeleteBranch(ctx context.Context, repoSlug, name string) error {
+	url := fmt.Sprintf("%s/repositories/%s/%s/refs/branches/%s", c.config.BaseURL(), c.config.Workspace, repoSlug, neturl.PathEscape(name))
+	_, err := c.doRequest(ctx, http.MethodDelete, url, acceptJSON, nil)
+	return err
+}
 
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("non-success status code: %d, response: %s", resp.StatusCode, string(body))
-		}
+func (c *Client) ListTags(ctx context.Context, repoSlug string) ([]domain.Tag, error) {
+	url := fmt.Sprintf("%s/repositories/%s/%s/refs/tags?pagelen=100&sort=-target.date&%s", c.config.BaseURL(), c.config.Workspace, repoSlug, pageFields(tagFields))
+	return listAll(ctx, c, url, "tags", mapAPITag)
+}
 
-		var decoded branchesResponse
-		if err := json.Unmarshal(body, &decoded); err != nil {
-			return nil, fmt.Errorf("unable to decode branches response: %w", err)
-		}
+// CreateTag creates a lightweight tag on targetHash, which may also name a
+// branch to tag its head.
+func (c *Client) CreateTag(ctx context.Context, repoSlug, name, targetHash string) (domain.Tag, error) {
+	url := fmt.Sprintf("%s/repositories/%s/%s/refs/tags", c.config.BaseURL(), c.config.Workspace, repoSlug)
 
-		for _, item := range decoded.Values {
-			allBranches = append(allBranches, domain.Branch{
-				Name: item.Name,
-				Target: domain.BranchTarget{
-					Hash: item.Target.Hash,
-					Date: item.Target.Date,
-				},
-			})
-		}
+	// Tags are created with the same body as branches.
+	request := createBranchRequest{Name: name}
+	request.Target.Hash = targetHash
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return domain.Tag{}, err
+	}
+
+	body, err := c.doRequest(ctx, http.MethodPost, url, acceptJSON, payload)
+	if err != nil {
+		return domain.Tag{}, err
+	}
 
-		url = decoded.Next
+	var decoded apiTag
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return domain.Tag{}, fmt.Errorf("unable to decode tag response: %w", err)
 	}
+	return mapAPITag(decoded), nil
+}
 
-	return allBranches, nil
+func (c *Client) DeleteTag(ctx context.Context, repoSlug, name string) error {
+	url := fmt.Sprintf("%s/repositories/%s/%s/refs/tags/%s", c.config.BaseURL(), c.config.Workspace, repoSlug, neturl.PathEscape(name))
+	_, err := c.doRequest(ctx, http.MethodDelete, url, acceptJSON, nil)
+	return err
 }
 
-func (c *Client) ListPullRequests(repoSlug string) ([]domain.PullRequest, error) {
-	var allPRs []domain.PullRequest
+func (c *Client) ListPullRequests(ctx context.Context, repoSlug string) ([]domain.PullRequest, error) {
 	url := fmt.Sprintf(
-		"https://api.bitbucket.org/2.0/repositories/%s/%s/pullrequests?pagelen=50&fields=values.id,values.title,values.description,values.state,values.draft,values.author.display_name,values.source.branch.name,values.destination.branch.name,values.created_on,values.updated_on,values.links.html.href,values.links.self.href,values.participants.approved,values.participants.user.display_name,next",
+		"%s/repositories/%s/%s/pullrequests?pagelen=50&%s",
+		c.config.BaseURL(),
 		c.config.Workspace,
 		repoSlug,
+		pageFields(pullRequestFields),
 	)
+	return c.listPullRequestPages(ctx, url)
+}
 
-	for url != "" {
-		req, err := http.NewRequest(http.MethodGet, url, nil)
-		if err != nil {
-			return nil, err
-		}
+// ListPullRequestsByState returns the pull requests of a repository in state:
+// OPEN, MERGED, DECLINED or SUPERSEDED, or ALL for the first three.
+func (c *Client) ListPullRequestsByState(ctx context.Context, repoSlug, state string) ([]domain.PullRequest, error) {
+	states := "state=" + neturl.QueryEscape(state)
+	if strings.EqualFold(state, "ALL") {
+		states = "state=OPEN&state=MERGED&state=DECLINED"
+	}
+	url := fmt.Sprintf(
+		"%s/repositories/%s/%s/pullrequests?%s&pagelen=50&%s",
+		c.config.BaseURL(),
+		c.config.Workspace,
+		repoSlug,
+		states,
+		pageFields(pullRequestFields),
+	)
+	return c.listPullRequestPages(ctx, url)
+}
 
-		setJSONHeaders(req, c.config.BasicAuth)
+// ListUserPullRequests returns the open pull requests authored by the given
+// user across every repository of the workspace.
+func (c *Client) ListUserPullRequests(ctx context.Context, userUUID string) ([]domain.PullRequest, error) {
+	url := fmt.Sprintf(
+		"%s/workspaces/%s/pullrequests/%s?state=OPEN&pagelen=50&%s",
+		c.config.BaseURL(),
+		c.config.Workspace,
+		neturl.PathEscape(userUUID),
+		pageFields(pullRequestFields),
+	)
+	return c.listPullRequestPages(ctx, url)
+}
 
-		resp, err := c.httpClient.Do(req)
-		if err != nil {
-			return nil, fmt.Errorf("request failed: %w", err)
-		}
-		defer resp.Body.Close()
+// ListReviewPullRequests returns the open pull requests of a repository on
+// which the given user is a reviewer.
+func (c *Client) ListReviewPullRequests(ctx context.Context, repoSlug, userUUID string) ([]domain.PullRequest, error) {
+	query := neturl.QueryEscape(fmt.Sprintf(`reviewers.uuid="%s" AND state="OPEN"`, userUUID))
+	url := fmt.Sprintf("%s/repositories/%s/%s/pullrequests?pagelen=50&q=%s&%s", c.config.BaseURL(), c.config.Workspace, repoSlug, query, pageFields(pullRequestFields))
 
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
+	prs, err := c.listPullRequestPages(ctx, url)
+	for i := range prs {
+		if prs[i].RepoSlug == "" {
+			prs[i].RepoSlug = repoSlug
 		}
+	}
+	return prs, err
+}
 
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("non-success status code: %d, response: %s", resp.StatusCode, string(body))
-		}
+// ListRecentPullRequests returns the most recently updated pull requests of a
+// repository in any state, newest first. Only the first page is fetched.
+func (c *Client) ListRecentPullRequests(ctx context.Context, repoSlug string) ([]domain.PullRequest, error) {
+	url := fmt.Sprintf(
+		"%s/repositories/%s/%s/pullrequests?state=OPEN&state=MERGED&state=DECLINED&sort=-updated_on&pagelen=25&%s",
+		c.config.BaseURL(),
+		c.config.Workspace,
+		repoSlug,
+		pageFields(pullRequestFields),
+	)
+	body, err := c.doRequest(ctx, http.MethodGet, url, acceptJSON, nil)
+	if err != nil {
+		return nil, err
+	}
 
-		var decoded pullRequestsResponse
-		if err := json.Unmarshal(body, &decoded); err != nil {
-			return nil, fmt.Errorf("unable to decode pull requests response: %w", err)
-		}
+	var decoded page[apiPullRequest]
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return nil, fmt.Errorf("unable to decode pull requests response: %w", err)
+	}
 
-		for _, item := range decoded.Values {
-			prURL := item.Links.HTML.Href
-			if prURL == "" {
-				prURL = item.Links.Self.Href
-			}
+	prs := make([]domain.PullRequest, 0, len(decoded.Values))
+	for _, item := range decoded.Values {
+		pr := mapAPIPullRequest(item)
+		if pr.RepoSlug == "" {
+			pr.RepoSlug = repoSlug
+		}
+		prs = append(prs, pr)
+	}
+	return prs, nil
+}
 
-			approvalCount := 0
-			approverNames := make([]string, 0, len(item.Participants))
+// ListApprovedPullRequests returns the open pull requests of a repository
+// that the given user has approved.
+func (c *Client) ListApprovedPullRequests(ctx context.Context, repoSlug, userUUID string) ([]domain.Approval, error) {
+	// Matching approved=true in the query could hit another participant's
+	// approval, so the user's own participant entry is checked below.
+	query := neturl.QueryEscape(fmt.Sprintf(`participants.user.uuid="%s" AND state="OPEN"`, userUUID))
+	url := fmt.Sprintf("%s/repositories/%s/%s/pullrequests?pagelen=50&q=%s&%s", c.config.BaseURL(), c.config.Workspace, repoSlug, query, pageFields(pullRequestFields))
+
+	var approvals []domain.Approval
+	err := paginate(ctx, c, url, "pull requests", func(values []apiPullRequest) error {
+		for _, item := range values {
 			for _, participant := range item.Participants {
-				if participant.Approved {
-					approvalCount++
-					name := strings.TrimSpace(participant.User.DisplayName)
-					if name != "" {
-						approverNames = append(approverNames, name)
-					}
+				if participant.User.UUID != userUUID || !participant.Approved {
+					continue
 				}
+				pr := mapAPIPullRequest(item)
+				if pr.RepoSlug == "" {
+					pr.RepoSlug = repoSlug
+				}
+				approvals = append(approvals, domain.Approval{PullRequest: pr, ParticipatedOn: participant.ParticipatedOn})
+				break
 			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return approvals, nil
+}
 
-			allPRs = append(allPRs, domain.PullRequest{
-				ID:            item.ID,
-				Title:         item.Title,
-				Description:   item.Description,
-				State:         item.State,
-				Draft:         item.Draft,
-				Approved:      approvalCount > 0,
-				Approvals:     approvalCount,
-				ApproverNames: approverNames,
-				Author:        item.Author.DisplayName,
-				SourceBranch:  item.Source.Branch.Name,
-				DestBranch:    item.Destination.Branch.Name,
-				CreatedOn:     item.CreatedOn,
-				UpdatedOn:     item.UpdatedOn,
-				URL:           prURL,
-			})
+// ListBranchRestrictions returns the branch permissions and merge checks of a
+// repository. Reading them needs admin access to the repository.
+func (c *Client) ListBranchRestrictions(ctx context.Context, repoSlug string) ([]domain.BranchRestriction, error) {
+	url := fmt.Sprintf("%s/repositories/%s/%s/branch-restrictions?pagelen=100&%s", c.config.BaseURL(), c.config.Workspace, repoSlug, pageFields(branchRestrictionFields))
+	return listAll(ctx, c, url, "branch restrictions", func(item apiBranchRestriction) domain.BranchRestriction {
+		return domain.BranchRestriction{
+			Kind:            item.Kind,
+			BranchMatchKind: item.BranchMatchKind,
+			Pattern:         item.Pattern,
+			BranchType:      item.BranchType,
+			Value:           item.Value,
 		}
+	})
+}
 
-		url = decoded.Next
-	}
+// ListPullRequestStatuses returns the build statuses reported on the commits
+// of a pull request.
+func (c *Client) ListPullRequestStatuses(ctx context.Context, repoSlug string, pullRequestID int) ([]domain.BuildStatus, error) {
+	url := fmt.Sprintf("%s/repositories/%s/%s/pullrequests/%d/statuses?pagelen=100&%s", c.config.BaseURL(), c.config.Workspace, repoSlug, pullRequestID, pageFields(buildStatusFields))
+	return listAll(ctx, c, url, "build statuses", mapAPIBuildStatus)
+}
+
+// ListCommitStatuses returns the build statuses reported on one commit, such
+// as the head of a pull request's source branch.
+func (c *Client) ListCommitStatuses(ctx context.Context, repoSlug, commitHash string) ([]domain.BuildStatus, error) {
+	url := fmt.Sprintf("%s/repositories/%s/%s/commit/%s/statuses?pagelen=100&%s", c.config.BaseURL(), c.config.Workspace, repoSlug, neturl.PathEscape(commitHash), pageFields(buildStatusFields))
+	return listAll(ctx, c, url, "build statuses", mapAPIBuildStatus)
+}
+
+func mapAPIBuildStatus(item apiBuildStatus) domain.BuildStatus {
+	return domain.BuildStatus{Key: item.Key, Name: item.Name, State: item.State, URL: item.URL}
+}
+
+type apiActivityUser struct {
+	DisplayName string `json:"display_name"`
+}
 
-	return allPRs, nil
+type apiPullRequestActivity struct {
+	Update *struct {
+		State  string          `json:"state"`
+		Date   string          `json:"date"`
+		Author apiActivityUser `json:"author"`
+	} `json:"update"`
+	Approval *struct {
+		Date string          `json:"date"`
+		User apiActivityUser `json:"user"`
+	} `json:"approval"`
+	ChangesRequested *struct {
+		Date string          `json:"date"`
+		User apiActivityUser `json:"user"`
+	} `json:"changes_requested"`
+	Comment *struct {
+		Content struct {
+			Raw string `json:"raw"`
+		} `json:"content"`
+		User      apiActivityUser `json:"user"`
+		CreatedOn string          `json:"created_on"`
+	} `json:"comment"`
 }
 
-func (c *Client) ListPipelines(repoSlug string) ([]domain.Pipeline, error) {
-	url := fmt.Sprintf("https://api.bitbucket.org/2.0/repositories/%s/%s/pipelines?sort=-created_on&pagelen=30", c.config.Workspace, repoSlug)
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+// ListPullRequestActivity returns the most recent events of a pull request,
+// newest first. Only the first page is fetched; long-lived pull requests
+// have hundreds of events.
+func (c *Client) ListPullRequestActivity(ctx context.Context, repoSlug string, pullRequestID int) ([]domain.PullRequestActivity, error) {
+	url := fmt.Sprintf("%s/repositories/%s/%s/pullrequests/%d/activity?pagelen=50&%s", c.config.BaseURL(), c.config.Workspace, repoSlug, pullRequestID, pageFields(activityFields))
+
+	body, err := c.doRequest(ctx, http.MethodGet, url, acceptJSON, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	setJSONHeaders(req, c.config.BasicAuth)
+	var decoded page[apiPullRequestActivity]
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return nil, fmt.Errorf("unable to decode pull request activity response: %w", err)
+	}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+	activity := make([]domain.PullRequestActivity, 0, len(decoded.Values))
+	for _, item := range decoded.Values {
+		switch {
+		case item.Update != nil:
+			activity = append(activity, domain.PullRequestActivity{Kind: "update", Author: item.Update.Author.DisplayName, Date: item.Update.Date, Detail: item.Update.State})
+		case item.Approval != nil:
+			activity = append(activity, domain.PullRequestActivity{Kind: "approval", Author: item.Approval.User.DisplayName, Date: item.Approval.Date})
+		case item.ChangesRequested != nil:
+			activity = append(activity, domain.PullRequestActivity{Kind: "changes_requested", Author: item.ChangesRequested.User.DisplayName, Date: item.ChangesRequested.Date})
+		case item.Comment != nil:
+			activity = append(activity, domain.PullRequestActivity{Kind: "comment", Author: item.Comment.User.DisplayName, Date: item.Comment.CreatedOn, Detail: item.Comment.Content.Raw})
+		}
 	}
-	defer resp.Body.Close()
+	return activity, nil
+}
+
+func (c *Client) listPullRequestPages(ctx context.Context, url string) ([]domain.PullRequest, error) {
+	return listAll(ctx, c, url, "pull requests", mapAPIPullRequest)
+}
 
-	body, err := io.ReadAll(resp.Body)
+func (c *Client) GetPullRequest(ctx context.Context, repoSlug string, pullRequestID int) (domain.PullRequest, error) {
+	url := fmt.Sprintf("%s/repositories/%s/%s/pullrequests/%d", c.config.BaseURL(), c.config.Workspace, repoSlug, pullRequestID)
+
+	body, err := c.doRequest(ctx, http.MethodGet, url, acceptJSON, nil)
 	if err != nil {
-		return nil, err
+		return domain.PullRequest{}, err
+	}
+
+	var decoded apiPullRequest
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return domain.PullRequest{}, fmt.Errorf("unable to decode pull request response: %w", err)
 	}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("non-success status code: %d, response: %s", resp.StatusCode, string(body))
+	return mapAPIPullRequest(decoded), nil
+}
+
+func (c *Client) ListPipelines(ctx context.Context, repoSlug string) ([]domain.Pipeline, error) {
+	url := fmt.Sprintf("%s/repositories/%s/%s/pipelines?sort=-created_on&pagelen=30&%s", c.config.BaseURL(), c.config.Workspace, repoSlug, pageFields(pipelineFields))
+	body, err := c.doRequest(ctx, http.MethodGet, url, acceptJSON, nil)
+	if err != nil {
+		return nil, err
 	}
 
-	var decoded pipelinesResponse
+	var decoded page[apiPipeline]
 	if err := json.Unmarshal(body, &decoded); err != nil {
 		return nil, fmt.Errorf("unable to decode pipelines response: %w", err)
 	}
@@ -441,343 +906,932 @@ func (c *Client) ListPipelines(repoSlug string) ([]domain.Pipeline, error) {
 	return pipelines, nil
 }
 
-func (c *Client) ApprovePullRequest(repoSlug string, pullRequestID int) error {
-	url := fmt.Sprintf("https://api.bitbucket.org/2.0/repositories/%s/%s/pullrequests/%d/approve", c.config.Workspace, repoSlug, pullRequestID)
-	req, err := http.NewRequest(http.MethodPost, url, nil)
-	if err != nil {
-		return err
-	}
+type apiBranchRef struct {
+	Branch struct {
+		Name string `json:"name"`
+	} `json:"branch"`
+}
+
+type createPullRequestRequest struct {
+	Title             string          `json:"title"`
+	Description       string          `json:"description,omitempty"`
+	Source            apiBranchRef    `json:"source"`
+	Destination       *apiBranchRef   `json:"destination,omitempty"`
+	CloseSourceBranch bool            `json:"close_source_branch"`
+	Reviewers         []apiAccountRef `json:"reviewers,omitempty"`
+}
 
-	req.Header.Set("Authorization", c.config.BasicAuth)
-	req.Header.Set("Accept", "application/json")
+// CreatePullRequest opens a pull request and returns it as created.
+func (c *Client) CreatePullRequest(ctx context.Context, repoSlug string, input domain.NewPullRequest) (domain.PullRequest, error) {
+	url := fmt.Sprintf("%s/repositories/%s/%s/pullrequests", c.config.BaseURL(), c.config.Workspace, repoSlug)
 
-	resp, err := c.httpClient.Do(req)
+	request := createPullRequestRequest{
+		Title:             input.Title,
+		Description:       input.Description,
+		CloseSourceBranch: input.CloseSourceBranch,
+	}
+	request.Source.Branch.Name = input.SourceBranch
+	if input.DestBranch != "" {
+		request.Destination = &apiBranchRef{}
+		request.Destination.Branch.Name = input.DestBranch
+	}
+	for _, accountID := range input.Reviewers {
+		request.Reviewers = append(request.Reviewers, apiAccountRef{AccountID: accountID})
+	}
+	payload, err := json.Marshal(request)
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return domain.PullRequest{}, err
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	body, err := c.doRequest(ctx, http.MethodPost, url, acceptJSON, payload)
 	if err != nil {
-		return err
+		return domain.PullRequest{}, err
 	}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		responseText := strings.ToLower(strings.TrimSpace(string(body)))
-		if resp.StatusCode == http.StatusBadRequest && strings.Contains(responseText, "already approved") {
-			return nil
-		}
-		return fmt.Errorf("non-success status code: %d, response: %s", resp.StatusCode, string(body))
+	var decoded apiPullRequest
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return domain.PullRequest{}, fmt.Errorf("unable to decode pull request response: %w", err)
 	}
 
-	return nil
+	pr := mapAPIPullRequest(decoded)
+	if pr.RepoSlug == "" {
+		pr.RepoSlug = repoSlug
+	}
+	return pr, nil
 }
 
-func (c *Client) UnapprovePullRequest(repoSlug string, pullRequestID int) error {
-	url := fmt.Sprintf("https://api.bitbucket.org/2.0/repositories/%s/%s/pullrequests/%d/approve", c.config.Workspace, repoSlug, pullRequestID)
-	req, err := http.NewRequest(http.MethodDelete, url, nil)
+// ListPullRequestComments returns the comments of a pull request, oldest
+// first. Replies name their parent, so the threads can be rebuilt.
+func (c *Client) ListPullRequestComments(ctx context.Context, repoSlug string, pullRequestID int) ([]domain.Comment, error) {
+	url := fmt.Sprintf("%s/repositories/%s/%s/pullrequests/%d/comments?pagelen=100&sort=created_on&%s", c.config.BaseURL(), c.config.Workspace, repoSlug, pullRequestID, pageFields(pullRequestCommentFields))
+	return listAll(ctx, c, url, "pull request comments", mapAPIComment)
+}
+
+type apiTask struct {
+	ID      int `json:"id"`
+	Content struct {
+		Raw string `json:"raw"`
+	} `json:"content"`
+	State   string `json:"state"`
+	Creator struct {
+		DisplayName string `json:"display_name"`
+	} `json:"creator"`
+	Comment *struct {
+		ID int `json:"id"`
+	} `json:"comment"`
+}
+
+func mapAPITask(item apiTask) domain.Task {
+	task := domain.Task{
+		ID:       item.ID,
+		Content:  item.Content.Raw,
+		Creator:  item.Creator.DisplayName,
+		Resolved: strings.EqualFold(item.State, "RESOLVED"),
+	}
+	if item.Comment != nil {
+		task.CommentID = item.Comment.ID
+	}
+	return task
+}
+
+// ListPullRequestTasks returns the tasks of a pull request, resolved or not.
+func (c *Client) ListPullRequestTasks(ctx context.Context, repoSlug string, pullRequestID int) ([]domain.Task, error) {
+	url := fmt.Sprintf("%s/repositories/%s/%s/pullrequests/%d/tasks?pagelen=100&%s", c.config.BaseURL(), c.config.Workspace, repoSlug, pullRequestID, pageFields(taskFields))
+	return listAll(ctx, c, url, "pull request tasks", mapAPITask)
+}
+
+type createTaskRequest struct {
+	Content struct {
+		Raw string `json:"raw"`
+	} `json:"content"`
+	Comment *struct {
+		ID int `json:"id"`
+	} `json:"comment,omitempty"`
+}
+
+// CreatePullRequestTask adds an unresolved task to a pull request, attached
+// to the comment commentID unless it is 0.
+func (c *Client) CreatePullRequestTask(ctx context.Context, repoSlug string, pullRequestID int, content string, commentID int) (domain.Task, error) {
+	url := fmt.Sprintf("%s/repositories/%s/%s/pullrequests/%d/tasks", c.config.BaseURL(), c.config.Workspace, repoSlug, pullRequestID)
+
+	var request createTaskRequest
+	request.Content.Raw = content
+	if commentID != 0 {
+		request.Comment = &struct {
+			ID int `json:"id"`
+		}{ID: commentID}
+	}
+	payload, err := json.Marshal(request)
 	if err != nil {
-		return err
+		return domain.Task{}, err
 	}
+	return c.writeTask(ctx, http.MethodPost, url, payload)
+}
 
-	req.Header.Set("Authorization", c.config.BasicAuth)
-	req.Header.Set("Accept", "application/json")
+// SetPullRequestTaskResolved resolves a task or opens it again.
+func (c *Client) SetPullRequestTaskResolved(ctx context.Context, repoSlug string, pullRequestID, taskID int, resolved bool) (domain.Task, error) {
+	url := fmt.Sprintf("%s/repositories/%s/%s/pullrequests/%d/tasks/%d", c.config.BaseURL(), c.config.Workspace, repoSlug, pullRequestID, taskID)
 
-	resp, err := c.httpClient.Do(req)
+	state := "UNRESOLVED"
+	if resolved {
+		state = "RESOLVED"
+	}
+	payload, err := json.Marshal(map[string]string{"state": state})
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return domain.Task{}, err
 	}
-	defer resp.Body.Close()
+	return c.writeTask(ctx, http.MethodPut, url, payload)
+}
 
-	body, err := io.ReadAll(resp.Body)
+func (c *Client) writeTask(ctx context.Context, method, url string, payload []byte) (domain.Task, error) {
+	body, err := c.doRequest(ctx, method, url, acceptJSON, payload)
 	if err != nil {
-		return err
+		return domain.Task{}, err
 	}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		responseText := strings.ToLower(strings.TrimSpace(string(body)))
-		if resp.StatusCode == http.StatusBadRequest && (strings.Contains(responseText, "not approved") || strings.Contains(responseText, "has not approved")) {
-			return nil
-		}
-		return fmt.Errorf("non-success status code: %d, response: %s", resp.StatusCode, string(body))
+	var decoded apiTask
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return domain.Task{}, fmt.Errorf("unable to decode task response: %w", err)
 	}
+	return mapAPITask(decoded), nil
+}
 
-	return nil
+type createCommentRequest struct {
+	Content struct {
+		Raw string `json:"raw"`
+	} `json:"content"`
+	Inline *apiInline `json:"inline,omitempty"`
 }
 
-func (c *Client) ListPullRequestCommits(repoSlug string, pullRequestID int) ([]domain.Commit, error) {
-	var allCommits []domain.Commit
-	url := fmt.Sprintf("https://api.bitbucket.org/2.0/repositories/%s/%s/pullrequests/%d/commits?pagelen=50", c.config.Workspace, repoSlug, pullRequestID)
+// CreatePullRequestComment adds a top-level comment, in Markdown, to a pull
+// request.
+func (c *Client) CreatePullRequestComment(ctx context.Context, repoSlug string, pullRequestID int, content string) (domain.Comment, error) {
+	var request createCommentRequest
+	request.Content.Raw = content
+	return c.postPullRequestComment(ctx, repoSlug, pullRequestID, request)
+}
 
-	for url != "" {
-		req, err := http.NewRequest(http.MethodGet, url, nil)
-		if err != nil {
-			return nil, err
-		}
+// CreatePullRequestInlineComment adds a comment, in Markdown, on a line of a
+// pull request's diff.
+func (c *Client) CreatePullRequestInlineComment(ctx context.Context, repoSlug string, pullRequestID int, content string, anchor domain.CommentAnchor) (domain.Comment, error) {
+	var request createCommentRequest
+	request.Content.Raw = content
+	request.Inline = &apiInline{Path: anchor.Path, From: anchor.From, To: anchor.To}
+	return c.postPullRequestComment(ctx, repoSlug, pullRequestID, request)
+}
 
-		setJSONHeaders(req, c.config.BasicAuth)
+func (c *Client) postPullRequestComment(ctx context.Context, repoSlug string, pullRequestID int, request createCommentRequest) (domain.Comment, error) {
+	url := fmt.Sprintf("%s/repositories/%s/%s/pullrequests/%d/comments", c.config.BaseURL(), c.config.Workspace, repoSlug, pullRequestID)
 
-		resp, err := c.httpClient.Do(req)
-		if err != nil {
-			return nil, fmt.Errorf("request failed: %w", err)
-		}
-		defer resp.Body.Close()
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return domain.Comment{}, err
+	}
 
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+	body, err := c.doRequest(ctx, http.MethodPost, url, acceptJSON, payload)
+	if err != nil {
+		return domain.Comment{}, err
+	}
 
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("non-success status code: %d, response: %s", resp.StatusCode, string(body))
-		}
+	var decoded apiComment
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return domain.Comment{}, fmt.Errorf("unable to decode comment response: %w", err)
+	}
+	return mapAPIComment(decoded), nil
+}
 
-		var decoded commitsResponse
-		if err := json.Unmarshal(body, &decoded); err != nil {
-			return nil, fmt.Errorf("unable to decode pull request commits response: %w", err)
+func (c *Client) ApprovePullRequest(ctx context.Context, repoSlug string, pullRequestID int) error {
+	url := fmt.Sprintf("%s/repositories/%s/%s/pullrequests/%d/approve", c.config.BaseURL(), c.config.Workspace, repoSlug, pullRequestID)
+	_, err := c.doRequest(ctx, http.MethodPost, url, acceptJSON, nil)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		responseText := strings.ToLower(strings.TrimSpace(apiErr.Message))
+		if apiErr.StatusCode == http.StatusBadRequest && strings.Contains(responseText, "already approved") {
+			return nil
 		}
+	}
 
-		for _, item := range decoded.Values {
-			author := strings.TrimSpace(item.Author.User.DisplayName)
-			if author == "" {
-				author = strings.TrimSpace(item.Author.Raw)
-			}
+	return err
+}
 
-			allCommits = append(allCommits, domain.Commit{
-				Hash:    item.Hash,
-				Message: item.Message,
-				Author:  author,
-				Date:    item.Date,
-			})
+func (c *Client) UnapprovePullRequest(ctx context.Context, repoSlug string, pullRequestID int) error {
+	url := fmt.Sprintf("%s/repositories/%s/%s/pullrequests/%d/approve", c.config.BaseURL(), c.config.Workspace, repoSlug, pullRequestID)
+	_, err := c.doRequest(ctx, http.MethodDelete, url, acceptJSON, nil)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		responseText := strings.ToLower(strings.TrimSpace(apiErr.Message))
+		if apiErr.StatusCode == http.StatusBadRequest && (strings.Contains(responseText, "not approved") || strings.Contains(responseText, "has not approved")) {
+			return nil
 		}
+	}
+
+	return err
+}
 
-		url = decoded.Next
+// DeclinePullRequest declines an open pull request and returns it as
+// declined.
+func (c *Client) DeclinePullRequest(ctx context.Context, repoSlug string, pullRequestID int) (domain.PullRequest, error) {
+	url := fmt.Sprintf("%s/repositories/%s/%s/pullrequests/%d/decline", c.config.BaseURL(), c.config.Workspace, repoSlug, pullRequestID)
+
+	body, err := c.doRequest(ctx, http.MethodPost, url, acceptJSON, nil)
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+
+	var decoded apiPullRequest
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return domain.PullRequest{}, fmt.Errorf("unable to decode pull request response: %w", err)
 	}
 
-	return allCommits, nil
+	pr := mapAPIPullRequest(decoded)
+	if pr.RepoSlug == "" {
+		pr.RepoSlug = repoSlug
+	}
+	return pr, nil
 }
 
-func (c *Client) ListCommitChanges(repoSlug, commitHash string) ([]domain.CommitChange, error) {
-	var allChanges []domain.CommitChange
-	escapedHash := neturl.PathEscape(commitHash)
-	url := fmt.Sprintf("https://api.bitbucket.org/2.0/repositories/%s/%s/diffstat/%s?pagelen=100", c.config.Workspace, repoSlug, escapedHash)
+// ReopenPullRequest brings back a declined pull request. Bitbucket Cloud
+// cannot reopen one, so this opens a new pull request from the same branches
+// with the same title and description, as the web UI suggests.
+func (c *Client) ReopenPullRequest(ctx context.Context, repoSlug string, pullRequestID int) (domain.PullRequest, error) {
+	declined, err := c.GetPullRequest(ctx, repoSlug, pullRequestID)
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+	if declined.State != "DECLINED" {
+		return domain.PullRequest{}, fmt.Errorf("PR #%d is %s, not declined", pullRequestID, strings.ToLower(declined.State))
+	}
+	return c.CreatePullRequest(ctx, repoSlug, domain.NewPullRequest{
+		Title:        declined.Title,
+		Description:  declined.Description,
+		SourceBranch: declined.SourceBranch,
+		DestBranch:   declined.DestBranch,
+	})
+}
 
-	for url != "" {
-		req, err := http.NewRequest(http.MethodGet, url, nil)
-		if err != nil {
-			return nil, err
-		}
+type mergePullRequestRequest struct {
+	MergeStrategy string `json:"merge_strategy"`
+}
 
-		setJSONHeaders(req, c.config.BasicAuth)
+// apiMergeResponse is either the merged pull request or, for a merge that
+// takes a while, a task to poll until it is done.
+type apiMergeResponse struct {
+	apiPullRequest
+	TaskStatus  string `json:"task_status"`
+	MergeResult *struct {
+		PullRequest apiPullRequest `json:"pullrequest"`
+	} `json:"merge_result"`
+}
 
-		resp, err := c.httpClient.Do(req)
-		if err != nil {
-			return nil, fmt.Errorf("request failed: %w", err)
-		}
-		defer resp.Body.Close()
+// mergePollInterval and mergePollAttempts bound the wait for a merge that
+// Bitbucket finishes in the background.
+const (
+	mergePollInterval = time.Second
+	mergePollAttempts = 60
+)
 
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
-		}
+// MergePullRequest merges a pull request with strategy (merge_commit, squash
+// or fast_forward) and returns it as merged. Long merges are accepted as a
+// task, which is polled until it completes.
+func (c *Client) MergePullRequest(ctx context.Context, repoSlug string, pullRequestID int, strategy string) (domain.PullRequest, error) {
+	url := fmt.Sprintf("%s/repositories/%s/%s/pullrequests/%d/merge", c.config.BaseURL(), c.config.Workspace, repoSlug, pullRequestID)
 
-		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			return nil, fmt.Errorf("non-success status code: %d, response: %s", resp.StatusCode, string(body))
+	payload, err := json.Marshal(mergePullRequestRequest{MergeStrategy: strategy})
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+	body, err := c.doRequest(ctx, http.MethodPost, url, acceptJSON, payload)
+	for attempt := 0; ; attempt++ {
+		if err != nil {
+			return domain.PullRequest{}, err
 		}
-
-		var decoded diffstatResponse
+		var decoded apiMergeResponse
 		if err := json.Unmarshal(body, &decoded); err != nil {
-			return nil, fmt.Errorf("unable to decode diffstat response: %w", err)
+			return domain.PullRequest{}, fmt.Errorf("unable to decode merge response: %w", err)
 		}
 
-		for _, item := range decoded.Values {
-			path := strings.TrimSpace(item.New.Path)
-			if path == "" {
-				path = strings.TrimSpace(item.Old.Path)
+		merged := decoded.apiPullRequest
+		switch {
+		case decoded.TaskStatus == "":
+		case decoded.MergeResult != nil:
+			merged = decoded.MergeResult.PullRequest
+		case attempt == mergePollAttempts:
+			return domain.PullRequest{}, fmt.Errorf("merge of PR #%d is still in progress", pullRequestID)
+		default:
+			select {
+			case <-ctx.Done():
+				return domain.PullRequest{}, ctx.Err()
+			case <-time.After(mergePollInterval):
 			}
+			body, err = c.doRequest(WithoutCache(ctx), http.MethodGet, decoded.Links.Self.Href, acceptJSON, nil)
+			continue
+		}
 
-			allChanges = append(allChanges, domain.CommitChange{
-				Path:         path,
-				Status:       item.Status,
-				LinesAdded:   item.LinesAdded,
-				LinesRemoved: item.LinesRemoved,
-			})
+		pr := mapAPIPullRequest(merged)
+		if pr.RepoSlug == "" {
+			pr.RepoSlug = repoSlug
 		}
+		return pr, nil
+	}
+}
 
-		url = decoded.Next
+func (c *Client) ListPullRequestCommits(ctx context.Context, repoSlug string, pullRequestID int) ([]domain.Commit, error) {
+	url := fmt.Sprintf("%s/repositories/%s/%s/pullrequests/%d/commits?pagelen=50&%s", c.config.BaseURL(), c.config.Workspace, repoSlug, pullRequestID, pageFields(commitFields))
+	return listAll(ctx, c, url, "pull request commits", mapAPICommit)
+}
+
+// ListBranchCommits returns the newest limit commits reachable from branch,
+// newest first. Only the first page is fetched, as the history of a
+// long-lived branch has no useful end.
+func (c *Client) ListBranchCommits(ctx context.Context, repoSlug, branch string, limit int) ([]domain.Commit, error) {
+	url := fmt.Sprintf("%s/repositories/%s/%s/commits/%s?pagelen=%d&%s", c.config.BaseURL(), c.config.Workspace, repoSlug, neturl.PathEscape(branch), limit, pageFields(commitFields))
+	body, err := c.doRequest(ctx, http.MethodGet, url, acceptJSON, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded page[apiCommit]
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return nil, fmt.Errorf("unable to decode branch commits response: %w", err)
 	}
 
-	return allChanges, nil
+	commits := make([]domain.Commit, 0, len(decoded.Values))
+	for _, item := range decoded.Values {
+		commits = append(commits, mapAPICommit(item))
+	}
+	return commits, nil
 }
 
-func (c *Client) GetCommitDiff(repoSlug, commitHash string) (string, error) {
-	escapedHash := neturl.PathEscape(commitHash)
-	url := fmt.Sprintf("https://api.bitbucket.org/2.0/repositories/%s/%s/diff/%s", c.config.Workspace, repoSlug, escapedHash)
+// ListCommitsBetween returns the commits reachable from include but not from
+// exclude, newest first. It stops after limit commits and then reports that
+// there are more.
+func (c *Client) ListCommitsBetween(ctx context.Context, repoSlug, include, exclude string, limit int) ([]domain.Commit, bool, error) {
+	url := fmt.Sprintf("%s/repositories/%s/%s/commits/%s?exclude=%s&pagelen=100&%s", c.config.BaseURL(), c.config.Workspace, repoSlug, neturl.PathEscape(include), neturl.QueryEscape(exclude), pageFields(commitFields))
+
+	var commits []domain.Commit
+	more := false
+	err := paginate(ctx, c, url, "commits", func(values []apiCommit) error {
+		for _, item := range values {
+			if len(commits) == limit {
+				more = true
+				return errStopPaging
+			}
+			commits = append(commits, mapAPICommit(item))
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errStopPaging) {
+		return nil, false, err
+	}
+	return commits, more, nil
+}
 
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+// GetCommit returns a single commit with its parents.
+func (c *Client) GetCommit(ctx context.Context, repoSlug, commitHash string) (domain.Commit, error) {
+	url := fmt.Sprintf("%s/repositories/%s/%s/commit/%s?fields=%s", c.config.BaseURL(), c.config.Workspace, repoSlug, neturl.PathEscape(commitHash), strings.Join(commitFields, ","))
+
+	body, err := c.doRequest(ctx, http.MethodGet, url, acceptJSON, nil)
 	if err != nil {
-		return "", err
+		return domain.Commit{}, err
+	}
+
+	var decoded apiCommit
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return domain.Commit{}, fmt.Errorf("unable to decode commit response: %w", err)
 	}
+	return mapAPICommit(decoded), nil
+}
 
-	req.Header.Set("Authorization", c.config.BasicAuth)
-	req.Header.Set("Accept", "text/plain")
+func mapAPICommit(item apiCommit) domain.Commit {
+	author := strings.TrimSpace(item.Author.User.DisplayName)
+	if author == "" {
+		author = strings.TrimSpace(item.Author.Raw)
+	}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("request failed: %w", err)
+	commit := domain.Commit{
+		Hash:    item.Hash,
+		Message: item.Message,
+		Author:  author,
+		Date:    item.Date,
+	}
+	for _, parent := range item.Parents {
+		commit.Parents = append(commit.Parents, parent.Hash)
 	}
-	defer resp.Body.Close()
+	return commit
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", err
+// GetFileContent returns the raw content of a file at a commit.
+func (c *Client) GetFileContent(ctx context.Context, repoSlug, commitHash, path string) ([]byte, error) {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = neturl.PathEscape(segment)
 	}
+	url := fmt.Sprintf("%s/repositories/%s/%s/src/%s/%s", c.config.BaseURL(), c.config.Workspace, repoSlug, neturl.PathEscape(commitHash), strings.Join(segments, "/"))
+	return c.doRequest(ctx, http.MethodGet, url, acceptAny, nil)
+}
+
+// CreateCommit commits file changes through the src endpoint.
+func (c *Client) CreateCommit(ctx context.Context, repoSlug string, input domain.NewCommit) error {
+	url := fmt.Sprintf("%s/repositories/%s/%s/src", c.config.BaseURL(), c.config.Workspace, repoSlug)
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return "", fmt.Errorf("non-success status code: %d, response: %s", resp.StatusCode, string(body))
+	var form bytes.Buffer
+	writer := multipart.NewWriter(&form)
+	fields := [][2]string{{"message", input.Message}, {"branch", input.Branch}, {"parents", input.Parent}}
+	for _, path := range input.Deleted {
+		fields = append(fields, [2]string{"files", path})
+	}
+	for _, field := range fields {
+		if err := writer.WriteField(field[0], field[1]); err != nil {
+			return err
+		}
+	}
+	for path, content := range input.Files {
+		part, err := writer.CreateFormFile(path, path)
+		if err != nil {
+			return err
+		}
+		if _, err := part.Write(content); err != nil {
+			return err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return err
 	}
 
-	return string(body), nil
+	_, err := c.sendWithRetry(ctx, apiRequest{method: http.MethodPost, url: url, accept: acceptJSON, payload: form.Bytes(), contentType: writer.FormDataContentType()})
+	if err == nil {
+		c.cache.clear()
+	}
+	return err
+}
+
+func (c *Client) ListCommitChanges(ctx context.Context, repoSlug, commitHash string) ([]domain.CommitChange, error) {
+	escapedHash := neturl.PathEscape(commitHash)
+	url := fmt.Sprintf("%s/repositories/%s/%s/diffstat/%s?pagelen=100&%s", c.config.BaseURL(), c.config.Workspace, repoSlug, escapedHash, pageFields(diffstatFields))
+	return listAll(ctx, c, url, "diffstat", func(item apiDiffstat) domain.CommitChange {
+		path := strings.TrimSpace(item.New.Path)
+		if path == "" {
+			path = strings.TrimSpace(item.Old.Path)
+		}
+
+		return domain.CommitChange{
+			Path:         path,
+			Status:       item.Status,
+			LinesAdded:   item.LinesAdded,
+			LinesRemoved: item.LinesRemoved,
+			OldPath:      strings.TrimSpace(item.Old.Path),
+		}
+	})
 }
 
-func (c *Client) GetPullRequestDiff(repoSlug string, pullRequestID int) (string, error) {
-	url := fmt.Sprintf("https://api.bitbucket.org/2.0/repositories/%s/%s/pullrequests/%d/diff", c.config.Workspace, repoSlug, pullRequestID)
+func (c *Client) GetCommitDiff(ctx context.Context, repoSlug, commitHash string) (string, error) {
+	escapedHash := neturl.PathEscape(commitHash)
+	url := fmt.Sprintf("%s/repositories/%s/%s/diff/%s", c.config.BaseURL(), c.config.Workspace, repoSlug, escapedHash)
 
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+	body, err := c.doRequest(ctx, http.MethodGet, url, acceptText, nil)
 	if err != nil {
 		return "", err
 	}
 
-	req.Header.Set("Authorization", c.config.BasicAuth)
-	req.Header.Set("Accept", "text/plain")
+	return string(body), nil
+}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("request failed: %w", err)
-	}
-	defer resp.Body.Close()
+func (c *Client) GetPullRequestDiff(ctx context.Context, repoSlug string, pullRequestID int) (string, error) {
+	url := fmt.Sprintf("%s/repositories/%s/%s/pullrequests/%d/diff", c.config.BaseURL(), c.config.Workspace, repoSlug, pullRequestID)
 
-	body, err := io.ReadAll(resp.Body)
+	body, err := c.doRequest(ctx, http.MethodGet, url, acceptText, nil)
 	if err != nil {
 		return "", err
 	}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return "", fmt.Errorf("non-success status code: %d, response: %s", resp.StatusCode, string(body))
-	}
-
 	return string(body), nil
 }
 
-func (c *Client) GetPipeline(repoSlug, pipelineUUID string) (domain.Pipeline, error) {
+func (c *Client) GetPipeline(ctx context.Context, repoSlug, pipelineUUID string) (domain.Pipeline, error) {
 	escapedUUID := neturl.PathEscape(pipelineUUID)
-	url := fmt.Sprintf("https://api.bitbucket.org/2.0/repositories/%s/%s/pipelines/%s", c.config.Workspace, repoSlug, escapedUUID)
+	url := fmt.Sprintf("%s/repositories/%s/%s/pipelines/%s", c.config.BaseURL(), c.config.Workspace, repoSlug, escapedUUID)
 
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+	body, err := c.doRequest(ctx, http.MethodGet, url, acceptJSON, nil)
 	if err != nil {
 		return domain.Pipeline{}, err
 	}
 
-	setJSONHeaders(req, c.config.BasicAuth)
+	var decoded apiPipeline
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return domain.Pipeline{}, fmt.Errorf("unable to decode pipeline response: %w", err)
+	}
+
+	return mapAPIPipeline(decoded), nil
+}
+
+// RerunPipeline starts a new pipeline on the target of an earlier one: the
+// same branch or tag and commit, custom selector or pull request.
+func (c *Client) RerunPipeline(ctx context.Context, repoSlug, pipelineUUID string) (domain.Pipeline, error) {
+	escapedUUID := neturl.PathEscape(pipelineUUID)
+	url := fmt.Sprintf("%s/repositories/%s/%s/pipelines/%s?fields=target", c.config.BaseURL(), c.config.Workspace, repoSlug, escapedUUID)
 
-	resp, err := c.httpClient.Do(req)
+	body, err := c.doRequest(ctx, http.MethodGet, url, acceptJSON, nil)
 	if err != nil {
-		return domain.Pipeline{}, fmt.Errorf("request failed: %w", err)
+		return domain.Pipeline{}, err
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	var previous struct {
+		Target apiPipelineTarget `json:"target"`
+	}
+	if err := json.Unmarshal(body, &previous); err != nil {
+		return domain.Pipeline{}, fmt.Errorf("unable to decode pipeline response: %w", err)
+	}
+	return c.runPipeline(ctx, repoSlug, previous.Target)
+}
+
+// runPipeline triggers a pipeline on target.
+func (c *Client) runPipeline(ctx context.Context, repoSlug string, target apiPipelineTarget) (domain.Pipeline, error) {
+	url := fmt.Sprintf("%s/repositories/%s/%s/pipelines/", c.config.BaseURL(), c.config.Workspace, repoSlug)
+
+	payload, err := json.Marshal(struct {
+		Target apiPipelineTarget `json:"target"`
+	}{target})
 	if err != nil {
 		return domain.Pipeline{}, err
 	}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return domain.Pipeline{}, fmt.Errorf("non-success status code: %d, response: %s", resp.StatusCode, string(body))
+	body, err := c.doRequest(ctx, http.MethodPost, url, acceptJSON, payload)
+	if err != nil {
+		return domain.Pipeline{}, err
 	}
 
 	var decoded apiPipeline
 	if err := json.Unmarshal(body, &decoded); err != nil {
 		return domain.Pipeline{}, fmt.Errorf("unable to decode pipeline response: %w", err)
 	}
-
 	return mapAPIPipeline(decoded), nil
 }
 
-func (c *Client) ListPipelineSteps(repoSlug, pipelineUUID string) ([]domain.PipelineStep, error) {
+func (c *Client) ListPipelineSteps(ctx context.Context, repoSlug, pipelineUUID string) ([]domain.PipelineStep, error) {
 	escapedUUID := neturl.PathEscape(pipelineUUID)
-	url := fmt.Sprintf("https://api.bitbucket.org/2.0/repositories/%s/%s/pipelines/%s/steps", c.config.Workspace, repoSlug, escapedUUID)
+	url := fmt.Sprintf("%s/repositories/%s/%s/pipelines/%s/steps?pagelen=100&%s", c.config.BaseURL(), c.config.Workspace, repoSlug, escapedUUID, pageFields(pipelineStepFields))
+	return listAll(ctx, c, url, "pipeline steps", func(item apiPipelineStep) domain.PipelineStep {
+		step := domain.PipelineStep{
+			UUID:        item.UUID,
+			Name:        item.Name,
+			State:       item.State.Name,
+			Result:      item.State.Result.Name,
+			StartedOn:   item.StartedOn,
+			CompletedOn: item.CompletedOn,
+		}
+		for _, command := range item.ScriptCommands {
+			step.Commands = append(step.Commands, domain.StepCommand{Name: command.Name, Command: command.Command})
+		}
+		return step
+	})
+}
 
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+func (c *Client) GetPipelineStepLog(ctx context.Context, repoSlug, pipelineUUID, stepUUID string) (string, error) {
+	escapedPipelineUUID := neturl.PathEscape(pipelineUUID)
+	escapedStepUUID := neturl.PathEscape(stepUUID)
+	url := fmt.Sprintf("%s/repositories/%s/%s/pipelines/%s/steps/%s/log", c.config.BaseURL(), c.config.Workspace, repoSlug, escapedPipelineUUID, escapedStepUUID)
+
+	body, err := c.doRequest(ctx, http.MethodGet, url, acceptAny, nil)
 	if err != nil {
-		return nil, err
+		return "", err
+	}
+
+	return string(body), nil
+}
+
+// GetPipelineStepLogFrom returns the log of a step past its first offset
+// bytes, for following a step while it runs. It asks for the rest with a
+// Range header and bypasses the cache. A server that ignores the range sends
+// the whole log, which is cut at offset; one that has nothing past offset
+// answers 416, which is an empty result.
+func (c *Client) GetPipelineStepLogFrom(ctx context.Context, repoSlug, pipelineUUID, stepUUID string, offset int64) (string, error) {
+	escapedPipelineUUID := neturl.PathEscape(pipelineUUID)
+	escapedStepUUID := neturl.PathEscape(stepUUID)
+	url := fmt.Sprintf("%s/repositories/%s/%s/pipelines/%s/steps/%s/log", c.config.BaseURL(), c.config.Workspace, repoSlug, escapedPipelineUUID, escapedStepUUID)
+
+	req := apiRequest{method: http.MethodGet, url: url, accept: acceptAny}
+	if offset > 0 {
+		req.byteRange = fmt.Sprintf("bytes=%d-", offset)
 	}
+	resp, err := c.sendWithRetry(ctx, req)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if resp.header.Get("Content-Range") != "" {
+		return string(resp.body), nil
+	}
+	if int64(len(resp.body)) <= offset {
+		return "", nil
+	}
+	return string(resp.body[offset:]), nil
+}
 
-	setJSONHeaders(req, c.config.BasicAuth)
+// ListPipelineSchedules returns the schedules that run the repository's
+// pipelines.
+func (c *Client) ListPipelineSchedules(ctx context.Context, repoSlug string) ([]domain.PipelineSchedule, error) {
+	url := fmt.Sprintf("%s/repositories/%s/%s/pipelines_config/schedules?pagelen=100&%s", c.config.BaseURL(), c.config.Workspace, repoSlug, pageFields(scheduleFields))
+	return listAll(ctx, c, url, "pipeline schedules", mapAPISchedule)
+}
 
-	resp, err := c.httpClient.Do(req)
+// CreatePipelineSchedule adds an enabled schedule running the pipeline of
+// schedule.Branch, or its custom pipeline schedule.Pipeline, at
+// schedule.CronPattern.
+func (c *Client) CreatePipelineSchedule(ctx context.Context, repoSlug string, schedule domain.PipelineSchedule) (domain.PipelineSchedule, error) {
+	url := fmt.Sprintf("%s/repositories/%s/%s/pipelines_config/schedules", c.config.BaseURL(), c.config.Workspace, repoSlug)
+
+	request := createScheduleRequest{Type: "pipeline_schedule", Enabled: true, CronPattern: schedule.CronPattern}
+	request.Target.Type = "pipeline_ref_target"
+	request.Target.RefType = "branch"
+	request.Target.RefName = schedule.Branch
+	request.Target.Selector.Type = "branches"
+	request.Target.Selector.Pattern = schedule.Branch
+	if schedule.Pipeline != "" {
+		request.Target.Selector.Type = "custom"
+		request.Target.Selector.Pattern = schedule.Pipeline
+	}
+	payload, err := json.Marshal(request)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return domain.PipelineSchedule{}, err
 	}
-	defer resp.Body.Close()
+	return c.sendSchedule(ctx, http.MethodPost, url, payload)
+}
 
-	body, err := io.ReadAll(resp.Body)
+// SetPipelineScheduleEnabled pauses or resumes a schedule.
+func (c *Client) SetPipelineScheduleEnabled(ctx context.Context, repoSlug, scheduleUUID string, enabled bool) (domain.PipelineSchedule, error) {
+	url := fmt.Sprintf("%s/repositories/%s/%s/pipelines_config/schedules/%s", c.config.BaseURL(), c.config.Workspace, repoSlug, neturl.PathEscape(scheduleUUID))
+	payload, err := json.Marshal(map[string]bool{"enabled": enabled})
 	if err != nil {
-		return nil, err
+		return domain.PipelineSchedule{}, err
 	}
+	return c.sendSchedule(ctx, http.MethodPut, url, payload)
+}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("non-success status code: %d, response: %s", resp.StatusCode, string(body))
+func (c *Client) sendSchedule(ctx context.Context, method, url string, payload []byte) (domain.PipelineSchedule, error) {
+	body, err := c.doRequest(ctx, method, url, acceptJSON, payload)
+	if err != nil {
+		return domain.PipelineSchedule{}, err
 	}
 
-	var decoded pipelineStepsResponse
+	var decoded apiSchedule
 	if err := json.Unmarshal(body, &decoded); err != nil {
-		return nil, fmt.Errorf("unable to decode pipeline steps response: %w", err)
+		return domain.PipelineSchedule{}, fmt.Errorf("unable to decode schedule response: %w", err)
 	}
+	return mapAPISchedule(decoded), nil
+}
 
-	steps := make([]domain.PipelineStep, 0, len(decoded.Values))
-	for _, item := range decoded.Values {
-		steps = append(steps, domain.PipelineStep{
-			UUID:        item.UUID,
-			Name:        item.Name,
-			State:       item.State.Name,
-			Result:      item.State.Result.Name,
-			StartedOn:   item.StartedOn,
-			CompletedOn: item.CompletedOn,
-		})
+func (c *Client) DeletePipelineSchedule(ctx context.Context, repoSlug, scheduleUUID string) error {
+	url := fmt.Sprintf("%s/repositories/%s/%s/pipelines_config/schedules/%s", c.config.BaseURL(), c.config.Workspace, repoSlug, neturl.PathEscape(scheduleUUID))
+	_, err := c.doRequest(ctx, http.MethodDelete, url, acceptJSON, nil)
+	return err
+}
+
+func mapAPISchedule(item apiSchedule) domain.PipelineSchedule {
+	schedule := domain.PipelineSchedule{
+		UUID:        item.UUID,
+		Enabled:     item.Enabled,
+		CronPattern: item.CronPattern,
+		Branch:      item.Target.RefName,
+		CreatedOn:   item.CreatedOn,
+		UpdatedOn:   item.UpdatedOn,
+	}
+	if item.Target.Selector != nil && item.Target.Selector.Type == "custom" {
+		schedule.Pipeline = item.Target.Selector.Pattern
 	}
+	return schedule
+}
 
-	return steps, nil
+// ListPipelineArtifacts returns the files a step kept as artifacts.
+func (c *Client) ListPipelineArtifacts(ctx context.Context, repoSlug, pipelineUUID, stepUUID string) ([]domain.PipelineArtifact, error) {
+	escapedPipelineUUID := neturl.PathEscape(pipelineUUID)
+	escapedStepUUID := neturl.PathEscape(stepUUID)
+	url := fmt.Sprintf("%s/repositories/%s/%s/pipelines/%s/steps/%s/artifacts?pagelen=100&%s", c.config.BaseURL(), c.config.Workspace, repoSlug, escapedPipelineUUID, escapedStepUUID, pageFields(artifactFields))
+	return listAll(ctx, c, url, "pipeline artifacts", func(item apiArtifact) domain.PipelineArtifact {
+		return domain.PipelineArtifact{
+			UUID:      item.UUID,
+			Path:      item.Path,
+			Size:      item.Size,
+			CreatedOn: item.CreatedOn,
+		}
+	})
 }
 
-func (c *Client) GetPipelineStepLog(repoSlug, pipelineUUID, stepUUID string) (string, error) {
+// DownloadPipelineArtifact writes the content of an artifact to w as it
+// arrives, so large files are not held in memory. The API redirects to the
+// file's storage; the response bypasses the cache and is not retried once
+// part of it has been written.
+func (c *Client) DownloadPipelineArtifact(ctx context.Context, repoSlug, pipelineUUID, stepUUID, artifactUUID string, w io.Writer) error {
 	escapedPipelineUUID := neturl.PathEscape(pipelineUUID)
 	escapedStepUUID := neturl.PathEscape(stepUUID)
-	url := fmt.Sprintf("https://api.bitbucket.org/2.0/repositories/%s/%s/pipelines/%s/steps/%s/log", c.config.Workspace, repoSlug, escapedPipelineUUID, escapedStepUUID)
+	escapedArtifactUUID := neturl.PathEscape(artifactUUID)
+	url := fmt.Sprintf("%s/repositories/%s/%s/pipelines/%s/steps/%s/artifacts/%s/content", c.config.BaseURL(), c.config.Workspace, repoSlug, escapedPipelineUUID, escapedStepUUID, escapedArtifactUUID)
+	_, err := c.sendWithRetry(ctx, apiRequest{method: http.MethodGet, url: url, accept: acceptAny, output: w})
+	return err
+}
 
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+// ListEnvironments returns the deployment environments of a repository.
+func (c *Client) ListEnvironments(ctx context.Context, repoSlug string) ([]domain.Environment, error) {
+	url := fmt.Sprintf("%s/repositories/%s/%s/environments?pagelen=100&%s", c.config.BaseURL(), c.config.Workspace, repoSlug, pageFields(environmentFields))
+	return listAll(ctx, c, url, "environments", func(item apiEnvironment) domain.Environment {
+		return domain.Environment{
+			UUID: item.UUID,
+			Name: item.Name,
+			Type: item.EnvironmentType.Name,
+			Rank: item.Rank,
+		}
+	})
+}
+
+// ListDeployments returns the 100 most recently started deployments of a
+// repository, across its environments. Deployments waiting for their step
+// to be run have not started and may sort either end.
+func (c *Client) ListDeployments(ctx context.Context, repoSlug string) ([]domain.Deployment, error) {
+	url := fmt.Sprintf("%s/repositories/%s/%s/deployments?sort=-state.started_on&pagelen=100&%s", c.config.BaseURL(), c.config.Workspace, repoSlug, pageFields(deploymentFields))
+	body, err := c.doRequest(ctx, http.MethodGet, url, acceptJSON, nil)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	req.Header.Set("Authorization", c.config.BasicAuth)
-	req.Header.Set("Accept", "*/*")
+	var decoded page[apiDeployment]
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return nil, fmt.Errorf("unable to decode deployments response: %w", err)
+	}
+
+	deployments := make([]domain.Deployment, 0, len(decoded.Values))
+	for _, item := range decoded.Values {
+		deployments = append(deployments, domain.Deployment{
+			UUID:            item.UUID,
+			EnvironmentUUID: item.Environment.UUID,
+			State:           item.State.Name,
+			Result:          item.State.Status.Name,
+			Release:         item.Release.Name,
+			CommitHash:      item.Release.Commit.Hash,
+			PipelineUUID:    item.Deployable.Pipeline.UUID,
+			StepUUID:        item.Step.UUID,
+			CreatedOn:       item.Release.CreatedOn,
+			StartedOn:       item.State.StartedOn,
+			CompletedOn:     item.State.CompletedOn,
+		})
+	}
+	return deployments, nil
+}
 
-	resp, err := c.httpClient.Do(req)
+// ListIssues returns the 50 most recently updated issues of a repository.
+// Repositories without the issue tracker enabled answer with ErrNotFound.
+func (c *Client) ListIssues(ctx context.Context, repoSlug string) ([]domain.Issue, error) {
+	url := fmt.Sprintf("%s/repositories/%s/%s/issues?sort=-updated_on&pagelen=50&%s", c.config.BaseURL(), c.config.Workspace, repoSlug, pageFields(issueFields))
+	body, err := c.doRequest(ctx, http.MethodGet, url, acceptJSON, nil)
 	if err != nil {
-		return "", fmt.Errorf("request failed: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	var decoded page[apiIssue]
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return nil, fmt.Errorf("unable to decode issues response: %w", err)
+	}
+
+	issues := make([]domain.Issue, 0, len(decoded.Values))
+	for _, item := range decoded.Values {
+		issues = append(issues, mapAPIIssue(item))
+	}
+	return issues, nil
+}
+
+// ListIssueComments returns the comments of an issue, oldest first. The
+// empty comments Bitbucket records for state and assignee changes are left
+// out.
+func (c *Client) ListIssueComments(ctx context.Context, repoSlug string, issueID int) ([]domain.Comment, error) {
+	url := fmt.Sprintf("%s/repositories/%s/%s/issues/%d/comments?pagelen=100&%s", c.config.BaseURL(), c.config.Workspace, repoSlug, issueID, pageFields(commentFields))
+	comments, err := listAll(ctx, c, url, "issue comments", mapAPIComment)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return "", fmt.Errorf("non-success status code: %d, response: %s", resp.StatusCode, string(body))
+	written := comments[:0]
+	for _, comment := range comments {
+		if strings.TrimSpace(comment.Content) != "" {
+			written = append(written, comment)
+		}
 	}
+	return written, nil
+}
 
-	return string(body), nil
+type createIssueRequest struct {
+	Title   string `json:"title"`
+	Kind    string `json:"kind,omitempty"`
+	Content struct {
+		Raw string `json:"raw"`
+	} `json:"content"`
+}
+
+// CreateIssue files a new issue and returns it as created.
+func (c *Client) CreateIssue(ctx context.Context, repoSlug string, input domain.NewIssue) (domain.Issue, error) {
+	url := fmt.Sprintf("%s/repositories/%s/%s/issues", c.config.BaseURL(), c.config.Workspace, repoSlug)
+
+	request := createIssueRequest{Title: input.Title, Kind: input.Kind}
+	request.Content.Raw = input.Content
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return domain.Issue{}, err
+	}
+
+	return c.writeIssue(ctx, http.MethodPost, url, payload)
+}
+
+type apiAccountRef struct {
+	AccountID string `json:"account_id"`
+}
+
+// UpdateIssue changes the state and/or assignee of an issue and returns it
+// as updated.
+func (c *Client) UpdateIssue(ctx context.Context, repoSlug string, issueID int, update domain.IssueUpdate) (domain.Issue, error) {
+	url := fmt.Sprintf("%s/repositories/%s/%s/issues/%d", c.config.BaseURL(), c.config.Workspace, repoSlug, issueID)
+
+	// A map rather than a struct, since an explicit null assignee is how an
+	// issue is unassigned while an absent one leaves it alone.
+	request := map[string]any{}
+	if update.State != "" {
+		request["state"] = update.State
+	}
+	if update.Assignee != nil {
+		if *update.Assignee == "" {
+			request["assignee"] = nil
+		} else {
+			request["assignee"] = apiAccountRef{AccountID: *update.Assignee}
+		}
+	}
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return domain.Issue{}, err
+	}
+
+	return c.writeIssue(ctx, http.MethodPut, url, payload)
+}
+
+func (c *Client) writeIssue(ctx context.Context, method, url string, payload []byte) (domain.Issue, error) {
+	body, err := c.doRequest(ctx, method, url, acceptJSON, payload)
+	if err != nil {
+		return domain.Issue{}, err
+	}
+
+	var decoded apiIssue
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return domain.Issue{}, fmt.Errorf("unable to decode issue response: %w", err)
+	}
+	return mapAPIIssue(decoded), nil
+}
+
+// ListDownloads returns the files in a repository's Downloads section,
+// newest first.
+func (c *Client) ListDownloads(ctx context.Context, repoSlug string) ([]domain.Download, error) {
+	url := fmt.Sprintf("%s/repositories/%s/%s/downloads?pagelen=100&%s", c.config.BaseURL(), c.config.Workspace, repoSlug, pageFields(downloadFields))
+	return listAll(ctx, c, url, "downloads", func(item apiDownload) domain.Download {
+		return domain.Download{
+			Name:      item.Name,
+			Size:      item.Size,
+			CreatedOn: item.CreatedOn,
+			Downloads: item.Downloads,
+			Uploader:  item.User.DisplayName,
+		}
+	})
+}
+
+// GetDownload writes the content of a file in the Downloads section to w as
+// it arrives, like DownloadPipelineArtifact. The API redirects to the file's
+// storage; the response bypasses the cache and is not retried once part of
+// it has been written.
+func (c *Client) GetDownload(ctx context.Context, repoSlug, name string, w io.Writer) error {
+	url := fmt.Sprintf("%s/repositories/%s/%s/downloads/%s", c.config.BaseURL(), c.config.Workspace, repoSlug, neturl.PathEscape(name))
+	_, err := c.sendWithRetry(ctx, apiRequest{method: http.MethodGet, url: url, accept: acceptAny, output: w})
+	return err
+}
+
+// UploadDownload adds a file to the Downloads section, replacing a file of
+// the same name.
+func (c *Client) UploadDownload(ctx context.Context, repoSlug, name string, content []byte) error {
+	url := fmt.Sprintf("%s/repositories/%s/%s/downloads", c.config.BaseURL(), c.config.Workspace, repoSlug)
+
+	var form bytes.Buffer
+	writer := multipart.NewWriter(&form)
+	part, err := writer.CreateFormFile("files", name)
+	if err != nil {
+		return err
+	}
+	if _, err := part.Write(content); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	_, err = c.sendWithRetry(ctx, apiRequest{method: http.MethodPost, url: url, accept: acceptJSON, payload: form.Bytes(), contentType: writer.FormDataContentType()})
+	if err == nil {
+		c.cache.clear()
+	}
+	return err
 }
 
 func sortByUpdatedOn(repos []domain.Repository) {
@@ -786,10 +1840,15 @@ func sortByUpdatedOn(repos []domain.Repository) {
 	})
 }
 
-func setJSONHeaders(req *http.Request, authValue string) {
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", authValue)
+func mapAPIRepository(item apiRepository) domain.Repository {
+	return domain.Repository{
+		Name:       item.Name,
+		Slug:       item.Slug,
+		UUID:       item.UUID,
+		Mainbranch: item.Mainbranch.Name,
+		UpdatedOn:  item.UpdatedOn,
+		ProjectKey: item.Project.Key,
+	}
 }
 
 func mapAPIPipeline(item apiPipeline) domain.Pipeline {
@@ -807,5 +1866,129 @@ func mapAPIPipeline(item apiPipeline) domain.Pipeline {
 		CreatedOn:   item.CreatedOn,
 		StartedOn:   item.State.Stage.StartedOn,
 		CompletedOn: item.CompletedOn,
+
+		CommitHash:    item.Target.Commit.Hash,
+		CommitMessage: item.Target.Commit.Message,
+		Trigger:       strings.ToLower(item.Trigger.Name),
+		Creator:       item.Creator.DisplayName,
+	}
+}
+
+func mapAPIComment(item apiComment) domain.Comment {
+	comment := domain.Comment{
+		ID:        item.ID,
+		Author:    item.User.DisplayName,
+		Content:   item.Content.Raw,
+		CreatedOn: item.CreatedOn,
+		Deleted:   item.Deleted,
+	}
+	if item.Parent != nil {
+		comment.ParentID = item.Parent.ID
+	}
+	if item.Inline != nil {
+		comment.Anchor = domain.CommentAnchor{Path: item.Inline.Path, From: item.Inline.From, To: item.Inline.To}
+	}
+	return comment
+}
+
+func mapAPIIssue(item apiIssue) domain.Issue {
+	issue := domain.Issue{
+		ID:        item.ID,
+		Title:     item.Title,
+		Content:   item.Content.Raw,
+		State:     item.State,
+		Kind:      item.Kind,
+		Priority:  item.Priority,
+		Reporter:  item.Reporter.DisplayName,
+		CreatedOn: item.CreatedOn,
+		UpdatedOn: item.UpdatedOn,
+		URL:       item.Links.HTML.Href,
+	}
+	if item.Assignee != nil {
+		issue.Assignee = item.Assignee.DisplayName
+		issue.AssigneeAccountID = item.Assignee.AccountID
+	}
+	return issue
+}
+
+// mapAPIParticipants lists the reviewers first, including those who have
+// not taken part yet, then the other participants.
+func mapAPIParticipants(item apiPullRequest) []domain.Participant {
+	var reviewers, others []domain.Participant
+	seen := make(map[string]bool)
+	for _, participant := range item.Participants {
+		entry := domain.Participant{
+			Name:     participant.User.DisplayName,
+			UUID:     participant.User.UUID,
+			Reviewer: participant.Role == "REVIEWER",
+			Approved: participant.Approved,
+			State:    participant.State,
+		}
+		seen[entry.UUID] = true
+		if entry.Reviewer {
+			reviewers = append(reviewers, entry)
+		} else {
+			others = append(others, entry)
+		}
+	}
+	for _, reviewer := range item.Reviewers {
+		if !seen[reviewer.UUID] {
+			reviewers = append(reviewers, domain.Participant{Name: reviewer.DisplayName, UUID: reviewer.UUID, Reviewer: true})
+		}
+	}
+	return append(reviewers, others...)
+}
+
+func mapAPIPullRequest(item apiPullRequest) domain.PullRequest {
+	prURL := item.Links.HTML.Href
+	if prURL == "" {
+		prURL = item.Links.Self.Href
+	}
+
+	approvalCount := 0
+	changesRequested := 0
+	approverNames := make([]string, 0, len(item.Participants))
+	for _, participant := range item.Participants {
+		if participant.State == "changes_requested" {
+			changesRequested++
+		}
+		if participant.Approved {
+			approvalCount++
+			name := strings.TrimSpace(participant.User.DisplayName)
+			if name != "" {
+				approverNames = append(approverNames, name)
+			}
+		}
+	}
+
+	repoSlug := item.Destination.Repository.FullName
+	if index := strings.LastIndex(repoSlug, "/"); index >= 0 {
+		repoSlug = repoSlug[index+1:]
+	}
+
+	return domain.PullRequest{
+
+		ID:            item.ID,
+		RepoSlug:      repoSlug,
+		Title:         item.Title,
+		Description:   item.Description,
+		State:         item.State,
+		Draft:         item.Draft,
+		Approved:      approvalCount > 0,
+		Approvals:     approvalCount,
+		ApproverNames: approverNames,
+		Author:        item.Author.DisplayName,
+		AuthorUUID:    item.Author.UUID,
+		SourceBranch:  item.Source.Branch.Name,
+		DestBranch:    item.Destination.Branch.Name,
+		CreatedOn:     item.CreatedOn,
+		UpdatedOn:     item.UpdatedOn,
+		URL:           prURL,
+
+		TaskCount:        item.TaskCount,
+		ChangesRequested: changesRequested,
+		MergeCommit:      item.MergeCommit.Hash,
+		SourceCommit:     item.Source.Commit.Hash,
+		Participants:     mapAPIParticipants(item),
 	}
 }
@@ -0,0 +1,201 @@
+package bitbucket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	neturl "net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"bitbucket-cli/internal/config"
+)
+
+// newTestClient returns a client for the acme workspace talking to handler,
+// without retries so error tests stay fast.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	noRetries := 0
+	cfg := config.FromProfile(config.Profile{Name: "test", Workspace: "acme", MaxRetries: &noRetries}).WithBaseURL(server.URL)
+	return NewClient(cfg)
+}
+
+func TestListAllFollowsNextLinks(t *testing.T) {
+	var queries []string
+	var serverURL string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `{"values":[{"name":"feature/b","target":{"hash":"bbb"}}]}`)
+			return
+		}
+		fmt.Fprintf(w, `{"values":[{"name":"main","target":{"hash":"aaa"}}],"next":%q}`, serverURL+r.URL.Path+"?page=2")
+	})
+	serverURL = strings.TrimSuffix(client.config.BaseURL(), "/")
+
+	branches, err := client.ListBranches(context.Background(), "api")
+	if err != nil {
+		t.Fatalf("ListBranches: %v", err)
+	}
+	if len(branches) != 2 || branches[0].Name != "main" || branches[1].Name != "feature/b" || branches[1].Target.Hash != "bbb" {
+		t.Fatalf("branches = %+v, want main and feature/b", branches)
+	}
+	if len(queries) != 2 {
+		t.Fatalf("got %d requests, want 2", len(queries))
+	}
+	if !strings.Contains(queries[0], "fields=values.name") || !strings.Contains(queries[0], "next") {
+		t.Errorf("first request query %q does not select fields with next", queries[0])
+	}
+}
+
+func TestPaginateStopsWhenOnPageFails(t *testing.T) {
+	requests := 0
+	var serverURL string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprintf(w, `{"values":[1,2],"next":%q}`, serverURL+"/numbers?page=2")
+	})
+	serverURL = client.config.BaseURL()
+
+	var seen []int
+	err := paginate(context.Background(), client, serverURL+"/numbers", "numbers", func(values []int) error {
+		seen = append(seen, values...)
+		return errStopPaging
+	})
+	if !errors.Is(err, errStopPaging) {
+		t.Fatalf("err = %v, want errStopPaging", err)
+	}
+	if requests != 1 || len(seen) != 2 {
+		t.Errorf("fetched %d pages with values %v, want the first page only", requests, seen)
+	}
+}
+
+func TestPaginateStopsOnSelfLink(t *testing.T) {
+	requests := 0
+	var serverURL string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprintf(w, `{"values":[1],"next":%q}`, serverURL+"/numbers")
+	})
+	serverURL = client.config.BaseURL()
+
+	values, err := listAll(context.Background(), client, serverURL+"/numbers", "numbers", func(n int) int { return n })
+	if err != nil {
+		t.Fatalf("listAll: %v", err)
+	}
+	if requests != 1 || len(values) != 1 {
+		t.Errorf("fetched %d pages with values %v, want one page", requests, values)
+	}
+}
+
+func TestAPIErrorsUnwrapToSentinels(t *testing.T) {
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusForbidden, ErrForbidden},
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusTooManyRequests, ErrRateLimited},
+		{http.StatusBadGateway, ErrServer},
+	}
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, `{"type":"error","error":{"message":"Repository not available"}}`)
+			})
+
+			_, err := client.GetRepository(context.Background(), "api")
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("err = %v, want an *APIError", err)
+			}
+			if apiErr.StatusCode != tt.status || apiErr.Message != "Repository not available" {
+				t.Errorf("APIError = %+v, want status %d with the body's message", apiErr, tt.status)
+			}
+			if apiErr.Endpoint != "/repositories/acme/api" {
+				t.Errorf("Endpoint = %q, want the path without host and query", apiErr.Endpoint)
+			}
+		})
+	}
+}
+
+// redirectTransport sends every request to the server at target.
+type redirectTransport struct{ target *neturl.URL }
+
+func (r redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme, req.URL.Host = r.target.Scheme, r.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestOAuthRenewKeepsRefreshToken(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.Form.Get("refresh_token") != "refresh-1" {
+			http.Error(w, `{"error": "invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, `{"access_token": "access-2", "expires_in": 3600}`)
+	}))
+	t.Cleanup(server.Close)
+	target, _ := neturl.Parse(server.URL)
+
+	oauth := config.OAuth{Profile: "test", Flow: config.OAuthAuthorizationCode}
+	if err := config.SaveOAuthToken(oauth.Profile, config.OAuthToken{AccessToken: "access-1", RefreshToken: "refresh-1", ExpiresAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	tokens := newOAuthTokens(oauth, &http.Client{Transport: redirectTransport{target}})
+
+	header, err := tokens.authorization(context.Background())
+	if err != nil || header != "Bearer access-2" {
+		t.Fatalf("authorization = %q, %v; want the renewed token", header, err)
+	}
+	if tokens.token.RefreshToken != "refresh-1" {
+		t.Errorf("refresh token in memory = %q, want refresh-1 kept", tokens.token.RefreshToken)
+	}
+	saved, err := config.LoadOAuthToken(oauth.Profile)
+	if err != nil || saved.RefreshToken != "refresh-1" {
+		t.Errorf("saved refresh token = %q, %v; want refresh-1 kept", saved.RefreshToken, err)
+	}
+}
+
+func TestStepLogRangeIsNotCompressed(t *testing.T) {
+	var encoding string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		encoding = r.Header.Get("Accept-Encoding")
+		w.Header().Set("Content-Range", "bytes 5-9/10")
+		w.WriteHeader(http.StatusPartialContent)
+		fmt.Fprint(w, "world")
+	})
+
+	log, err := client.GetPipelineStepLogFrom(context.Background(), "api", "{p}", "{s}", 5)
+	if err != nil || log != "world" {
+		t.Fatalf("log = %q, %v; want world", log, err)
+	}
+	if encoding != "identity" {
+		t.Errorf("Accept-Encoding = %q on a range request, want identity", encoding)
+	}
+}
+
+func TestRecordedBodyMasksSecretAtTheCut(t *testing.T) {
+	prefix := `{"padding": "` + strings.Repeat("x", maxRecordedBody-40) + `", "access_token": "`
+	body := recordedBody([]byte(prefix + strings.Repeat("s", 100) + `"}`))
+	if strings.Contains(body, "sss") {
+		t.Errorf("secret cut by the truncation was recorded: %q", body[len(body)-80:])
+	}
+	if !strings.HasSuffix(body, "(truncated)") || len(body) > maxRecordedBody+len("\n… (truncated)") {
+		t.Errorf("recorded %d bytes ending in %q, want the truncated first %d", len(body), body[len(body)-20:], maxRecordedBody)
+	}
+}
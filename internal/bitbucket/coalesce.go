@@ -0,0 +1,58 @@
+package bitbucket
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// inflightCall is a request other callers can wait on instead of sending
+// their own.
+type inflightCall struct {
+	done chan struct{}
+	resp apiResponse
+	err  error
+}
+
+// requestGroup coalesces identical GETs: while one is in flight, later
+// callers share its result rather than making another network request.
+type requestGroup struct {
+	mu    sync.Mutex
+	calls map[string]*inflightCall
+}
+
+// do runs send unless a call for key is already running, in which case it
+// waits for that one; shared reports which happened. A waiter stops waiting
+// when its own ctx ends. If the running call was canceled by its caller, a
+// waiter that still wants the result sends the request itself.
+func (g *requestGroup) do(ctx context.Context, key string, send func() (apiResponse, error)) (resp apiResponse, shared bool, err error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*inflightCall)
+	}
+	if call, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		select {
+		case <-call.done:
+			if errors.Is(call.err, context.Canceled) && ctx.Err() == nil {
+				return g.do(ctx, key, send)
+			}
+			return call.resp, true, call.err
+		case <-ctx.Done():
+			return apiResponse{}, true, ctx.Err()
+		}
+	}
+
+	call := &inflightCall{done: make(chan struct{})}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	call.resp, call.err = send()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+	close(call.done)
+
+	return call.resp, false, call.err
+}
@@ -0,0 +1,106 @@
+package bitbucket
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	neturl "net/url"
+	"strings"
+	"time"
+)
+
+// Sentinel errors for the status classes callers react to. An *APIError
+// unwraps to one of them, so errors.Is(err, ErrNotFound) works on any error
+// returned by the client.
+var (
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrServer       = errors.New("server error")
+)
+
+// APIError is returned for responses outside the 2xx range.
+type APIError struct {
+	StatusCode int
+	Method     string
+	// Endpoint is the request path without host and query, e.g.
+	// /2.0/repositories/acme/api/pipelines/.
+	Endpoint string
+	// Message is the error message from Bitbucket's JSON error body, or the
+	// raw body when it is not JSON.
+	Message    string
+	RetryAfter time.Duration
+}
+
+func newAPIError(method, rawURL string, resp *http.Response, body []byte) *APIError {
+	endpoint := rawURL
+	if parsed, err := neturl.Parse(rawURL); err == nil {
+		endpoint = parsed.Path
+	}
+
+	return &APIError{
+		StatusCode: resp.StatusCode,
+		Method:     method,
+		Endpoint:   endpoint,
+		Message:    errorMessage(body),
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+	}
+}
+
+func (e *APIError) Error() string {
+	text := fmt.Sprintf("%s %s: %d %s", e.Method, e.Endpoint, e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Message != "" {
+		text = fmt.Sprintf("%s: %s", text, e.Message)
+	}
+	return text
+}
+
+func (e *APIError) Unwrap() error {
+	switch {
+	case e.StatusCode == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case e.StatusCode == http.StatusForbidden:
+		return ErrForbidden
+	case e.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case e.StatusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case e.StatusCode >= 500:
+		return ErrServer
+	default:
+		return nil
+	}
+}
+
+// Scope guesses the OAuth scope an endpoint needs, for hints on 403s.
+func (e *APIError) Scope() string {
+	switch {
+	case strings.Contains(e.Endpoint, "/pipelines"):
+		return "pipeline"
+	case strings.Contains(e.Endpoint, "/pullrequests"):
+		return "pullrequest"
+	case strings.Contains(e.Endpoint, "/issues"):
+		return "issue"
+	case strings.HasSuffix(e.Endpoint, "/user"):
+		return "account"
+	case strings.Contains(e.Endpoint, "/projects"):
+		return "project"
+	default:
+		return "repository"
+	}
+}
+
+// errorMessage extracts error.message from a Bitbucket error body.
+func errorMessage(body []byte) string {
+	var decoded struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &decoded); err == nil && decoded.Error.Message != "" {
+		return decoded.Error.Message
+	}
+	return strings.TrimSpace(string(body))
+}
@@ -0,0 +1,1240 @@
+// Package fake provides stand-ins for the Bitbucket API: an in-memory client
+// for driving the TUI without a network, and a fixture server that replays
+// recorded JSON responses to the real HTTP client.
+package fake
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"bitbucket-cli/internal/bitbucket"
+	"bitbucket-cli/internal/domain"
+)
+
+// Client is an in-memory bitbucket.BitbucketAPI. Fill in the exported fields
+// before use; maps are keyed as documented on each field. Calls are safe for
+// concurrent use as long as the fields are not modified at the same time.
+type Client struct {
+	mu sync.Mutex
+
+	User         domain.User
+	Workspace    domain.Workspace
+	Projects     []domain.Project
+	Repositories []domain.Repository
+	// PageSize splits Repositories into pages for ListRepositoryPage; zero
+	// returns them all on the first page.
+	PageSize int
+	// Branches, PullRequests and Pipelines are keyed by repository slug.
+	Branches     map[string][]domain.Branch
+	PullRequests map[string][]domain.PullRequest
+	Pipelines    map[string][]domain.Pipeline
+	// ReviewRequests lists, per repository slug, the IDs of the pull requests
+	// waiting for User's review.
+	ReviewRequests map[string][]int
+	// ApprovedOn records when User approved a pull request, keyed by
+	// "<repo>#<id>". ApprovePullRequest sets it.
+	ApprovedOn map[string]string
+	// Commits, PullRequestDiffs and Comments are keyed by "<repo>#<id>".
+	Commits          map[string][]domain.Commit
+	PullRequestDiffs map[string]string
+	Comments         map[string][]domain.Comment
+	// Tags is keyed by repository slug.
+	Tags map[string][]domain.Tag
+	// BranchCommits is keyed by "<repo>/<branch>", newest first.
+	BranchCommits map[string][]domain.Commit
+	// Changes and CommitDiffs are keyed by commit hash.
+	Changes     map[string][]domain.CommitChange
+	CommitDiffs map[string]string
+	// Files holds file contents keyed by "<commit>:<path>". CreateCommit
+	// records its input in NewCommits.
+	Files      map[string][]byte
+	NewCommits []domain.NewCommit
+	// Steps is keyed by pipeline UUID and StepLogs by step UUID.
+	Steps    map[string][]domain.PipelineStep
+	StepLogs map[string]string
+	// Artifacts is keyed by step UUID and ArtifactContents by artifact UUID.
+	Artifacts        map[string][]domain.PipelineArtifact
+	ArtifactContents map[string][]byte
+	// Schedules is keyed by repository slug.
+	Schedules map[string][]domain.PipelineSchedule
+	// Environments and Deployments are keyed by repository slug, deployments
+	// newest first.
+	Environments map[string][]domain.Environment
+	Deployments  map[string][]domain.Deployment
+	// Restrictions holds the branch restrictions per repository slug and
+	// Statuses the build statuses per "<repo>#<id>".
+	Restrictions map[string][]domain.BranchRestriction
+	Statuses     map[string][]domain.BuildStatus
+	// CommitStatuses is keyed by commit hash.
+	CommitStatuses map[string][]domain.BuildStatus
+	// Downloads is keyed by repository slug and DownloadContents by
+	// "<repo>/<name>".
+	Downloads        map[string][]domain.Download
+	DownloadContents map[string][]byte
+	// Issues is keyed by repository slug; a repository without an entry has
+	// no issue tracker. IssueComments is keyed by "<repo>#<id>".
+	Issues        map[string][]domain.Issue
+	IssueComments map[string][]domain.Comment
+	// Members are the workspace's users besides User.
+	Members []domain.User
+	// Activity is keyed by "<repo>#<id>", newest first.
+	Activity map[string][]domain.PullRequestActivity
+	// Tasks is keyed by "<repo>#<id>".
+	Tasks map[string][]domain.Task
+
+	// Errors makes a method fail, keyed by method name (e.g. "ListBranches").
+	Errors map[string]error
+	// Calls records every method called, in order.
+	Calls []string
+
+	Limit bitbucket.RateLimit
+}
+
+var _ bitbucket.BitbucketAPI = (*Client)(nil)
+
+// NewClient returns an empty fake with all maps allocated.
+func NewClient() *Client {
+	return &Client{
+		Branches:         make(map[string][]domain.Branch),
+		PullRequests:     make(map[string][]domain.PullRequest),
+		Pipelines:        make(map[string][]domain.Pipeline),
+		ReviewRequests:   make(map[string][]int),
+		ApprovedOn:       make(map[string]string),
+		Commits:          make(map[string][]domain.Commit),
+		BranchCommits:    make(map[string][]domain.Commit),
+		Tags:             make(map[string][]domain.Tag),
+		PullRequestDiffs: make(map[string]string),
+		Comments:         make(map[string][]domain.Comment),
+		Changes:          make(map[string][]domain.CommitChange),
+		CommitDiffs:      make(map[string]string),
+		Steps:            make(map[string][]domain.PipelineStep),
+		StepLogs:         make(map[string]string),
+		Artifacts:        make(map[string][]domain.PipelineArtifact),
+		ArtifactContents: make(map[string][]byte),
+		Schedules:        make(map[string][]domain.PipelineSchedule),
+		Environments:     make(map[string][]domain.Environment),
+		Deployments:      make(map[string][]domain.Deployment),
+		Issues:           make(map[string][]domain.Issue),
+		Restrictions:     make(map[string][]domain.BranchRestriction),
+		Statuses:         make(map[string][]domain.BuildStatus),
+		CommitStatuses:   make(map[string][]domain.BuildStatus),
+		IssueComments:    make(map[string][]domain.Comment),
+		Downloads:        make(map[string][]domain.Download),
+		Files:            make(map[string][]byte),
+		DownloadContents: make(map[string][]byte),
+		Errors:           make(map[string]error),
+		Activity:         make(map[string][]domain.PullRequestActivity),
+		Tasks:            make(map[string][]domain.Task),
+	}
+}
+
+// PullRequestKey returns the key used by Commits, PullRequestDiffs and
+// Comments. IssueComments uses the same "<repo>#<id>" form for issues.
+func PullRequestKey(repoSlug string, pullRequestID int) string {
+	return fmt.Sprintf("%s#%d", repoSlug, pullRequestID)
+}
+
+// call records the method and returns its configured error, or the context
+// error when ctx is already done.
+func (c *Client) call(ctx context.Context, method string) error {
+	c.Calls = append(c.Calls, method)
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return c.Errors[method]
+}
+
+func notFound(kind, id string) error {
+	return fmt.Errorf("%s %s: %w", kind, id, bitbucket.ErrNotFound)
+}
+
+func (c *Client) ListProjects(ctx context.Context) (string, []domain.Project, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListProjects"); err != nil {
+		return "", nil, err
+	}
+	return "200 OK", append([]domain.Project(nil), c.Projects...), nil
+}
+
+func (c *Client) GetCurrentUser(ctx context.Context) (domain.User, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "GetCurrentUser"); err != nil {
+		return domain.User{}, err
+	}
+	return c.User, nil
+}
+
+// ListWorkspaceMembers returns User followed by Members.
+func (c *Client) ListWorkspaceMembers(ctx context.Context) ([]domain.User, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListWorkspaceMembers"); err != nil {
+		return nil, err
+	}
+	return append([]domain.User{c.User}, c.Members...), nil
+}
+
+func (c *Client) GetWorkspace(ctx context.Context) (domain.Workspace, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "GetWorkspace"); err != nil {
+		return domain.Workspace{}, err
+	}
+	return c.Workspace, nil
+}
+
+func (c *Client) ListRepositoryPage(ctx context.Context, pageNumber int) ([]domain.Repository, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListRepositoryPage"); err != nil {
+		return nil, false, err
+	}
+	if c.PageSize <= 0 {
+		if pageNumber > 1 {
+			return nil, false, nil
+		}
+		return append([]domain.Repository(nil), c.Repositories...), false, nil
+	}
+	start := min((pageNumber-1)*c.PageSize, len(c.Repositories))
+	end := min(start+c.PageSize, len(c.Repositories))
+	return append([]domain.Repository(nil), c.Repositories[start:end]...), end < len(c.Repositories), nil
+}
+
+// ListProjectRepositories returns the repositories whose ProjectKey is the
+// key of the project with this repositories link.
+func (c *Client) ListProjectRepositories(ctx context.Context, repositoriesURL string) ([]domain.Repository, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListProjectRepositories"); err != nil {
+		return nil, err
+	}
+	for _, project := range c.Projects {
+		if project.RepositoriesURL != repositoriesURL {
+			continue
+		}
+		var repos []domain.Repository
+		for _, repo := range c.Repositories {
+			if repo.ProjectKey == project.Key {
+				repos = append(repos, repo)
+			}
+		}
+		return repos, nil
+	}
+	return nil, notFound("project repositories", repositoriesURL)
+}
+
+func (c *Client) ListRepositories(ctx context.Context) ([]domain.Repository, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListRepositories"); err != nil {
+		return nil, err
+	}
+	return append([]domain.Repository(nil), c.Repositories...), nil
+}
+
+func (c *Client) GetRepository(ctx context.Context, repoSlug string) (domain.Repository, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "GetRepository"); err != nil {
+		return domain.Repository{}, err
+	}
+	for _, repo := range c.Repositories {
+		if repo.Slug == repoSlug {
+			return repo, nil
+		}
+	}
+	return domain.Repository{}, notFound("repository", repoSlug)
+}
+
+func (c *Client) ListBranches(ctx context.Context, repoSlug string) ([]domain.Branch, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListBranches"); err != nil {
+		return nil, err
+	}
+	return append([]domain.Branch(nil), c.Branches[repoSlug]...), nil
+}
+
+func (c *Client) GetBranch(ctx context.Context, repoSlug, name string) (domain.Branch, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "GetBranch"); err != nil {
+		return domain.Branch{}, err
+	}
+	for _, branch := range c.Branches[repoSlug] {
+		if branch.Name == name {
+			return branch, nil
+		}
+	}
+	return domain.Branch{}, notFound("branch", name)
+}
+
+// CreateBranch adds a branch at targetHash, or at the head of the branch
+// targetHash names. An existing name fails like Bitbucket does.
+func (c *Client) CreateBranch(ctx context.Context, repoSlug, name, targetHash string) (domain.Branch, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "CreateBranch"); err != nil {
+		return domain.Branch{}, err
+	}
+	target := domain.BranchTarget{Hash: targetHash, Date: time.Now().UTC().Format(time.RFC3339)}
+	for _, branch := range c.Branches[repoSlug] {
+		if branch.Name == name {
+			return domain.Branch{}, fmt.Errorf("branch %s already exists", name)
+		}
+		if branch.Name == targetHash {
+			target = branch.Target
+		}
+	}
+	branch := domain.Branch{Name: name, Target: target}
+	c.Branches[repoSlug] = append(c.Branches[repoSlug], branch)
+	return branch, nil
+}
+
+func (c *Client) DeleteBranch(ctx context.Context, repoSlug, name string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "DeleteBranch"); err != nil {
+		return err
+	}
+	branches := c.Branches[repoSlug]
+	for i := range branches {
+		if branches[i].Name == name {
+			c.Branches[repoSlug] = slices.Delete(branches, i, i+1)
+			return nil
+		}
+	}
+	return notFound("branch", name)
+}
+
+func (c *Client) ListTags(ctx context.Context, repoSlug string) ([]domain.Tag, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListTags"); err != nil {
+		return nil, err
+	}
+	return append([]domain.Tag(nil), c.Tags[repoSlug]...), nil
+}
+
+// CreateTag tags targetHash, or the head of the branch it names.
+func (c *Client) CreateTag(ctx context.Context, repoSlug, name, targetHash string) (domain.Tag, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "CreateTag"); err != nil {
+		return domain.Tag{}, err
+	}
+	for _, tag := range c.Tags[repoSlug] {
+		if tag.Name == name {
+			return domain.Tag{}, fmt.Errorf("tag %s already exists", name)
+		}
+	}
+	tag := domain.Tag{Name: name, Target: domain.BranchTarget{Hash: targetHash, Date: time.Now().UTC().Format(time.RFC3339)}}
+	for _, branch := range c.Branches[repoSlug] {
+		if branch.Name == targetHash {
+			tag.Target = branch.Target
+		}
+	}
+	c.Tags[repoSlug] = append(c.Tags[repoSlug], tag)
+	return tag, nil
+}
+
+func (c *Client) DeleteTag(ctx context.Context, repoSlug, name string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "DeleteTag"); err != nil {
+		return err
+	}
+	tags := c.Tags[repoSlug]
+	for i := range tags {
+		if tags[i].Name == name {
+			c.Tags[repoSlug] = slices.Delete(tags, i, i+1)
+			return nil
+		}
+	}
+	return notFound("tag", name)
+}
+
+func (c *Client) ListPullRequests(ctx context.Context, repoSlug string) ([]domain.PullRequest, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListPullRequests"); err != nil {
+		return nil, err
+	}
+	return append([]domain.PullRequest(nil), c.PullRequests[repoSlug]...), nil
+}
+
+// ListPullRequestsByState returns the pull requests of repoSlug in state, or
+// all of them for ALL. A pull request without a state counts as OPEN.
+func (c *Client) ListPullRequestsByState(ctx context.Context, repoSlug, state string) ([]domain.PullRequest, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListPullRequestsByState"); err != nil {
+		return nil, err
+	}
+	var prs []domain.PullRequest
+	for _, pr := range c.PullRequests[repoSlug] {
+		prState := pr.State
+		if prState == "" {
+			prState = "OPEN"
+		}
+		if strings.EqualFold(state, "ALL") || strings.EqualFold(prState, state) {
+			prs = append(prs, pr)
+		}
+	}
+	return prs, nil
+}
+
+// ListUserPullRequests returns the pull requests authored by User, across all
+// repositories. Any other UUID has no pull requests.
+func (c *Client) ListUserPullRequests(ctx context.Context, userUUID string) ([]domain.PullRequest, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListUserPullRequests"); err != nil {
+		return nil, err
+	}
+	if userUUID != c.User.UUID {
+		return nil, nil
+	}
+	var prs []domain.PullRequest
+	for _, repo := range c.Repositories {
+		for _, pr := range c.PullRequests[repo.Slug] {
+			if pr.Author == c.User.DisplayName {
+				prs = append(prs, pr)
+			}
+		}
+	}
+	return prs, nil
+}
+
+func (c *Client) ListReviewPullRequests(ctx context.Context, repoSlug, userUUID string) ([]domain.PullRequest, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListReviewPullRequests"); err != nil {
+		return nil, err
+	}
+	if userUUID != c.User.UUID {
+		return nil, nil
+	}
+	var prs []domain.PullRequest
+	for _, id := range c.ReviewRequests[repoSlug] {
+		if pr := c.findPullRequest(repoSlug, id); pr != nil {
+			prs = append(prs, *pr)
+		}
+	}
+	return prs, nil
+}
+
+// ListRecentPullRequests returns the pull requests of repoSlug in every state,
+// most recently updated first.
+func (c *Client) ListRecentPullRequests(ctx context.Context, repoSlug string) ([]domain.PullRequest, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListRecentPullRequests"); err != nil {
+		return nil, err
+	}
+	prs := append([]domain.PullRequest(nil), c.PullRequests[repoSlug]...)
+	sort.SliceStable(prs, func(i, j int) bool { return prs[i].UpdatedOn > prs[j].UpdatedOn })
+	return prs, nil
+}
+
+// ListApprovedPullRequests returns the open pull requests of repoSlug that
+// User approved, with ApprovedOn as the time they took part.
+func (c *Client) ListApprovedPullRequests(ctx context.Context, repoSlug, userUUID string) ([]domain.Approval, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListApprovedPullRequests"); err != nil {
+		return nil, err
+	}
+	if userUUID != c.User.UUID {
+		return nil, nil
+	}
+	var approvals []domain.Approval
+	for _, pr := range c.PullRequests[repoSlug] {
+		if (pr.State == "" || pr.State == "OPEN") && slices.Contains(pr.ApproverNames, c.User.DisplayName) {
+			approvals = append(approvals, domain.Approval{PullRequest: pr, ParticipatedOn: c.ApprovedOn[PullRequestKey(repoSlug, pr.ID)]})
+		}
+	}
+	return approvals, nil
+}
+
+func (c *Client) ListBranchRestrictions(ctx context.Context, repoSlug string) ([]domain.BranchRestriction, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListBranchRestrictions"); err != nil {
+		return nil, err
+	}
+	return append([]domain.BranchRestriction(nil), c.Restrictions[repoSlug]...), nil
+}
+
+func (c *Client) ListPullRequestStatuses(ctx context.Context, repoSlug string, pullRequestID int) ([]domain.BuildStatus, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListPullRequestStatuses"); err != nil {
+		return nil, err
+	}
+	return append([]domain.BuildStatus(nil), c.Statuses[PullRequestKey(repoSlug, pullRequestID)]...), nil
+}
+
+func (c *Client) ListCommitStatuses(ctx context.Context, repoSlug, commitHash string) ([]domain.BuildStatus, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListCommitStatuses"); err != nil {
+		return nil, err
+	}
+	return append([]domain.BuildStatus(nil), c.CommitStatuses[commitHash]...), nil
+}
+
+func (c *Client) ListPullRequestActivity(ctx context.Context, repoSlug string, pullRequestID int) ([]domain.PullRequestActivity, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListPullRequestActivity"); err != nil {
+		return nil, err
+	}
+	return append([]domain.PullRequestActivity(nil), c.Activity[PullRequestKey(repoSlug, pullRequestID)]...), nil
+}
+
+func (c *Client) GetPullRequest(ctx context.Context, repoSlug string, pullRequestID int) (domain.PullRequest, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "GetPullRequest"); err != nil {
+		return domain.PullRequest{}, err
+	}
+	pr := c.findPullRequest(repoSlug, pullRequestID)
+	if pr == nil {
+		return domain.PullRequest{}, notFound("pull request", PullRequestKey(repoSlug, pullRequestID))
+	}
+	return *pr, nil
+}
+
+// CreatePullRequest appends an open pull request to the repository, numbered
+// after the highest existing ID.
+func (c *Client) CreatePullRequest(ctx context.Context, repoSlug string, input domain.NewPullRequest) (domain.PullRequest, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "CreatePullRequest"); err != nil {
+		return domain.PullRequest{}, err
+	}
+
+	id := 1
+	for _, pr := range c.PullRequests[repoSlug] {
+		id = max(id, pr.ID+1)
+	}
+	pr := domain.PullRequest{
+		ID:           id,
+		RepoSlug:     repoSlug,
+		Title:        input.Title,
+		Description:  input.Description,
+		State:        "OPEN",
+		Author:       c.User.DisplayName,
+		SourceBranch: input.SourceBranch,
+		DestBranch:   input.DestBranch,
+	}
+	for _, accountID := range input.Reviewers {
+		for _, member := range c.Members {
+			if member.AccountID == accountID {
+				pr.Participants = append(pr.Participants, domain.Participant{Name: member.DisplayName, UUID: member.UUID, Reviewer: true})
+			}
+		}
+	}
+	c.PullRequests[repoSlug] = append(c.PullRequests[repoSlug], pr)
+	return pr, nil
+}
+
+func (c *Client) ListPullRequestComments(ctx context.Context, repoSlug string, pullRequestID int) ([]domain.Comment, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListPullRequestComments"); err != nil {
+		return nil, err
+	}
+	key := PullRequestKey(repoSlug, pullRequestID)
+	if c.findPullRequest(repoSlug, pullRequestID) == nil {
+		return nil, notFound("pull request", key)
+	}
+	return append([]domain.Comment(nil), c.Comments[key]...), nil
+}
+
+// CreatePullRequestComment appends a comment by User, numbered after the
+// highest comment ID of the pull request.
+func (c *Client) CreatePullRequestComment(ctx context.Context, repoSlug string, pullRequestID int, content string) (domain.Comment, error) {
+	return c.addComment(ctx, "CreatePullRequestComment", repoSlug, pullRequestID, domain.Comment{Content: content})
+}
+
+// CreatePullRequestInlineComment is CreatePullRequestComment anchored to a
+// line of the diff.
+func (c *Client) CreatePullRequestInlineComment(ctx context.Context, repoSlug string, pullRequestID int, content string, anchor domain.CommentAnchor) (domain.Comment, error) {
+	return c.addComment(ctx, "CreatePullRequestInlineComment", repoSlug, pullRequestID, domain.Comment{Content: content, Anchor: anchor})
+}
+
+func (c *Client) addComment(ctx context.Context, method, repoSlug string, pullRequestID int, comment domain.Comment) (domain.Comment, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, method); err != nil {
+		return domain.Comment{}, err
+	}
+
+	key := PullRequestKey(repoSlug, pullRequestID)
+	if c.findPullRequest(repoSlug, pullRequestID) == nil {
+		return domain.Comment{}, notFound("pull request", key)
+	}
+	comment.ID = 1
+	for _, existing := range c.Comments[key] {
+		comment.ID = max(comment.ID, existing.ID+1)
+	}
+	comment.Author = c.User.DisplayName
+	c.Comments[key] = append(c.Comments[key], comment)
+	return comment, nil
+}
+
+func (c *Client) ListPullRequestTasks(ctx context.Context, repoSlug string, pullRequestID int) ([]domain.Task, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListPullRequestTasks"); err != nil {
+		return nil, err
+	}
+	key := PullRequestKey(repoSlug, pullRequestID)
+	if c.findPullRequest(repoSlug, pullRequestID) == nil {
+		return nil, notFound("pull request", key)
+	}
+	return append([]domain.Task(nil), c.Tasks[key]...), nil
+}
+
+// CreatePullRequestTask appends an unresolved task by User and counts it in
+// the pull request's TaskCount.
+func (c *Client) CreatePullRequestTask(ctx context.Context, repoSlug string, pullRequestID int, content string, commentID int) (domain.Task, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "CreatePullRequestTask"); err != nil {
+		return domain.Task{}, err
+	}
+
+	key := PullRequestKey(repoSlug, pullRequestID)
+	pr := c.findPullRequest(repoSlug, pullRequestID)
+	if pr == nil {
+		return domain.Task{}, notFound("pull request", key)
+	}
+	task := domain.Task{ID: 1, Content: content, Creator: c.User.DisplayName, CommentID: commentID}
+	for _, existing := range c.Tasks[key] {
+		task.ID = max(task.ID, existing.ID+1)
+	}
+	c.Tasks[key] = append(c.Tasks[key], task)
+	pr.TaskCount++
+	return task, nil
+}
+
+func (c *Client) SetPullRequestTaskResolved(ctx context.Context, repoSlug string, pullRequestID, taskID int, resolved bool) (domain.Task, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "SetPullRequestTaskResolved"); err != nil {
+		return domain.Task{}, err
+	}
+
+	key := PullRequestKey(repoSlug, pullRequestID)
+	pr := c.findPullRequest(repoSlug, pullRequestID)
+	if pr == nil {
+		return domain.Task{}, notFound("pull request", key)
+	}
+	for i := range c.Tasks[key] {
+		task := &c.Tasks[key][i]
+		if task.ID != taskID {
+			continue
+		}
+		if task.Resolved != resolved {
+			task.Resolved = resolved
+			if resolved {
+				pr.TaskCount--
+			} else {
+				pr.TaskCount++
+			}
+		}
+		return *task, nil
+	}
+	return domain.Task{}, notFound("task", fmt.Sprintf("%s/%d", key, taskID))
+}
+
+func (c *Client) ApprovePullRequest(ctx context.Context, repoSlug string, pullRequestID int) error {
+	return c.setApproved(ctx, "ApprovePullRequest", repoSlug, pullRequestID, true)
+}
+
+func (c *Client) UnapprovePullRequest(ctx context.Context, repoSlug string, pullRequestID int) error {
+	return c.setApproved(ctx, "UnapprovePullRequest", repoSlug, pullRequestID, false)
+}
+
+// MergePullRequest marks an open pull request MERGED. The strategy is
+// ignored.
+func (c *Client) MergePullRequest(ctx context.Context, repoSlug string, pullRequestID int, strategy string) (domain.PullRequest, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "MergePullRequest"); err != nil {
+		return domain.PullRequest{}, err
+	}
+	key := PullRequestKey(repoSlug, pullRequestID)
+	pr := c.findPullRequest(repoSlug, pullRequestID)
+	if pr == nil {
+		return domain.PullRequest{}, notFound("pull request", key)
+	}
+	if pr.State != "OPEN" {
+		return domain.PullRequest{}, fmt.Errorf("pull request %s is %s", key, pr.State)
+	}
+	pr.State = "MERGED"
+	pr.MergeCommit = fmt.Sprintf("%040x", pullRequestID)
+	return *pr, nil
+}
+
+// DeclinePullRequest marks an open pull request DECLINED.
+func (c *Client) DeclinePullRequest(ctx context.Context, repoSlug string, pullRequestID int) (domain.PullRequest, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "DeclinePullRequest"); err != nil {
+		return domain.PullRequest{}, err
+	}
+	key := PullRequestKey(repoSlug, pullRequestID)
+	pr := c.findPullRequest(repoSlug, pullRequestID)
+	if pr == nil {
+		return domain.PullRequest{}, notFound("pull request", key)
+	}
+	if pr.State != "OPEN" {
+		return domain.PullRequest{}, fmt.Errorf("pull request %s is %s", key, pr.State)
+	}
+	pr.State = "DECLINED"
+	return *pr, nil
+}
+
+// ReopenPullRequest opens a new pull request like a declined one, as the
+// real client does.
+func (c *Client) ReopenPullRequest(ctx context.Context, repoSlug string, pullRequestID int) (domain.PullRequest, error) {
+	c.mu.Lock()
+	key := PullRequestKey(repoSlug, pullRequestID)
+	pr := c.findPullRequest(repoSlug, pullRequestID)
+	err := c.call(ctx, "ReopenPullRequest")
+	switch {
+	case err != nil:
+	case pr == nil:
+		err = notFound("pull request", key)
+	case pr.State != "DECLINED":
+		err = fmt.Errorf("pull request %s is %s", key, pr.State)
+	}
+	if err != nil {
+		c.mu.Unlock()
+		return domain.PullRequest{}, err
+	}
+	input := domain.NewPullRequest{Title: pr.Title, Description: pr.Description, SourceBranch: pr.SourceBranch, DestBranch: pr.DestBranch}
+	c.mu.Unlock()
+	return c.CreatePullRequest(ctx, repoSlug, input)
+}
+
+func (c *Client) setApproved(ctx context.Context, method, repoSlug string, pullRequestID int, approved bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, method); err != nil {
+		return err
+	}
+	pr := c.findPullRequest(repoSlug, pullRequestID)
+	if pr == nil {
+		return notFound("pull request", PullRequestKey(repoSlug, pullRequestID))
+	}
+	if pr.Approved == approved {
+		return nil
+	}
+	pr.Approved = approved
+	c.setParticipant(pr, c.User, approved)
+	if approved {
+		c.ApprovedOn[PullRequestKey(repoSlug, pullRequestID)] = time.Now().UTC().Format(time.RFC3339)
+		pr.Approvals++
+		pr.ApproverNames = append(pr.ApproverNames, c.User.DisplayName)
+		return nil
+	}
+	pr.Approvals--
+	for i, name := range pr.ApproverNames {
+		if name == c.User.DisplayName {
+			pr.ApproverNames = append(pr.ApproverNames[:i:i], pr.ApproverNames[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// setParticipant records user's approval on pr, adding them as a
+// participant when they are not one yet.
+func (c *Client) setParticipant(pr *domain.PullRequest, user domain.User, approved bool) {
+	state := ""
+	if approved {
+		state = "approved"
+	}
+	for i := range pr.Participants {
+		if pr.Participants[i].UUID == user.UUID {
+			pr.Participants[i].Approved = approved
+			pr.Participants[i].State = state
+			return
+		}
+	}
+	pr.Participants = append(pr.Participants, domain.Participant{Name: user.DisplayName, UUID: user.UUID, Approved: approved, State: state})
+}
+
+func (c *Client) ListPullRequestCommits(ctx context.Context, repoSlug string, pullRequestID int) ([]domain.Commit, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListPullRequestCommits"); err != nil {
+		return nil, err
+	}
+	return append([]domain.Commit(nil), c.Commits[PullRequestKey(repoSlug, pullRequestID)]...), nil
+}
+
+func (c *Client) ListBranchCommits(ctx context.Context, repoSlug, branch string, limit int) ([]domain.Commit, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListBranchCommits"); err != nil {
+		return nil, err
+	}
+	commits := c.BranchCommits[repoSlug+"/"+branch]
+	return append([]domain.Commit(nil), commits[:min(limit, len(commits))]...), nil
+}
+
+// ListCommitsBetween returns the BranchCommits of include whose hashes are
+// not among those of exclude.
+func (c *Client) ListCommitsBetween(ctx context.Context, repoSlug, include, exclude string, limit int) ([]domain.Commit, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListCommitsBetween"); err != nil {
+		return nil, false, err
+	}
+	excluded := make(map[string]bool)
+	for _, commit := range c.BranchCommits[repoSlug+"/"+exclude] {
+		excluded[commit.Hash] = true
+	}
+	var commits []domain.Commit
+	for _, commit := range c.BranchCommits[repoSlug+"/"+include] {
+		if excluded[commit.Hash] {
+			continue
+		}
+		if len(commits) == limit {
+			return commits, true, nil
+		}
+		commits = append(commits, commit)
+	}
+	return commits, false, nil
+}
+
+// GetCommit looks the hash up among the pull request and branch commits.
+func (c *Client) GetCommit(ctx context.Context, repoSlug, commitHash string) (domain.Commit, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "GetCommit"); err != nil {
+		return domain.Commit{}, err
+	}
+	for _, byKey := range []map[string][]domain.Commit{c.Commits, c.BranchCommits} {
+		for _, commits := range byKey {
+			for _, commit := range commits {
+				if commit.Hash == commitHash {
+					return commit, nil
+				}
+			}
+		}
+	}
+	return domain.Commit{}, notFound("commit", commitHash)
+}
+
+func (c *Client) GetFileContent(ctx context.Context, repoSlug, commitHash, path string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "GetFileContent"); err != nil {
+		return nil, err
+	}
+	content, ok := c.Files[commitHash+":"+path]
+	if !ok {
+		return nil, notFound("file", commitHash+":"+path)
+	}
+	return content, nil
+}
+
+// CreateCommit records the commit and points Branch at a new hash, creating
+// the branch when needed.
+func (c *Client) CreateCommit(ctx context.Context, repoSlug string, input domain.NewCommit) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "CreateCommit"); err != nil {
+		return err
+	}
+	c.NewCommits = append(c.NewCommits, input)
+	target := domain.BranchTarget{Hash: fmt.Sprintf("new-commit-%d", len(c.NewCommits)), Date: time.Now().UTC().Format(time.RFC3339)}
+	branches := c.Branches[repoSlug]
+	for i := range branches {
+		if branches[i].Name == input.Branch {
+			branches[i].Target = target
+			return nil
+		}
+	}
+	c.Branches[repoSlug] = append(branches, domain.Branch{Name: input.Branch, Target: target})
+	return nil
+}
+
+func (c *Client) ListCommitChanges(ctx context.Context, repoSlug, commitHash string) ([]domain.CommitChange, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListCommitChanges"); err != nil {
+		return nil, err
+	}
+	return append([]domain.CommitChange(nil), c.Changes[commitHash]...), nil
+}
+
+func (c *Client) GetCommitDiff(ctx context.Context, repoSlug, commitHash string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "GetCommitDiff"); err != nil {
+		return "", err
+	}
+	return c.CommitDiffs[commitHash], nil
+}
+
+func (c *Client) GetPullRequestDiff(ctx context.Context, repoSlug string, pullRequestID int) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "GetPullRequestDiff"); err != nil {
+		return "", err
+	}
+	return c.PullRequestDiffs[PullRequestKey(repoSlug, pullRequestID)], nil
+}
+
+func (c *Client) ListPipelines(ctx context.Context, repoSlug string) ([]domain.Pipeline, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListPipelines"); err != nil {
+		return nil, err
+	}
+	return append([]domain.Pipeline(nil), c.Pipelines[repoSlug]...), nil
+}
+
+func (c *Client) GetPipeline(ctx context.Context, repoSlug, pipelineUUID string) (domain.Pipeline, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "GetPipeline"); err != nil {
+		return domain.Pipeline{}, err
+	}
+	for _, pipeline := range c.Pipelines[repoSlug] {
+		if pipeline.UUID == pipelineUUID {
+			return pipeline, nil
+		}
+	}
+	return domain.Pipeline{}, notFound("pipeline", pipelineUUID)
+}
+
+// RerunPipeline adds a pending pipeline on the branch of the earlier one, at
+// the top of the list with the next build number.
+func (c *Client) RerunPipeline(ctx context.Context, repoSlug, pipelineUUID string) (domain.Pipeline, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "RerunPipeline"); err != nil {
+		return domain.Pipeline{}, err
+	}
+	pipelines := c.Pipelines[repoSlug]
+	i := slices.IndexFunc(pipelines, func(pipeline domain.Pipeline) bool { return pipeline.UUID == pipelineUUID })
+	if i < 0 {
+		return domain.Pipeline{}, notFound("pipeline", pipelineUUID)
+	}
+	next := 0
+	for _, pipeline := range pipelines {
+		next = max(next, pipeline.BuildNumber)
+	}
+	next++
+	rerun := domain.Pipeline{
+		UUID:        fmt.Sprintf("{pipeline-%d}", next),
+		BuildNumber: next,
+		BranchName:  pipelines[i].BranchName,
+		State:       "PENDING",
+		CreatedOn:   time.Now().UTC().Format(time.RFC3339),
+
+		CommitHash:    pipelines[i].CommitHash,
+		CommitMessage: pipelines[i].CommitMessage,
+		Trigger:       "manual",
+	}
+	c.Pipelines[repoSlug] = append([]domain.Pipeline{rerun}, pipelines...)
+	return rerun, nil
+}
+
+func (c *Client) ListPipelineSteps(ctx context.Context, repoSlug, pipelineUUID string) ([]domain.PipelineStep, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListPipelineSteps"); err != nil {
+		return nil, err
+	}
+	return append([]domain.PipelineStep(nil), c.Steps[pipelineUUID]...), nil
+}
+
+func (c *Client) GetPipelineStepLog(ctx context.Context, repoSlug, pipelineUUID, stepUUID string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "GetPipelineStepLog"); err != nil {
+		return "", err
+	}
+	return c.StepLogs[stepUUID], nil
+}
+
+func (c *Client) GetPipelineStepLogFrom(ctx context.Context, repoSlug, pipelineUUID, stepUUID string, offset int64) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "GetPipelineStepLogFrom"); err != nil {
+		return "", err
+	}
+	log := c.StepLogs[stepUUID]
+	if offset >= int64(len(log)) {
+		return "", nil
+	}
+	return log[offset:], nil
+}
+
+func (c *Client) ListPipelineSchedules(ctx context.Context, repoSlug string) ([]domain.PipelineSchedule, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListPipelineSchedules"); err != nil {
+		return nil, err
+	}
+	return append([]domain.PipelineSchedule(nil), c.Schedules[repoSlug]...), nil
+}
+
+// CreatePipelineSchedule adds the schedule, enabled, at the end of the list.
+func (c *Client) CreatePipelineSchedule(ctx context.Context, repoSlug string, schedule domain.PipelineSchedule) (domain.PipelineSchedule, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "CreatePipelineSchedule"); err != nil {
+		return domain.PipelineSchedule{}, err
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	// Calls only grows, so its length makes a unique ID.
+	schedule.UUID = fmt.Sprintf("{schedule-%d}", len(c.Calls))
+	schedule.Enabled = true
+	schedule.CreatedOn, schedule.UpdatedOn = now, now
+	c.Schedules[repoSlug] = append(c.Schedules[repoSlug], schedule)
+	return schedule, nil
+}
+
+func (c *Client) SetPipelineScheduleEnabled(ctx context.Context, repoSlug, scheduleUUID string, enabled bool) (domain.PipelineSchedule, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "SetPipelineScheduleEnabled"); err != nil {
+		return domain.PipelineSchedule{}, err
+	}
+	for i, schedule := range c.Schedules[repoSlug] {
+		if schedule.UUID == scheduleUUID {
+			schedule.Enabled = enabled
+			schedule.UpdatedOn = time.Now().UTC().Format(time.RFC3339)
+			c.Schedules[repoSlug][i] = schedule
+			return schedule, nil
+		}
+	}
+	return domain.PipelineSchedule{}, notFound("schedule", scheduleUUID)
+}
+
+func (c *Client) DeletePipelineSchedule(ctx context.Context, repoSlug, scheduleUUID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "DeletePipelineSchedule"); err != nil {
+		return err
+	}
+	schedules := c.Schedules[repoSlug]
+	i := slices.IndexFunc(schedules, func(schedule domain.PipelineSchedule) bool { return schedule.UUID == scheduleUUID })
+	if i < 0 {
+		return notFound("schedule", scheduleUUID)
+	}
+	c.Schedules[repoSlug] = slices.Delete(schedules, i, i+1)
+	return nil
+}
+
+func (c *Client) ListEnvironments(ctx context.Context, repoSlug string) ([]domain.Environment, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListEnvironments"); err != nil {
+		return nil, err
+	}
+	return append([]domain.Environment(nil), c.Environments[repoSlug]...), nil
+}
+
+func (c *Client) ListDeployments(ctx context.Context, repoSlug string) ([]domain.Deployment, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListDeployments"); err != nil {
+		return nil, err
+	}
+	return append([]domain.Deployment(nil), c.Deployments[repoSlug]...), nil
+}
+
+func (c *Client) ListPipelineArtifacts(ctx context.Context, repoSlug, pipelineUUID, stepUUID string) ([]domain.PipelineArtifact, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListPipelineArtifacts"); err != nil {
+		return nil, err
+	}
+	return append([]domain.PipelineArtifact(nil), c.Artifacts[stepUUID]...), nil
+}
+
+func (c *Client) DownloadPipelineArtifact(ctx context.Context, repoSlug, pipelineUUID, stepUUID, artifactUUID string, w io.Writer) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "DownloadPipelineArtifact"); err != nil {
+		return err
+	}
+	content, ok := c.ArtifactContents[artifactUUID]
+	if !ok {
+		return notFound("artifact", artifactUUID)
+	}
+	_, err := w.Write(content)
+	return err
+}
+
+func (c *Client) ListIssues(ctx context.Context, repoSlug string) ([]domain.Issue, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListIssues"); err != nil {
+		return nil, err
+	}
+	issues, ok := c.Issues[repoSlug]
+	if !ok {
+		return nil, notFound("issue tracker", repoSlug)
+	}
+	return append([]domain.Issue(nil), issues...), nil
+}
+
+func (c *Client) ListIssueComments(ctx context.Context, repoSlug string, issueID int) ([]domain.Comment, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListIssueComments"); err != nil {
+		return nil, err
+	}
+	if c.findIssue(repoSlug, issueID) == nil {
+		return nil, notFound("issue", PullRequestKey(repoSlug, issueID))
+	}
+	return append([]domain.Comment(nil), c.IssueComments[PullRequestKey(repoSlug, issueID)]...), nil
+}
+
+// CreateIssue files a new issue reported by User, numbered after the highest
+// existing ID.
+func (c *Client) CreateIssue(ctx context.Context, repoSlug string, input domain.NewIssue) (domain.Issue, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "CreateIssue"); err != nil {
+		return domain.Issue{}, err
+	}
+	if _, ok := c.Issues[repoSlug]; !ok {
+		return domain.Issue{}, notFound("issue tracker", repoSlug)
+	}
+
+	id := 1
+	for _, issue := range c.Issues[repoSlug] {
+		id = max(id, issue.ID+1)
+	}
+	kind := input.Kind
+	if kind == "" {
+		kind = "bug"
+	}
+	issue := domain.Issue{
+		ID:       id,
+		Title:    input.Title,
+		Content:  input.Content,
+		State:    "new",
+		Kind:     kind,
+		Priority: "major",
+		Reporter: c.User.DisplayName,
+	}
+	c.Issues[repoSlug] = append(c.Issues[repoSlug], issue)
+	return issue, nil
+}
+
+// UpdateIssue applies update in place. An assignee matching User's account
+// ID gets User's display name, any other shows as the ID.
+func (c *Client) UpdateIssue(ctx context.Context, repoSlug string, issueID int, update domain.IssueUpdate) (domain.Issue, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "UpdateIssue"); err != nil {
+		return domain.Issue{}, err
+	}
+	issue := c.findIssue(repoSlug, issueID)
+	if issue == nil {
+		return domain.Issue{}, notFound("issue", PullRequestKey(repoSlug, issueID))
+	}
+	if update.State != "" {
+		issue.State = update.State
+	}
+	if update.Assignee != nil {
+		issue.AssigneeAccountID = *update.Assignee
+		issue.Assignee = *update.Assignee
+		if *update.Assignee == c.User.AccountID {
+			issue.Assignee = c.User.DisplayName
+		}
+	}
+	return *issue, nil
+}
+
+func (c *Client) ListDownloads(ctx context.Context, repoSlug string) ([]domain.Download, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "ListDownloads"); err != nil {
+		return nil, err
+	}
+	return append([]domain.Download(nil), c.Downloads[repoSlug]...), nil
+}
+
+func (c *Client) GetDownload(ctx context.Context, repoSlug, name string, w io.Writer) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "GetDownload"); err != nil {
+		return err
+	}
+	content, ok := c.DownloadContents[repoSlug+"/"+name]
+	if !ok {
+		return notFound("download", name)
+	}
+	_, err := w.Write(content)
+	return err
+}
+
+// UploadDownload stores the file as the newest download, replacing one of
+// the same name.
+func (c *Client) UploadDownload(ctx context.Context, repoSlug, name string, content []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.call(ctx, "UploadDownload"); err != nil {
+		return err
+	}
+	downloads := slices.DeleteFunc(c.Downloads[repoSlug], func(d domain.Download) bool { return d.Name == name })
+	download := domain.Download{Name: name, Size: int64(len(content)), CreatedOn: time.Now().UTC().Format(time.RFC3339), Uploader: c.User.DisplayName}
+	c.Downloads[repoSlug] = append([]domain.Download{download}, downloads...)
+	c.DownloadContents[repoSlug+"/"+name] = content
+	return nil
+}
+
+func (c *Client) RateLimit() bitbucket.RateLimit {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.Limit
+}
+
+// RecentRequests is always empty; the fake makes no HTTP requests.
+func (c *Client) RecentRequests() []bitbucket.RequestRecord {
+	return nil
+}
+
+func (c *Client) findPullRequest(repoSlug string, pullRequestID int) *domain.PullRequest {
+	prs := c.PullRequests[repoSlug]
+	for i := range prs {
+		if prs[i].ID == pullRequestID {
+			return &prs[i]
+		}
+	}
+	return nil
+}
+
+func (c *Client) findIssue(repoSlug string, issueID int) *domain.Issue {
+	issues := c.Issues[repoSlug]
+	for i := range issues {
+		if issues[i].ID == issueID {
+			return &issues[i]
+		}
+	}
+	return nil
+}
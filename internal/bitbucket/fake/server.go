@@ -0,0 +1,112 @@
+package fake
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// APIPrefix is the path under which the fixture server answers, so that a
+// client configured with server.URL+APIPrefix builds the same paths as
+// against api.bitbucket.org.
+const APIPrefix = "/2.0"
+
+// baseURLPlaceholder is replaced in fixture bodies with the server's API root,
+// so recorded "next" links can point back at the fixture server.
+const baseURLPlaceholder = "{{base}}"
+
+// NewFixtureServer starts a server replaying recorded responses from dir. A
+// GET for /2.0/repositories/acme/api/refs/branches is answered with
+// dir/repositories/acme/api/refs/branches.json, or the file without the .json
+// extension for plain text endpoints such as diffs and logs. The query string
+// is ignored except for page=N, which selects <path>.page<N>.json. Writes
+// (POST, PUT, DELETE) succeed with an empty JSON object unless a fixture
+// exists for them. Missing fixtures return a Bitbucket-style 404.
+func NewFixtureServer(dir string) *httptest.Server {
+	server := httptest.NewUnstartedServer(nil)
+	server.Config.Handler = fixtureHandler(dir, func() string { return server.URL + APIPrefix })
+	server.Start()
+	return server
+}
+
+func fixtureHandler(dir string, baseURL func() string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rel, ok := strings.CutPrefix(r.URL.Path, APIPrefix+"/")
+		if !ok {
+			writeFixtureError(w, http.StatusNotFound, "not an API path")
+			return
+		}
+		rel = strings.TrimSuffix(path.Clean("/"+rel), "/")
+		if page := r.URL.Query().Get("page"); page != "" && page != "1" {
+			rel += ".page" + page
+		}
+
+		body, contentType, err := readFixture(dir, rel)
+		if err != nil {
+			if r.Method != http.MethodGet {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte("{}"))
+				return
+			}
+			writeFixtureError(w, http.StatusNotFound, "no fixture for "+r.URL.Path)
+			return
+		}
+
+		body = bytes.ReplaceAll(body, []byte(baseURLPlaceholder), []byte(baseURL()))
+		w.Header().Set("Content-Type", contentType)
+		_, _ = w.Write(body)
+	})
+}
+
+// readFixture looks up rel as a JSON fixture first, then as a plain file.
+// rel is already cleaned, so it cannot escape dir.
+func readFixture(dir, rel string) ([]byte, string, error) {
+	name := filepath.Join(dir, filepath.FromSlash(rel))
+	if body, err := os.ReadFile(name + ".json"); err == nil {
+		return body, "application/json", nil
+	}
+	info, err := os.Stat(name)
+	if err != nil {
+		return nil, "", err
+	}
+	if info.IsDir() {
+		return nil, "", os.ErrNotExist
+	}
+	body, err := os.ReadFile(name)
+	return body, "text/plain; charset=utf-8", err
+}
+
+func writeFixtureError(w http.ResponseWriter, status int, message string) {
+	body, _ := json.Marshal(map[string]any{
+		"type":  "error",
+		"error": map[string]string{"message": message},
+	})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+// FixtureWorkspace guesses the workspace recorded in dir: the only directory
+// under dir/repositories. It returns "" when there is none or several.
+func FixtureWorkspace(dir string) string {
+	entries, err := os.ReadDir(filepath.Join(dir, "repositories"))
+	if err != nil {
+		return ""
+	}
+	workspace := ""
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if workspace != "" {
+			return ""
+		}
+		workspace = entry.Name()
+	}
+	return workspace
+}
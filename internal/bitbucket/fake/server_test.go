@@ -0,0 +1,102 @@
+package fake_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"bitbucket-cli/internal/bitbucket"
+	"bitbucket-cli/internal/bitbucket/fake"
+	"bitbucket-cli/internal/config"
+)
+
+// writeFixtures lays out files, keyed by path relative to dir.
+func writeFixtures(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, body := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func newFixtureClient(t *testing.T, dir string) *bitbucket.Client {
+	t.Helper()
+	server := fake.NewFixtureServer(dir)
+	t.Cleanup(server.Close)
+	noRetries := 0
+	cfg := config.FromProfile(config.Profile{Name: "fixtures", Workspace: "acme", MaxRetries: &noRetries}).
+		WithBaseURL(server.URL + fake.APIPrefix)
+	return bitbucket.NewClient(cfg)
+}
+
+func TestFixtureServerPages(t *testing.T) {
+	dir := t.TempDir()
+	writeFixtures(t, dir, map[string]string{
+		"repositories/acme/api/refs/branches.json": `{"values":[{"name":"main","target":{"hash":"aaa"}}],
+			"next":"{{base}}/repositories/acme/api/refs/branches?page=2"}`,
+		"repositories/acme/api/refs/branches.page2.json": `{"values":[{"name":"develop","target":{"hash":"bbb"}}]}`,
+	})
+	client := newFixtureClient(t, dir)
+
+	branches, err := client.ListBranches(context.Background(), "api")
+	if err != nil {
+		t.Fatalf("ListBranches: %v", err)
+	}
+	if len(branches) != 2 || branches[0].Name != "main" || branches[1].Name != "develop" {
+		t.Fatalf("branches = %+v, want main then develop", branches)
+	}
+	if workspace := fake.FixtureWorkspace(dir); workspace != "acme" {
+		t.Errorf("FixtureWorkspace = %q, want acme", workspace)
+	}
+}
+
+func TestFixtureServerPlainText(t *testing.T) {
+	dir := t.TempDir()
+	writeFixtures(t, dir, map[string]string{
+		"repositories/acme/api/pullrequests/7/diff": "diff --git a/x b/x\n",
+	})
+	client := newFixtureClient(t, dir)
+
+	diff, err := client.GetPullRequestDiff(context.Background(), "api", 7)
+	if err != nil {
+		t.Fatalf("GetPullRequestDiff: %v", err)
+	}
+	if diff != "diff --git a/x b/x\n" {
+		t.Errorf("diff = %q, want the fixture's content", diff)
+	}
+}
+
+func TestFixtureServerMissingFixture(t *testing.T) {
+	client := newFixtureClient(t, t.TempDir())
+
+	_, err := client.GetRepository(context.Background(), "api")
+	if !errors.Is(err, bitbucket.ErrNotFound) {
+		t.Fatalf("err = %v, want ErrNotFound", err)
+	}
+	var apiErr *bitbucket.APIError
+	if !errors.As(err, &apiErr) || apiErr.Message == "" {
+		t.Errorf("err = %v, want an *APIError with the fixture server's message", err)
+	}
+}
+
+func TestFakeClientErrors(t *testing.T) {
+	client := fake.NewClient()
+	client.Errors["ListBranches"] = bitbucket.ErrForbidden
+
+	if _, err := client.ListBranches(context.Background(), "api"); !errors.Is(err, bitbucket.ErrForbidden) {
+		t.Fatalf("err = %v, want the configured error", err)
+	}
+	if _, err := client.GetBranch(context.Background(), "api", "main"); !errors.Is(err, bitbucket.ErrNotFound) {
+		t.Errorf("GetBranch of a missing branch: err = %v, want ErrNotFound", err)
+	}
+	if len(client.Calls) != 2 || client.Calls[0] != "ListBranches" || client.Calls[1] != "GetBranch" {
+		t.Errorf("Calls = %v, want ListBranches, GetBranch", client.Calls)
+	}
+}
@@ -0,0 +1,69 @@
+package bitbucket
+
+import "strings"
+
+// Partial response selectors for the list endpoints. Each lists exactly the
+// JSON fields read by the matching api* struct, so keep them in sync when a
+// struct gains a field.
+var (
+	repositoryFields  = []string{"name", "slug", "uuid", "updated_on", "mainbranch.name", "project.key"}
+	branchFields      = []string{"name", "target.hash", "target.date"}
+	tagFields         = []string{"name", "message", "date", "target.hash", "target.date"}
+	pullRequestFields = []string{
+		"id", "title", "description", "state", "draft",
+		"author.display_name", "author.uuid",
+		"source.branch.name", "source.commit.hash",
+		"destination.branch.name", "destination.repository.full_name",
+		"created_on", "updated_on",
+		"links.html.href", "links.self.href",
+		"participants.approved", "participants.participated_on", "participants.state", "participants.role",
+		"participants.user.display_name", "participants.user.uuid",
+		"reviewers.display_name", "reviewers.uuid",
+		"task_count", "merge_commit.hash",
+	}
+	commitFields   = []string{"hash", "message", "date", "author.raw", "author.user.display_name", "parents.hash"}
+	diffstatFields = []string{"status", "lines_added", "lines_removed", "old.path", "new.path"}
+	pipelineFields = []string{
+		"uuid", "build_number", "created_on", "completed_on",
+		"target.ref_name", "target.commit.hash", "target.commit.message",
+		"trigger.name", "creator.display_name",
+		"state.name", "state.stage.name", "state.stage.started_on", "state.result.name",
+	}
+	pipelineStepFields = []string{"uuid", "name", "started_on", "completed_on", "state.name", "state.result.name", "script_commands.name", "script_commands.command"}
+	issueFields        = []string{
+		"id", "title", "state", "kind", "priority", "content.raw",
+		"reporter.display_name", "assignee.display_name", "assignee.account_id",
+		"created_on", "updated_on", "links.html.href",
+	}
+	commentFields           = []string{"id", "content.raw", "user.display_name", "created_on"}
+	branchRestrictionFields = []string{"kind", "branch_match_kind", "pattern", "branch_type", "value"}
+	buildStatusFields       = []string{"key", "name", "state", "url"}
+	downloadFields          = []string{"name", "size", "created_on", "downloads", "user.display_name"}
+	artifactFields          = []string{"uuid", "path", "file_size_bytes", "created_on"}
+	scheduleFields          = []string{"uuid", "enabled", "cron_pattern", "target.ref_name", "target.selector.type", "target.selector.pattern", "created_on", "updated_on"}
+	environmentFields       = []string{"uuid", "name", "rank", "environment_type.name"}
+	deploymentFields        = []string{"uuid", "state.name", "state.status.name", "state.started_on", "state.completed_on", "environment.uuid", "step.uuid", "release.name", "release.commit.hash", "release.created_on", "deployable.pipeline.uuid"}
+	memberFields            = []string{"user.uuid", "user.account_id", "user.nickname", "user.display_name"}
+	activityFields          = []string{
+		"update.state", "update.date", "update.author.display_name",
+		"approval.date", "approval.user.display_name",
+		"changes_requested.date", "changes_requested.user.display_name",
+		"comment.content.raw", "comment.user.display_name", "comment.created_on",
+	}
+	pullRequestCommentFields = []string{
+		"id", "content.raw", "user.display_name", "created_on", "parent.id", "deleted",
+		"inline.path", "inline.from", "inline.to",
+	}
+	taskFields = []string{"id", "content.raw", "state", "creator.display_name", "comment.id"}
+)
+
+// pageFields returns a fields= query parameter selecting the given fields of
+// each value of a paginated response, plus its next link.
+func pageFields(fields []string) string {
+	selected := make([]string, 0, len(fields)+1)
+	for _, field := range fields {
+		selected = append(selected, "values."+field)
+	}
+	selected = append(selected, "next")
+	return "fields=" + strings.Join(selected, ",")
+}
@@ -0,0 +1,65 @@
+package bitbucket
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// gzipTransport asks for gzip-compressed responses and decompresses them
+// before the body reaches the request helper. net/http only does this on its
+// own when nothing else sets Accept-Encoding; doing it here keeps it in effect
+// for every request, whatever transport sits underneath. Range requests are
+// left alone: their offsets are only meaningful on the uncompressed body.
+type gzipTransport struct {
+	base http.RoundTripper
+}
+
+func newGzipTransport(base http.RoundTripper) http.RoundTripper {
+	return &gzipTransport{base: base}
+}
+
+func (t *gzipTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("Range") != "" {
+		return t.base.RoundTrip(req)
+	}
+	if req.Header.Get("Accept-Encoding") == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("Accept-Encoding", "gzip")
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return resp, err
+	}
+
+	resp.Body = &gzipBody{body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return resp, nil
+}
+
+// gzipBody opens the gzip stream lazily, on the first Read, so a response
+// whose body is never read costs nothing extra.
+type gzipBody struct {
+	body   io.ReadCloser
+	reader *gzip.Reader
+	err    error
+}
+
+func (b *gzipBody) Read(p []byte) (int, error) {
+	if b.reader == nil && b.err == nil {
+		b.reader, b.err = gzip.NewReader(b.body)
+	}
+	if b.err != nil {
+		return 0, b.err
+	}
+	return b.reader.Read(p)
+}
+
+func (b *gzipBody) Close() error {
+	return b.body.Close()
+}
@@ -0,0 +1,77 @@
+package bitbucket
+
+import (
+	"regexp"
+	"sync"
+	"time"
+)
+
+const (
+	maxRequestRecords = 200
+	maxRecordedBody   = 8 << 10
+)
+
+// RequestRecord is one API call kept for the in-app request inspector.
+// Credentials never end up in it: the Authorization header is not recorded
+// and secret-looking JSON fields are masked.
+type RequestRecord struct {
+	Started  time.Time
+	Method   string
+	URL      string
+	Status   int
+	Duration time.Duration
+	// Cached is set when the response came from the response cache without
+	// a request; NotModified when the server answered 304; Coalesced when
+	// it was shared with an identical request already in flight.
+	Cached       bool
+	NotModified  bool
+	Coalesced    bool
+	RequestBody  string
+	ResponseBody string
+	Err          string
+}
+
+type requestLog struct {
+	mu      sync.Mutex
+	records []RequestRecord
+}
+
+// secretField matches a secret-looking JSON string field, including one whose
+// value runs to the end of a truncated body.
+var secretField = regexp.MustCompile(`(?i)("[a-z_]*(?:token|secret|password)[a-z_]*"\s*:\s*)"(?:[^"]*"|[^"]*$)`)
+
+// RecentRequests returns the recorded API calls, newest first.
+func (c *Client) RecentRequests() []RequestRecord {
+	c.requests.mu.Lock()
+	defer c.requests.mu.Unlock()
+
+	records := make([]RequestRecord, len(c.requests.records))
+	for i, record := range c.requests.records {
+		records[len(records)-1-i] = record
+	}
+	return records
+}
+
+func (c *Client) recordRequest(record RequestRecord) {
+	c.requests.mu.Lock()
+	defer c.requests.mu.Unlock()
+	c.requests.records = append(c.requests.records, record)
+	if len(c.requests.records) > maxRequestRecords {
+		c.requests.records = c.requests.records[len(c.requests.records)-maxRequestRecords:]
+	}
+}
+
+// recordedBody is what the inspector keeps of a request or response body:
+// its first maxRecordedBody bytes with the secrets masked. Only those are
+// copied and scanned, however large the body is.
+func recordedBody(body []byte) string {
+	truncated := len(body) > maxRecordedBody
+	if truncated {
+		body = body[:maxRecordedBody]
+	}
+	recorded := secretField.ReplaceAllString(string(body), `$1"***"`)
+	if truncated {
+		recorded += "\n… (truncated)"
+	}
+	return recorded
+}
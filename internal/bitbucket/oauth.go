@@ -0,0 +1,285 @@
+package bitbucket
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	neturl "net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"bitbucket-cli/internal/config"
+)
+
+const (
+	oauthAuthorizeURL = "https://bitbucket.org/site/oauth2/authorize"
+	oauthTokenURL     = "https://bitbucket.org/site/oauth2/access_token"
+	// oauthExpiryMargin renews access tokens this long before they expire,
+	// so no request starts with a token about to lapse.
+	oauthExpiryMargin = time.Minute
+	// oauthLoginTimeout bounds the wait for the browser's redirect.
+	oauthLoginTimeout = 5 * time.Minute
+)
+
+// oauthTokens hands out the access token of a profile's OAuth consumer,
+// renewing it with the refresh token, or with the client credentials, when it
+// expires. Renewed tokens are stored for the next run.
+type oauthTokens struct {
+	oauth      config.OAuth
+	httpClient *http.Client
+
+	mu     sync.Mutex
+	loaded bool
+	token  config.OAuthToken
+	// renewed is when the access token was granted in this run, zero for a
+	// token read from disk.
+	renewed time.Time
+}
+
+type apiOAuthToken struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+func newOAuthTokens(oauth config.OAuth, httpClient *http.Client) *oauthTokens {
+	return &oauthTokens{oauth: oauth, httpClient: httpClient}
+}
+
+// authorization returns the Authorization header of a request: the profile's
+// token, or its OAuth consumer's access token.
+func (c *Client) authorization(ctx context.Context) (string, error) {
+	if c.oauth == nil {
+		return c.config.Authorization, nil
+	}
+	return c.oauth.authorization(ctx)
+}
+
+// authorization returns the Authorization header for the next request.
+func (t *oauthTokens) authorization(ctx context.Context) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.loaded {
+		token, err := config.LoadOAuthToken(t.oauth.Profile)
+		if err != nil {
+			return "", err
+		}
+		t.token, t.loaded = token, true
+	}
+	if t.token.AccessToken == "" || time.Until(t.token.ExpiresAt) < oauthExpiryMargin {
+		if err := t.renew(ctx); err != nil {
+			return "", err
+		}
+	}
+	return "Bearer " + t.token.AccessToken, nil
+}
+
+// expire drops an access token Bitbucket rejected on a request sent at sent,
+// unless it has been renewed since.
+func (t *oauthTokens) expire(sent time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.renewed.Before(sent) {
+		t.token.AccessToken = ""
+	}
+}
+
+// renew gets a new access token with the refresh token. The client
+// credentials flow falls back to a new grant when there is none or it was
+// revoked; the authorization code flow needs the user to log in again.
+func (t *oauthTokens) renew(ctx context.Context) error {
+	var token config.OAuthToken
+	err := errNoRefreshToken
+	if t.token.RefreshToken != "" {
+		token, err = requestOAuthToken(ctx, t.httpClient, t.oauth, neturl.Values{
+			"grant_type":    {"refresh_token"},
+			"refresh_token": {t.token.RefreshToken},
+		})
+	}
+	if err != nil && t.oauth.Flow == config.OAuthClientCredentials {
+		token, err = requestOAuthToken(ctx, t.httpClient, t.oauth, neturl.Values{"grant_type": {"client_credentials"}})
+	}
+	if errors.Is(err, errNoRefreshToken) || (errors.Is(err, ErrUnauthorized) && t.oauth.Flow == config.OAuthAuthorizationCode) {
+		return fmt.Errorf("%w: profile %s is not logged in, run bitbucket-cli --profile %s auth login", ErrUnauthorized, t.oauth.Profile, t.oauth.Profile)
+	}
+	if err != nil {
+		return err
+	}
+
+	// A refresh grant may answer without a new refresh token; the old one
+	// stays valid then.
+	if token.RefreshToken == "" {
+		token.RefreshToken = t.token.RefreshToken
+	}
+	t.token, t.renewed = token, time.Now()
+	return config.SaveOAuthToken(t.oauth.Profile, token)
+}
+
+var errNoRefreshToken = errors.New("no refresh token")
+
+// requestOAuthToken asks Bitbucket for a token with the grant in form,
+// authenticating as the consumer. A refused grant is an ErrUnauthorized.
+func requestOAuthToken(ctx context.Context, httpClient *http.Client, oauth config.OAuth, form neturl.Values) (config.OAuthToken, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, oauthTokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return config.OAuthToken{}, err
+	}
+	req.SetBasicAuth(oauth.ClientID, oauth.ClientSecret)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", acceptJSON)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return config.OAuthToken{}, fmt.Errorf("OAuth token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return config.OAuthToken{}, fmt.Errorf("OAuth token request failed: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		// Bitbucket answers 400 invalid_grant for revoked or expired grants,
+		// and 400 or 401 for unknown consumers.
+		if resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusUnauthorized {
+			return config.OAuthToken{}, fmt.Errorf("%w: OAuth token refused: %s", ErrUnauthorized, oauthErrorDescription(body))
+		}
+		return config.OAuthToken{}, newAPIError(http.MethodPost, oauthTokenURL, resp, body)
+	}
+
+	var granted apiOAuthToken
+	if err := json.Unmarshal(body, &granted); err != nil {
+		return config.OAuthToken{}, fmt.Errorf("failed to decode OAuth token: %w", err)
+	}
+	return config.OAuthToken{
+		AccessToken:  granted.AccessToken,
+		RefreshToken: granted.RefreshToken,
+		ExpiresAt:    time.Now().Add(time.Duration(granted.ExpiresIn) * time.Second),
+	}, nil
+}
+
+// oauthErrorDescription is the error_description of an OAuth error body.
+func oauthErrorDescription(body []byte) string {
+	var payload struct {
+		Error       string `json:"error"`
+		Description string `json:"error_description"`
+	}
+	if json.Unmarshal(body, &payload) != nil {
+		return strings.TrimSpace(string(body))
+	}
+	if payload.Description != "" {
+		return payload.Description
+	}
+	if payload.Error != "" {
+		return payload.Error
+	}
+	return strings.TrimSpace(string(body))
+}
+
+// NeedsOAuthLogin reports whether a profile using the authorization code
+// flow has no refresh token yet, so the user has to authorize it in the
+// browser first.
+func NeedsOAuthLogin(oauth config.OAuth) bool {
+	if oauth.Flow != config.OAuthAuthorizationCode {
+		return false
+	}
+	token, err := config.LoadOAuthToken(oauth.Profile)
+	return err == nil && token.RefreshToken == ""
+}
+
+// LoginOAuth gets and stores the first token of a profile. With the client
+// credentials flow this only checks the consumer's key and secret. With the
+// authorization code flow, the user opens the printed URL and approves the
+// consumer; Bitbucket then redirects the browser to the consumer's callback
+// URL, which must be http://<CallbackAddr>/, where LoginOAuth waits for the
+// code.
+func LoginOAuth(ctx context.Context, oauth config.OAuth, out io.Writer) error {
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	if oauth.Flow == config.OAuthClientCredentials {
+		token, err := requestOAuthToken(ctx, httpClient, oauth, neturl.Values{"grant_type": {"client_credentials"}})
+		if err != nil {
+			return err
+		}
+		return config.SaveOAuthToken(oauth.Profile, token)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, oauthLoginTimeout)
+	defer cancel()
+
+	state, err := randomState()
+	if err != nil {
+		return err
+	}
+	listener, err := net.Listen("tcp", oauth.CallbackAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen for the OAuth callback: %w", err)
+	}
+
+	type callback struct {
+		code string
+		err  error
+	}
+	callbacks := make(chan callback, 1)
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("state") != state {
+			http.Error(w, "unexpected OAuth state", http.StatusBadRequest)
+			return
+		}
+		result := callback{code: query.Get("code")}
+		if denied := query.Get("error"); denied != "" || result.code == "" {
+			result.err = fmt.Errorf("authorization failed: %s", strings.TrimSpace(denied+" "+query.Get("error_description")))
+			fmt.Fprintln(w, "bitbucket-cli was not authorized. You can close this tab.")
+		} else {
+			fmt.Fprintln(w, "bitbucket-cli is authorized. You can close this tab.")
+		}
+		select {
+		case callbacks <- result:
+		default:
+		}
+	})}
+	go server.Serve(listener)
+	defer server.Close()
+
+	authorizeURL := oauthAuthorizeURL + "?" + neturl.Values{
+		"client_id":     {oauth.ClientID},
+		"response_type": {"code"},
+		"state":         {state},
+	}.Encode()
+	fmt.Fprintf(out, "Open this URL to authorize bitbucket-cli for profile %s:\n\n  %s\n\nWaiting for the redirect to http://%s/ ...\n", oauth.Profile, authorizeURL, oauth.CallbackAddr)
+
+	var result callback
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("no OAuth callback received: %w", ctx.Err())
+	case result = <-callbacks:
+	}
+	if result.err != nil {
+		return result.err
+	}
+
+	token, err := requestOAuthToken(ctx, httpClient, oauth, neturl.Values{
+		"grant_type": {"authorization_code"},
+		"code":       {result.code},
+	})
+	if err != nil {
+		return err
+	}
+	return config.SaveOAuthToken(oauth.Profile, token)
+}
+
+func randomState() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate OAuth state: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
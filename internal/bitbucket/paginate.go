@@ -0,0 +1,62 @@
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// page is the envelope Bitbucket wraps every list response in.
+type page[T any] struct {
+	Values []T    `json:"values"`
+	Next   string `json:"next"`
+}
+
+// errStopPaging is returned by an onPage callback that has seen enough; the
+// caller of paginate treats it as success.
+var errStopPaging = errors.New("stop paging")
+
+// paginate fetches url and every page after it by following the next links,
+// handing each page's values to onPage as soon as it is decoded. what names
+// the resource in decode errors. Returning an error from onPage stops the
+// walk and is returned as is.
+func paginate[T any](ctx context.Context, c *Client, url, what string, onPage func([]T) error) error {
+	for url != "" {
+		body, err := c.doRequest(ctx, http.MethodGet, url, acceptJSON, nil)
+		if err != nil {
+			return err
+		}
+
+		var decoded page[T]
+		if err := json.Unmarshal(body, &decoded); err != nil {
+			return fmt.Errorf("unable to decode %s response: %w", what, err)
+		}
+
+		if err := onPage(decoded.Values); err != nil {
+			return err
+		}
+
+		if decoded.Next == url {
+			break
+		}
+		url = decoded.Next
+	}
+	return nil
+}
+
+// listAll collects every page of url, converting each item with convert.
+func listAll[T, R any](ctx context.Context, c *Client, url, what string, convert func(T) R) ([]R, error) {
+	var all []R
+	err := paginate(ctx, c, url, what, func(values []T) error {
+		for _, item := range values {
+			all = append(all, convert(item))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
@@ -0,0 +1,99 @@
+package bitbucket
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimit is the API quota reported by the most recent response.
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+	// NearLimit is set by Bitbucket once less than 20% of the quota is left.
+	NearLimit bool
+	// CooldownUntil is when requests may resume after a 429 response.
+	CooldownUntil time.Time
+}
+
+// defaultCooldown is how long to hold off after a 429 that neither carried a
+// Retry-After nor a reset time.
+const defaultCooldown = time.Minute
+
+// Known reports whether any response carried rate-limit headers yet.
+func (r RateLimit) Known() bool {
+	return r.Limit > 0
+}
+
+// Fraction returns the share of the quota still available, 1 when unknown.
+func (r RateLimit) Fraction() float64 {
+	if r.Limit <= 0 {
+		return 1
+	}
+	return float64(r.Remaining) / float64(r.Limit)
+}
+
+// Cooldown returns how long requests should still be held off after a 429,
+// or zero once they may resume.
+func (r RateLimit) Cooldown() time.Duration {
+	if r.CooldownUntil.IsZero() {
+		return 0
+	}
+	return max(time.Until(r.CooldownUntil), 0)
+}
+
+type rateLimitState struct {
+	mu            sync.Mutex
+	limit         RateLimit
+	cooldownUntil time.Time
+}
+
+// RateLimit returns the last quota seen by the client.
+func (c *Client) RateLimit() RateLimit {
+	c.rateLimit.mu.Lock()
+	defer c.rateLimit.mu.Unlock()
+	limit := c.rateLimit.limit
+	limit.CooldownUntil = c.rateLimit.cooldownUntil
+	return limit
+}
+
+// startCooldown records a 429. The wait is the server's Retry-After, else
+// the quota reset time, else defaultCooldown.
+func (c *Client) startCooldown(retryAfter time.Duration) {
+	c.rateLimit.mu.Lock()
+	defer c.rateLimit.mu.Unlock()
+
+	until := time.Now().Add(defaultCooldown)
+	switch {
+	case retryAfter > 0:
+		until = time.Now().Add(retryAfter)
+	case c.rateLimit.limit.Reset.After(time.Now()):
+		until = c.rateLimit.limit.Reset
+	}
+	if until.After(c.rateLimit.cooldownUntil) {
+		c.rateLimit.cooldownUntil = until
+	}
+}
+
+func (c *Client) recordRateLimit(header http.Header) {
+	limit, err := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	if err != nil || limit <= 0 {
+		return
+	}
+
+	current := RateLimit{Limit: limit, Remaining: limit}
+	if remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining")); err == nil {
+		current.Remaining = remaining
+	}
+	if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		current.Reset = time.Unix(reset, 0)
+	}
+	current.NearLimit = strings.EqualFold(header.Get("X-RateLimit-NearLimit"), "true")
+
+	c.rateLimit.mu.Lock()
+	c.rateLimit.limit = current
+	c.rateLimit.mu.Unlock()
+}
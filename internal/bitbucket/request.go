@@ -0,0 +1,311 @@
+package bitbucket
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	acceptJSON = "application/json"
+	acceptText = "text/plain"
+	acceptAny  = "*/*"
+
+	// maxRetryDelay caps the backoff between attempts. A Retry-After longer
+	// than this is not waited for; the error is returned instead.
+	maxRetryDelay = 30 * time.Second
+)
+
+// apiRequest describes one call to the API. validator, when set, is the
+// cached response the request revalidates with If-None-Match or
+// If-Modified-Since.
+type apiRequest struct {
+	method    string
+	url       string
+	accept    string
+	payload   []byte
+	validator *cacheEntry
+	// contentType overrides the JSON content type of payload.
+	contentType string
+	// byteRange asks for part of the body, e.g. "bytes=1024-".
+	byteRange string
+	// output receives the body of a successful response instead of the
+	// response, for files too large to hold in memory.
+	output io.Writer
+}
+
+type apiResponse struct {
+	body        []byte
+	header      http.Header
+	notModified bool
+	// streamed tells that part of the body may have been written to the
+	// request's output, so the request must not be sent again.
+	streamed bool
+}
+
+// doRequest sends a request to the API and returns the body of a successful
+// response. GET responses are served from the response cache while fresh and
+// revalidated with their ETag or Last-Modified once stale. Identical GETs
+// made while one is in flight share its response.
+func (c *Client) doRequest(ctx context.Context, method, url, accept string, payload []byte) ([]byte, error) {
+	req := apiRequest{method: method, url: url, accept: accept, payload: payload}
+	if method != http.MethodGet {
+		resp, err := c.sendWithRetry(ctx, req)
+		if err == nil {
+			c.cache.clear()
+		}
+		return resp.body, err
+	}
+
+	key := accept + " " + url
+	if entry, ok := c.cache.lookup(key); ok {
+		if !cacheBypassed(ctx) && time.Now().Before(entry.expires) {
+			c.recordRequest(RequestRecord{
+				Started:      time.Now(),
+				Method:       method,
+				URL:          url,
+				Status:       http.StatusOK,
+				Cached:       true,
+				ResponseBody: recordedBody(entry.body),
+			})
+			return entry.body, nil
+		}
+		if entry.etag != "" || entry.lastModified != "" {
+			req.validator = &entry
+		}
+	}
+
+	started := time.Now()
+	resp, shared, err := c.inflight.do(ctx, key, func() (apiResponse, error) {
+		return c.sendWithRetry(ctx, req)
+	})
+	if shared {
+		record := RequestRecord{Started: started, Method: method, URL: url, Duration: time.Since(started), Coalesced: true}
+		if err != nil {
+			record.Err = err.Error()
+		} else {
+			record.Status = http.StatusOK
+			record.ResponseBody = recordedBody(resp.body)
+		}
+		c.recordRequest(record)
+		// The call that made the request has already cached the result.
+		return resp.body, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	entry := cacheEntry{
+		body:         resp.body,
+		expires:      time.Now().Add(cacheTTL(url)),
+		etag:         resp.header.Get("ETag"),
+		lastModified: resp.header.Get("Last-Modified"),
+	}
+	if resp.notModified {
+		if entry.etag == "" {
+			entry.etag = req.validator.etag
+		}
+		if entry.lastModified == "" {
+			entry.lastModified = req.validator.lastModified
+		}
+	}
+	c.cache.put(key, entry)
+	return resp.body, nil
+}
+
+// sendWithRetry retries transient failures (network errors, 5xx and 429) with
+// jittered exponential backoff, up to the profile's max_retries.
+func (c *Client) sendWithRetry(ctx context.Context, req apiRequest) (apiResponse, error) {
+	reauthorized := false
+	for attempt := 0; ; attempt++ {
+		sent := time.Now()
+		resp, err := c.sendRequest(ctx, req)
+		// An OAuth access token can be revoked before it expires; a new one
+		// is tried once.
+		if c.oauth != nil && !reauthorized && errors.Is(err, ErrUnauthorized) {
+			c.oauth.expire(sent)
+			reauthorized = true
+			continue
+		}
+		if err == nil || resp.streamed || attempt >= c.config.MaxRetries || !isRetryable(ctx, req.method, err) {
+			return resp, err
+		}
+
+		delay, ok := c.retryDelay(attempt, err)
+		if !ok {
+			return apiResponse{}, err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return apiResponse{}, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (c *Client) sendRequest(ctx context.Context, r apiRequest) (resp apiResponse, err error) {
+	record := RequestRecord{Started: time.Now(), Method: r.method, URL: r.url, RequestBody: recordedBody(r.payload)}
+	defer func() {
+		record.Duration = time.Since(record.Started)
+		record.NotModified = resp.notModified
+		if err != nil {
+			record.Err = err.Error()
+		}
+		c.recordRequest(record)
+	}()
+
+	var reader io.Reader
+	if r.payload != nil {
+		reader = bytes.NewReader(r.payload)
+	}
+
+	timeout := c.requestTimeout(r.accept)
+	attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(attemptCtx, r.method, r.url, reader)
+	if err != nil {
+		return apiResponse{}, err
+	}
+
+	authorization, err := c.authorization(ctx)
+	if err != nil {
+		return apiResponse{}, err
+	}
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Accept", r.accept)
+	if r.contentType != "" {
+		req.Header.Set("Content-Type", r.contentType)
+	} else if r.accept == acceptJSON || r.payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if r.byteRange != "" {
+		// Ranges count bytes of the encoded body, so the offset only lines
+		// up with the log when the body is sent as is.
+		req.Header.Set("Range", r.byteRange)
+		req.Header.Set("Accept-Encoding", "identity")
+	}
+	if r.validator != nil {
+		if r.validator.etag != "" {
+			req.Header.Set("If-None-Match", r.validator.etag)
+		}
+		if r.validator.lastModified != "" {
+			req.Header.Set("If-Modified-Since", r.validator.lastModified)
+		}
+	}
+
+	httpResp, err := c.httpClient.Do(req)
+	if err != nil {
+		return apiResponse{}, timeoutError(ctx, attemptCtx, timeout, fmt.Errorf("request failed: %w", err))
+	}
+	defer httpResp.Body.Close()
+	c.recordRateLimit(httpResp.Header)
+	record.Status = httpResp.StatusCode
+
+	if r.output != nil && httpResp.StatusCode >= 200 && httpResp.StatusCode < 300 {
+		written, err := io.Copy(r.output, httpResp.Body)
+		record.ResponseBody = fmt.Sprintf("(%d bytes written to file)", written)
+		if err != nil {
+			return apiResponse{streamed: true}, timeoutError(ctx, attemptCtx, timeout, err)
+		}
+		return apiResponse{header: httpResp.Header}, nil
+	}
+
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return apiResponse{}, timeoutError(ctx, attemptCtx, timeout, err)
+	}
+	record.ResponseBody = recordedBody(body)
+
+	if httpResp.StatusCode == http.StatusNotModified && r.validator != nil {
+		return apiResponse{body: r.validator.body, header: httpResp.Header, notModified: true}, nil
+	}
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		apiErr := newAPIError(r.method, r.url, httpResp, body)
+		if httpResp.StatusCode == http.StatusTooManyRequests {
+			c.startCooldown(apiErr.RetryAfter)
+		}
+		return apiResponse{}, apiErr
+	}
+
+	return apiResponse{body: body, header: httpResp.Header}, nil
+}
+
+// requestTimeout picks the per-attempt timeout: plain text downloads (diffs
+// and logs) can be several megabytes and get the longer download timeout.
+func (c *Client) requestTimeout(accept string) time.Duration {
+	if accept == acceptJSON {
+		return c.config.ListTimeout
+	}
+	return c.config.DownloadTimeout
+}
+
+// timeoutError rewords err when the attempt ran out of time while the
+// caller's own context is still live, so the message names the limit hit.
+func timeoutError(ctx, attemptCtx context.Context, timeout time.Duration, err error) error {
+	if ctx.Err() == nil && errors.Is(attemptCtx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("request timed out after %s: %w", timeout, context.DeadlineExceeded)
+	}
+	return err
+}
+
+// isRetryable reports whether a failed request may be sent again. A 429 means
+// the request was not processed, so it is retried for every method; other
+// failures only for idempotent methods.
+func isRetryable(ctx context.Context, method string, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	idempotent := method == http.MethodGet || method == http.MethodHead || method == http.MethodPut || method == http.MethodDelete
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return idempotent
+	}
+	if errors.Is(apiErr, ErrRateLimited) {
+		return true
+	}
+	return idempotent && errors.Is(apiErr, ErrServer)
+}
+
+// retryDelay returns how long to wait before the next attempt, preferring the
+// server's Retry-After. ok is false when the server asks for a longer wait
+// than maxRetryDelay.
+func (c *Client) retryDelay(attempt int, err error) (time.Duration, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		return apiErr.RetryAfter, apiErr.RetryAfter <= maxRetryDelay
+	}
+
+	backoff := c.config.RetryBackoff << attempt
+	if backoff <= 0 || backoff > maxRetryDelay {
+		backoff = maxRetryDelay
+	}
+	half := backoff / 2
+	return half + rand.N(half+1), true
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as an
+// HTTP date.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return time.Until(at)
+	}
+	return 0
+}
@@ -0,0 +1,27 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"bitbucket-cli/internal/bitbucket"
+)
+
+// runAuthLogin gets the first token of a profile signing in with an OAuth
+// consumer. The authorization code flow waits for the user to approve the
+// consumer in the browser; later runs renew the token on their own.
+func runAuthLogin(ctx context.Context, e *env, args []string) error {
+	flags := newFlagSet(e, "auth login")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if e.oauth == nil {
+		return fmt.Errorf("the profile signs in with a token; set oauth_client_id and oauth_client_secret to use an OAuth consumer")
+	}
+
+	if err := bitbucket.LoginOAuth(ctx, *e.oauth, e.stderr); err != nil {
+		return err
+	}
+	fmt.Fprintf(e.stdout, "Logged in profile %s with OAuth (%s)\n", e.oauth.Profile, e.oauth.Flow)
+	return nil
+}
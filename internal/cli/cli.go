@@ -0,0 +1,252 @@
+// Package cli implements the non-interactive subcommands, e.g.
+// "bitbucket-cli pr list --repo api --json", which print API data to stdout
+// for scripts instead of starting the TUI.
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"bitbucket-cli/internal/bitbucket"
+	"bitbucket-cli/internal/config"
+)
+
+// env is what a command runs with.
+type env struct {
+	client    bitbucket.BitbucketAPI
+	workspace string
+	stdout    io.Writer
+	stderr    io.Writer
+	// oauth is the OAuth consumer of the profile, nil when it uses a token.
+	oauth *config.OAuth
+}
+
+// command is one "<noun> <verb>" subcommand, or a bare "<noun>" when listed
+// under the empty verb. run gets the arguments after the verb.
+type command struct {
+	usage   string
+	summary string
+	run     func(ctx context.Context, e *env, args []string) error
+}
+
+// pipelineCommands is reachable as both "pipeline" and "pipelines".
+var pipelineCommands = map[string]command{
+	"list": {usage: "pipelines list --repo REPO", summary: "list recent pipelines", run: runPipelineList},
+	"logs": {usage: "pipeline logs --repo REPO --build N [--step NAME] [--follow]", summary: "print step logs", run: runPipelineLogs},
+	"wait": {usage: "pipeline wait --repo REPO --build N", summary: "wait for a pipeline, failing unless it succeeds", run: runPipelineWait},
+}
+
+var commands = map[string]map[string]command{
+	"pr": {
+		"list": {usage: "pr list --repo REPO", summary: "list open pull requests", run: runPullRequestList},
+		"create": {
+			usage:   "pr create --repo REPO --source BRANCH --title TITLE",
+			summary: "open a pull request",
+			run:     runPullRequestCreate,
+		},
+		"comment": {usage: "pr comment --repo REPO --id N --body TEXT|-", summary: "comment on a pull request", run: runPullRequestComment},
+	},
+	"branches": {
+		"list": {usage: "branches list --repo REPO", summary: "list branches", run: runBranchList},
+	},
+	"pipelines": pipelineCommands,
+	"pipeline":  pipelineCommands,
+	"repos": {
+		"list": {usage: "repos list", summary: "list repositories of the workspace", run: runRepositoryList},
+	},
+	"export": {
+		"prs":       {usage: "export prs --repo REPO [--output FILE]", summary: "write the open pull requests as Markdown", run: runExportPullRequests},
+		"pipelines": {usage: "export pipelines --repo REPO [--output FILE]", summary: "write recent pipelines as Markdown", run: runExportPipelines},
+		"review":    {usage: "export review --repo REPO --id N [--output FILE]", summary: "write a pull request's review summary as Markdown", run: runExportReview},
+	},
+	"status": {
+		"": {usage: "status [--repo REPO] [--short]", summary: "latest main branch pipeline and open PR count", run: runStatus},
+	},
+	"auth": {
+		"login": {usage: "auth login", summary: "authorize the profile's OAuth consumer and store its token", run: runAuthLogin},
+	},
+}
+
+// Options are the global flags given before the command.
+type Options struct {
+	// Profile names the config profile to use instead of the default.
+	Profile string
+	// Workspace overrides the workspace of the profile.
+	Workspace string
+}
+
+// Exit codes of Run. Scripts can branch on them, so they only ever grow.
+const (
+	exitOK = 0
+	// exitError covers API failures and everything without a code of its
+	// own, such as usage and config errors.
+	exitError          = 1
+	exitAuth           = 2
+	exitNotFound       = 3
+	exitPipelineFailed = 4
+)
+
+// errUsage marks errors caused by how the command was invoked.
+var errUsage = errors.New("usage error")
+
+// IsCommand reports whether arg names a subcommand, i.e. whether the
+// arguments should go to Run rather than start the TUI.
+func IsCommand(arg string) bool {
+	_, ok := commands[arg]
+	return ok || arg == "help"
+}
+
+// Run executes the subcommand in args (without the program name) and returns
+// the process exit code, one of the exit constants.
+func Run(args []string, opts Options, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "help" {
+		printUsage(stdout)
+		return exitOK
+	}
+
+	verbs := commands[args[0]]
+	cmd, cmdArgs, ok := findCommand(verbs, args[1:])
+	if !ok {
+		if len(args) < 2 {
+			fmt.Fprintf(stderr, "missing subcommand for %s\n", args[0])
+		} else {
+			fmt.Fprintf(stderr, "unknown command: %s %s\n", args[0], args[1])
+		}
+		printUsage(stderr)
+		return exitError
+	}
+
+	cfg, err := loadConfig(opts)
+	if err != nil {
+		fmt.Fprintf(stderr, "failed to load config: %v\n", err)
+		return exitError
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	e := &env{client: bitbucket.NewClient(cfg), workspace: cfg.Workspace, stdout: stdout, stderr: stderr, oauth: cfg.OAuth}
+	return execute(ctx, e, cmd, cmdArgs)
+}
+
+// execute runs cmd, printing its error, and returns Run's exit code for how
+// it ended.
+func execute(ctx context.Context, e *env, cmd command, args []string) int {
+	if err := cmd.run(ctx, e, args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		if !errors.Is(err, errUsage) {
+			fmt.Fprintf(e.stderr, "error: %v\n", err)
+		}
+		return exitCode(err)
+	}
+	return exitOK
+}
+
+// findCommand picks the verb named by args[0] and returns the arguments
+// after it. A noun with a command under the empty verb, such as status, runs
+// it when no verb is given.
+func findCommand(verbs map[string]command, args []string) (command, []string, bool) {
+	if len(args) > 0 {
+		if cmd, ok := verbs[args[0]]; ok && args[0] != "" {
+			return cmd, args[1:], true
+		}
+	}
+	if cmd, ok := verbs[""]; ok && (len(args) == 0 || strings.HasPrefix(args[0], "-")) {
+		return cmd, args, true
+	}
+	return command{}, nil, false
+}
+
+// exitCode maps the error of a command to its exit code.
+func exitCode(err error) int {
+	switch {
+	case errors.Is(err, bitbucket.ErrUnauthorized), errors.Is(err, bitbucket.ErrForbidden):
+		return exitAuth
+	case errors.Is(err, bitbucket.ErrNotFound):
+		return exitNotFound
+	case errors.Is(err, errPipelineFailed):
+		return exitPipelineFailed
+	default:
+		return exitError
+	}
+}
+
+// loadConfig returns the profile chosen by opts. Headless commands cannot
+// show the workspace selector, so without --profile a default is required.
+func loadConfig(opts Options) (config.Config, error) {
+	configFile, err := config.LoadConfig()
+	if err != nil {
+		return config.Config{}, err
+	}
+	profile, err := configFile.ResolveProfile(opts.Profile, opts.Workspace)
+	if err != nil {
+		return config.Config{}, err
+	}
+	return config.FromProfile(profile), nil
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: bitbucket-cli [--profile NAME] [--workspace SLUG] <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+
+	var lines []string
+	for _, verbs := range commands {
+		for _, cmd := range verbs {
+			lines = append(lines, fmt.Sprintf("  %s\t%s", cmd.usage, cmd.summary))
+		}
+	}
+	sort.Strings(lines)
+	// Aliased nouns list the same commands twice.
+	lines = slices.Compact(lines)
+	tw := tabwriter.NewWriter(w, 0, 4, 3, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(lines, "\n"))
+	tw.Flush()
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run without a command to start the interactive UI.")
+}
+
+// newFlagSet returns a flag set for cmd that reports errors instead of
+// exiting, so Run controls the exit code.
+func newFlagSet(e *env, cmd string) *flag.FlagSet {
+	flags := flag.NewFlagSet(cmd, flag.ContinueOnError)
+	flags.SetOutput(e.stderr)
+	return flags
+}
+
+// parseFlags parses args, turning a parse failure into errUsage (the flag
+// package has already printed the problem).
+func parseFlags(flags *flag.FlagSet, args []string) error {
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+	if flags.NArg() > 0 {
+		fmt.Fprintf(flags.Output(), "unexpected arguments: %s\n", strings.Join(flags.Args(), " "))
+		return errUsage
+	}
+	return nil
+}
+
+// requireRepo checks that --repo was given.
+func requireRepo(flags *flag.FlagSet, repo string) error {
+	if repo == "" {
+		fmt.Fprintln(flags.Output(), "--repo is required")
+		flags.Usage()
+		return errUsage
+	}
+	return nil
+}
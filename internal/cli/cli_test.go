@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"bitbucket-cli/internal/bitbucket"
+	"bitbucket-cli/internal/bitbucket/fake"
+	"bitbucket-cli/internal/domain"
+)
+
+func TestExitCode(t *testing.T) {
+	client := fake.NewClient()
+	_, stepNotFound := selectSteps([]domain.PipelineStep{{UUID: "{1}", Name: "build"}}, "deploy")
+	_, stepGone := findStep(context.Background(), client, "api", "{p}", "{1}")
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"unauthorized", fmt.Errorf("GET /user: %w", bitbucket.ErrUnauthorized), exitAuth},
+		{"forbidden", fmt.Errorf("GET /repositories: %w", bitbucket.ErrForbidden), exitAuth},
+		{"not found", fmt.Errorf("GET /pipelines/7: %w", bitbucket.ErrNotFound), exitNotFound},
+		{"unknown step", stepNotFound, exitNotFound},
+		{"step gone", stepGone, exitNotFound},
+		{"pipeline failed", fmt.Errorf("%w: #7 failed", errPipelineFailed), exitPipelineFailed},
+		{"other", errors.New("connection reset"), exitError},
+		{"usage", errUsage, exitError},
+	}
+	for _, test := range tests {
+		if got := exitCode(test.err); got != test.want {
+			t.Errorf("%s: exitCode(%v) = %d, want %d", test.name, test.err, got, test.want)
+		}
+	}
+}
+
+func TestCommandExitCodes(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []string
+		setup func(client *fake.Client)
+		want  int
+	}{
+		{"pipeline succeeded", []string{"pipeline", "wait", "--repo", "api", "--build", "7"}, nil, exitOK},
+		{"pipeline failed", []string{"pipeline", "wait", "--repo", "api", "--build", "8"}, nil, exitPipelineFailed},
+		{"unknown build", []string{"pipeline", "wait", "--repo", "api", "--build", "9"}, nil, exitNotFound},
+		{"unknown step", []string{"pipeline", "logs", "--repo", "api", "--build", "7", "--step", "deploy"}, nil, exitNotFound},
+		{"signed out", []string{"pipeline", "logs", "--repo", "api", "--build", "7"}, func(client *fake.Client) {
+			client.Errors["GetPipeline"] = fmt.Errorf("GET pipeline: %w", bitbucket.ErrUnauthorized)
+		}, exitAuth},
+		{"missing repo", []string{"pipeline", "logs", "--build", "7"}, nil, exitError},
+	}
+	for _, test := range tests {
+		client := fake.NewClient()
+		client.Pipelines["api"] = []domain.Pipeline{
+			{UUID: "7", BuildNumber: 7, State: "COMPLETED", Result: "SUCCESSFUL"},
+			{UUID: "8", BuildNumber: 8, State: "COMPLETED", Result: "FAILED"},
+		}
+		client.Steps["7"] = []domain.PipelineStep{{UUID: "{1}", Name: "build", State: "COMPLETED"}}
+		if test.setup != nil {
+			test.setup(client)
+		}
+
+		var stdout, stderr bytes.Buffer
+		e := &env{client: client, workspace: "acme", stdout: &stdout, stderr: &stderr}
+		cmd, args, ok := findCommand(commands[test.args[0]], test.args[1:])
+		if !ok {
+			t.Fatalf("%s: no command for %v", test.name, test.args)
+		}
+		if got := execute(context.Background(), e, cmd, args); got != test.want {
+			t.Errorf("%s: exit code %d, want %d; stderr: %s", test.name, got, test.want, stderr.String())
+		}
+	}
+}
+
+func TestRunWithoutACommand(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if got := Run([]string{"help"}, Options{}, &stdout, &stderr); got != exitOK {
+		t.Errorf("help: exit code %d, want %d", got, exitOK)
+	}
+	if got := Run([]string{"pipeline", "rerun"}, Options{}, &stdout, &stderr); got != exitError {
+		t.Errorf("unknown command: exit code %d, want %d", got, exitError)
+	}
+}
@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"bitbucket-cli/internal/report"
+)
+
+func runExportPullRequests(ctx context.Context, e *env, args []string) error {
+	flags := newFlagSet(e, "export prs")
+	repo := flags.String("repo", "", "repository slug")
+	output := flags.String("output", "-", "write the report to `file` instead of stdout")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if err := requireRepo(flags, *repo); err != nil {
+		return err
+	}
+
+	prs, err := e.client.ListPullRequests(ctx, *repo)
+	if err != nil {
+		return err
+	}
+	return writeReport(e, *output, func(w io.Writer) error {
+		return report.PullRequests(w, *repo, prs, time.Now())
+	})
+}
+
+func runExportPipelines(ctx context.Context, e *env, args []string) error {
+	flags := newFlagSet(e, "export pipelines")
+	repo := flags.String("repo", "", "repository slug")
+	output := flags.String("output", "-", "write the report to `file` instead of stdout")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if err := requireRepo(flags, *repo); err != nil {
+		return err
+	}
+
+	pipelines, err := e.client.ListPipelines(ctx, *repo)
+	if err != nil {
+		return err
+	}
+	return writeReport(e, *output, func(w io.Writer) error {
+		return report.Pipelines(w, *repo, pipelines, time.Now())
+	})
+}
+
+func runExportReview(ctx context.Context, e *env, args []string) error {
+	flags := newFlagSet(e, "export review")
+	repo := flags.String("repo", "", "repository slug")
+	id := flags.Int("id", 0, "pull request number")
+	output := flags.String("output", "-", "write the report to `file` instead of stdout")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if err := requireRepo(flags, *repo); err != nil {
+		return err
+	}
+	if *id <= 0 {
+		fmt.Fprintln(flags.Output(), "--id is required")
+		flags.Usage()
+		return errUsage
+	}
+
+	pr, err := e.client.GetPullRequest(ctx, *repo, *id)
+	if err != nil {
+		return err
+	}
+	commits, err := e.client.ListPullRequestCommits(ctx, *repo, *id)
+	if err != nil {
+		return err
+	}
+	return writeReport(e, *output, func(w io.Writer) error {
+		return report.Review(w, *repo, pr, commits, time.Now())
+	})
+}
+
+// writeReport runs write against stdout, or the named file when path is not
+// "-".
+func writeReport(e *env, path string, write func(io.Writer) error) error {
+	if path == "-" {
+		return write(e.stdout)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(file); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(e.stderr, "Wrote %s\n", path)
+	return nil
+}
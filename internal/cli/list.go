@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"context"
+	"strconv"
+
+	"bitbucket-cli/internal/domain"
+)
+
+// pullRequestColumns are shared by pr list and pr create.
+var pullRequestColumns = []column[domain.PullRequest]{
+	{"ID", func(pr domain.PullRequest) string { return strconv.Itoa(pr.ID) }},
+	{"STATE", func(pr domain.PullRequest) string { return pr.State }},
+	{"AUTHOR", func(pr domain.PullRequest) string { return pr.Author }},
+	{"SOURCE", func(pr domain.PullRequest) string { return pr.SourceBranch }},
+	{"DEST", func(pr domain.PullRequest) string { return pr.DestBranch }},
+	{"APPROVALS", func(pr domain.PullRequest) string { return strconv.Itoa(pr.Approvals) }},
+	{"TITLE", func(pr domain.PullRequest) string { return pr.Title }},
+}
+
+func runPullRequestList(ctx context.Context, e *env, args []string) error {
+	flags := newFlagSet(e, "pr list")
+	repo := flags.String("repo", "", "repository slug")
+	formats := addFormatFlags(flags)
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	format, err := formats.resolve(flags.Output())
+	if err != nil {
+		return err
+	}
+	if err := requireRepo(flags, *repo); err != nil {
+		return err
+	}
+
+	prs, err := e.client.ListPullRequests(ctx, *repo)
+	if err != nil {
+		return err
+	}
+	return writeList(e.stdout, prs, format, pullRequestColumns)
+}
+
+func runBranchList(ctx context.Context, e *env, args []string) error {
+	flags := newFlagSet(e, "branches list")
+	repo := flags.String("repo", "", "repository slug")
+	formats := addFormatFlags(flags)
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	format, err := formats.resolve(flags.Output())
+	if err != nil {
+		return err
+	}
+	if err := requireRepo(flags, *repo); err != nil {
+		return err
+	}
+
+	branches, err := e.client.ListBranches(ctx, *repo)
+	if err != nil {
+		return err
+	}
+	return writeList(e.stdout, branches, format, []column[domain.Branch]{
+		{"NAME", func(b domain.Branch) string { return b.Name }},
+		{"COMMIT", func(b domain.Branch) string { return shortHash(b.Target.Hash) }},
+		{"DATE", func(b domain.Branch) string { return b.Target.Date }},
+	})
+}
+
+func runPipelineList(ctx context.Context, e *env, args []string) error {
+	flags := newFlagSet(e, "pipelines list")
+	repo := flags.String("repo", "", "repository slug")
+	formats := addFormatFlags(flags)
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	format, err := formats.resolve(flags.Output())
+	if err != nil {
+		return err
+	}
+	if err := requireRepo(flags, *repo); err != nil {
+		return err
+	}
+
+	pipelines, err := e.client.ListPipelines(ctx, *repo)
+	if err != nil {
+		return err
+	}
+	return writeList(e.stdout, pipelines, format, []column[domain.Pipeline]{
+		{"BUILD", func(p domain.Pipeline) string { return strconv.Itoa(p.BuildNumber) }},
+		{"STATE", func(p domain.Pipeline) string { return p.State }},
+		{"RESULT", func(p domain.Pipeline) string { return p.Result }},
+		{"BRANCH", func(p domain.Pipeline) string { return p.BranchName }},
+		{"CREATED", func(p domain.Pipeline) string { return p.CreatedOn }},
+	})
+}
+
+func runRepositoryList(ctx context.Context, e *env, args []string) error {
+	flags := newFlagSet(e, "repos list")
+	formats := addFormatFlags(flags)
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	format, err := formats.resolve(flags.Output())
+	if err != nil {
+		return err
+	}
+
+	repos, err := e.client.ListRepositories(ctx)
+	if err != nil {
+		return err
+	}
+	return writeList(e.stdout, repos, format, []column[domain.Repository]{
+		{"SLUG", func(r domain.Repository) string { return r.Slug }},
+		{"NAME", func(r domain.Repository) string { return r.Name }},
+		{"MAIN", func(r domain.Repository) string { return r.Mainbranch }},
+		{"UPDATED", func(r domain.Repository) string { return r.UpdatedOn }},
+	})
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+)
+
+// column is one field of the table and TSV output.
+type column[T any] struct {
+	header string
+	value  func(T) string
+}
+
+const (
+	formatTable    = "table"
+	formatJSON     = "json"
+	formatTSV      = "tsv"
+	formatTemplate = "template"
+)
+
+// outputFormat is how a command prints its records.
+type outputFormat struct {
+	kind     string
+	template *template.Template
+}
+
+// formatFlags are the --format and --json flags shared by commands that
+// print records.
+type formatFlags struct {
+	format string
+	json   bool
+}
+
+func addFormatFlags(flags *flag.FlagSet) *formatFlags {
+	f := &formatFlags{}
+	flags.StringVar(&f.format, "format", formatTable, "output format: table, json, tsv, or a Go template such as '{{.ID}} {{.Title}}'")
+	flags.BoolVar(&f.json, "json", false, "shorthand for --format json")
+	return f
+}
+
+// resolve validates the flags once parsed. A value containing "{{" is a
+// template, executed once per record over the domain struct's fields.
+func (f *formatFlags) resolve(output io.Writer) (outputFormat, error) {
+	if f.json {
+		return outputFormat{kind: formatJSON}, nil
+	}
+	switch f.format {
+	case formatTable, formatJSON, formatTSV:
+		return outputFormat{kind: f.format}, nil
+	}
+	if !strings.Contains(f.format, "{{") {
+		fmt.Fprintf(output, "unknown --format %q: use table, json, tsv or a template\n", f.format)
+		return outputFormat{}, errUsage
+	}
+	tmpl, err := template.New("format").Parse(f.format)
+	if err != nil {
+		fmt.Fprintf(output, "invalid --format template: %v\n", err)
+		return outputFormat{}, errUsage
+	}
+	return outputFormat{kind: formatTemplate, template: tmpl}, nil
+}
+
+// writeList prints items in the chosen format. JSON always encodes an
+// array, even when empty; table and TSV start with a header row.
+func writeList[T any](w io.Writer, items []T, format outputFormat, columns []column[T]) error {
+	switch format.kind {
+	case formatJSON:
+		if items == nil {
+			items = []T{}
+		}
+		return writeJSON(w, items)
+	case formatTemplate:
+		for _, item := range items {
+			if err := writeTemplate(w, format.template, item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	out := w
+	var tw *tabwriter.Writer
+	if format.kind == formatTable {
+		tw = tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		out = tw
+	}
+	headers := make([]string, len(columns))
+	for i, col := range columns {
+		headers[i] = col.header
+	}
+	fmt.Fprintln(out, strings.Join(headers, "\t"))
+	for _, item := range items {
+		values := make([]string, len(columns))
+		for i, col := range columns {
+			values[i] = singleLine(col.value(item))
+		}
+		fmt.Fprintln(out, strings.Join(values, "\t"))
+	}
+	if tw != nil {
+		return tw.Flush()
+	}
+	return nil
+}
+
+// writeItem prints a single record. The table format uses human, a
+// free-form description, instead of a one-row table.
+func writeItem[T any](w io.Writer, item T, format outputFormat, columns []column[T], human func(io.Writer, T)) error {
+	switch format.kind {
+	case formatJSON:
+		return writeJSON(w, item)
+	case formatTemplate:
+		return writeTemplate(w, format.template, item)
+	case formatTSV:
+		return writeList(w, []T{item}, format, columns)
+	}
+	human(w, item)
+	return nil
+}
+
+func writeJSON(w io.Writer, v any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// writeTemplate executes tmpl for one record and ends it with a newline,
+// unless the template already does.
+func writeTemplate(w io.Writer, tmpl *template.Template, item any) error {
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, item); err != nil {
+		return fmt.Errorf("executing --format template: %w", err)
+	}
+	text := sb.String()
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	_, err := io.WriteString(w, text)
+	return err
+}
+
+// singleLine keeps a table cell on one line.
+func singleLine(s string) string {
+	s, _, _ = strings.Cut(s, "\n")
+	return strings.ReplaceAll(strings.TrimSpace(s), "\t", " ")
+}
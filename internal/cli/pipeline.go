@@ -0,0 +1,245 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"bitbucket-cli/internal/bitbucket"
+	"bitbucket-cli/internal/domain"
+)
+
+// followInterval is how often --follow polls a running step's log.
+const followInterval = 3 * time.Second
+
+func runPipelineLogs(ctx context.Context, e *env, args []string) error {
+	flags := newFlagSet(e, "pipeline logs")
+	repo := flags.String("repo", "", "repository slug")
+	build := flags.Int("build", 0, "pipeline build number")
+	stepName := flags.String("step", "", "only print the step with this name (default: all steps)")
+	follow := flags.Bool("follow", false, "keep printing the log of running steps until they finish")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if err := requireRepo(flags, *repo); err != nil {
+		return err
+	}
+	if *build <= 0 {
+		fmt.Fprintln(flags.Output(), "--build is required")
+		flags.Usage()
+		return errUsage
+	}
+
+	// The pipeline endpoint takes a build number in place of the UUID.
+	pipeline, err := e.client.GetPipeline(ctx, *repo, strconv.Itoa(*build))
+	if err != nil {
+		return err
+	}
+	steps, err := e.client.ListPipelineSteps(ctx, *repo, pipeline.UUID)
+	if err != nil {
+		return err
+	}
+	steps, err = selectSteps(steps, *stepName)
+	if err != nil {
+		return err
+	}
+
+	for i, step := range steps {
+		// Step headers go to stderr so stdout carries nothing but log text.
+		if len(steps) > 1 {
+			if i > 0 {
+				fmt.Fprintln(e.stderr)
+			}
+			fmt.Fprintf(e.stderr, "==> %s\n", stepLabel(step))
+		}
+		if *follow {
+			err = followStepLog(ctx, e, *repo, pipeline.UUID, step.UUID)
+		} else {
+			err = printStepLog(ctx, e, *repo, pipeline.UUID, step.UUID)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// selectSteps keeps the step called name, or all steps when name is empty.
+func selectSteps(steps []domain.PipelineStep, name string) ([]domain.PipelineStep, error) {
+	if name == "" {
+		if len(steps) == 0 {
+			return nil, fmt.Errorf("pipeline has no steps")
+		}
+		return steps, nil
+	}
+	for _, step := range steps {
+		if strings.EqualFold(step.Name, name) {
+			return []domain.PipelineStep{step}, nil
+		}
+	}
+	names := make([]string, 0, len(steps))
+	for _, step := range steps {
+		names = append(names, stepLabel(step))
+	}
+	return nil, fmt.Errorf("step %q %w (steps: %s)", name, bitbucket.ErrNotFound, strings.Join(names, ", "))
+}
+
+func stepLabel(step domain.PipelineStep) string {
+	if step.Name != "" {
+		return step.Name
+	}
+	return step.UUID
+}
+
+func printStepLog(ctx context.Context, e *env, repo, pipelineUUID, stepUUID string) error {
+	log, err := e.client.GetPipelineStepLog(ctx, repo, pipelineUUID, stepUUID)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprint(e.stdout, log)
+	return err
+}
+
+// followStepLog prints the log of a step as it grows, until the step has
+// completed. A step that has not started yet is waited for.
+func followStepLog(ctx context.Context, e *env, repo, pipelineUUID, stepUUID string) error {
+	ctx = bitbucket.WithoutCache(ctx)
+	var printed int64
+	for {
+		step, err := findStep(ctx, e.client, repo, pipelineUUID, stepUUID)
+		if err != nil {
+			return err
+		}
+
+		if !isStepPending(step) {
+			log, err := e.client.GetPipelineStepLogFrom(ctx, repo, pipelineUUID, stepUUID, printed)
+			if err != nil && !isStepRunning(step) {
+				return err
+			}
+			// While a step runs its log may not exist yet; keep polling.
+			if err == nil && log != "" {
+				if _, err := fmt.Fprint(e.stdout, log); err != nil {
+					return err
+				}
+				printed += int64(len(log))
+			}
+		}
+		if isStepDone(step) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(followInterval):
+		}
+	}
+}
+
+func findStep(ctx context.Context, client bitbucket.BitbucketAPI, repo, pipelineUUID, stepUUID string) (domain.PipelineStep, error) {
+	steps, err := client.ListPipelineSteps(ctx, repo, pipelineUUID)
+	if err != nil {
+		return domain.PipelineStep{}, err
+	}
+	for _, step := range steps {
+		if step.UUID == stepUUID {
+			return step, nil
+		}
+	}
+	return domain.PipelineStep{}, fmt.Errorf("step %s disappeared from the pipeline: %w", stepUUID, bitbucket.ErrNotFound)
+}
+
+func isStepPending(step domain.PipelineStep) bool {
+	state := strings.ToUpper(step.State)
+	return state == "PENDING" || state == "READY"
+}
+
+func isStepRunning(step domain.PipelineStep) bool {
+	return strings.ToUpper(step.State) == "IN_PROGRESS"
+}
+
+func isStepDone(step domain.PipelineStep) bool {
+	return strings.ToUpper(step.State) == "COMPLETED"
+}
+
+// errPipelineFailed is returned by pipeline wait when the pipeline finished
+// without succeeding. Run exits with exitPipelineFailed for it.
+var errPipelineFailed = errors.New("pipeline did not succeed")
+
+func runPipelineWait(ctx context.Context, e *env, args []string) error {
+	flags := newFlagSet(e, "pipeline wait")
+	repo := flags.String("repo", "", "repository slug")
+	build := flags.Int("build", 0, "pipeline build number")
+	interval := flags.Duration("interval", 10*time.Second, "time between status checks")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if err := requireRepo(flags, *repo); err != nil {
+		return err
+	}
+	if *build <= 0 {
+		fmt.Fprintln(flags.Output(), "--build is required")
+		flags.Usage()
+		return errUsage
+	}
+	if *interval < time.Second {
+		*interval = time.Second
+	}
+
+	ctx = bitbucket.WithoutCache(ctx)
+	lastStatus := ""
+	stepStates := make(map[string]string)
+	for {
+		pipeline, err := e.client.GetPipeline(ctx, *repo, strconv.Itoa(*build))
+		if err != nil {
+			return err
+		}
+
+		if status := pipelineStatus(pipeline); status != lastStatus {
+			fmt.Fprintf(e.stdout, "%s #%d %s\n", time.Now().Format("15:04:05"), pipeline.BuildNumber, status)
+			lastStatus = status
+		}
+
+		steps, err := e.client.ListPipelineSteps(ctx, *repo, pipeline.UUID)
+		if err != nil {
+			return err
+		}
+		for _, step := range steps {
+			status := stepStatus(step)
+			if stepStates[step.UUID] != status {
+				fmt.Fprintf(e.stdout, "%s   %s: %s\n", time.Now().Format("15:04:05"), stepLabel(step), status)
+				stepStates[step.UUID] = status
+			}
+		}
+
+		if strings.EqualFold(pipeline.State, "COMPLETED") {
+			if strings.EqualFold(pipeline.Result, "SUCCESSFUL") {
+				return nil
+			}
+			return fmt.Errorf("%w: #%d %s", errPipelineFailed, pipeline.BuildNumber, strings.ToLower(pipeline.Result))
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(*interval):
+		}
+	}
+}
+
+func pipelineStatus(pipeline domain.Pipeline) string {
+	if pipeline.Result != "" {
+		return fmt.Sprintf("%s (%s)", pipeline.State, pipeline.Result)
+	}
+	return pipeline.State
+}
+
+func stepStatus(step domain.PipelineStep) string {
+	if step.Result != "" {
+		return fmt.Sprintf("%s (%s)", step.State, step.Result)
+	}
+	return step.State
+}
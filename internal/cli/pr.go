@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"bitbucket-cli/internal/domain"
+)
+
+func runPullRequestCreate(ctx context.Context, e *env, args []string) error {
+	flags := newFlagSet(e, "pr create")
+	repo := flags.String("repo", "", "repository slug")
+	source := flags.String("source", "", "source branch")
+	dest := flags.String("dest", "", "destination branch (default: the repository's main branch)")
+	title := flags.String("title", "", "pull request title")
+	body := flags.String("body", "", "pull request description (- to read it from stdin)")
+	bodyFile := flags.String("body-file", "", "read the description from `file` (- for stdin)")
+	closeSource := flags.Bool("close-source-branch", false, "delete the source branch once merged")
+	formats := addFormatFlags(flags)
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	format, err := formats.resolve(flags.Output())
+	if err != nil {
+		return err
+	}
+	if err := requireRepo(flags, *repo); err != nil {
+		return err
+	}
+	if *source == "" || strings.TrimSpace(*title) == "" {
+		fmt.Fprintln(flags.Output(), "--source and --title are required")
+		flags.Usage()
+		return errUsage
+	}
+
+	description, err := readBody(flags, *body, *bodyFile)
+	if err != nil {
+		return err
+	}
+
+	pr, err := e.client.CreatePullRequest(ctx, *repo, domain.NewPullRequest{
+		Title:             strings.TrimSpace(*title),
+		Description:       description,
+		SourceBranch:      *source,
+		DestBranch:        *dest,
+		CloseSourceBranch: *closeSource,
+	})
+	if err != nil {
+		return err
+	}
+
+	return writeItem(e.stdout, pr, format, pullRequestColumns, func(w io.Writer, pr domain.PullRequest) {
+		fmt.Fprintf(w, "Created PR #%d: %s\n", pr.ID, pr.Title)
+		if pr.URL != "" {
+			fmt.Fprintln(w, pr.URL)
+		}
+	})
+}
+
+func runPullRequestComment(ctx context.Context, e *env, args []string) error {
+	flags := newFlagSet(e, "pr comment")
+	repo := flags.String("repo", "", "repository slug")
+	id := flags.Int("id", 0, "pull request number")
+	body := flags.String("body", "", "comment text in Markdown (- to read it from stdin)")
+	bodyFile := flags.String("body-file", "", "read the comment from `file` (- for stdin)")
+	formats := addFormatFlags(flags)
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	format, err := formats.resolve(flags.Output())
+	if err != nil {
+		return err
+	}
+	if err := requireRepo(flags, *repo); err != nil {
+		return err
+	}
+	if *id <= 0 {
+		fmt.Fprintln(flags.Output(), "--id is required")
+		flags.Usage()
+		return errUsage
+	}
+
+	content, err := readBody(flags, *body, *bodyFile)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(content) == "" {
+		fmt.Fprintln(flags.Output(), "the comment is empty: give --body or --body-file")
+		return errUsage
+	}
+
+	comment, err := e.client.CreatePullRequestComment(ctx, *repo, *id, content)
+	if err != nil {
+		return err
+	}
+	return writeItem(e.stdout, comment, format, []column[domain.Comment]{
+		{"ID", func(c domain.Comment) string { return strconv.Itoa(c.ID) }},
+		{"AUTHOR", func(c domain.Comment) string { return c.Author }},
+		{"CONTENT", func(c domain.Comment) string { return c.Content }},
+	}, func(w io.Writer, c domain.Comment) {
+		fmt.Fprintf(w, "Commented on PR #%d (comment %d)\n", *id, c.ID)
+	})
+}
+
+// readBody returns the text given by a --body/--body-file pair. Either
+// may be "-" to read standard input, so text can be piped in from other
+// tools.
+func readBody(flags *flag.FlagSet, body, bodyFile string) (string, error) {
+	switch {
+	case body != "" && bodyFile != "":
+		fmt.Fprintln(flags.Output(), "use either --body or --body-file")
+		return "", errUsage
+	case body == "-":
+		return readTextFile("-")
+	case bodyFile != "":
+		return readTextFile(bodyFile)
+	}
+	return body, nil
+}
+
+// readTextFile reads a file argument, where "-" means standard input.
+func readTextFile(name string) (string, error) {
+	if name == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", fmt.Errorf("failed to read stdin: %w", err)
+		}
+		return string(data), nil
+	}
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
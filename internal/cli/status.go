@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"bitbucket-cli/internal/config"
+	"bitbucket-cli/internal/domain"
+	"bitbucket-cli/internal/gitrepo"
+)
+
+// repoStatus is what status prints, and what it caches between runs.
+type repoStatus struct {
+	Repo       string
+	MainBranch string
+	// Pipeline is the latest pipeline of MainBranch, nil when it has none
+	// among the recent pipelines.
+	Pipeline         *domain.Pipeline
+	OpenPullRequests int
+	FetchedAt        time.Time
+}
+
+// defaultStatusMaxAge keeps status cheap enough to run from a shell prompt or
+// a tmux status line, which may call it every few seconds.
+const defaultStatusMaxAge = time.Minute
+
+var statusColumns = []column[repoStatus]{
+	{"REPO", func(s repoStatus) string { return s.Repo }},
+	{"BRANCH", func(s repoStatus) string { return s.MainBranch }},
+	{"PIPELINE", func(s repoStatus) string { return statusPipelineResult(s.Pipeline) }},
+	{"BUILD", func(s repoStatus) string {
+		if s.Pipeline == nil {
+			return ""
+		}
+		return strconv.Itoa(s.Pipeline.BuildNumber)
+	}},
+	{"OPEN_PRS", func(s repoStatus) string { return strconv.Itoa(s.OpenPullRequests) }},
+}
+
+func runStatus(ctx context.Context, e *env, args []string) error {
+	flags := newFlagSet(e, "status")
+	repo := flags.String("repo", "", "repository slug (default: the git clone in the current directory)")
+	short := flags.Bool("short", false, "print a single compact line, e.g. for a tmux status bar or shell prompt")
+	maxAge := flags.Duration("max-age", defaultStatusMaxAge, "reuse a cached status younger than this")
+	formats := addFormatFlags(flags)
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	format, err := formats.resolve(flags.Output())
+	if err != nil {
+		return err
+	}
+	if *repo == "" {
+		if clone, ok := gitrepo.Detect("."); ok && strings.EqualFold(clone.Workspace, e.workspace) {
+			*repo = clone.Repo
+		}
+	}
+	if err := requireRepo(flags, *repo); err != nil {
+		return err
+	}
+
+	status, err := loadStatus(ctx, e, *repo, *maxAge)
+	if err != nil {
+		return err
+	}
+	return writeItem(e.stdout, status, format, statusColumns, func(w io.Writer, s repoStatus) {
+		if *short {
+			fmt.Fprintln(w, shortStatus(s))
+			return
+		}
+		printStatus(w, s)
+	})
+}
+
+// loadStatus returns the cached status of repo when it is younger than
+// maxAge, and fetches it otherwise. When fetching fails, a stale cached copy
+// is still better than nothing in a status bar.
+func loadStatus(ctx context.Context, e *env, repo string, maxAge time.Duration) (repoStatus, error) {
+	name := fmt.Sprintf("status-%s-%s", e.workspace, repo)
+	var cached repoStatus
+	savedAt, cacheErr := config.LoadSnapshot(name, &cached)
+	hasCache := cacheErr == nil && !savedAt.IsZero()
+	if hasCache && time.Since(savedAt) < maxAge {
+		return cached, nil
+	}
+
+	status, err := fetchStatus(ctx, e, repo)
+	if err != nil {
+		if hasCache {
+			return cached, nil
+		}
+		return repoStatus{}, err
+	}
+	_ = config.SaveSnapshot(name, status)
+	return status, nil
+}
+
+func fetchStatus(ctx context.Context, e *env, repo string) (repoStatus, error) {
+	repository, err := e.client.GetRepository(ctx, repo)
+	if err != nil {
+		return repoStatus{}, err
+	}
+	pipelines, err := e.client.ListPipelines(ctx, repo)
+	if err != nil {
+		return repoStatus{}, err
+	}
+	prs, err := e.client.ListPullRequests(ctx, repo)
+	if err != nil {
+		return repoStatus{}, err
+	}
+
+	status := repoStatus{
+		Repo:             repository.Slug,
+		MainBranch:       repository.Mainbranch,
+		OpenPullRequests: len(prs),
+		FetchedAt:        time.Now(),
+	}
+	for _, pipeline := range pipelines {
+		if pipeline.BranchName == repository.Mainbranch {
+			status.Pipeline = &pipeline
+			break
+		}
+	}
+	return status, nil
+}
+
+// statusPipelineResult is the pipeline's result once completed, and its
+// state before that.
+func statusPipelineResult(pipeline *domain.Pipeline) string {
+	if pipeline == nil {
+		return "none"
+	}
+	if pipeline.Result != "" {
+		return strings.ToLower(pipeline.Result)
+	}
+	return strings.ToLower(pipeline.State)
+}
+
+// statusSymbols are the one-character pipeline results of --short.
+var statusSymbols = map[string]string{
+	"successful":  "✓",
+	"failed":      "✗",
+	"error":       "✗",
+	"stopped":     "■",
+	"in_progress": "●",
+	"running":     "●",
+	"pending":     "○",
+	"none":        "-",
+}
+
+// shortStatus renders e.g. "api main ✓ 3 PRs".
+func shortStatus(s repoStatus) string {
+	result := statusPipelineResult(s.Pipeline)
+	symbol, ok := statusSymbols[result]
+	if !ok {
+		symbol = "?"
+	}
+	prs := "PRs"
+	if s.OpenPullRequests == 1 {
+		prs = "PR"
+	}
+	return fmt.Sprintf("%s %s %s %d %s", s.Repo, s.MainBranch, symbol, s.OpenPullRequests, prs)
+}
+
+func printStatus(w io.Writer, s repoStatus) {
+	fmt.Fprintf(w, "Repository:    %s\n", s.Repo)
+	if s.Pipeline == nil {
+		fmt.Fprintf(w, "Pipeline:      no recent pipeline on %s\n", s.MainBranch)
+	} else {
+		fmt.Fprintf(w, "Pipeline:      #%d on %s %s\n", s.Pipeline.BuildNumber, s.MainBranch, statusPipelineResult(s.Pipeline))
+	}
+	fmt.Fprintf(w, "Pull requests: %d open\n", s.OpenPullRequests)
+	fmt.Fprintf(w, "As of:         %s\n", s.FetchedAt.Local().Format(time.DateTime))
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// snapshot wraps cached API data with the time it was fetched.
+type snapshot struct {
+	SavedAt time.Time       `json:"saved_at"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// CacheDir returns the directory holding cached API data, e.g.
+// ~/.cache/bitbucket-cli on Linux.
+func CacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get cache directory: %w", err)
+	}
+	return filepath.Join(dir, "bitbucket-cli"), nil
+}
+
+// LoadSnapshot decodes the cached data stored under name into v and returns
+// when it was saved. A missing snapshot returns the zero time and no error.
+func LoadSnapshot(name string, v any) (time.Time, error) {
+	dir, err := CacheDir()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, name+".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read cached %s: %w", name, err)
+	}
+
+	var decoded snapshot
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return time.Time{}, fmt.Errorf("failed to decode cached %s: %w", name, err)
+	}
+	if err := json.Unmarshal(decoded.Data, v); err != nil {
+		return time.Time{}, fmt.Errorf("failed to decode cached %s: %w", name, err)
+	}
+	return decoded.SavedAt, nil
+}
+
+// SaveSnapshot stores v under name with the current time.
+func SaveSnapshot(name string, v any) error {
+	dir, err := CacheDir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode cached %s: %w", name, err)
+	}
+	encoded, err := json.Marshal(snapshot{SavedAt: time.Now(), Data: data})
+	if err != nil {
+		return fmt.Errorf("failed to encode cached %s: %w", name, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, name+".json"), encoded, 0o600); err != nil {
+		return fmt.Errorf("failed to write cached %s: %w", name, err)
+	}
+	return nil
+}
@@ -1,15 +1,113 @@
 package config
 
 import (
+	"encoding/base64"
 	"fmt"
+	"strings"
 	"time"
 )
 
+const (
+	defaultMaxRetries      = 3
+	defaultRetryBackoff    = 500 * time.Millisecond
+	defaultListTimeout     = 20 * time.Second
+	defaultDownloadTimeout = 2 * time.Minute
+	defaultOAuthCallback   = "localhost:8976"
+)
+
+// The OAuth flows a profile can sign in with.
+const (
+	OAuthClientCredentials = "client_credentials"
+	OAuthAuthorizationCode = "authorization_code"
+)
+
 type Config struct {
-	baseURL   string
-	BasicAuth string
-	Timeout   time.Duration
-	Workspace string
+	baseURL string
+	// Authorization is the Authorization header sent with requests, built
+	// from the profile's credentials.
+	Authorization string
+	// OAuth is set when the profile signs in with an OAuth consumer; requests
+	// then carry its access token instead of Authorization.
+	OAuth *OAuth
+	// ListTimeout bounds JSON API calls; DownloadTimeout bounds plain text
+	// downloads such as diffs and pipeline logs. Both apply per attempt.
+	ListTimeout     time.Duration
+	DownloadTimeout time.Duration
+	Workspace       string
+	Theme           string
+	BadgeStyle      string
+	Icons           string
+	Favorites       []string
+	TrackedBranches []string
+	StartView       string
+	Viewer          string
+	Editor          string
+	Notify          string
+	TimeFormat      string
+	Clock           string
+	KeyBindings     map[string][]string
+	MaxRetries      int
+	RetryBackoff    time.Duration
+	// AutoRefresh reloads the current view at this interval when set; a
+	// webhook listener makes it unnecessary.
+	AutoRefresh   time.Duration
+	WebhookSecret string
+	// ExportDir is where Markdown exports are written; empty means the
+	// current directory.
+	ExportDir string
+	// Clipboard is "auto" (also when empty), "system" or "osc52".
+	Clipboard string
+	// DiffPager is a shell command diffs are piped through instead of
+	// opening them in Viewer.
+	DiffPager string
+	// BrowserCommand opens URLs instead of the platform opener; "%s" in it
+	// is replaced by the URL.
+	BrowserCommand string
+	// Accessible renders plain text for screen readers: no color, borders
+	// or box drawing, and a line announcing the current view.
+	Accessible bool
+	// Locale selects the translation of the interface; empty means English.
+	Locale string
+}
+
+// OAuth is the OAuth consumer a profile signs in with. Its tokens are stored
+// under the profile's name.
+type OAuth struct {
+	Profile      string
+	ClientID     string
+	ClientSecret string
+	// Flow is OAuthClientCredentials or OAuthAuthorizationCode.
+	Flow string
+	// CallbackAddr is the host:port the authorization code flow waits on for
+	// the browser's redirect, which must match the consumer's callback URL.
+	CallbackAddr string
+}
+
+// authorization builds the Authorization header of a profile: Basic auth
+// from its username and app password, or its token, which is taken as
+// already encoded Basic credentials unless token_type is bearer.
+func authorization(profile Profile) string {
+	switch {
+	case profile.Username != "":
+		credentials := base64.StdEncoding.EncodeToString([]byte(profile.Username + ":" + profile.AppPassword))
+		return "Basic " + credentials
+	case profile.TokenType == "bearer":
+		return "Bearer " + profile.Token
+	default:
+		return "Basic " + profile.Token
+	}
+}
+
+// BaseURL returns the root of the REST API, without a trailing slash.
+func (c Config) BaseURL() string {
+	return c.baseURL
+}
+
+// WithBaseURL returns a copy of c talking to another API root, such as a
+// fixture server.
+func (c Config) WithBaseURL(baseURL string) Config {
+	c.baseURL = strings.TrimSuffix(baseURL, "/")
+	return c
 }
 
 func (c Config) ProjectsURL(workspace string) string {
@@ -17,10 +115,67 @@ func (c Config) ProjectsURL(workspace string) string {
 }
 
 func FromProfile(profile Profile) Config {
+	maxRetries := defaultMaxRetries
+	if profile.MaxRetries != nil {
+		maxRetries = *profile.MaxRetries
+	}
+	retryBackoff := profile.RetryBackoff
+	if retryBackoff <= 0 {
+		retryBackoff = defaultRetryBackoff
+	}
+	listTimeout := profile.ListTimeout
+	if listTimeout <= 0 {
+		listTimeout = defaultListTimeout
+	}
+	downloadTimeout := profile.DownloadTimeout
+	if downloadTimeout <= 0 {
+		downloadTimeout = defaultDownloadTimeout
+	}
+	var oauth *OAuth
+	if profile.OAuthClientID != "" {
+		oauth = &OAuth{
+			Profile:      profile.Name,
+			ClientID:     profile.OAuthClientID,
+			ClientSecret: profile.OAuthClientSecret,
+			Flow:         profile.OAuthFlow,
+			CallbackAddr: profile.OAuthCallback,
+		}
+		if oauth.Flow == "" {
+			oauth.Flow = OAuthClientCredentials
+		}
+		if oauth.CallbackAddr == "" {
+			oauth.CallbackAddr = defaultOAuthCallback
+		}
+	}
+
 	return Config{
-		baseURL:   "https://api.bitbucket.org/2.0",
-		BasicAuth: fmt.Sprintf("Basic %s", profile.Token),
-		Timeout:   20 * time.Second,
-		Workspace: profile.Workspace,
+		baseURL:         "https://api.bitbucket.org/2.0",
+		Authorization:   authorization(profile),
+		OAuth:           oauth,
+		ListTimeout:     listTimeout,
+		DownloadTimeout: downloadTimeout,
+		Workspace:       profile.Workspace,
+		Theme:           profile.Theme,
+		BadgeStyle:      profile.BadgeStyle,
+		Icons:           profile.Icons,
+		Favorites:       profile.Favorites,
+		TrackedBranches: profile.TrackedBranches,
+		StartView:       profile.StartView,
+		Viewer:          profile.Viewer,
+		Editor:          profile.Editor,
+		Notify:          profile.Notify,
+		AutoRefresh:     profile.AutoRefresh,
+		WebhookSecret:   profile.WebhookSecret,
+		ExportDir:       profile.ExportDir,
+		Clipboard:       profile.Clipboard,
+		DiffPager:       profile.DiffPager,
+		BrowserCommand:  profile.BrowserCommand,
+		Accessible:      profile.Accessible,
+		Locale:          profile.Locale,
+		TimeFormat:      profile.TimeFormat,
+		Clock:           profile.Clock,
+		KeyBindings:     profile.KeyBindings,
+		MaxRetries:      maxRetries,
+		RetryBackoff:    retryBackoff,
 	}
 }
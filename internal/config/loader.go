@@ -2,16 +2,70 @@ package config
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
+	"net"
 	"os"
+	"path"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 )
 
 type Profile struct {
-	Name      string
-	Workspace string
-	Token     string
+	Name       string
+	Workspace  string
+	Token      string
+	Theme      string
+	BadgeStyle string
+	Icons      string
+	Favorites  []string
+	StartView  string
+	Viewer     string
+	Editor     string
+	Notify     string
+	TimeFormat string
+	Clock      string
+	// TrackedBranches holds the tracked_branches names or patterns whose
+	// pipelines are listed; nil when not set.
+	TrackedBranches []string
+	// MaxRetries is nil when the profile does not set max_retries.
+	MaxRetries   *int
+	RetryBackoff time.Duration
+	// ListTimeout and DownloadTimeout are zero when not set.
+	ListTimeout     time.Duration
+	DownloadTimeout time.Duration
+	// KeyBindings holds "key.<action>" remaps, e.g. key.refresh = r,ctrl+r.
+	KeyBindings map[string][]string
+	// AutoRefresh is zero when auto_refresh is not set.
+	AutoRefresh   time.Duration
+	WebhookSecret string
+	ExportDir     string
+	Clipboard     string
+	DiffPager     string
+	// BrowserCommand keeps its "%s" placeholder for the URL.
+	BrowserCommand string
+	Accessible     bool
+	Locale         string
+	// Username and AppPassword, an app password or an Atlassian API token,
+	// sign the profile in instead of Token when set.
+	Username    string
+	AppPassword string
+	// TokenType is "basic" when Token is base64 encoded username:password,
+	// which is the default, or "bearer" when it is an access token.
+	TokenType string
+	// OAuthClientID and OAuthClientSecret are the key and secret of an OAuth
+	// consumer, which signs the profile in instead of Token when set.
+	OAuthClientID     string
+	OAuthClientSecret string
+	// OAuthFlow is "client_credentials" or "authorization_code"; empty
+	// means client_credentials.
+	OAuthFlow string
+	// OAuthCallback is the host:port the authorization code flow waits on
+	// for the browser's redirect; empty means localhost:8976.
+	OAuthCallback string
 }
 
 type ConfigFile struct {
@@ -79,6 +133,116 @@ func LoadConfig() (*ConfigFile, error) {
 				profile.Workspace = value
 			case "token":
 				profile.Token = value
+			case "token_type":
+				value = strings.ToLower(value)
+				if value != "basic" && value != "bearer" {
+					return nil, fmt.Errorf("invalid token_type %q in profile %s: use basic or bearer", value, currentSection)
+				}
+				profile.TokenType = value
+			case "username":
+				profile.Username = value
+			case "app_password", "api_token":
+				profile.AppPassword = value
+			case "oauth_client_id":
+				profile.OAuthClientID = value
+			case "oauth_client_secret":
+				profile.OAuthClientSecret = value
+			case "oauth_flow":
+				if value != OAuthClientCredentials && value != OAuthAuthorizationCode {
+					return nil, fmt.Errorf("invalid oauth_flow %q in profile %s: use %s or %s", value, currentSection, OAuthClientCredentials, OAuthAuthorizationCode)
+				}
+				profile.OAuthFlow = value
+			case "oauth_callback":
+				if _, _, err := net.SplitHostPort(value); err != nil {
+					return nil, fmt.Errorf("invalid oauth_callback %q in profile %s: use host:port, e.g. localhost:8976", value, currentSection)
+				}
+				profile.OAuthCallback = value
+			case "theme":
+				profile.Theme = value
+			case "badge_style":
+				profile.BadgeStyle = value
+			case "icons":
+				profile.Icons = value
+			case "favorites":
+				profile.Favorites = splitList(value)
+			case "tracked_branches":
+				for _, pattern := range splitList(value) {
+					if _, err := path.Match(pattern, ""); err != nil {
+						return nil, fmt.Errorf("invalid tracked_branches pattern %q in profile %s", pattern, currentSection)
+					}
+				}
+				profile.TrackedBranches = splitList(value)
+			case "start_view":
+				profile.StartView = value
+			case "viewer":
+				profile.Viewer = value
+			case "editor":
+				profile.Editor = value
+			case "notify":
+				profile.Notify = value
+			case "auto_refresh":
+				interval, err := time.ParseDuration(value)
+				if err != nil || interval < 5*time.Second {
+					return nil, fmt.Errorf("invalid auto_refresh %q in profile %s: use a duration of at least 5s", value, currentSection)
+				}
+				profile.AutoRefresh = interval
+			case "webhook_secret":
+				profile.WebhookSecret = value
+			case "export_dir":
+				if rest, ok := strings.CutPrefix(value, "~/"); ok {
+					value = filepath.Join(homeDir, rest)
+				}
+				profile.ExportDir = value
+			case "clipboard":
+				if value != "auto" && value != "system" && value != "osc52" {
+					return nil, fmt.Errorf("invalid clipboard %q in profile %s: use auto, system or osc52", value, currentSection)
+				}
+				profile.Clipboard = value
+			case "diff_pager":
+				profile.DiffPager = value
+			case "browser_command":
+				profile.BrowserCommand = value
+			case "locale":
+				profile.Locale = value
+			case "accessible":
+				accessible, err := strconv.ParseBool(value)
+				if err != nil {
+					return nil, fmt.Errorf("invalid accessible %q in profile %s: use true or false", value, currentSection)
+				}
+				profile.Accessible = accessible
+			case "time_format":
+				profile.TimeFormat = value
+			case "clock":
+				profile.Clock = value
+			case "max_retries":
+				retries, err := strconv.Atoi(value)
+				if err != nil || retries < 0 {
+					return nil, fmt.Errorf("invalid max_retries %q in profile %s", value, currentSection)
+				}
+				profile.MaxRetries = &retries
+			case "retry_backoff":
+				backoff, err := time.ParseDuration(value)
+				if err != nil {
+					return nil, fmt.Errorf("invalid retry_backoff %q in profile %s: %w", value, currentSection, err)
+				}
+				profile.RetryBackoff = backoff
+			case "list_timeout", "download_timeout":
+				timeout, err := time.ParseDuration(value)
+				if err != nil || timeout <= 0 {
+					return nil, fmt.Errorf("invalid %s %q in profile %s", key, value, currentSection)
+				}
+				if key == "list_timeout" {
+					profile.ListTimeout = timeout
+				} else {
+					profile.DownloadTimeout = timeout
+				}
+			default:
+				if action, ok := strings.CutPrefix(key, "key."); ok {
+					if profile.KeyBindings == nil {
+						profile.KeyBindings = make(map[string][]string)
+					}
+					profile.KeyBindings[action] = splitList(value)
+				}
 			}
 
 			cfg.Profiles[currentSection] = profile
@@ -89,9 +253,30 @@ func LoadConfig() (*ConfigFile, error) {
 		return nil, fmt.Errorf("error reading config file: %w", err)
 	}
 
+	for _, name := range cfg.ListProfiles() {
+		if err := checkCredentials(cfg.Profiles[name]); err != nil {
+			return nil, err
+		}
+	}
+
 	return cfg, nil
 }
 
+// checkCredentials rejects credentials that do not add up to one way of
+// signing in: a username needs its app password or API token, and goes with
+// Basic auth only.
+func checkCredentials(profile Profile) error {
+	switch {
+	case profile.Username != "" && profile.AppPassword == "":
+		return fmt.Errorf("profile %s sets username but no app_password or api_token", profile.Name)
+	case profile.Username == "" && profile.AppPassword != "":
+		return fmt.Errorf("profile %s sets app_password or api_token but no username", profile.Name)
+	case profile.Username != "" && profile.TokenType == "bearer":
+		return fmt.Errorf("profile %s sets both username and token_type bearer: a bearer token signs in without a username", profile.Name)
+	}
+	return nil
+}
+
 // GetProfile returns a specific profile by name
 func (c *ConfigFile) GetProfile(name string) (Profile, error) {
 	profile, exists := c.Profiles[name]
@@ -101,19 +286,75 @@ func (c *ConfigFile) GetProfile(name string) (Profile, error) {
 	return profile, nil
 }
 
+// ErrNoDefaultProfile is returned when a profile is needed but the config
+// file sets no default.
+var ErrNoDefaultProfile = errors.New("no default profile set")
+
 // GetDefaultProfile returns the default profile if set
 func (c *ConfigFile) GetDefaultProfile() (Profile, error) {
 	if c.DefaultProfile == "" {
-		return Profile{}, fmt.Errorf("no default profile set")
+		return Profile{}, ErrNoDefaultProfile
 	}
 	return c.GetProfile(c.DefaultProfile)
 }
 
-// ListProfiles returns a list of all profile names
+// ProfileForWorkspace returns the profile for the given workspace. When
+// several match, the default profile wins, then the first by name.
+func (c *ConfigFile) ProfileForWorkspace(workspace string) (Profile, bool) {
+	if workspace == "" {
+		return Profile{}, false
+	}
+	if profile, err := c.GetDefaultProfile(); err == nil && profile.Workspace == workspace {
+		return profile, true
+	}
+	for _, name := range c.ListProfiles() {
+		if profile := c.Profiles[name]; profile.Workspace == workspace {
+			return profile, true
+		}
+	}
+	return Profile{}, false
+}
+
+// ResolveProfile picks the profile for the --profile and --workspace flags,
+// either of which may be empty. A named profile wins; otherwise the profile
+// configured for workspace, then the default profile. A workspace without a
+// profile of its own is used with the credentials of the chosen profile.
+func (c *ConfigFile) ResolveProfile(name, workspace string) (Profile, error) {
+	var profile Profile
+	var err error
+	if name != "" {
+		profile, err = c.GetProfile(name)
+	} else if match, ok := c.ProfileForWorkspace(workspace); ok {
+		return match, nil
+	} else {
+		profile, err = c.GetDefaultProfile()
+	}
+	if err != nil {
+		return Profile{}, err
+	}
+	if workspace != "" {
+		profile.Workspace = workspace
+	}
+	return profile, nil
+}
+
+// ListProfiles returns the names of all profiles, sorted
 func (c *ConfigFile) ListProfiles() []string {
 	profiles := make([]string, 0, len(c.Profiles))
 	for name := range c.Profiles {
 		profiles = append(profiles, name)
 	}
+	sort.Strings(profiles)
 	return profiles
 }
+
+// splitList parses a comma separated value, dropping empty entries.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if trimmed := strings.TrimSpace(item); trimmed != "" {
+			items = append(items, trimmed)
+		}
+	}
+	return items
+}
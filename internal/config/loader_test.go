@@ -0,0 +1,54 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProfileForWorkspacePicksFirstByName(t *testing.T) {
+	cfg := &ConfigFile{Profiles: map[string]Profile{
+		"work":     {Name: "work", Workspace: "acme"},
+		"ci":       {Name: "ci", Workspace: "acme"},
+		"personal": {Name: "personal", Workspace: "me"},
+		"admin":    {Name: "admin", Workspace: "acme"},
+	}}
+	for range 20 {
+		if profile, ok := cfg.ProfileForWorkspace("acme"); !ok || profile.Name != "admin" {
+			t.Fatalf("ProfileForWorkspace = %q, %v; want admin", profile.Name, ok)
+		}
+	}
+
+	cfg.DefaultProfile = "work"
+	if profile, _ := cfg.ProfileForWorkspace("acme"); profile.Name != "work" {
+		t.Errorf("ProfileForWorkspace = %q, want the default profile work", profile.Name)
+	}
+}
+
+func TestLoadConfigRejectsMixedCredentials(t *testing.T) {
+	tests := []struct {
+		profile string
+		want    string
+	}{
+		{"username = me", "profile work sets username but no app_password or api_token"},
+		{"api_token = secret", "profile work sets app_password or api_token but no username"},
+		{"username = me\napi_token = secret\ntoken_type = bearer", "profile work sets both username and token_type bearer: a bearer token signs in without a username"},
+	}
+	for _, test := range tests {
+		home := t.TempDir()
+		t.Setenv("HOME", home)
+		dir := filepath.Join(home, ".config", "bitbucket-cli")
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			t.Fatal(err)
+		}
+		content := "[work]\nworkspace = acme\n" + test.profile + "\n"
+		if err := os.WriteFile(filepath.Join(dir, "config"), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := LoadConfig()
+		if err == nil || err.Error() != test.want {
+			t.Errorf("LoadConfig with %q: error = %v, want %q", test.profile, err, test.want)
+		}
+	}
+}
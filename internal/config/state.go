@@ -0,0 +1,146 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// WatchItem is a PR or pipeline pinned to the watch dashboard.
+type WatchItem struct {
+	Kind     string `json:"kind"`
+	RepoSlug string `json:"repo"`
+	ID       int    `json:"id"`
+	UUID     string `json:"uuid,omitempty"`
+	Label    string `json:"label"`
+}
+
+const (
+	WatchPullRequest = "pr"
+	WatchPipeline    = "pipeline"
+)
+
+// StateDir returns ~/.config/bitbucket-cli, where the config and any state the
+// application persists live.
+func StateDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".config", "bitbucket-cli"), nil
+}
+
+// LoadWatchList reads the pinned items for a workspace. A missing file is not
+// an error.
+func LoadWatchList(workspace string) ([]WatchItem, error) {
+	var items []WatchItem
+	if err := readStateFile(watchFileName(workspace), &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// SaveWatchList writes the pinned items for a workspace.
+func SaveWatchList(workspace string, items []WatchItem) error {
+	return writeStateFile(watchFileName(workspace), items)
+}
+
+func watchFileName(workspace string) string {
+	return fmt.Sprintf("watch-%s.json", workspace)
+}
+
+// ViewState holds the view choices remembered between runs.
+type ViewState struct {
+	// AllPipelineBranches lists the pipelines of every branch instead of the
+	// tracked ones.
+	AllPipelineBranches bool `json:"all_pipeline_branches"`
+}
+
+// LoadViewState reads the view choices for a workspace. A missing file is
+// not an error.
+func LoadViewState(workspace string) (ViewState, error) {
+	var state ViewState
+	if err := readStateFile(viewStateFileName(workspace), &state); err != nil {
+		return ViewState{}, err
+	}
+	return state, nil
+}
+
+// SaveViewState writes the view choices for a workspace.
+func SaveViewState(workspace string, state ViewState) error {
+	return writeStateFile(viewStateFileName(workspace), state)
+}
+
+func viewStateFileName(workspace string) string {
+	return fmt.Sprintf("view-%s.json", workspace)
+}
+
+// OAuthToken is what an OAuth consumer was last granted for a profile. The
+// refresh token outlives the access token and gets a new one when it expires.
+type OAuthToken struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// LoadOAuthToken reads the token stored for a profile. A missing file is not
+// an error and yields the zero token.
+func LoadOAuthToken(profile string) (OAuthToken, error) {
+	var token OAuthToken
+	if err := readStateFile(oauthFileName(profile), &token); err != nil {
+		return OAuthToken{}, err
+	}
+	return token, nil
+}
+
+// SaveOAuthToken writes the token of a profile, readable by its owner only.
+func SaveOAuthToken(profile string, token OAuthToken) error {
+	return writeStateFile(oauthFileName(profile), token)
+}
+
+func oauthFileName(profile string) string {
+	return fmt.Sprintf("oauth-%s.json", profile)
+}
+
+func readStateFile(name string, v any) error {
+	dir, err := StateDir()
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", name, err)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", name, err)
+	}
+	return nil
+}
+
+func writeStateFile(name string, v any) error {
+	dir, err := StateDir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", name, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, name), data, 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
@@ -7,12 +7,19 @@ type Project struct {
 	RepositoriesURL string
 }
 
+type Workspace struct {
+	Slug string
+	Name string
+	UUID string
+}
+
 type Repository struct {
 	Name       string
 	Slug       string
 	UUID       string
 	Mainbranch string
 	UpdatedOn  string
+	ProjectKey string
 }
 
 type Branch struct {
@@ -25,8 +32,25 @@ type BranchTarget struct {
 	Date string
 }
 
+// Tag is a git tag. Message and Date are the annotation's and empty for a
+// lightweight tag; Target.Date is the tagged commit's date.
+type Tag struct {
+	Name    string
+	Target  BranchTarget
+	Message string
+	Date    string
+}
+
+type User struct {
+	UUID        string
+	AccountID   string
+	Nickname    string
+	DisplayName string
+}
+
 type PullRequest struct {
 	ID            int
+	RepoSlug      string
 	Title         string
 	Description   string
 	State         string
@@ -35,11 +59,151 @@ type PullRequest struct {
 	Approvals     int
 	ApproverNames []string
 	Author        string
+	AuthorUUID    string
 	SourceBranch  string
 	DestBranch    string
 	CreatedOn     string
 	UpdatedOn     string
 	URL           string
+	// TaskCount is the number of unresolved tasks.
+	TaskCount        int
+	ChangesRequested int
+	// MergeCommit is the hash of the merge commit of a merged pull request.
+	MergeCommit string
+	// SourceCommit is the hash of the head of the source branch.
+	SourceCommit string
+	// Participants are the reviewers, then everyone else who took part.
+	Participants []Participant
+}
+
+// PullRequestActivity is one event in a pull request's history: an update
+// of its state or branches, an approval, a change request or a comment.
+type PullRequestActivity struct {
+	// Kind is "update", "approval", "changes_requested" or "comment".
+	Kind   string
+	Author string
+	Date   string
+	// Detail is the state an update moved the pull request to, or the text
+	// of a comment.
+	Detail string
+}
+
+// Participant is a reviewer of a pull request or someone who commented on,
+// approved or requested changes to it.
+type Participant struct {
+	Name     string
+	UUID     string
+	Reviewer bool
+	Approved bool
+	// State is "approved", "changes_requested" or empty.
+	State string
+}
+
+// Approval is an open pull request a user has approved. ParticipatedOn is
+// when they last took part in it, which is the approval unless they
+// commented since.
+type Approval struct {
+	PullRequest    PullRequest
+	ParticipatedOn string
+}
+
+// BranchRestriction is a rule of a repository's branch permissions and merge
+// checks, e.g. kind "require_approvals_to_merge" with Value 2. It applies to
+// branches matching Pattern when BranchMatchKind is "glob", or to BranchType
+// of the branching model otherwise.
+type BranchRestriction struct {
+	Kind            string
+	BranchMatchKind string
+	Pattern         string
+	BranchType      string
+	Value           int
+}
+
+// BuildStatus is a build result reported on a commit. State is SUCCESSFUL,
+// FAILED, INPROGRESS or STOPPED.
+type BuildStatus struct {
+	Key   string
+	Name  string
+	State string
+	URL   string
+}
+
+// NewPullRequest is what it takes to open a pull request. An empty DestBranch
+// targets the repository's main branch.
+type NewPullRequest struct {
+	Title             string
+	Description       string
+	SourceBranch      string
+	DestBranch        string
+	CloseSourceBranch bool
+	// Reviewers are the account IDs of the requested reviewers.
+	Reviewers []string
+}
+
+type Comment struct {
+	ID        int
+	Author    string
+	Content   string
+	CreatedOn string
+	// ParentID is the comment a pull request comment replies to, 0 for a
+	// top-level comment. Deleted comments keep their place in the thread
+	// without content.
+	ParentID int
+	Deleted  bool
+	// Anchor is the diff line an inline pull request comment is on.
+	Anchor CommentAnchor
+}
+
+// Task is a to-do item on a pull request, usually raised from a comment.
+// Unresolved tasks can block merging.
+type Task struct {
+	ID       int
+	Content  string
+	Creator  string
+	Resolved bool
+	// CommentID is the comment the task was raised from, 0 if none.
+	CommentID int
+}
+
+// CommentAnchor places an inline comment on a line of a pull request's diff:
+// To is the line in the new version of Path, or From the line in the old
+// version for a removed line. Path is empty for comments on the whole pull
+// request.
+type CommentAnchor struct {
+	Path string
+	From int
+	To   int
+}
+
+type Issue struct {
+	ID                int
+	Title             string
+	Content           string
+	State             string
+	Kind              string
+	Priority          string
+	Reporter          string
+	Assignee          string
+	AssigneeAccountID string
+	CreatedOn         string
+	UpdatedOn         string
+	URL               string
+}
+
+// NewIssue is what it takes to file an issue. An empty Kind lets Bitbucket
+// pick its default, a bug.
+type NewIssue struct {
+	Title   string
+	Content string
+	Kind    string
+}
+
+// IssueUpdate lists the changes to make to an issue. An empty State keeps the
+// state; a nil Assignee keeps the assignee and an empty one unassigns.
+// Assignee holds an account ID.
+type IssueUpdate struct {
+	State    string
+	Assignee *string
 }
 
 type Commit struct {
@@ -47,6 +211,18 @@ type Commit struct {
 	Message string
 	Author  string
 	Date    string
+	Parents []string
+}
+
+// NewCommit is a commit made through the API on top of Parent. Files holds
+// the new content of each path and Deleted the paths to remove. Branch is
+// created when it does not exist.
+type NewCommit struct {
+	Branch  string
+	Parent  string
+	Message string
+	Files   map[string][]byte
+	Deleted []string
 }
 
 type CommitChange struct {
@@ -54,6 +230,9 @@ type CommitChange struct {
 	Status       string
 	LinesAdded   int
 	LinesRemoved int
+	// OldPath is the path before the change; it differs from Path for
+	// renames and is empty for added files.
+	OldPath string
 }
 
 type Pipeline struct {
@@ -65,6 +244,14 @@ type Pipeline struct {
 	CreatedOn   string
 	StartedOn   string
 	CompletedOn string
+	// CommitHash is the commit the pipeline ran on. CommitMessage is empty
+	// when the API leaves it out of the pipeline.
+	CommitHash    string
+	CommitMessage string
+	// Trigger is how the pipeline started, lower case: "push", "manual" or
+	// "schedule". Creator is who started it, empty for scheduled runs.
+	Trigger string
+	Creator string
 }
 
 type PipelineStep struct {
@@ -74,4 +261,71 @@ type PipelineStep struct {
 	Result      string
 	StartedOn   string
 	CompletedOn string
+	// Commands are the script commands of the step in the order they run.
+	Commands []StepCommand
+}
+
+// StepCommand is one command of a step's script. Name is the label
+// Bitbucket shows for it, usually the command itself.
+type StepCommand struct {
+	Name    string
+	Command string
+}
+
+// PipelineSchedule runs a branch's pipeline on a cron schedule. CronPattern
+// has seven fields, seconds first, and runs in UTC. Pipeline is the custom
+// pipeline run, empty for the branch's own pipeline.
+type PipelineSchedule struct {
+	UUID        string
+	Enabled     bool
+	CronPattern string
+	Branch      string
+	Pipeline    string
+	CreatedOn   string
+	UpdatedOn   string
+}
+
+// PipelineArtifact is a file a pipeline step kept with the artifacts option.
+// Path is relative to the build directory.
+type PipelineArtifact struct {
+	UUID      string
+	Path      string
+	Size      int64
+	CreatedOn string
+}
+
+// Environment is a deployment environment of a repository. Type is Test,
+// Staging or Production; Rank orders the environments of a type.
+type Environment struct {
+	UUID string
+	Name string
+	Type string
+	Rank int
+}
+
+// Deployment is a pipeline step deploying a release to an environment. State
+// is UNDEPLOYED while the step waits to be run, IN_PROGRESS or COMPLETED, and
+// Result SUCCESSFUL, FAILED or STOPPED once completed. Release is the name
+// Bitbucket gives the release, e.g. "#42" after the pipeline's build number.
+type Deployment struct {
+	UUID            string
+	EnvironmentUUID string
+	State           string
+	Result          string
+	Release         string
+	CommitHash      string
+	PipelineUUID    string
+	StepUUID        string
+	CreatedOn       string
+	StartedOn       string
+	CompletedOn     string
+}
+
+// Download is a file uploaded to a repository's Downloads section.
+type Download struct {
+	Name      string
+	Size      int64
+	CreatedOn string
+	Downloads int
+	Uploader  string
 }
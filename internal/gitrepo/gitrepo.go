@@ -0,0 +1,218 @@
+// Package gitrepo inspects the local git clone the app is started in, so the
+// matching Bitbucket repository can be opened without navigating to it.
+package gitrepo
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"strings"
+)
+
+// Clone describes a local git clone whose origin is on Bitbucket.
+type Clone struct {
+	// Root is the top-level directory of the working tree.
+	Root string
+	// Workspace and Repo identify the origin repository on Bitbucket.
+	Workspace string
+	Repo      string
+	// Branch is the checked-out branch, empty when HEAD is detached.
+	Branch string
+}
+
+// Detect inspects the git clone containing dir. ok is false when dir is not
+// inside a clone, git is not installed, or origin is not a Bitbucket remote.
+func Detect(dir string) (Clone, bool) {
+	root, err := git(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return Clone{}, false
+	}
+	remote, err := git(dir, "remote", "get-url", "origin")
+	if err != nil {
+		return Clone{}, false
+	}
+	workspace, repo, ok := ParseRemote(remote)
+	if !ok {
+		return Clone{}, false
+	}
+	return Clone{Root: root, Workspace: workspace, Repo: repo, Branch: CurrentBranch(root)}, true
+}
+
+// CurrentBranch returns the branch checked out in dir, or "" when HEAD is
+// detached or dir is not a clone.
+func CurrentBranch(dir string) string {
+	branch, err := git(dir, "symbolic-ref", "--short", "-q", "HEAD")
+	if err != nil {
+		return ""
+	}
+	return branch
+}
+
+// ParseRemote extracts the workspace and repository slug from a Bitbucket
+// remote URL in any of the forms git accepts:
+//
+//	git@bitbucket.org:acme/api.git
+//	ssh://git@bitbucket.org/acme/api.git
+//	https://user@bitbucket.org/acme/api.git
+func ParseRemote(remote string) (workspace, repo string, ok bool) {
+	remote = strings.TrimSpace(remote)
+	var host, path string
+	if parsed, err := url.Parse(remote); err == nil && parsed.Scheme != "" && parsed.Host != "" {
+		host, path = parsed.Hostname(), parsed.Path
+	} else if userHost, scpPath, found := strings.Cut(remote, ":"); found {
+		// scp-like syntax: [user@]host:path
+		_, host, _ = strings.Cut(userHost, "@")
+		if host == "" {
+			host = userHost
+		}
+		path = scpPath
+	} else {
+		return "", "", false
+	}
+
+	if !strings.EqualFold(host, "bitbucket.org") {
+		return "", "", false
+	}
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	repo = strings.TrimSuffix(parts[1], ".git")
+	if repo == "" {
+		return "", "", false
+	}
+	return parts[0], strings.ToLower(repo), true
+}
+
+// AddWorktree fetches branch from origin and checks it out, detached, in a
+// new worktree at dir, which must not exist or be empty. Detaching keeps it
+// usable when branch is also checked out in the clone itself.
+func AddWorktree(root, branch, dir string) error {
+	if _, err := git(root, "fetch", "origin", branch); err != nil {
+		return err
+	}
+	_, err := git(root, "worktree", "add", "--detach", dir, "FETCH_HEAD")
+	return err
+}
+
+// RemoveWorktree deletes a worktree made by AddWorktree, discarding any
+// changes left in it.
+func RemoveWorktree(root, dir string) error {
+	_, err := git(root, "worktree", "remove", "--force", dir)
+	return err
+}
+
+// Signature describes the signature on a commit or tag as verified by the
+// local git and gpg setup.
+type Signature struct {
+	// Status is "verified", "unverified" (signed, but the key is unknown,
+	// untrusted or expired), "bad" or "unsigned".
+	Status string
+	// Signer is the name on the signing key, when git could read it.
+	Signer string
+}
+
+// VerifySignatures checks the signatures of the given commits in the clone at
+// root. Commits the clone does not have are left out of the result.
+func VerifySignatures(root string, hashes []string) map[string]Signature {
+	signatures := make(map[string]Signature, len(hashes))
+	for _, hash := range hashes {
+		out, err := git(root, "show", "-s", "--format=%G?%x00%GS", hash, "--")
+		if err != nil {
+			continue
+		}
+		code, signer, _ := strings.Cut(out, "\x00")
+		signatures[hash] = Signature{Status: signatureStatus(code), Signer: signer}
+	}
+	return signatures
+}
+
+// VerifyTagSignatures checks the signatures of the given tags in the clone at
+// root. Lightweight tags are unsigned; tags the clone does not have are left
+// out of the result.
+func VerifyTagSignatures(root string, names []string) map[string]Signature {
+	signatures := make(map[string]Signature, len(names))
+	for _, name := range names {
+		ref := "refs/tags/" + name
+		kind, err := git(root, "cat-file", "-t", ref)
+		if err != nil {
+			continue
+		}
+		if kind != "tag" {
+			signatures[name] = Signature{Status: "unsigned"}
+			continue
+		}
+		// verify-tag fails for unsigned and bad signatures alike; the status
+		// lines it prints tell them apart.
+		out, _ := exec.Command("git", "-C", root, "verify-tag", "--raw", ref).CombinedOutput()
+		signatures[name] = tagSignature(string(out))
+	}
+	return signatures
+}
+
+// tagSignature reads the output of git verify-tag --raw: gpg's status lines,
+// or ssh-keygen's verdict for SSH signatures.
+func tagSignature(out string) Signature {
+	signature := Signature{Status: "unsigned"}
+	good, trusted := false, false
+	for _, line := range strings.Split(out, "\n") {
+		if rest, ok := strings.CutPrefix(line, `Good "git" signature for `); ok {
+			signer, _, _ := strings.Cut(rest, " with ")
+			return Signature{Status: "verified", Signer: signer}
+		}
+		status, ok := strings.CutPrefix(line, "[GNUPG:] ")
+		if !ok {
+			continue
+		}
+		code, args, _ := strings.Cut(status, " ")
+		switch code {
+		case "GOODSIG", "EXPSIG", "EXPKEYSIG", "BADSIG", "REVKEYSIG":
+			// The arguments are the key id and the signer.
+			_, signature.Signer, _ = strings.Cut(args, " ")
+			signature.Status = "unverified"
+			if code == "BADSIG" || code == "REVKEYSIG" {
+				signature.Status = "bad"
+			}
+			good = code == "GOODSIG"
+		case "ERRSIG":
+			signature.Status = "unverified"
+		case "TRUST_FULLY", "TRUST_ULTIMATE":
+			trusted = true
+		}
+	}
+	if good && trusted {
+		signature.Status = "verified"
+	}
+	return signature
+}
+
+// signatureStatus maps git's %G? code to a Signature status.
+func signatureStatus(code string) string {
+	switch code {
+	case "G":
+		return "verified"
+	case "B", "R":
+		return "bad"
+	case "N", "":
+		return "unsigned"
+	default:
+		// U: unknown validity, X/Y: expired signature or key, E: the key is
+		// missing.
+		return "unverified"
+	}
+}
+
+// git runs a git command in dir. A failure carries git's own message.
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("git %s: %s", args[0], strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,106 @@
+// Package notify sends desktop notifications through one of several
+// backends, chosen per profile with the notify setting.
+package notify
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Backend names accepted by New.
+const (
+	Auto       = "auto"
+	NotifySend = "notify-send"
+	OSAScript  = "osascript"
+	Bell       = "bell"
+	None       = "none"
+)
+
+// Notifier delivers a notification. Implementations may block briefly, so
+// callers run them off the UI loop.
+type Notifier interface {
+	Notify(title, body string) error
+}
+
+// Backends lists the names accepted by New.
+func Backends() []string {
+	return []string{Auto, NotifySend, OSAScript, Bell, None}
+}
+
+// New returns the notifier for backend. Empty and "auto" pick notify-send on
+// Linux or osascript on macOS when installed, and the terminal bell
+// otherwise.
+func New(backend string) (Notifier, error) {
+	switch strings.ToLower(strings.TrimSpace(backend)) {
+	case "", Auto:
+		return detect(), nil
+	case NotifySend:
+		return commandNotifier{build: notifySendArgs}, nil
+	case OSAScript:
+		return commandNotifier{build: osascriptArgs}, nil
+	case Bell:
+		return bellNotifier{out: os.Stderr}, nil
+	case None:
+		return noneNotifier{}, nil
+	default:
+		return nil, fmt.Errorf("unknown notify backend %q (expected one of %s)", backend, strings.Join(Backends(), ", "))
+	}
+}
+
+func detect() Notifier {
+	switch runtime.GOOS {
+	case "linux", "freebsd", "openbsd":
+		if _, err := exec.LookPath(NotifySend); err == nil {
+			return commandNotifier{build: notifySendArgs}
+		}
+	case "darwin":
+		if _, err := exec.LookPath(OSAScript); err == nil {
+			return commandNotifier{build: osascriptArgs}
+		}
+	}
+	return bellNotifier{out: os.Stderr}
+}
+
+// commandNotifier runs an external program built from the title and body.
+type commandNotifier struct {
+	build func(title, body string) []string
+}
+
+func (n commandNotifier) Notify(title, body string) error {
+	args := n.build(title, body)
+	if out, err := exec.Command(args[0], args[1:]...).CombinedOutput(); err != nil {
+		if text := strings.TrimSpace(string(out)); text != "" {
+			return fmt.Errorf("%s: %s", args[0], text)
+		}
+		return fmt.Errorf("%s: %w", args[0], err)
+	}
+	return nil
+}
+
+func notifySendArgs(title, body string) []string {
+	return []string{NotifySend, "--app-name=bitbucket-cli", title, body}
+}
+
+func osascriptArgs(title, body string) []string {
+	script := fmt.Sprintf("display notification %s with title %s", strconv.Quote(body), strconv.Quote(title))
+	return []string{OSAScript, "-e", script}
+}
+
+// bellNotifier rings the terminal bell; the text itself is shown by the app.
+type bellNotifier struct {
+	out io.Writer
+}
+
+func (n bellNotifier) Notify(title, body string) error {
+	_, err := io.WriteString(n.out, "\a")
+	return err
+}
+
+type noneNotifier struct{}
+
+func (noneNotifier) Notify(title, body string) error { return nil }
@@ -0,0 +1,185 @@
+// Package report renders pull request lists, pipeline history and review
+// summaries as Markdown, for pasting into standups and incident docs. The TUI
+// export action and the headless export command share it.
+package report
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"bitbucket-cli/internal/domain"
+)
+
+// PullRequests writes a table of prs under a heading naming repo.
+func PullRequests(w io.Writer, repo string, prs []domain.PullRequest, now time.Time) error {
+	b := bufio.NewWriter(w)
+	writeHeading(b, fmt.Sprintf("Pull requests: %s", repo), now)
+	if len(prs) == 0 {
+		fmt.Fprintln(b, "No pull requests.")
+		return b.Flush()
+	}
+
+	rows := make([][]string, 0, len(prs))
+	for _, pr := range prs {
+		title := pr.Title
+		if pr.Draft {
+			title = "[draft] " + title
+		}
+		rows = append(rows, []string{
+			link(fmt.Sprintf("#%d", pr.ID), pr.URL),
+			title,
+			pr.Author,
+			fmt.Sprintf("`%s` → `%s`", pr.SourceBranch, pr.DestBranch),
+			pr.State,
+			approvals(pr),
+			day(pr.UpdatedOn),
+		})
+	}
+	writeTable(b, []string{"PR", "Title", "Author", "Branches", "State", "Approvals", "Updated"}, rows)
+	return b.Flush()
+}
+
+// Pipelines writes a table of pipelines, newest first as given, with a count
+// of results below it.
+func Pipelines(w io.Writer, repo string, pipelines []domain.Pipeline, now time.Time) error {
+	b := bufio.NewWriter(w)
+	writeHeading(b, fmt.Sprintf("Pipelines: %s", repo), now)
+	if len(pipelines) == 0 {
+		fmt.Fprintln(b, "No pipelines.")
+		return b.Flush()
+	}
+
+	rows := make([][]string, 0, len(pipelines))
+	results := map[string]int{}
+	var order []string
+	for _, p := range pipelines {
+		result := strings.ToLower(p.Result)
+		if result == "" {
+			result = strings.ToLower(p.State)
+		}
+		if results[result] == 0 {
+			order = append(order, result)
+		}
+		results[result]++
+		rows = append(rows, []string{
+			fmt.Sprintf("#%d", p.BuildNumber),
+			"`" + strings.TrimPrefix(p.BranchName, "refs/heads/") + "`",
+			result,
+			timestamp(p.CreatedOn),
+			duration(p.StartedOn, p.CompletedOn),
+		})
+	}
+	writeTable(b, []string{"Build", "Branch", "Result", "Started", "Duration"}, rows)
+
+	summary := make([]string, 0, len(order))
+	for _, result := range order {
+		summary = append(summary, fmt.Sprintf("%d %s", results[result], result))
+	}
+	fmt.Fprintf(b, "\n%d pipelines: %s.\n", len(pipelines), strings.Join(summary, ", "))
+	return b.Flush()
+}
+
+// Review writes the summary of one pull request: its branches, state and
+// approvals, its description and the commits it contains.
+func Review(w io.Writer, repo string, pr domain.PullRequest, commits []domain.Commit, now time.Time) error {
+	b := bufio.NewWriter(w)
+	writeHeading(b, fmt.Sprintf("PR #%d: %s", pr.ID, pr.Title), now)
+
+	fmt.Fprintf(b, "- Repository: %s\n", repo)
+	fmt.Fprintf(b, "- Author: %s\n", pr.Author)
+	fmt.Fprintf(b, "- Branches: `%s` → `%s`\n", pr.SourceBranch, pr.DestBranch)
+	state := pr.State
+	if pr.Draft {
+		state += " (draft)"
+	}
+	fmt.Fprintf(b, "- State: %s\n", state)
+	fmt.Fprintf(b, "- Approvals: %s\n", approvals(pr))
+	if pr.URL != "" {
+		fmt.Fprintf(b, "- Link: %s\n", pr.URL)
+	}
+
+	if description := strings.TrimSpace(pr.Description); description != "" {
+		fmt.Fprintf(b, "\n## Description\n\n%s\n", description)
+	}
+
+	fmt.Fprintf(b, "\n## Commits (%d)\n\n", len(commits))
+	for _, commit := range commits {
+		subject, _, _ := strings.Cut(strings.TrimSpace(commit.Message), "\n")
+		fmt.Fprintf(b, "- `%s` %s (%s)\n", shortHash(commit.Hash), subject, commit.Author)
+	}
+	return b.Flush()
+}
+
+func writeHeading(w io.Writer, title string, now time.Time) {
+	fmt.Fprintf(w, "# %s\n\n_Exported %s_\n\n", title, now.Format("2006-01-02 15:04 MST"))
+}
+
+func writeTable(w io.Writer, headers []string, rows [][]string) {
+	fmt.Fprintf(w, "| %s |\n", strings.Join(headers, " | "))
+	fmt.Fprintf(w, "|%s\n", strings.Repeat("---|", len(headers)))
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = escapeCell(cell)
+		}
+		fmt.Fprintf(w, "| %s |\n", strings.Join(cells, " | "))
+	}
+}
+
+// escapeCell keeps a value on one line and from closing its table cell.
+func escapeCell(value string) string {
+	value = strings.Join(strings.Fields(value), " ")
+	return strings.ReplaceAll(value, "|", `\|`)
+}
+
+func link(text, url string) string {
+	if url == "" {
+		return text
+	}
+	return fmt.Sprintf("[%s](%s)", text, url)
+}
+
+func approvals(pr domain.PullRequest) string {
+	if len(pr.ApproverNames) == 0 {
+		return fmt.Sprintf("%d", pr.Approvals)
+	}
+	return fmt.Sprintf("%d (%s)", pr.Approvals, strings.Join(pr.ApproverNames, ", "))
+}
+
+// day shortens an RFC 3339 timestamp to its date.
+func day(value string) string {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.Local().Format("2006-01-02")
+	}
+	return value
+}
+
+func timestamp(value string) string {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.Local().Format("2006-01-02 15:04")
+	}
+	return value
+}
+
+// duration is how long a pipeline ran, or empty while it has not finished.
+func duration(startedOn, completedOn string) string {
+	start, err := time.Parse(time.RFC3339, startedOn)
+	if err != nil {
+		return ""
+	}
+	end, err := time.Parse(time.RFC3339, completedOn)
+	if err != nil || end.Before(start) {
+		return ""
+	}
+	return end.Sub(start).Round(time.Second).String()
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
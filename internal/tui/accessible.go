@@ -0,0 +1,152 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// plainSpinner replaces the animated spinner in accessible mode, where a
+// frame changing several times a second would be read out each time.
+var plainSpinner = spinner.Spinner{Frames: []string{"(busy)"}, FPS: time.Second}
+
+// useAccessibleOutput strips color and text attributes from everything
+// lipgloss renders and drops the border around the search overlay.
+func useAccessibleOutput() {
+	lipgloss.SetColorProfile(termenv.Ascii)
+	borderStyle = lipgloss.NewStyle().Padding(0, 1)
+}
+
+// viewNames are the names announced for each view in accessible mode.
+var viewNames = map[viewMode]string{
+	noSelection:         "Repositories",
+	branchesView:        "Branches",
+	prView:              "Pull requests",
+	prCommitsView:       "Pull request commits",
+	pipelinesView:       "Pipelines",
+	pipelineStepsView:   "Pipeline steps",
+	pipelineStepLogView: "Step log",
+	stepCommandsView:    "Step commands",
+	artifactsView:       "Artifacts",
+	schedulesView:       "Pipeline schedules",
+	watchView:           "Watch list",
+	inspectorView:       "API requests",
+	homeView:            "Home",
+	issuesView:          "Issues",
+	issueDetailView:     "Issue",
+	feedView:            "Activity feed",
+	staleApprovalsView:  "Stale approvals",
+	projectsView:        "Projects",
+	downloadsView:       "Downloads",
+	prDetailView:        "Pull request",
+	prDiffView:          "Pull request diff",
+	prCommentsView:      "Pull request comments",
+	myPRsView:           "My pull requests",
+	branchCommitsView:   "Branch commits",
+	branchCompareView:   "Branch comparison",
+	tagsView:            "Tags",
+	deploymentsView:     "Deployments",
+}
+
+// announcement is the first line of the screen in accessible mode: the view,
+// its repository and the position of the cursor, e.g. "Pull requests in
+// my-service, 3 of 12". It reads the same way in every view so a screen
+// reader user can find out where they are from one line.
+func (m AppModel) announcement() string {
+	view := m.currentView
+	if m.activePane == repoPane {
+		view = noSelection
+	}
+	line := tr(viewNames[view])
+	if view != noSelection && view != homeView && view != watchView && view != feedView && view != staleApprovalsView && view != myPRsView && view != projectsView && view != inspectorView && m.selectedRepo != "" {
+		line = fmt.Sprintf("%s in %s", line, m.selectedRepo)
+	}
+	if view == noSelection && m.project.Key != "" {
+		line = fmt.Sprintf("%s of project %s", line, m.project.Name)
+	}
+
+	cursor, total := m.cursorPosition(view)
+	switch {
+	case m.loading && total == 0:
+		line += ", loading"
+	case total == 0:
+		line += ", empty"
+	default:
+		line += fmt.Sprintf(", %d of %d", cursor+1, total)
+	}
+	if m.searchMode {
+		line = "Search"
+	}
+	return line
+}
+
+// cursorPosition returns the cursor and the number of entries of view.
+func (m AppModel) cursorPosition(view viewMode) (int, int) {
+	switch view {
+	case noSelection:
+		return m.repoCursor, len(m.getFilteredRepos())
+	case branchesView:
+		return m.branchCursor, len(m.getFilteredBranches())
+	case prView:
+		return m.prCursor, len(m.getFilteredPRs())
+	case prCommitsView, branchCommitsView, branchCompareView:
+		return m.prCommitCursor, len(m.prCommits)
+	case pipelinesView:
+		return m.pipelineCursor, len(m.getFilteredPipelines())
+	case pipelineStepsView:
+		return m.pipelineStepCursor, len(m.pipelineSteps)
+	case pipelineStepLogView:
+		return m.pipelineStepLogCursor, len(m.pipelineStepLogLines)
+	case stepCommandsView:
+		return m.commandCursor, len(m.commandsStep.Commands)
+	case artifactsView:
+		return m.artifactCursor, len(m.artifacts)
+	case schedulesView:
+		return m.scheduleCursor, len(m.schedules)
+	case watchView:
+		return m.watchCursor, len(m.watchItems)
+	case homeView:
+		return m.homeCursor, len(m.homeEntries())
+	case issuesView:
+		return m.issueCursor, len(m.getFilteredIssues())
+	case issueDetailView, prDetailView, prCommentsView:
+		return 0, 1
+	case prDiffView:
+		return m.prDiffCursor, len(m.prDiffLines)
+	case feedView:
+		return m.feedCursor, len(m.feedEvents())
+	case staleApprovalsView:
+		return m.staleApprovalCursor, len(m.staleApprovals)
+	case myPRsView:
+		return m.myPRsCursor, len(m.myPRsEntries())
+	case projectsView:
+		return m.projectCursor, len(m.projects)
+	case downloadsView:
+		return m.downloadCursor, len(m.getFilteredDownloads())
+	case tagsView:
+		return m.tagCursor, len(m.getFilteredTags())
+	case deploymentsView:
+		return m.environmentCursor, len(m.environments)
+	case inspectorView:
+		return m.inspectorCursor, len(m.client.RecentRequests())
+	}
+	return 0, 0
+}
+
+// moreAbove and moreBelow mark a list scrolled past its first or last entry.
+func moreAbove() string {
+	if currentTheme.plain {
+		return "  " + tr("(more above)")
+	}
+	return "  " + tr("↑ more")
+}
+
+func moreBelow() string {
+	if currentTheme.plain {
+		return "  " + tr("(more below)")
+	}
+	return "  " + tr("↓ more")
+}
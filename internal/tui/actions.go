@@ -0,0 +1,42 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// startAction tracks a mutating request (approve, merge, delete, ...) so that
+// quitting while it is still in flight asks for confirmation. The result
+// arrives wrapped in an actionDoneMsg, timed so that slow actions can notify,
+// whose handler calls finishAction before passing the result on; the result
+// handlers themselves do not count.
+func startAction(m *AppModel, cmd tea.Cmd) tea.Cmd {
+	m.pendingActions++
+	return func() tea.Msg {
+		started := time.Now()
+		msg := cmd()
+		return actionDoneMsg{msg: msg, took: time.Since(started)}
+	}
+}
+
+// finishAction stops tracking an action whose result has arrived.
+func finishAction(m *AppModel) {
+	if m.pendingActions > 0 {
+		m.pendingActions--
+	}
+}
+
+// requestQuit quits right away unless actions are pending, in which case the
+// first press only warns and a second one is needed.
+func requestQuit(m *AppModel) tea.Cmd {
+	if m.pendingActions == 0 || m.confirmQuit {
+		m.cancel()
+		return tea.Quit
+	}
+
+	m.confirmQuit = true
+	m.message = fmt.Sprintf("%d action(s) still in flight, their result will be unknown. Press q again to quit anyway", m.pendingActions)
+	return nil
+}
@@ -1,18 +1,24 @@
 package tui
 
 import (
+	"context"
 	"fmt"
 	"hash/fnv"
-	"os"
 	"os/exec"
+	"path"
 	"runtime"
+	"sort"
 	"strings"
 	"time"
 
 	"bitbucket-cli/internal/bitbucket"
 	"bitbucket-cli/internal/config"
 	"bitbucket-cli/internal/domain"
+	"bitbucket-cli/internal/gitrepo"
+	"bitbucket-cli/internal/notify"
 
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -36,6 +42,26 @@ const (
 	pipelinesView
 	pipelineStepsView
 	pipelineStepLogView
+	watchView
+	inspectorView
+	homeView
+	issuesView
+	issueDetailView
+	feedView
+	staleApprovalsView
+	projectsView
+	downloadsView
+	prDetailView
+	prDiffView
+	prCommentsView
+	myPRsView
+	branchCommitsView
+	branchCompareView
+	tagsView
+	stepCommandsView
+	artifactsView
+	schedulesView
+	deploymentsView
 )
 
 var (
@@ -64,8 +90,16 @@ var (
 
 type AppModel struct {
 	workspace             string
-	client                *bitbucket.Client
+	client                bitbucket.BitbucketAPI
+	ctx                   context.Context
+	cancel                context.CancelFunc
+	viewCtx               context.Context
+	cancelView            context.CancelFunc
+	viewGen               int
+	refreshQueued         bool
 	spinner               spinner.Model
+	keys                  keyMap
+	help                  help.Model
 	activePane            pane
 	currentView           viewMode
 	repositories          []domain.Repository
@@ -104,21 +138,277 @@ type AppModel struct {
 	branchFilterQuery     string
 	prFilterQuery         string
 	pipelineFilterQuery   string
+	jumpMode              bool
+	jumpQuery             string
+	searchMode            bool
+	searchQuery           string
+	searchCursor          int
+	searchPending         int
+	searchSeq             int
+	searchBranches        []domain.Branch
+	searchPullRequests    []domain.PullRequest
+	searchPipelines       []domain.Pipeline
+	pendingChord          string
+	inspectorCursor       int
+	inspectorReturnView   viewMode
+	inspectorReturnPane   pane
+	reposCachedAt         time.Time
+	cachedAt              time.Time
+	cacheOffline          bool
+	prefetch              *prefetchCache
+	filters               *filterCache
+	prefetchSeq           int
+	loadedAt              time.Time
+	logWrap               bool
+	logHOffset            int
+	diffHOffset           int
+	diffHunkCursor        int
+	logLineNumbers        bool
+	logMarks              []logMark
+	logColorLines         []string
+	logPlain              bool
+	logSearchMode         bool
+	logSearchQuery        string
+	logSearchOrigin       int
+	logMatches            []int
+	watchItems            []config.WatchItem
+	watchPipelines        map[string]domain.Pipeline
+	watchPullRequests     map[string]domain.PullRequest
+	watchErrors           map[string]error
+	watchCursor           int
+	watchPolling          bool
+	currentUser           domain.User
+	favorites             []string
+	trackedBranches       []string
+	allPipelineBranches   bool
+	homeMyPRs             []domain.PullRequest
+	homeReviewPRs         []domain.PullRequest
+	homePipelines         map[string]domain.Pipeline
+	homePending           int
+	workspaceInfo         domain.Workspace
+	homeCursor            int
+	focusPullRequestID    int
+	focusBranch           string
+	pendingActions        int
+	confirmQuit           bool
+	viewer                []string
+	editor                []string
+	// startRepo and startCmd carry the repository and tab given to OpenAt
+	// until the app starts and the repository list has loaded.
+	startRepo string
+	startCmd  tea.Cmd
+	// localClone is the git clone the app was started in, when it belongs
+	// to the workspace.
+	localClone gitrepo.Clone
+	// commitSignatures holds the signature status of the loaded commits,
+	// keyed by hash; nil until the local clone has been asked.
+	commitSignatures map[string]gitrepo.Signature
+	// tagSignatures is the same for the loaded tags, keyed by name.
+	tagSignatures map[string]gitrepo.Signature
+	// The activity feed keeps the recent pull requests and pipelines of
+	// each favorite repository; feedErrors lists the ones that failed.
+	feedPullRequests map[string][]domain.PullRequest
+	feedPipelines    map[string][]domain.Pipeline
+	feedErrors       []string
+	feedPending      int
+	feedCursor       int
+	// staleApprovals lists the pull requests with commits newer than the
+	// user's approval, across the favorite repositories.
+	staleApprovals        []staleApproval
+	staleApprovalErrors   []string
+	staleApprovalsPending int
+	staleApprovalCursor   int
+	// myAuthoredPRs and myReviewPRs are the open pull requests the user
+	// authored or is a reviewer on, across the workspace.
+	myAuthoredPRs []domain.PullRequest
+	myReviewPRs   []domain.PullRequest
+	myPRsErrors   []string
+	myPRsPending  int
+	myPRsCursor   int
+	// newBranchStep is the field of the new branch prompt being typed,
+	// "name" then "source", and empty while the prompt is closed.
+	newBranchStep   string
+	newBranchName   string
+	newBranchSource string
+	// deleteBranchCandidate is the branch to delete once deleteBranchText,
+	// typed at the prompt, matches its name.
+	deleteBranchCandidate string
+	deleteBranchText      string
+	// commitsBranch is the branch whose commits branchCommitsView shows.
+	commitsBranch string
+	// compareHead is the branch picked for a comparison while its base is
+	// being picked; comparison is what branchCompareView shows.
+	compareHead string
+	comparison  branchComparison
+	// mergeRules caches each repository's merge checks; prStatuses and
+	// mergeCheckErrors are keyed by pullRequestKey.
+	mergeRules       map[string]mergeRules
+	prStatuses       map[string][]domain.BuildStatus
+	mergeCheckErrors map[string]error
+	// commitStatuses holds the build statuses of pull requests' source
+	// commits, keyed by commit hash.
+	commitStatuses map[string][]domain.BuildStatus
+	// projects lists the workspace's projects. When project is set, the
+	// repository pane lists projectRepositories instead of repositories.
+	projects            []domain.Project
+	projectsPending     bool
+	projectCursor       int
+	project             domain.Project
+	projectRepositories []domain.Repository
+	// worktreeCleanup is the worktree waiting for a y/n answer on whether
+	// to remove it.
+	worktreeCleanup string
+	notifier        notify.Notifier
+	// tabStates keeps the cursor and filter of each tab while another one
+	// is shown.
+	tabStates map[viewMode]tabState
+	// liveSeq numbers live refreshes so a burst of events runs only the
+	// last one; quietReload keeps the cursor on the list being reloaded.
+	liveSeq     int
+	quietReload bool
+	autoRefresh time.Duration
+	exportDir   string
+	clipboard   string
+	diffPager   string
+	// browserCommand replaces the platform URL openers when configured.
+	browserCommand []string
+	// issues lists the repository's issues; openIssue is the one shown in
+	// the detail view, scrolled down by issueDetailOffset lines.
+	issues            []domain.Issue
+	issueCursor       int
+	issueFilterQuery  string
+	openIssue         domain.Issue
+	issueComments     []domain.Comment
+	issueDetailOffset int
+	// downloads lists the files of the repository's Downloads section;
+	// uploadMode shows the prompt for the path of a file to upload.
+	downloads           []domain.Download
+	downloadCursor      int
+	downloadFilterQuery string
+	uploadMode          bool
+	uploadPath          string
+	// tags lists the repository's tags. newTagStep is the field of the new
+	// tag prompt being typed, "name" then "target", and deleteTagCandidate
+	// the tag waiting for a y/n answer on its deletion.
+	tags               []domain.Tag
+	tagCursor          int
+	tagFilterQuery     string
+	newTagStep         string
+	newTagName         string
+	newTagTarget       string
+	deleteTagCandidate string
+	// schedules lists the repository's pipeline schedules. newScheduleStep
+	// is the field of the new schedule prompt being typed, "branch", "cron"
+	// then "pipeline", and deleteScheduleCandidate the schedule waiting for
+	// a y/n answer on its deletion.
+	schedules               []domain.PipelineSchedule
+	scheduleCursor          int
+	newScheduleStep         string
+	newSchedule             domain.PipelineSchedule
+	deleteScheduleCandidate domain.PipelineSchedule
+	// environments lists the repository's deployment environments with
+	// their latest deployments.
+	environments      []environmentStatus
+	environmentCursor int
+	// seenReviewRequests holds, per repository, the IDs of the pull
+	// requests awaiting review at the last load, to notify about new ones.
+	seenReviewRequests map[string]map[int]bool
+	// revertCandidate is the merged pull request waiting for a y/n answer
+	// on whether to revert it.
+	revertCandidate domain.PullRequest
+	// pullRequestSource is the branch of the pull request being written in
+	// the editor.
+	pullRequestSource string
+	// mergeCandidate is the pull request being merged while the strategy,
+	// then a y/n answer, is asked for.
+	mergeCandidate domain.PullRequest
+	mergeStrategy  string
+	// stateCandidate is the pull request waiting for a y/n answer on
+	// stateChange, "decline" or "reopen".
+	stateCandidate domain.PullRequest
+	stateChange    string
+	// rerunCandidate is the pipeline waiting for a y/n answer on running its
+	// target again.
+	rerunCandidate domain.Pipeline
+	// openPipeline is the pipeline whose steps are shown, for the commit and
+	// trigger above them.
+	openPipeline domain.Pipeline
+	// followStepUUID is the step whose log is shown while it runs and fetched
+	// again every logFollowInterval until the step completes.
+	followStepUUID string
+	// commandsStep is the step whose script commands are listed, with the
+	// log line each command starts at in commandLines, -1 for commands that
+	// did not run.
+	commandsStep  domain.PipelineStep
+	commandLines  []int
+	commandCursor int
+	// artifacts are the files kept by the step artifactsStepUUID.
+	// artifactDownload is the artifact being saved, nil when none is.
+	artifacts         []domain.PipelineArtifact
+	artifactsStepUUID string
+	artifactCursor    int
+	artifactDownload  *artifactDownload
+	// openPullRequest is the pull request shown in the detail view, scrolled
+	// down by prDetailOffset lines.
+	openPullRequest domain.PullRequest
+	prDetailOffset  int
+	// prActivity is the open pull request's recent activity, nil while it
+	// loads.
+	prActivity []domain.PullRequestActivity
+	// prTasks are the open pull request's tasks, nil while they load, with
+	// the one ] and [ select in the detail view at prTaskCursor.
+	prTasks      []domain.Task
+	prTaskCursor int
+	// prDiffPR is the pull request whose diff prDiffView shows, split into
+	// prDiffLines with the cursor on line prDiffCursor, and with its comments
+	// for the inline ones. esc returns to prDiffFrom.
+	prDiffPR       domain.PullRequest
+	prDiffLines    []string
+	prDiffComments []domain.Comment
+	prDiffCursor   int
+	prDiffFrom     viewMode
+	// prComments are the comments of openPullRequest, nil while they load;
+	// commentMode shows the prompt for a new top-level comment.
+	prComments       []domain.Comment
+	prCommentsOffset int
+	commentMode      bool
+	commentText      string
+	// commentPR and commentAnchor are where the comment at the prompt goes:
+	// a line of the diff, or the whole pull request for an empty anchor.
+	commentPR     domain.PullRequest
+	commentAnchor domain.CommentAnchor
+	// prCommentCursor is the comment a task is raised from in the comments
+	// view, in thread order.
+	prCommentCursor int
+	// prState is the state the pull request list shows, one of prStates;
+	// empty means OPEN.
+	prState string
 }
 
+// reposLoadedMsg carries one page of repositories; more is set when another
+// page follows.
 type reposLoadedMsg struct {
+	page  int
 	repos []domain.Repository
+	more  bool
 	err   error
 }
 
+type workspaceLoadedMsg struct {
+	workspace domain.Workspace
+	err       error
+}
+
 type branchesLoadedMsg struct {
+	repoSlug string
 	branches []domain.Branch
 	err      error
 }
 
 type pullRequestsLoadedMsg struct {
-	prs []domain.PullRequest
-	err error
+	repoSlug string
+	prs      []domain.PullRequest
+	err      error
 }
 
 type prApprovalUpdatedMsg struct {
@@ -151,6 +441,7 @@ type prDiffLoadedMsg struct {
 }
 
 type pipelinesLoadedMsg struct {
+	repoSlug  string
 	pipelines []domain.Pipeline
 	err       error
 }
@@ -182,108 +473,236 @@ type pipelinePolledMsg struct {
 
 const pipelinePollInterval = 8 * time.Second
 
+const horizontalScrollStep = 8
+
 func NewApp(workspace string, cfg config.Config) AppModel {
+	return NewAppWithClient(workspace, cfg, bitbucket.NewClient(cfg))
+}
+
+// NewAppWithClient builds the app around an existing API client, such as the
+// in-memory fake used in tests.
+func NewAppWithClient(workspace string, cfg config.Config, client bitbucket.BitbucketAPI) AppModel {
 	s := spinner.New()
 	s.Spinner = spinner.MiniDot
 	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
 
-	return AppModel{
+	currentTheme = themeFromConfig(cfg)
+	if currentTheme.plain {
+		s.Spinner = plainSpinner
+		useAccessibleOutput()
+	}
+	timestampLayout = timestampLayoutFromConfig(cfg)
+	catalog, localeErr := loadCatalog(cfg.Locale)
+	messages = catalog
+
+	ctx, cancel := context.WithCancel(context.Background())
+	keys := defaultKeyMap()
+	unknownKeys := keys.applyKeyRemaps(cfg.KeyBindings)
+	keys.translateHelp()
+
+	m := AppModel{
 		workspace:            workspace,
-		client:               bitbucket.NewClient(cfg),
+		client:               client,
+		ctx:                  ctx,
+		cancel:               cancel,
 		spinner:              s,
+		keys:                 keys,
+		help:                 help.New(),
 		activePane:           repoPane,
 		currentView:          noSelection,
-		loading:              true,
 		prCommitChangesCache: make(map[string][]domain.CommitChange),
 		prCommitDiffCache:    make(map[string]string),
+		watchPipelines:       make(map[string]domain.Pipeline),
+		watchPullRequests:    make(map[string]domain.PullRequest),
+		watchErrors:          make(map[string]error),
+		favorites:            cfg.Favorites,
+		trackedBranches:      cfg.TrackedBranches,
+		homePipelines:        make(map[string]domain.Pipeline),
+		prefetch:             newPrefetchCache(),
+		filters:              newFilterCache(),
+		viewer:               resolveCommand(cfg.Viewer, "PAGER"),
+		editor:               resolveCommand(cfg.Editor, "VISUAL", "EDITOR"),
+		seenReviewRequests:   make(map[string]map[int]bool),
+		autoRefresh:          cfg.AutoRefresh,
+		exportDir:            cfg.ExportDir,
+		clipboard:            cfg.Clipboard,
+		diffPager:            cfg.DiffPager,
+		browserCommand:       strings.Fields(cfg.BrowserCommand),
+		mergeRules:           make(map[string]mergeRules),
+		prStatuses:           make(map[string][]domain.BuildStatus),
+		mergeCheckErrors:     make(map[string]error),
+		commitStatuses:       make(map[string][]domain.BuildStatus),
+	}
+
+	notifier, err := notify.New(cfg.Notify)
+	if err != nil {
+		notifier, _ = notify.New(notify.Bell)
+		m.message = err.Error()
+	}
+	m.notifier = notifier
+
+	newViewContext(&m)
+	m.loading = true
+	if showSnapshot(&m, m.snapshotName("repos", ""), &m.repositories) {
+		m.reposCachedAt = m.cachedAt
+		m.cachedAt = time.Time{}
+	}
+
+	if !strings.EqualFold(strings.TrimSpace(cfg.StartView), "repos") {
+		m.activePane = branchPane
+		m.currentView = homeView
+		m.homePending = 1
 	}
+
+	if localeErr != nil {
+		m.message = fmt.Sprintf("%v; using English", localeErr)
+	}
+	if len(unknownKeys) > 0 {
+		sort.Strings(unknownKeys)
+		m.message = fmt.Sprintf("Unknown key bindings in config: %s", strings.Join(unknownKeys, ", "))
+	}
+
+	watchItems, err := config.LoadWatchList(workspace)
+	if err != nil {
+		m.message = fmt.Sprintf("Error loading watch list: %v", err)
+	}
+	m.watchItems = watchItems
+	m.watchPolling = len(watchItems) > 0
+
+	if len(m.trackedBranches) == 0 {
+		m.trackedBranches = defaultTrackedBranches
+	}
+	viewState, err := config.LoadViewState(workspace)
+	if err != nil {
+		m.message = fmt.Sprintf("Error loading view state: %v", err)
+	}
+	m.allPipelineBranches = viewState.AllPipelineBranches
+
+	return m
 }
 
 func (m AppModel) Init() tea.Cmd {
-	return tea.Batch(loadRepositories(m.client), m.spinner.Tick)
+	// The user, workspace and first page of repositories don't depend on
+	// each other, so they are fetched concurrently.
+	cmds := []tea.Cmd{
+		loadRepositoryPage(m.ctx, m.client, 1),
+		loadCurrentUser(m.ctx, m.client),
+		loadWorkspace(m.ctx, m.client),
+		m.spinner.Tick,
+		m.startCmd,
+		scheduleAutoRefresh(m),
+	}
+	if m.watchPolling {
+		cmds = append(cmds, refreshWatchList(m), pollWatchList(m.client))
+	}
+	return tea.Batch(cmds...)
+}
+
+func loadRepositoryPage(ctx context.Context, client bitbucket.BitbucketAPI, page int) tea.Cmd {
+	return func() tea.Msg {
+		repos, more, err := client.ListRepositoryPage(ctx, page)
+		return reposLoadedMsg{page: page, repos: repos, more: more, err: err}
+	}
 }
 
-func loadRepositories(client *bitbucket.Client) tea.Cmd {
+func loadWorkspace(ctx context.Context, client bitbucket.BitbucketAPI) tea.Cmd {
 	return func() tea.Msg {
-		repos, err := client.ListRepositories()
-		return reposLoadedMsg{repos: repos, err: err}
+		workspace, err := client.GetWorkspace(ctx)
+		return workspaceLoadedMsg{workspace: workspace, err: err}
 	}
 }
 
-func loadBranches(client *bitbucket.Client, repoSlug string) tea.Cmd {
+func loadBranches(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug string) tea.Cmd {
 	return func() tea.Msg {
-		branches, err := client.ListBranches(repoSlug)
-		return branchesLoadedMsg{branches: branches, err: err}
+		branches, err := client.ListBranches(ctx, repoSlug)
+		return branchesLoadedMsg{repoSlug: repoSlug, branches: branches, err: err}
 	}
 }
 
-func loadPullRequests(client *bitbucket.Client, repoSlug string) tea.Cmd {
+func loadPullRequests(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug, state string) tea.Cmd {
 	return func() tea.Msg {
-		prs, err := client.ListPullRequests(repoSlug)
-		return pullRequestsLoadedMsg{prs: prs, err: err}
+		prs, err := client.ListPullRequestsByState(ctx, repoSlug, state)
+		return pullRequestsLoadedMsg{repoSlug: repoSlug, prs: prs, err: err}
 	}
 }
 
-func approvePullRequest(client *bitbucket.Client, repoSlug string, pullRequestID int) tea.Cmd {
+func approvePullRequest(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug string, pullRequestID int) tea.Cmd {
 	return func() tea.Msg {
-		err := client.ApprovePullRequest(repoSlug, pullRequestID)
+		err := client.ApprovePullRequest(ctx, repoSlug, pullRequestID)
 		return prApprovalUpdatedMsg{pullRequestID: pullRequestID, approved: true, err: err}
 	}
 }
 
-func unapprovePullRequest(client *bitbucket.Client, repoSlug string, pullRequestID int) tea.Cmd {
+func unapprovePullRequest(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug string, pullRequestID int) tea.Cmd {
 	return func() tea.Msg {
-		err := client.UnapprovePullRequest(repoSlug, pullRequestID)
+		err := client.UnapprovePullRequest(ctx, repoSlug, pullRequestID)
 		return prApprovalUpdatedMsg{pullRequestID: pullRequestID, approved: false, err: err}
 	}
 }
 
-func loadPipelines(client *bitbucket.Client, repoSlug string) tea.Cmd {
+func loadPipelines(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug string) tea.Cmd {
 	return func() tea.Msg {
-		pipelines, err := client.ListPipelines(repoSlug)
-		return pipelinesLoadedMsg{pipelines: pipelines, err: err}
+		pipelines, err := client.ListPipelines(ctx, repoSlug)
+		return pipelinesLoadedMsg{repoSlug: repoSlug, pipelines: pipelines, err: err}
 	}
 }
 
-func pollPipelineUpdates() tea.Cmd {
-	return tea.Tick(pipelinePollInterval, func(time.Time) tea.Msg {
+func pollPipelineUpdates(client bitbucket.BitbucketAPI) tea.Cmd {
+	return tea.Tick(pollInterval(client), func(time.Time) tea.Msg {
 		return pipelinePollTickMsg{}
 	})
 }
 
-func loadPipeline(client *bitbucket.Client, repoSlug, pipelineUUID string) tea.Cmd {
+func loadPipeline(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug, pipelineUUID string) tea.Cmd {
 	return func() tea.Msg {
-		pipeline, err := client.GetPipeline(repoSlug, pipelineUUID)
+		pipeline, err := client.GetPipeline(ctx, repoSlug, pipelineUUID)
 		return pipelinePolledMsg{pipeline: pipeline, err: err}
 	}
 }
 
-func loadPullRequestDiff(client *bitbucket.Client, repoSlug string, pullRequestID int) tea.Cmd {
+func loadPullRequestDiff(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug string, pullRequestID int) tea.Cmd {
 	return func() tea.Msg {
-		diff, err := client.GetPullRequestDiff(repoSlug, pullRequestID)
+		diff, err := client.GetPullRequestDiff(ctx, repoSlug, pullRequestID)
 		return prDiffLoadedMsg{prID: pullRequestID, diff: diff, err: err}
 	}
 }
 
-func loadPipelineSteps(client *bitbucket.Client, repoSlug, pipelineUUID string) tea.Cmd {
+func loadPipelineSteps(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug, pipelineUUID string) tea.Cmd {
 	return func() tea.Msg {
-		steps, err := client.ListPipelineSteps(repoSlug, pipelineUUID)
+		steps, err := client.ListPipelineSteps(ctx, repoSlug, pipelineUUID)
 		return pipelineStepsLoadedMsg{steps: steps, err: err}
 	}
 }
 
-func loadPipelineStepLog(client *bitbucket.Client, repoSlug, pipelineUUID, stepUUID string) tea.Cmd {
+func loadPipelineStepLog(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug, pipelineUUID, stepUUID string) tea.Cmd {
 	return func() tea.Msg {
-		log, err := client.GetPipelineStepLog(repoSlug, pipelineUUID, stepUUID)
+		log, err := client.GetPipelineStepLog(ctx, repoSlug, pipelineUUID, stepUUID)
 		return pipelineStepLogLoadedMsg{log: log, err: err}
 	}
 }
 
-func openURL(url string) tea.Cmd {
+// openURL opens url with browser, the browser_command config split into
+// fields with "%s" standing for the URL (appended when absent), or else the
+// first opener that works on this platform.
+func openURL(browser []string, url string) tea.Cmd {
 	return func() tea.Msg {
 		var commands [][]string
-		switch runtime.GOOS {
-		case "linux":
+		switch {
+		case len(browser) > 0:
+			command := make([]string, 0, len(browser)+1)
+			substituted := false
+			for _, field := range browser {
+				if strings.Contains(field, "%s") {
+					field = strings.ReplaceAll(field, "%s", url)
+					substituted = true
+				}
+				command = append(command, field)
+			}
+			if !substituted {
+				command = append(command, url)
+			}
+			commands = [][]string{command}
+		case runtime.GOOS == "linux":
 			commands = [][]string{
 				{"xdg-open", url},
 				{"gio", "open", url},
@@ -291,9 +710,9 @@ func openURL(url string) tea.Cmd {
 				{"cmd.exe", "/c", "start", "", url},
 				{"powershell.exe", "-NoProfile", "-Command", "Start-Process", url},
 			}
-		case "darwin":
+		case runtime.GOOS == "darwin":
 			commands = [][]string{{"open", url}}
-		case "windows":
+		case runtime.GOOS == "windows":
 			commands = [][]string{{"cmd", "/c", "start", "", url}}
 		default:
 			return urlOpenedMsg{err: fmt.Errorf("opening URLs is not supported on %s", runtime.GOOS)}
@@ -329,84 +748,115 @@ func openURL(url string) tea.Cmd {
 	}
 }
 
-func openLogInEditor(logContent, stepName string) tea.Cmd {
-	content := logContent
-	if strings.TrimSpace(content) == "" {
-		content = "No log output returned for this step."
-	}
-
-	title := "pipeline-log"
-	if strings.TrimSpace(stepName) != "" {
-		title = strings.ReplaceAll(strings.TrimSpace(stepName), " ", "-")
-	}
-
-	tmpFile, err := os.CreateTemp("", fmt.Sprintf("bb-%s-*.log", title))
-	if err != nil {
-		return func() tea.Msg { return editorClosedMsg{err: err} }
-	}
-
-	filePath := tmpFile.Name()
-	if _, writeErr := tmpFile.WriteString(content); writeErr != nil {
-		_ = tmpFile.Close()
-		_ = os.Remove(filePath)
-		return func() tea.Msg { return editorClosedMsg{err: writeErr} }
-	}
-	_ = tmpFile.Close()
-
-	var cmd *exec.Cmd
-	if _, lookErr := exec.LookPath("nvim"); lookErr == nil {
-		cmd = exec.Command("nvim", filePath)
-	} else if _, lookErr := exec.LookPath("less"); lookErr == nil {
-		cmd = exec.Command("less", filePath)
-	} else {
-		_ = os.Remove(filePath)
-		return func() tea.Msg { return editorClosedMsg{err: fmt.Errorf("neither nvim nor less is installed")} }
+func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	msg, current := unwrapViewResult(&m, msg)
+	if !current || canceledResult(msg) {
+		return m, nil
 	}
 
-	return tea.ExecProcess(cmd, func(execErr error) tea.Msg {
-		_ = os.Remove(filePath)
-		return editorClosedMsg{err: execErr}
-	})
-}
-
-func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		m.help.Width = msg.Width
 
 	case reposLoadedMsg:
-		m.loading = false
-		if msg.err != nil {
-			m.message = fmt.Sprintf("Error loading repos: %v", msg.err)
+		if msg.page > 1 {
+			if msg.err != nil {
+				m.message = fmt.Sprintf("Error loading repos: %s", describeError(msg.err))
+				break
+			}
+			m.repositories = append(m.repositories, msg.repos...)
+			selectStartRepo(&m, !msg.more)
+			if msg.more {
+				return m, loadRepositoryPage(m.ctx, m.client, msg.page+1)
+			}
+			return m, saveSnapshot(m.snapshotName("repos", ""), m.repositories)
+		}
+
+		m.finishLoading()
+		if msg.err != nil && !m.reposCachedAt.IsZero() {
+			m.message = fmt.Sprintf("Showing cached repositories: %s", describeError(msg.err))
+		} else if msg.err != nil {
+			m.message = fmt.Sprintf("Error loading repos: %s", describeError(msg.err))
 		} else {
+			if !m.reposCachedAt.IsZero() {
+				m.repoCursor = keepCursor(m.repoCursor, len(msg.repos))
+			}
 			m.repositories = msg.repos
+			m.reposCachedAt = time.Time{}
 			m.message = ""
+			selectStartRepo(&m, !msg.more)
+			// The snapshot is saved once the last page is in.
+			next := saveSnapshot(m.snapshotName("repos", ""), msg.repos)
+			if msg.more {
+				next = loadRepositoryPage(m.ctx, m.client, 2)
+			}
+			if m.currentView == homeView && m.homePending == 0 && m.homeMyPRs == nil {
+				return m, tea.Batch(next, loadHome(&m))
+			}
+			return m, next
 		}
 
 	case branchesLoadedMsg:
-		m.loading = false
-		if msg.err != nil {
-			m.message = fmt.Sprintf("Error loading branches: %v", msg.err)
+		m.finishLoading()
+		if msg.err != nil && !m.cachedAt.IsZero() {
+			m.cacheOffline = true
+			m.message = fmt.Sprintf("Showing cached branches: %s", describeError(msg.err))
+		} else if msg.err != nil {
+			m.message = fmt.Sprintf("Error loading branches: %s", describeError(msg.err))
 		} else {
+			if m.cachedAt.IsZero() && !m.quietReload {
+				m.branchCursor = 0
+			} else {
+				m.branchCursor = keepCursor(m.branchCursor, len(msg.branches))
+			}
+			m.quietReload = false
 			m.branches = msg.branches
-			m.branchCursor = 0
+			focusStartBranch(&m)
+			m.cachedAt = time.Time{}
 			m.message = ""
+			return m, saveSnapshot(m.snapshotName("branches", msg.repoSlug), msg.branches)
 		}
 
 	case pullRequestsLoadedMsg:
-		m.loading = false
-		if msg.err != nil {
-			m.message = fmt.Sprintf("Error loading pull requests: %v", msg.err)
+		m.finishLoading()
+		if msg.err != nil && !m.cachedAt.IsZero() {
+			m.cacheOffline = true
+			m.message = fmt.Sprintf("Showing cached pull requests: %s", describeError(msg.err))
+		} else if msg.err != nil {
+			m.message = fmt.Sprintf("Error loading pull requests: %s", describeError(msg.err))
 		} else {
+			if m.cachedAt.IsZero() && !m.quietReload {
+				m.prCursor = 0
+			} else {
+				m.prCursor = keepCursor(m.prCursor, len(msg.prs))
+			}
+			m.quietReload = false
 			m.pullRequests = msg.prs
-			m.prCursor = 0
+			m.cachedAt = time.Time{}
 			m.message = ""
+			clearMergeChecks(&m, msg.repoSlug)
+			save := saveSnapshot(m.pullRequestSnapshotName(msg.repoSlug), msg.prs)
+			if m.focusPullRequestID != 0 {
+				for i, pr := range m.getFilteredPRs() {
+					if pr.ID == m.focusPullRequestID {
+						m.prCursor = i
+					}
+				}
+				m.focusPullRequestID = 0
+			}
+			focusStartBranch(&m)
+			statuses := loadSourceStatuses(&m, msg.repoSlug, msg.prs)
+			if m.currentView == prView {
+				return m, tea.Batch(save, statuses, schedulePrefetch(&m))
+			}
+			return m, tea.Batch(save, statuses)
 		}
 
 	case prApprovalUpdatedMsg:
 		if msg.err != nil {
-			m.message = fmt.Sprintf("Error updating approval: %v", msg.err)
+			m.message = fmt.Sprintf("Error updating approval: %s", describeError(msg.err))
 			break
 		}
 
@@ -415,25 +865,13 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				continue
 			}
 
-			if msg.approved {
-				if m.pullRequests[i].Approvals <= 0 {
-					m.pullRequests[i].Approvals = 1
-				}
-				m.pullRequests[i].Approved = true
-				if len(m.pullRequests[i].ApproverNames) == 0 {
-					m.pullRequests[i].ApproverNames = []string{"you"}
-				}
-			} else {
-				if m.pullRequests[i].Approvals > 0 {
-					m.pullRequests[i].Approvals--
-				}
-				m.pullRequests[i].Approved = m.pullRequests[i].Approvals > 0
-				if len(m.pullRequests[i].ApproverNames) > 0 {
-					m.pullRequests[i].ApproverNames = nil
-				}
-			}
+			applyApproval(&m.pullRequests[i], m.currentUser, msg.approved)
+			m.filters.invalidate("pullRequests")
 			break
 		}
+		if m.openPullRequest.ID == msg.pullRequestID {
+			applyApproval(&m.openPullRequest, m.currentUser, msg.approved)
+		}
 
 		if msg.approved {
 			m.message = fmt.Sprintf("Approved PR #%d", msg.pullRequestID)
@@ -442,24 +880,33 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case prCommitsLoadedMsg:
-		m.loading = false
+		m.finishLoading()
 		if msg.err != nil {
-			m.message = fmt.Sprintf("Error loading commits: %v", msg.err)
+			m.message = fmt.Sprintf("Error loading commits: %s", describeError(msg.err))
 		} else {
-			m.prCommits = msg.commits
-			m.prCommitCursor = 0
-			m.prCommitChanges = nil
-			m.prCommitDiff = ""
-			m.selectedCommitHash = ""
 			m.message = ""
-			if cmd := updateSelectedCommitDetails(&m); cmd != nil {
-				return m, cmd
-			}
+			return m, showCommits(&m, msg.commits)
 		}
 
+	case branchComparedMsg:
+		m.finishLoading()
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error comparing branches: %s", describeError(msg.err))
+		} else {
+			m.comparison = msg.comparison
+			m.message = ""
+			return m, showCommits(&m, msg.comparison.ahead)
+		}
+
+	case commitSignaturesMsg:
+		m.commitSignatures = msg.signatures
+
+	case tagSignaturesMsg:
+		m.tagSignatures = msg.signatures
+
 	case prCommitChangesLoadedMsg:
 		if msg.err != nil {
-			m.message = fmt.Sprintf("Error loading commit changes: %v", msg.err)
+			m.message = fmt.Sprintf("Error loading commit changes: %s", describeError(msg.err))
 			break
 		}
 
@@ -470,7 +917,7 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case prCommitDiffLoadedMsg:
 		if msg.err != nil {
-			m.message = fmt.Sprintf("Error loading commit diff: %v", msg.err)
+			m.message = fmt.Sprintf("Error loading commit diff: %s", describeError(msg.err))
 			break
 		}
 
@@ -480,24 +927,23 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case prDiffLoadedMsg:
-		m.loading = false
+		m.finishLoading()
 		if msg.err != nil {
-			m.message = fmt.Sprintf("Error loading PR diff: %v", msg.err)
+			m.message = fmt.Sprintf("Error loading PR diff: %s", describeError(msg.err))
 			break
 		}
 
-		diff := strings.TrimSpace(msg.diff)
-		if diff == "" {
-			m.message = fmt.Sprintf("PR #%d has no textual diff", msg.prID)
-			break
+		if msg.prID == m.prDiffPR.ID && strings.TrimSpace(msg.diff) != "" {
+			m.prDiffLines = strings.Split(strings.TrimRight(msg.diff, "\n"), "\n")
 		}
 
-		return m, openLogInEditor(msg.diff, fmt.Sprintf("pr-%d-diff", msg.prID))
-
 	case pipelinesLoadedMsg:
-		m.loading = false
-		if msg.err != nil {
-			m.message = fmt.Sprintf("Error loading pipelines: %v", msg.err)
+		m.finishLoading()
+		if msg.err != nil && !m.cachedAt.IsZero() {
+			m.cacheOffline = true
+			m.message = fmt.Sprintf("Showing cached pipelines: %s", describeError(msg.err))
+		} else if msg.err != nil {
+			m.message = fmt.Sprintf("Error loading pipelines: %s", describeError(msg.err))
 		} else {
 			previousCursor := m.pipelineCursor
 			m.pipelines = msg.pipelines
@@ -508,87 +954,799 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			} else {
 				m.pipelineCursor = len(m.pipelines) - 1
 			}
-			m.message = ""
+			focusStartBranch(&m)
+			if !m.cachedAt.IsZero() {
+				m.message = ""
+			}
+			m.cachedAt = time.Time{}
 
+			save := saveSnapshot(m.snapshotName("pipelines", msg.repoSlug), msg.pipelines)
 			if m.activePane == branchPane && m.currentView == pipelinesView && selectedRunningPipelineUUID(m) != "" {
-				return m, pollPipelineUpdates()
+				return m, tea.Batch(save, pollPipelineUpdates(m.client))
+			}
+			return m, save
+		}
+
+	case issuesLoadedMsg:
+		m.finishLoading()
+		if msg.err != nil && !m.cachedAt.IsZero() {
+			m.cacheOffline = true
+			m.message = fmt.Sprintf("Showing cached issues: %s", describeError(msg.err))
+		} else if msg.err != nil {
+			m.message = issueLoadError(msg.err)
+		} else {
+			m.issueCursor = keepCursor(m.issueCursor, len(msg.issues))
+			m.issues = msg.issues
+			m.cachedAt = time.Time{}
+			m.message = ""
+			return m, saveSnapshot(m.snapshotName("issues", msg.repoSlug), msg.issues)
+		}
+
+	case downloadsLoadedMsg:
+		m.finishLoading()
+		if msg.err != nil && !m.cachedAt.IsZero() {
+			m.cacheOffline = true
+			m.message = fmt.Sprintf("Showing cached downloads: %s", describeError(msg.err))
+		} else if msg.err != nil {
+			m.message = fmt.Sprintf("Error loading downloads: %s", describeError(msg.err))
+		} else {
+			m.downloadCursor = keepCursor(m.downloadCursor, len(msg.downloads))
+			m.downloads = msg.downloads
+			if !m.cachedAt.IsZero() {
+				m.message = ""
+			}
+			m.cachedAt = time.Time{}
+			return m, saveSnapshot(m.snapshotName("downloads", msg.repoSlug), msg.downloads)
+		}
+
+	case downloadSavedMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Download error: %s", describeError(msg.err))
+		} else {
+			m.message = fmt.Sprintf("Saved %s", msg.path)
+		}
+
+	case downloadUploadedMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error uploading %s: %s", msg.name, describeError(msg.err))
+			break
+		}
+		m.message = fmt.Sprintf("Uploaded %s", msg.name)
+		if m.currentView == downloadsView && m.selectedRepoSlug == msg.repoSlug {
+			return m, forView(&m, loadDownloads(m.viewCtx, m.client, msg.repoSlug))
+		}
+
+	case revertCreatedMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error reverting PR #%d: %s", msg.reverted.ID, describeError(msg.err))
+			break
+		}
+		m.message = fmt.Sprintf("Opened PR #%d reverting #%d", msg.pr.ID, msg.reverted.ID)
+
+	case pullRequestMergedMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error merging PR #%d: %s", msg.pr.ID, describeError(msg.err))
+			break
+		}
+		m.message = fmt.Sprintf("Merged PR #%d into %s (%s)", msg.pr.ID, msg.pr.DestBranch, msg.strategy)
+		applyOpenPullRequest(&m, msg.pr)
+		return m, reloadPullRequests(&m, msg.repoSlug)
+
+	case pullRequestLoadedMsg:
+		m.finishLoading()
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error loading pull request: %s", describeError(msg.err))
+			break
+		}
+		applyOpenPullRequest(&m, msg.pr)
+		// A push since the list loaded moves the source branch to a commit
+		// whose builds are not known yet.
+		return m, loadSourceStatuses(&m, msg.pr.RepoSlug, []domain.PullRequest{msg.pr})
+
+	case pullRequestCommentsLoadedMsg:
+		if m.currentView == prDiffView {
+			if msg.id != m.prDiffPR.ID {
+				break
+			}
+			m.prDiffComments = msg.comments
+			if msg.err != nil {
+				m.message = fmt.Sprintf("Error loading comments: %s", describeError(msg.err))
+			}
+			break
+		}
+		m.finishLoading()
+		if msg.id != m.openPullRequest.ID {
+			break
+		}
+		m.prComments = msg.comments
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error loading comments: %s", describeError(msg.err))
+		}
+		if m.prComments == nil {
+			m.prComments = []domain.Comment{}
+		}
+
+	case pullRequestCommentedMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error commenting on PR #%d: %s", msg.id, describeError(msg.err))
+			break
+		}
+		m.message = fmt.Sprintf("Commented on PR #%d", msg.id)
+		if msg.id == m.openPullRequest.ID && m.prComments != nil {
+			m.prComments = append(m.prComments, msg.comment)
+			if m.currentView == prCommentsView {
+				m.prCommentsOffset = m.maxPRCommentsOffset()
+			}
+		}
+		if msg.id == m.prDiffPR.ID {
+			m.prDiffComments = append(m.prDiffComments, msg.comment)
+		}
+
+	case branchCreatedMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error creating branch: %s", describeError(msg.err))
+			break
+		}
+		m.message = fmt.Sprintf("Created branch %s from %s", msg.branch.Name, msg.source)
+		if m.selectedRepoSlug == msg.repoSlug && m.branches != nil {
+			addBranch(&m, msg.branch)
+		}
+
+	case branchDeletedMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error deleting branch %s: %s", msg.name, describeError(msg.err))
+			break
+		}
+		m.message = fmt.Sprintf("Deleted branch %s", msg.name)
+		if m.selectedRepoSlug == msg.repoSlug {
+			removeBranch(&m, msg.name)
+		}
+
+	case tagsLoadedMsg:
+		m.finishLoading()
+		if msg.err != nil && !m.cachedAt.IsZero() {
+			m.cacheOffline = true
+			m.message = fmt.Sprintf("Showing cached tags: %s", describeError(msg.err))
+		} else if msg.err != nil {
+			m.message = fmt.Sprintf("Error loading tags: %s", describeError(msg.err))
+		} else {
+			m.tagCursor = keepCursor(m.tagCursor, len(msg.tags))
+			m.tags = msg.tags
+			if !m.cachedAt.IsZero() {
+				m.message = ""
+			}
+			m.cachedAt = time.Time{}
+			return m, tea.Batch(saveSnapshot(m.snapshotName("tags", msg.repoSlug), msg.tags), verifyTagSignatures(&m, msg.tags))
+		}
+
+	case tagCreatedMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error creating tag: %s", describeError(msg.err))
+			break
+		}
+		m.message = fmt.Sprintf("Created tag %s on %s", msg.tag.Name, msg.target)
+		if m.selectedRepoSlug == msg.repoSlug && m.tags != nil {
+			addTag(&m, msg.tag)
+		}
+
+	case schedulesLoadedMsg:
+		m.finishLoading()
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error loading schedules: %s", describeError(msg.err))
+		} else if msg.repoSlug == m.selectedRepoSlug {
+			m.scheduleCursor = keepCursor(m.scheduleCursor, len(msg.schedules))
+			m.schedules = msg.schedules
+		}
+
+	case scheduleCreatedMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error creating schedule: %s", describeError(msg.err))
+			break
+		}
+		m.message = fmt.Sprintf("Scheduled %s at %s", scheduleLabel(msg.schedule), msg.schedule.CronPattern)
+		if m.currentView == schedulesView && m.selectedRepoSlug == msg.repoSlug {
+			m.schedules = append(m.schedules, msg.schedule)
+			m.scheduleCursor = len(m.schedules) - 1
+		}
+
+	case scheduleUpdatedMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error updating schedule of %s: %s", scheduleLabel(msg.schedule), describeError(msg.err))
+			break
+		}
+		if msg.schedule.Enabled {
+			m.message = fmt.Sprintf("Resumed schedule of %s", scheduleLabel(msg.schedule))
+		} else {
+			m.message = fmt.Sprintf("Paused schedule of %s", scheduleLabel(msg.schedule))
+		}
+		if m.selectedRepoSlug == msg.repoSlug {
+			replaceSchedule(&m, msg.schedule)
+		}
+
+	case scheduleDeletedMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error deleting schedule of %s: %s", scheduleLabel(msg.schedule), describeError(msg.err))
+			break
+		}
+		m.message = fmt.Sprintf("Deleted schedule of %s", scheduleLabel(msg.schedule))
+		if m.selectedRepoSlug == msg.repoSlug {
+			removeSchedule(&m, msg.schedule.UUID)
+		}
+
+	case tagDeletedMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error deleting tag %s: %s", msg.name, describeError(msg.err))
+			break
+		}
+		m.message = fmt.Sprintf("Deleted tag %s", msg.name)
+		if m.selectedRepoSlug == msg.repoSlug {
+			removeTag(&m, msg.name)
+		}
+
+	case deploymentsLoadedMsg:
+		m.finishLoading()
+		if msg.err != nil && !m.cachedAt.IsZero() {
+			m.cacheOffline = true
+			m.message = fmt.Sprintf("Showing cached deployments: %s", describeError(msg.err))
+		} else if msg.err != nil {
+			m.message = fmt.Sprintf("Error loading deployments: %s", describeError(msg.err))
+		} else {
+			m.environmentCursor = keepCursor(m.environmentCursor, len(msg.environments))
+			m.environments = msg.environments
+			if !m.cachedAt.IsZero() {
+				m.message = ""
 			}
+			m.cachedAt = time.Time{}
+			return m, saveSnapshot(m.snapshotName("deployments", msg.repoSlug), msg.environments)
+		}
+
+	case pullRequestTasksLoadedMsg:
+		if msg.id != m.openPullRequest.ID {
+			break
+		}
+		m.prTasks = msg.tasks
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error loading pull request tasks: %s", describeError(msg.err))
+		}
+		if m.prTasks == nil {
+			m.prTasks = []domain.Task{}
 		}
 
+	case pullRequestTaskUpdatedMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error updating a task on PR #%d: %s", msg.id, describeError(msg.err))
+			break
+		}
+		switch {
+		case msg.created:
+			m.message = fmt.Sprintf("Created task #%d on PR #%d", msg.task.ID, msg.id)
+		case msg.task.Resolved:
+			m.message = fmt.Sprintf("Resolved task #%d", msg.task.ID)
+		default:
+			m.message = fmt.Sprintf("Reopened task #%d", msg.task.ID)
+		}
+		applyTask(&m, msg)
+
+	case pullRequestActivityLoadedMsg:
+		if msg.id != m.openPullRequest.ID {
+			break
+		}
+		m.prActivity = msg.activity
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error loading pull request activity: %s", describeError(msg.err))
+		}
+		if m.prActivity == nil {
+			m.prActivity = []domain.PullRequestActivity{}
+		}
+
+	case pullRequestStateChangedMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error changing PR #%d: %s", msg.id, describeError(msg.err))
+			break
+		}
+		if msg.change == "reopen" {
+			m.message = fmt.Sprintf("Reopened PR #%d as PR #%d", msg.id, msg.pr.ID)
+		} else {
+			m.message = fmt.Sprintf("Declined PR #%d", msg.id)
+			applyOpenPullRequest(&m, msg.pr)
+		}
+		return m, reloadPullRequests(&m, msg.repoSlug)
+
+	case pullRequestCreatedMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error creating pull request: %s", describeError(msg.err))
+			break
+		}
+		delete(m.prefetch.pullRequests, msg.repoSlug)
+		m.message = fmt.Sprintf("Opened PR #%d from %s into %s", msg.pr.ID, msg.pr.SourceBranch, msg.pr.DestBranch)
+
+	case issueCommentsLoadedMsg:
+		m.finishLoading()
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error loading comments: %s", describeError(msg.err))
+		} else if msg.issueID == m.openIssue.ID {
+			m.issueComments = msg.comments
+		}
+
+	case issueUpdatedMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error updating issue #%d: %s", msg.issueID, describeError(msg.err))
+			break
+		}
+		applyIssueUpdate(&m, msg.issue)
+		m.message = fmt.Sprintf("Issue #%d %s", msg.issueID, msg.change)
+
+	case issueCreatedMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error creating issue: %s", describeError(msg.err))
+			break
+		}
+		m.issues = append([]domain.Issue{msg.issue}, m.issues...)
+		if m.currentView == issuesView {
+			m.issueFilterQuery = ""
+			m.issueCursor = 0
+		}
+		m.message = fmt.Sprintf("Created issue #%d", msg.issue.ID)
+
+	case textEditedMsg:
+		switch msg.purpose {
+		case issueEditPurpose:
+			return m, submitIssue(&m, msg)
+		case pullRequestEditPurpose:
+			return m, submitPullRequest(&m, msg)
+		}
+
+	case cooldownEndedMsg:
+		return m, runQueuedRefresh(&m)
+
 	case pipelinePollTickMsg:
+		if m.client.RateLimit().Cooldown() > 0 {
+			return m, pollPipelineUpdates(m.client)
+		}
 		if m.activePane == branchPane && m.currentView == pipelinesView && m.selectedRepoSlug != "" {
 			pipelineUUID := selectedRunningPipelineUUID(m)
 			if pipelineUUID != "" {
-				return m, loadPipeline(m.client, m.selectedRepoSlug, pipelineUUID)
+				return m, forView(&m, loadPipeline(bitbucket.WithoutCache(m.viewCtx), m.client, m.selectedRepoSlug, pipelineUUID))
 			}
 		}
 
 	case pipelinePolledMsg:
 		if msg.err != nil {
-			m.message = fmt.Sprintf("Error polling pipeline: %v", msg.err)
+			m.message = fmt.Sprintf("Error polling pipeline: %s", describeError(msg.err))
 			if m.activePane == branchPane && m.currentView == pipelinesView && selectedRunningPipelineUUID(m) != "" {
-				return m, pollPipelineUpdates()
+				return m, pollPipelineUpdates(m.client)
 			}
 			break
 		}
 
+		var notifyCmd tea.Cmd
 		for i := range m.pipelines {
 			if m.pipelines[i].UUID == msg.pipeline.UUID {
+				notifyCmd = notifyPipelineFinished(&m, m.selectedRepoSlug, m.pipelines[i], msg.pipeline)
 				m.pipelines[i] = msg.pipeline
+				m.filters.invalidate("pipelines", "allPipelines")
 				break
 			}
 		}
 
 		if m.activePane == branchPane && m.currentView == pipelinesView && isPipelineRunning(msg.pipeline) {
-			return m, pollPipelineUpdates()
+			return m, tea.Batch(notifyCmd, pollPipelineUpdates(m.client))
+		}
+		return m, notifyCmd
+
+	case pipelineRerunMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error running pipeline #%d again: %s", msg.previous, describeError(msg.err))
+			break
+		}
+		m.message = fmt.Sprintf("Started pipeline #%d (rerun of #%d)", msg.pipeline.BuildNumber, msg.previous)
+		return m, showRerunPipeline(&m, msg)
+
+	case pipelineHeaderLoadedMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error loading pipeline commit: %s", describeError(msg.err))
+		} else if msg.pipeline.UUID == m.openPipeline.UUID {
+			m.openPipeline = msg.pipeline
 		}
 
 	case pipelineStepsLoadedMsg:
-		m.loading = false
+		m.finishLoading()
 		if msg.err != nil {
-			m.message = fmt.Sprintf("Error loading pipeline steps: %v", msg.err)
+			m.message = fmt.Sprintf("Error loading pipeline steps: %s", describeError(msg.err))
 		} else {
+			if m.pipelineSteps == nil {
+				// A pipeline opened just now starts at the step that broke it.
+				m.pipelineStepCursor = firstFailedStep(msg.steps)
+			}
 			m.pipelineSteps = msg.steps
-			m.pipelineStepCursor = 0
+			m.pipelineStepCursor = keepCursor(m.pipelineStepCursor, len(msg.steps))
 			m.message = ""
 		}
 
 	case pipelineStepLogLoadedMsg:
-		m.loading = false
+		m.finishLoading()
 		if msg.err != nil {
-			m.message = fmt.Sprintf("Error loading pipeline log: %v", msg.err)
+			m.message = fmt.Sprintf("Error loading pipeline log: %s", describeError(msg.err))
 		} else {
 			m.pipelineStepLog = msg.log
 			if strings.TrimSpace(msg.log) == "" {
 				m.pipelineStepLogLines = []string{"No log output returned for this step."}
+				m.logColorLines = nil
 			} else {
-				m.pipelineStepLogLines = strings.Split(msg.log, "\n")
+				m.pipelineStepLogLines, m.logColorLines = logLines(msg.log)
 			}
+			m.logMarks = markLogLines(m.pipelineStepLogLines)
 			m.pipelineStepLogCursor = 0
+			clearLogSearch(&m)
 			m.message = ""
 		}
 
-	case editorClosedMsg:
+	case stepLogFollowTickMsg:
+		if msg.stepUUID != m.followStepUUID || m.currentView != pipelineStepLogView {
+			break
+		}
+		if m.client.RateLimit().Cooldown() > 0 {
+			return m, followStepLogTick(&m, msg.stepUUID)
+		}
+		return m, fetchFollowedLog(&m)
+
+	case stepLogFollowedMsg:
+		return m, applyFollowedLog(&m, msg)
+
+	case stepCommandsLoadedMsg:
+		applyStepCommands(&m, msg)
+
+	case artifactsLoadedMsg:
+		if msg.stepUUID != m.artifactsStepUUID {
+			break
+		}
+		m.finishLoading()
 		if msg.err != nil {
-			m.message = fmt.Sprintf("Editor error: %v", msg.err)
+			m.message = fmt.Sprintf("Error loading artifacts: %s", describeError(msg.err))
 		} else {
-			m.message = "Closed log viewer"
+			m.artifactCursor = keepCursor(m.artifactCursor, len(msg.artifacts))
+			m.artifacts = msg.artifacts
 		}
 
-	case urlOpenedMsg:
+	case artifactProgressTickMsg:
+		if m.artifactDownload != nil {
+			m.message = m.artifactProgress()
+			return m, artifactProgressTick()
+		}
+
+	case artifactSavedMsg:
+		m.artifactDownload = nil
 		if msg.err != nil {
-			m.message = fmt.Sprintf("Open URL error: %v", msg.err)
+			m.message = fmt.Sprintf("Download error: %s", describeError(msg.err))
 		} else {
-			m.message = "Opened PR in browser"
+			m.message = fmt.Sprintf("Saved %s", msg.path)
 		}
 
-	case spinner.TickMsg:
-		var cmd tea.Cmd
-		m.spinner, cmd = m.spinner.Update(msg)
+	case editorClosedMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Editor error: %v", msg.err)
+		} else {
+			m.message = "Closed log viewer"
+		}
+
+	case liveEventMsg:
+		return m, handleLiveEvent(&m, msg)
+
+	case liveRefreshMsg:
+		if msg.seq == m.liveSeq {
+			return m, liveRefresh(&m)
+		}
+
+	case autoRefreshTickMsg:
+		return m, tea.Batch(liveRefresh(&m), scheduleAutoRefresh(m))
+
+	case actionDoneMsg:
+		finishAction(&m)
+		model, cmd := m.Update(msg.msg)
+		next := model.(AppModel)
+		if msg.took >= longActionThreshold && next.message != "" {
+			cmd = tea.Batch(cmd, sendNotification(next.notifier, "bitbucket-cli", next.message))
+		}
+		return next, cmd
+
+	case exportedMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Export error: %v", msg.err)
+		} else {
+			m.message = fmt.Sprintf("Exported to %s", msg.path)
+		}
+
+	case notificationFailedMsg:
+		m.message = fmt.Sprintf("Notification error: %v", msg.err)
+
+	case fileEditedMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Editor error: %v", msg.err)
+		} else {
+			m.message = fmt.Sprintf("Closed %s", msg.path)
+		}
+
+	case worktreeCreatedMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Worktree error: %v", msg.err)
+			break
+		}
+		m.message = fmt.Sprintf("Reviewing PR #%d in %s", msg.pullRequestID, msg.dir)
+		return m, editWorktree(m.editor, msg.dir)
+
+	case worktreeEditedMsg:
+		m.worktreeCleanup = msg.dir
+		m.message = fmt.Sprintf("Remove worktree %s? (y/n)", msg.dir)
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Editor error: %v. %s", msg.err, m.message)
+		}
+
+	case worktreeRemovedMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error removing worktree: %v", msg.err)
+		} else {
+			m.message = fmt.Sprintf("Removed worktree %s", msg.dir)
+		}
+
+	case urlOpenedMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Open URL error: %v", msg.err)
+		} else {
+			m.message = "Opened PR in browser"
+		}
+
+	case searchBranchesLoadedMsg:
+		if msg.search != m.searchSeq {
+			break
+		}
+		m.searchPending--
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error searching branches: %s", describeError(msg.err))
+		} else if msg.repoSlug == m.selectedRepoSlug {
+			m.searchBranches = msg.branches
+		}
+
+	case searchPullRequestsLoadedMsg:
+		if msg.search != m.searchSeq {
+			break
+		}
+		m.searchPending--
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error searching pull requests: %s", describeError(msg.err))
+		} else if msg.repoSlug == m.selectedRepoSlug {
+			m.searchPullRequests = msg.prs
+		}
+
+	case searchPipelinesLoadedMsg:
+		if msg.search != m.searchSeq {
+			break
+		}
+		m.searchPending--
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error searching pipelines: %s", describeError(msg.err))
+		} else if msg.repoSlug == m.selectedRepoSlug {
+			m.searchPipelines = msg.pipelines
+		}
+
+	case localBranchMsg:
+		m.localClone.Branch = msg.branch
+
+	case workspaceLoadedMsg:
+		// The workspace name is cosmetic; on failure the slug stays in use.
+		if msg.err == nil {
+			m.workspaceInfo = msg.workspace
+		}
+
+	case currentUserLoadedMsg:
+		m.homePending = 0
+		if msg.err != nil {
+			// Only the views that need the user report the failure.
+			if m.currentView == homeView || m.currentView == staleApprovalsView || m.currentView == myPRsView {
+				m.message = fmt.Sprintf("Error loading current user: %s", describeError(msg.err))
+			}
+			m.staleApprovalsPending = 0
+			m.myPRsPending = 0
+			break
+		}
+		m.currentUser = msg.user
+		if m.currentView == homeView {
+			return m, loadHome(&m)
+		}
+		if m.currentView == staleApprovalsView {
+			return m, loadAllStaleApprovals(&m, m.viewCtx)
+		}
+		if m.currentView == myPRsView {
+			return m, loadMyPRs(&m, m.viewCtx)
+		}
+
+	case homeMyPRsLoadedMsg:
+		m.homePending--
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error loading my pull requests: %s", describeError(msg.err))
+			break
+		}
+		m.homeMyPRs = msg.prs
+		sortPullRequestsByUpdated(m.homeMyPRs)
+
+	case homeReviewPRsLoadedMsg:
+		m.homePending--
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error loading review requests for %s: %v", msg.repoSlug, msg.err)
+			break
+		}
+		m.homeReviewPRs = append(m.homeReviewPRs, msg.prs...)
+		sortPullRequestsByUpdated(m.homeReviewPRs)
+		return m, notifyNewReviewRequests(&m, msg.repoSlug, msg.prs)
+
+	case homePipelineLoadedMsg:
+		m.homePending--
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error loading pipelines for %s: %v", msg.repoSlug, msg.err)
+			break
+		}
+		if msg.found {
+			m.homePipelines[msg.repoSlug] = msg.pipeline
+		}
+
+	case feedPullRequestsLoadedMsg:
+		m.feedPending--
+		if msg.err != nil {
+			m.feedErrors = append(m.feedErrors, fmt.Sprintf("Error loading pull requests for %s: %s", msg.repoSlug, describeError(msg.err)))
+			break
+		}
+		m.feedPullRequests[msg.repoSlug] = msg.prs
+		clampFeedCursor(&m)
+
+	case feedPipelinesLoadedMsg:
+		m.feedPending--
+		if msg.err != nil {
+			m.feedErrors = append(m.feedErrors, fmt.Sprintf("Error loading pipelines for %s: %s", msg.repoSlug, describeError(msg.err)))
+			break
+		}
+		m.feedPipelines[msg.repoSlug] = msg.pipelines
+		clampFeedCursor(&m)
+
+	case staleApprovalsLoadedMsg:
+		m.staleApprovalsPending--
+		if msg.err != nil {
+			m.staleApprovalErrors = append(m.staleApprovalErrors, fmt.Sprintf("Error checking approvals in %s: %s", msg.repoSlug, describeError(msg.err)))
+			break
+		}
+		addStaleApprovals(&m, msg.repoSlug, msg.stale)
+
+	case myAuthoredPRsLoadedMsg:
+		m.myPRsPending--
+		if msg.err != nil {
+			m.myPRsErrors = append(m.myPRsErrors, fmt.Sprintf("Error loading my pull requests: %s", describeError(msg.err)))
+			break
+		}
+		m.myAuthoredPRs = msg.prs
+		sortPullRequestsByUpdated(m.myAuthoredPRs)
+		m.myPRsCursor = min(m.myPRsCursor, max(len(m.myPRsEntries())-1, 0))
+
+	case myReviewPRsLoadedMsg:
+		m.myPRsPending--
+		if msg.err != nil {
+			m.myPRsErrors = append(m.myPRsErrors, fmt.Sprintf("Error loading review requests for %s: %s", msg.repoSlug, describeError(msg.err)))
+			break
+		}
+		addMyReviewPRs(&m, msg.repoSlug, msg.prs)
+
+	case watchPollTickMsg:
+		if len(m.watchItems) == 0 {
+			m.watchPolling = false
+			break
+		}
+		if m.client.RateLimit().Cooldown() > 0 {
+			return m, pollWatchList(m.client)
+		}
+		return m, tea.Batch(refreshWatchList(m), pollWatchList(m.client))
+
+	case watchPipelineLoadedMsg:
+		if msg.err != nil {
+			m.watchErrors[msg.key] = msg.err
+		} else {
+			delete(m.watchErrors, msg.key)
+			previous := m.watchPipelines[msg.key]
+			m.watchPipelines[msg.key] = msg.pipeline
+			return m, notifyPipelineFinished(&m, msg.repoSlug, previous, msg.pipeline)
+		}
+
+	case watchPullRequestLoadedMsg:
+		if msg.err != nil {
+			m.watchErrors[msg.key] = msg.err
+		} else {
+			delete(m.watchErrors, msg.key)
+			m.watchPullRequests[msg.key] = msg.pr
+		}
+
+	case prefetchTickMsg:
+		if msg.seq == m.prefetchSeq {
+			return m, prefetchSelection(&m)
+		}
+
+	case prefetchedBranchesMsg, prefetchedPullRequestsMsg, prefetchedPipelinesMsg, prefetchedCommitsMsg:
+		storePrefetched(&m, msg)
+
+	case mergeChecksLoadedMsg:
+		storeMergeChecks(&m, msg)
+
+	case commitStatusesLoadedMsg:
+		storeCommitStatuses(&m, msg)
+
+	case projectsLoadedMsg:
+		m.projectsPending = false
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error loading projects: %s", describeError(msg.err))
+			break
+		}
+		m.projects = msg.projects
+		m.projectCursor = min(m.projectCursor, max(len(m.projects)-1, 0))
+
+	case projectRepositoriesLoadedMsg:
+		if msg.project.Key != m.project.Key {
+			break
+		}
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error loading repositories of %s: %s", msg.project.Name, describeError(msg.err))
+			msg.repos = []domain.Repository{}
+		}
+		if msg.repos == nil {
+			msg.repos = []domain.Repository{}
+		}
+		m.projectRepositories = msg.repos
+
+	case clipboardCopiedMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Copy error: %v", msg.err)
+		} else if msg.terminal {
+			m.message = fmt.Sprintf("Sent %s to the terminal clipboard (OSC 52)", msg.label)
+		} else {
+			m.message = fmt.Sprintf("Copied %s to clipboard", msg.label)
+		}
+
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
 		return m, cmd
 
 	case tea.KeyMsg:
 		m.message = ""
+		if !key.Matches(msg, m.keys.Quit) {
+			m.confirmQuit = false
+		}
+
+		if m.worktreeCleanup != "" {
+			return handleWorktreeCleanupKey(m, msg)
+		}
+
+		if m.revertCandidate.ID != 0 {
+			return handleRevertConfirmKey(m, msg)
+		}
+
+		if m.mergeCandidate.ID != 0 {
+			return handleMergeKey(m, msg)
+		}
+
+		if m.stateCandidate.ID != 0 {
+			return handleStateChangeKey(m, msg)
+		}
+
+		if m.deleteTagCandidate != "" {
+			return handleDeleteTagKey(m, msg)
+		}
+
+		if m.deleteScheduleCandidate.UUID != "" {
+			return handleDeleteScheduleKey(m, msg)
+		}
+
+		if m.rerunCandidate.UUID != "" {
+			return handleRerunKey(m, msg)
+		}
+
+		if m.searchMode {
+			return handleSearchKey(m, msg)
+		}
 
 		if m.filterMode {
 			currentFilter := &m.repoFilterQuery
@@ -603,7 +1761,16 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				} else if m.currentView == pipelinesView {
 					currentFilter = &m.pipelineFilterQuery
 					currentCursor = &m.pipelineCursor
-				} else if m.currentView == prCommitsView || m.currentView == pipelineStepsView || m.currentView == pipelineStepLogView {
+				} else if m.currentView == issuesView {
+					currentFilter = &m.issueFilterQuery
+					currentCursor = &m.issueCursor
+				} else if m.currentView == downloadsView {
+					currentFilter = &m.downloadFilterQuery
+					currentCursor = &m.downloadCursor
+				} else if m.currentView == tagsView {
+					currentFilter = &m.tagFilterQuery
+					currentCursor = &m.tagCursor
+				} else if m.showsCommitList() || m.currentView == pipelineStepsView || m.currentView == pipelineStepLogView || m.currentView == stepCommandsView || m.currentView == artifactsView || m.currentView == schedulesView || m.currentView == deploymentsView || m.currentView == issueDetailView || m.currentView == prDetailView || m.currentView == prDiffView || m.currentView == prCommentsView {
 					return m, nil
 				}
 			}
@@ -632,50 +1799,267 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
-		switch msg.String() {
-		case "q", "ctrl+c":
-			return m, tea.Quit
+		if m.jumpMode {
+			return handleJumpKey(m, msg)
+		}
+
+		if m.logSearchMode {
+			return handleLogSearchKey(m, msg)
+		}
+
+		if m.uploadMode {
+			return handleUploadKey(m, msg)
+		}
+
+		if m.commentMode {
+			return handleCommentKey(m, msg)
+		}
+
+		if m.newBranchStep != "" {
+			return handleNewBranchKey(m, msg)
+		}
+
+		if m.deleteBranchCandidate != "" {
+			return handleDeleteBranchKey(m, msg)
+		}
+
+		if m.newTagStep != "" {
+			return handleNewTagKey(m, msg)
+		}
+
+		if m.newScheduleStep != "" {
+			return handleNewScheduleKey(m, msg)
+		}
+
+		if m.pendingChord != "" {
+			return handleChordKey(m, msg)
+		}
+
+		if key.Matches(msg, m.keys.Chords) {
+			m.pendingChord = msg.String()
+			return m, nil
+		}
+
+		switch {
+		case key.Matches(msg, m.keys.Quit):
+			return m, requestQuit(&m)
+
+		case key.Matches(msg, m.keys.Help):
+			m.help.ShowAll = !m.help.ShowAll
+			return m, nil
+
+		case key.Matches(msg, m.keys.Search):
+			return m, openSearch(&m)
 
-		case "esc":
-			if m.activePane == branchPane && m.currentView == pipelineStepLogView {
+		case key.Matches(msg, m.keys.Back):
+			if m.currentView == inspectorView {
+				closeInspector(&m)
+				return m, nil
+			}
+			if m.compareHead != "" {
+				m.compareHead = ""
+				return m, nil
+			}
+			newViewContext(&m)
+			if m.activePane == branchPane && m.currentView == pipelineStepLogView && m.commandsStep.UUID != "" {
+				m.currentView = stepCommandsView
+				m.logHOffset = 0
+				clearLogSearch(&m)
+			} else if m.activePane == branchPane && m.currentView == stepCommandsView {
+				closeStepCommands(&m)
+			} else if m.activePane == branchPane && m.currentView == artifactsView {
+				closeArtifacts(&m)
+			} else if m.activePane == branchPane && m.currentView == schedulesView {
+				closeSchedules(&m)
+			} else if m.activePane == branchPane && m.currentView == pipelineStepLogView {
 				m.currentView = pipelineStepsView
+				m.followStepUUID = ""
 				m.pipelineStepLog = ""
 				m.pipelineStepLogLines = nil
+				m.logColorLines = nil
+				m.logMarks = nil
 				m.pipelineStepLogCursor = 0
+				m.logHOffset = 0
+				clearLogSearch(&m)
 			} else if m.activePane == branchPane && m.currentView == prCommitsView {
 				m.currentView = prView
+				if m.openPullRequest.ID == m.selectedPullRequestID {
+					m.currentView = prDetailView
+				}
 				m.prCommits = nil
 				m.prCommitCursor = 0
 				m.prCommitChanges = nil
 				m.prCommitDiff = ""
 				m.selectedCommitHash = ""
+				m.diffHOffset = 0
+				m.diffHunkCursor = 0
+			} else if m.activePane == branchPane && (m.currentView == branchCommitsView || m.currentView == branchCompareView) {
+				closeBranchCommits(&m)
 			} else if m.activePane == branchPane && m.currentView == pipelineStepsView {
 				m.currentView = pipelinesView
 				m.pipelineStepCursor = 0
 				m.pipelineSteps = nil
+				if m.pipelines == nil {
+					// The steps were opened from another repository's item.
+					return m, openTab(&m, tabIndex(pipelinesView))
+				}
+			} else if m.activePane == branchPane && m.currentView == prDiffView {
+				closePullRequestDiff(&m)
+			} else if m.activePane == branchPane && m.currentView == prCommentsView {
+				m.currentView = prDetailView
+				m.prComments = nil
+				m.prCommentsOffset = 0
+			} else if m.activePane == branchPane && m.currentView == prDetailView {
+				m.currentView = prView
+				m.openPullRequest = domain.PullRequest{}
+				m.prDetailOffset = 0
+				m.prActivity = nil
+				m.prTasks = nil
+				m.prTaskCursor = 0
+			} else if m.activePane == branchPane && m.currentView == issueDetailView {
+				m.currentView = issuesView
+				m.openIssue = domain.Issue{}
+				m.issueComments = nil
+				m.issueDetailOffset = 0
 			} else if m.activePane == branchPane {
+				if current := tabIndex(m.currentView); current >= 0 {
+					saveTabState(&m, current)
+				}
 				m.activePane = repoPane
 				m.currentView = noSelection
+			} else if m.project.Key != "" {
+				leaveProject(&m)
 			}
 
-		case "/":
-			if m.currentView != prCommitsView && m.currentView != pipelineStepsView && m.currentView != pipelineStepLogView {
-				m.filterMode = true
+		case key.Matches(msg, m.keys.Jump):
+			if canJumpToNumber(m) {
+				startJump(&m, "")
+			}
+
+		case !m.filterMode && canJumpToNumber(m) && isDigitKey(msg.String()):
+			// Lists of numbered items take digits as the start of a number
+			// to jump to; alt+digit switches tabs there.
+			running := selectedRunningPipelineUUID(m)
+			startJump(&m, msg.String())
+			if selected := selectedRunningPipelineUUID(m); selected != "" && selected != running {
+				return m, pollPipelineUpdates(m.client)
 			}
 
-		case "enter":
+		case key.Matches(msg, m.keys.Tabs):
+			keys := msg.String()
+			index := int(keys[len(keys)-1] - '1')
 			if !m.filterMode && m.activePane == repoPane && len(m.getFilteredRepos()) > 0 {
-				m.currentView = prView
+				return m, openTabForSelectedRepo(&m, index)
+			}
+			if !m.filterMode && m.activePane == branchPane && m.selectedRepoSlug != "" {
+				return m, openTab(&m, index)
+			}
+
+		case key.Matches(msg, m.keys.Home):
+			return m, openHome(&m)
+
+		case key.Matches(msg, m.keys.Inspector):
+			toggleInspector(&m)
+
+		case key.Matches(msg, m.keys.Feed):
+			if m.currentView == feedView {
+				m.activePane = repoPane
+				m.currentView = noSelection
+			} else {
+				return m, openFeed(&m)
+			}
+
+		case key.Matches(msg, m.keys.MyPRs):
+			if m.currentView == myPRsView {
+				m.activePane = repoPane
+				m.currentView = noSelection
+			} else {
+				return m, openMyPRs(&m)
+			}
+
+		case key.Matches(msg, m.keys.Projects):
+			if m.currentView == projectsView {
+				m.activePane = repoPane
+				m.currentView = noSelection
+			} else {
+				return m, openProjects(&m)
+			}
+
+		case key.Matches(msg, m.keys.Watch):
+			if m.currentView == watchView {
+				m.activePane = repoPane
+				m.currentView = noSelection
+			} else {
 				m.activePane = branchPane
-				m.loading = true
-				m.pullRequests = nil
-				m.prFilterQuery = ""
-				m.prCursor = 0
-				repos := m.getFilteredRepos()
-				repo := repos[m.repoCursor]
-				m.selectedRepo = repo.Name
-				m.selectedRepoSlug = repo.Slug
-				return m, loadPullRequests(m.client, repo.Slug)
+				m.currentView = watchView
+				m.watchCursor = 0
+				return m, refreshWatchList(m)
+			}
+
+		case key.Matches(msg, m.keys.Pin):
+			if m.activePane == branchPane && m.currentView == watchView {
+				if m.watchCursor < len(m.watchItems) {
+					return m, toggleWatch(&m, m.watchItems[m.watchCursor])
+				}
+			} else if item, ok := m.selectedWatchItem(); ok {
+				return m, toggleWatch(&m, item)
+			}
+
+		case key.Matches(msg, m.keys.GotoLine):
+			if canJumpToLine(m) {
+				startJump(&m, "")
+			}
+
+		case key.Matches(msg, m.keys.LineNumbers):
+			if !m.filterMode && m.activePane == branchPane && m.currentView == pipelineStepLogView {
+				m.logLineNumbers = !m.logLineNumbers
+			}
+
+		case key.Matches(msg, m.keys.LogColors):
+			if !m.filterMode && m.activePane == branchPane && m.currentView == pipelineStepLogView {
+				m.logPlain = !m.logPlain
+			}
+
+		case key.Matches(msg, m.keys.Filter) && m.currentView == pipelineStepLogView:
+			if m.activePane == branchPane && len(m.pipelineStepLogLines) > 0 {
+				startLogSearch(&m)
+			}
+
+		case key.Matches(msg, m.keys.Filter):
+			if !m.showsCommitList() && m.currentView != pipelineStepsView && m.currentView != pipelineStepLogView && m.currentView != stepCommandsView && m.currentView != artifactsView && m.currentView != schedulesView && m.currentView != deploymentsView && m.currentView != issueDetailView && m.currentView != prDetailView && m.currentView != prDiffView && m.currentView != prCommentsView && m.currentView != feedView && m.currentView != staleApprovalsView && m.currentView != projectsView && m.currentView != myPRsView {
+				m.filterMode = true
+			}
+
+		case key.Matches(msg, m.keys.Select):
+			if !m.filterMode && m.activePane == repoPane && len(m.getFilteredRepos()) > 0 {
+				return m, openTabForSelectedRepo(&m, tabIndex(prView))
+			}
+			if !m.filterMode && m.activePane == branchPane && m.currentView == watchView && len(m.watchItems) > 0 {
+				return m, openWatchedItem(&m)
+			}
+			if !m.filterMode && m.activePane == branchPane && m.currentView == homeView {
+				return m, openHomeEntry(&m)
+			}
+			if !m.filterMode && m.activePane == branchPane && m.currentView == feedView {
+				return m, openFeedEvent(&m)
+			}
+			if !m.filterMode && m.activePane == branchPane && m.currentView == staleApprovalsView {
+				return m, openStaleApproval(&m)
+			}
+			if !m.filterMode && m.activePane == branchPane && m.currentView == myPRsView {
+				return m, openMyPR(&m)
+			}
+			if !m.filterMode && m.activePane == branchPane && m.currentView == projectsView {
+				return m, openProject(&m)
+			}
+			if !m.filterMode && m.activePane == branchPane && m.currentView == branchesView {
+				return m, openBranchCommits(&m)
+			}
+			if !m.filterMode && m.activePane == branchPane && m.currentView == issuesView {
+				return m, openIssueDetail(&m)
+			}
+			if !m.filterMode && m.activePane == branchPane && m.currentView == downloadsView {
+				return m, downloadSelected(&m)
 			}
 			if !m.filterMode && m.activePane == branchPane && m.currentView == pipelinesView && len(m.getFilteredPipelines()) > 0 {
 				filtered := m.getFilteredPipelines()
@@ -684,13 +2068,7 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.message = "Selected pipeline has no UUID"
 					return m, nil
 				}
-				m.selectedPipelineRef = fmt.Sprintf("#%d", selectedPipeline.BuildNumber)
-				m.selectedPipelineUUID = selectedPipeline.UUID
-				m.currentView = pipelineStepsView
-				m.loading = true
-				m.pipelineSteps = nil
-				m.pipelineStepCursor = 0
-				return m, loadPipelineSteps(m.client, m.selectedRepoSlug, selectedPipeline.UUID)
+				return m, openPipelineSteps(&m, m.selectedRepoSlug, selectedPipeline)
 			}
 			if !m.filterMode && m.activePane == branchPane && m.currentView == pipelineStepsView && len(m.pipelineSteps) > 0 && m.selectedPipelineUUID != "" {
 				selectedStep := m.pipelineSteps[m.pipelineStepCursor]
@@ -703,99 +2081,48 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.selectedStepName = selectedStep.UUID
 				}
 				m.currentView = pipelineStepLogView
+				ctx := newViewContext(&m)
 				m.loading = true
 				m.pipelineStepLog = ""
 				m.pipelineStepLogLines = nil
+				m.logColorLines = nil
+				m.logMarks = nil
 				m.pipelineStepLogCursor = 0
-				return m, loadPipelineStepLog(m.client, m.selectedRepoSlug, m.selectedPipelineUUID, selectedStep.UUID)
+				m.followStepUUID = ""
+				clearLogSearch(&m)
+				if !isStepDone(selectedStep) {
+					m.followStepUUID = selectedStep.UUID
+					return m, fetchFollowedLog(&m)
+				}
+				return m, forView(&m, loadPipelineStepLog(ctx, m.client, m.selectedRepoSlug, m.selectedPipelineUUID, selectedStep.UUID))
+			}
+			if !m.filterMode && m.activePane == branchPane && m.currentView == stepCommandsView && !m.loading {
+				openCommandLog(&m)
+				return m, nil
 			}
 			if !m.filterMode && m.activePane == branchPane && m.currentView == prView && len(m.getFilteredPRs()) > 0 {
-				filtered := m.getFilteredPRs()
-				selectedPR := filtered[m.prCursor]
-				m.selectedPullRequestID = selectedPR.ID
-				m.selectedPullRequest = selectedPR.Title
-				m.prCommitChangesCache = make(map[string][]domain.CommitChange)
-				m.prCommitDiffCache = make(map[string]string)
-				m.currentView = prCommitsView
-				m.loading = true
-				m.prCommits = nil
-				m.prCommitCursor = 0
-				m.prCommitChanges = nil
-				m.prCommitDiff = ""
-				m.selectedCommitHash = ""
-				return m, loadPullRequestCommits(m.client, m.selectedRepoSlug, selectedPR.ID)
-			}
-
-		case "h":
-			if !m.filterMode && m.activePane == branchPane && m.selectedRepoSlug != "" && m.currentView != prCommitsView && m.currentView != pipelineStepsView && m.currentView != pipelineStepLogView {
-				switch m.currentView {
-				case branchesView:
-					m.currentView = prView
-					m.loading = true
-					m.pullRequests = nil
-					m.prFilterQuery = ""
-					m.prCursor = 0
-					return m, loadPullRequests(m.client, m.selectedRepoSlug)
-				case prView:
-					m.currentView = pipelinesView
-					m.loading = true
-					m.pipelines = nil
-					m.pipelineFilterQuery = ""
-					m.pipelineCursor = 0
-					return m, loadPipelines(m.client, m.selectedRepoSlug)
-				case pipelinesView:
-					m.currentView = branchesView
-					m.loading = true
-					m.branches = nil
-					m.branchFilterQuery = ""
-					m.branchCursor = 0
-					return m, loadBranches(m.client, m.selectedRepoSlug)
-				}
+				return m, openPullRequestDetail(&m)
+			}
+			if m.activePane == branchPane && m.currentView == prDetailView {
+				return m, openPullRequestCommits(&m, m.openPullRequest)
 			}
 
-		case "l":
-			if !m.filterMode && m.activePane == branchPane && m.selectedRepoSlug != "" && m.currentView != prCommitsView && m.currentView != pipelineStepsView && m.currentView != pipelineStepLogView {
-				switch m.currentView {
-				case prView:
-					m.currentView = branchesView
-					m.loading = true
-					m.branches = nil
-					m.branchFilterQuery = ""
-					m.branchCursor = 0
-					return m, loadBranches(m.client, m.selectedRepoSlug)
-				case branchesView:
-					m.currentView = pipelinesView
-					m.loading = true
-					m.pipelines = nil
-					m.pipelineFilterQuery = ""
-					m.pipelineCursor = 0
-					return m, loadPipelines(m.client, m.selectedRepoSlug)
-				case pipelinesView:
-					m.currentView = prView
-					m.loading = true
-					m.pullRequests = nil
-					m.prFilterQuery = ""
-					m.prCursor = 0
-					return m, loadPullRequests(m.client, m.selectedRepoSlug)
-				}
+		case key.Matches(msg, m.keys.PrevTab):
+			if !m.filterMode && m.activePane == branchPane && m.selectedRepoSlug != "" && isTabRoot(m.currentView) {
+				return m, cycleTab(&m, -1)
 			}
 
-		case "b":
+		case key.Matches(msg, m.keys.NextTab):
+			if !m.filterMode && m.activePane == branchPane && m.selectedRepoSlug != "" && isTabRoot(m.currentView) {
+				return m, cycleTab(&m, 1)
+			}
+
+		case key.Matches(msg, m.keys.Branches):
 			if !m.filterMode && m.activePane == repoPane && len(m.getFilteredRepos()) > 0 {
-				m.currentView = branchesView
-				m.activePane = branchPane
-				m.loading = true
-				m.branches = nil
-				m.branchFilterQuery = ""
-				m.branchCursor = 0
-				repos := m.getFilteredRepos()
-				repo := repos[m.repoCursor]
-				m.selectedRepo = repo.Name
-				m.selectedRepoSlug = repo.Slug
-				return m, loadBranches(m.client, repo.Slug)
+				return m, openTabForSelectedRepo(&m, tabIndex(branchesView))
 			}
 
-		case "j", "down":
+		case key.Matches(msg, m.keys.Down):
 			if !m.filterMode {
 				cursorChanged := false
 				if m.activePane == repoPane {
@@ -817,7 +2144,7 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							m.prCursor++
 							cursorChanged = true
 						}
-					} else if m.currentView == prCommitsView {
+					} else if m.showsCommitList() {
 						if m.prCommitCursor < len(m.prCommits)-1 {
 							m.prCommitCursor++
 							cursorChanged = true
@@ -838,20 +2165,101 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							m.pipelineStepLogCursor++
 							cursorChanged = true
 						}
+					} else if m.currentView == stepCommandsView {
+						if m.commandCursor < len(m.commandsStep.Commands)-1 {
+							m.commandCursor++
+							cursorChanged = true
+						}
+					} else if m.currentView == artifactsView {
+						if m.artifactCursor < len(m.artifacts)-1 {
+							m.artifactCursor++
+							cursorChanged = true
+						}
+					} else if m.currentView == schedulesView {
+						if m.scheduleCursor < len(m.schedules)-1 {
+							m.scheduleCursor++
+							cursorChanged = true
+						}
+					} else if m.currentView == deploymentsView {
+						if m.environmentCursor < len(m.environments)-1 {
+							m.environmentCursor++
+							cursorChanged = true
+						}
+					} else if m.currentView == inspectorView {
+						if m.inspectorCursor < len(m.client.RecentRequests())-1 {
+							m.inspectorCursor++
+						}
+					} else if m.currentView == watchView {
+						if m.watchCursor < len(m.watchItems)-1 {
+							m.watchCursor++
+							cursorChanged = true
+						}
+					} else if m.currentView == homeView {
+						if m.homeCursor < len(m.homeEntries())-1 {
+							m.homeCursor++
+							cursorChanged = true
+						}
+					} else if m.currentView == feedView {
+						if m.feedCursor < len(m.feedEvents())-1 {
+							m.feedCursor++
+						}
+					} else if m.currentView == staleApprovalsView {
+						if m.staleApprovalCursor < len(m.staleApprovals)-1 {
+							m.staleApprovalCursor++
+						}
+					} else if m.currentView == myPRsView {
+						if m.myPRsCursor < len(m.myPRsEntries())-1 {
+							m.myPRsCursor++
+						}
+					} else if m.currentView == projectsView {
+						if m.projectCursor < len(m.projects)-1 {
+							m.projectCursor++
+						}
+					} else if m.currentView == issuesView {
+						if m.issueCursor < len(m.getFilteredIssues())-1 {
+							m.issueCursor++
+							cursorChanged = true
+						}
+					} else if m.currentView == tagsView {
+						if m.tagCursor < len(m.getFilteredTags())-1 {
+							m.tagCursor++
+							cursorChanged = true
+						}
+					} else if m.currentView == downloadsView {
+						if m.downloadCursor < len(m.getFilteredDownloads())-1 {
+							m.downloadCursor++
+						}
+					} else if m.currentView == issueDetailView {
+						if m.issueDetailOffset < m.maxIssueDetailOffset() {
+							m.issueDetailOffset++
+						}
+					} else if m.currentView == prDiffView {
+						moveDiffCursor(&m, 1)
+					} else if m.currentView == prCommentsView {
+						if m.prCommentsOffset < m.maxPRCommentsOffset() {
+							m.prCommentsOffset++
+						}
+					} else if m.currentView == prDetailView {
+						if m.prDetailOffset < m.maxPRDetailOffset() {
+							m.prDetailOffset++
+						}
 					}
 				}
 
+				if cursorChanged && (m.activePane == repoPane || m.currentView == prView) {
+					return m, schedulePrefetch(&m)
+				}
 				if cursorChanged && m.activePane == branchPane && m.currentView == pipelinesView && selectedRunningPipelineUUID(m) != "" {
-					return m, pollPipelineUpdates()
+					return m, pollPipelineUpdates(m.client)
 				}
-				if cursorChanged && m.activePane == branchPane && m.currentView == prCommitsView {
+				if cursorChanged && m.activePane == branchPane && m.showsCommitList() {
 					if cmd := updateSelectedCommitDetails(&m); cmd != nil {
 						return m, cmd
 					}
 				}
 			}
 
-		case "k", "up":
+		case key.Matches(msg, m.keys.Up):
 			if !m.filterMode {
 				cursorChanged := false
 				if m.activePane == repoPane {
@@ -870,7 +2278,7 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							m.prCursor--
 							cursorChanged = true
 						}
-					} else if m.currentView == prCommitsView {
+					} else if m.showsCommitList() {
 						if m.prCommitCursor > 0 {
 							m.prCommitCursor--
 							cursorChanged = true
@@ -890,79 +2298,312 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							m.pipelineStepLogCursor--
 							cursorChanged = true
 						}
+					} else if m.currentView == stepCommandsView {
+						if m.commandCursor > 0 {
+							m.commandCursor--
+							cursorChanged = true
+						}
+					} else if m.currentView == artifactsView {
+						if m.artifactCursor > 0 {
+							m.artifactCursor--
+							cursorChanged = true
+						}
+					} else if m.currentView == schedulesView {
+						if m.scheduleCursor > 0 {
+							m.scheduleCursor--
+							cursorChanged = true
+						}
+					} else if m.currentView == deploymentsView {
+						if m.environmentCursor > 0 {
+							m.environmentCursor--
+							cursorChanged = true
+						}
+					} else if m.currentView == inspectorView {
+						if m.inspectorCursor > 0 {
+							m.inspectorCursor--
+						}
+					} else if m.currentView == watchView {
+						if m.watchCursor > 0 {
+							m.watchCursor--
+							cursorChanged = true
+						}
+					} else if m.currentView == homeView {
+						if m.homeCursor > 0 {
+							m.homeCursor--
+							cursorChanged = true
+						}
+					} else if m.currentView == feedView {
+						if m.feedCursor > 0 {
+							m.feedCursor--
+						}
+					} else if m.currentView == staleApprovalsView {
+						if m.staleApprovalCursor > 0 {
+							m.staleApprovalCursor--
+						}
+					} else if m.currentView == myPRsView {
+						if m.myPRsCursor > 0 {
+							m.myPRsCursor--
+						}
+					} else if m.currentView == projectsView {
+						if m.projectCursor > 0 {
+							m.projectCursor--
+						}
+					} else if m.currentView == issuesView {
+						if m.issueCursor > 0 {
+							m.issueCursor--
+							cursorChanged = true
+						}
+					} else if m.currentView == tagsView {
+						if m.tagCursor > 0 {
+							m.tagCursor--
+							cursorChanged = true
+						}
+					} else if m.currentView == downloadsView {
+						if m.downloadCursor > 0 {
+							m.downloadCursor--
+						}
+					} else if m.currentView == issueDetailView {
+						if m.issueDetailOffset > 0 {
+							m.issueDetailOffset--
+						}
+					} else if m.currentView == prDiffView {
+						moveDiffCursor(&m, -1)
+					} else if m.currentView == prCommentsView {
+						if m.prCommentsOffset > 0 {
+							m.prCommentsOffset--
+						}
+					} else if m.currentView == prDetailView {
+						if m.prDetailOffset > 0 {
+							m.prDetailOffset--
+						}
+					}
+				}
+
+				if cursorChanged && (m.activePane == repoPane || m.currentView == prView) {
+					return m, schedulePrefetch(&m)
+				}
+				if cursorChanged && m.activePane == branchPane && m.currentView == pipelinesView && selectedRunningPipelineUUID(m) != "" {
+					return m, pollPipelineUpdates(m.client)
+				}
+				if cursorChanged && m.activePane == branchPane && m.showsCommitList() {
+					if cmd := updateSelectedCommitDetails(&m); cmd != nil {
+						return m, cmd
+					}
+				}
+			}
+
+		case key.Matches(msg, m.keys.PullReqs):
+			if !m.filterMode && m.activePane == repoPane && len(m.getFilteredRepos()) > 0 {
+				return m, openTabForSelectedRepo(&m, tabIndex(prView))
+			}
+
+		case key.Matches(msg, m.keys.OpenBrowser):
+			if pr, ok := m.pullRequestUnderCursor(); ok && (m.currentView == prView || m.currentView == prDetailView) {
+				prURL := m.pullRequestURL(pr)
+				if prURL != "" {
+					return m, openURL(m.browserCommand, prURL)
+				}
+				m.message = "Selected PR has no URL"
+				return m, nil
+			}
+			if issue, ok := m.selectedIssue(); ok && !m.filterMode {
+				if issueURL := m.issueURL(issue); issueURL != "" {
+					return m, openURL(m.browserCommand, issueURL)
+				}
+			}
+			if stale, ok := m.selectedStaleApproval(); ok && !m.filterMode && stale.pr.URL != "" {
+				return m, openURL(m.browserCommand, stale.pr.URL)
+			}
+			if pr, ok := m.selectedMyPR(); ok && !m.filterMode && pr.URL != "" {
+				return m, openURL(m.browserCommand, pr.URL)
+			}
+			if !m.filterMode && m.activePane == branchPane && (m.currentView == downloadsView || m.currentView == deploymentsView) {
+				return m, openURL(m.browserCommand, m.selectedItemURL())
+			}
+			if !m.filterMode && m.activePane == branchPane && m.currentView == projectsView && m.projectCursor < len(m.projects) {
+				return m, openURL(m.browserCommand, m.projectURL(m.projects[m.projectCursor]))
+			}
+			if !m.filterMode && m.activePane == branchPane && m.currentView == feedView {
+				if events := m.feedEvents(); m.feedCursor < len(events) {
+					if eventURL := m.feedEventURL(events[m.feedCursor]); eventURL != "" {
+						return m, openURL(m.browserCommand, eventURL)
 					}
 				}
+			}
 
-				if cursorChanged && m.activePane == branchPane && m.currentView == pipelinesView && selectedRunningPipelineUUID(m) != "" {
-					return m, pollPipelineUpdates()
-				}
-				if cursorChanged && m.activePane == branchPane && m.currentView == prCommitsView {
-					if cmd := updateSelectedCommitDetails(&m); cmd != nil {
-						return m, cmd
-					}
-				}
+		case key.Matches(msg, m.keys.DeleteTag) && m.currentView == tagsView:
+			if !m.filterMode && m.activePane == branchPane && m.selectedRepoSlug != "" {
+				confirmDeleteTag(&m)
 			}
 
-		case "p":
-			if !m.filterMode && m.activePane == repoPane && len(m.getFilteredRepos()) > 0 {
-				m.currentView = prView
-				m.activePane = branchPane
-				m.loading = true
-				m.pullRequests = nil
-				m.prFilterQuery = ""
-				m.prCursor = 0
-				repos := m.getFilteredRepos()
-				repo := repos[m.repoCursor]
-				m.selectedRepo = repo.Name
-				m.selectedRepoSlug = repo.Slug
-				return m, loadPullRequests(m.client, repo.Slug)
+		case key.Matches(msg, m.keys.DeleteBranch) && m.currentView == branchesView:
+			if !m.filterMode && m.activePane == branchPane && m.selectedRepoSlug != "" {
+				confirmDeleteBranch(&m)
 			}
 
-		case "o":
-			if !m.filterMode && m.activePane == branchPane && m.currentView == prView && len(m.getFilteredPRs()) > 0 {
-				filtered := m.getFilteredPRs()
-				selectedPR := filtered[m.prCursor]
-				prURL := strings.TrimSpace(selectedPR.URL)
-				if !strings.HasPrefix(prURL, "https://") && !strings.HasPrefix(prURL, "http://") {
-					prURL = ""
-				}
-				if prURL == "" && selectedPR.ID > 0 && m.workspace != "" && m.selectedRepoSlug != "" {
-					prURL = fmt.Sprintf("https://bitbucket.org/%s/%s/pull-requests/%d", m.workspace, m.selectedRepoSlug, selectedPR.ID)
-				}
-				if prURL != "" {
-					return m, openURL(prURL)
-				}
-				m.message = "Selected PR has no URL"
-				return m, nil
+		case key.Matches(msg, m.keys.DeleteSchedule) && m.currentView == schedulesView:
+			if !m.filterMode && m.activePane == branchPane && !m.loading {
+				confirmDeleteSchedule(&m)
 			}
 
-		case "d":
-			if !m.filterMode && m.activePane == branchPane && m.currentView == prView && len(m.getFilteredPRs()) > 0 {
-				selectedPR := m.getFilteredPRs()[m.prCursor]
-				if selectedPR.ID <= 0 || strings.TrimSpace(m.selectedRepoSlug) == "" {
-					m.message = "Unable to load PR diff for selected PR"
-					return m, nil
+		case key.Matches(msg, m.keys.Download) && m.currentView == artifactsView:
+			if !m.filterMode && m.activePane == branchPane && !m.loading {
+				return m, downloadArtifact(&m)
+			}
+
+		case key.Matches(msg, m.keys.Diff):
+			if !m.filterMode && m.activePane == branchPane && (m.currentView == prView || m.currentView == prDetailView) && m.selectedRepoSlug != "" {
+				return m, openPullRequestDiff(&m)
+			}
+
+		case key.Matches(msg, m.keys.AllBranches) && m.currentView == pipelinesView:
+			if !m.filterMode && m.activePane == branchPane {
+				return m, toggleAllPipelineBranches(&m)
+			}
+
+		case key.Matches(msg, m.keys.Approve):
+			if selectedPR, ok := m.pullRequestUnderCursor(); ok && (m.currentView == prView || m.currentView == prDetailView) {
+				return m, startAction(&m, approvePullRequest(m.ctx, m.client, m.selectedRepoSlug, selectedPR.ID))
+			}
+
+		case key.Matches(msg, m.keys.Unapprove):
+			if selectedPR, ok := m.pullRequestUnderCursor(); ok && (m.currentView == prView || m.currentView == prDetailView) {
+				return m, startAction(&m, unapprovePullRequest(m.ctx, m.client, m.selectedRepoSlug, selectedPR.ID))
+			}
+
+		case key.Matches(msg, m.keys.NextHunk), key.Matches(msg, m.keys.PrevHunk):
+			delta := 1
+			if key.Matches(msg, m.keys.PrevHunk) {
+				delta = -1
+			}
+			if m.activePane == branchPane && m.showsCommitList() {
+				moveDiffHunk(&m, delta)
+			} else if !m.filterMode && m.activePane == branchPane && m.currentView == prDiffView {
+				moveToPullRequestDiffHunk(&m, delta)
+			} else if !m.filterMode && m.activePane == branchPane && m.currentView == prDetailView {
+				moveTaskCursor(&m, delta)
+			} else if !m.filterMode && m.activePane == branchPane && m.currentView == prCommentsView {
+				moveCommentCursor(&m, delta)
+			} else if !m.filterMode && m.activePane == branchPane && m.currentView == pipelineStepLogView {
+				moveLogMark(&m, delta)
+			}
+
+		case key.Matches(msg, m.keys.EditFile):
+			if m.activePane == branchPane && m.showsCommitList() {
+				return m, openDiffHunkInEditor(&m)
+			}
+
+		case key.Matches(msg, m.keys.Export):
+			if !m.filterMode {
+				return m, exportView(&m)
+			}
+
+		case key.Matches(msg, m.keys.Assign):
+			if !m.filterMode {
+				return m, toggleIssueAssignee(&m)
+			}
+
+		case key.Matches(msg, m.keys.NextMatch) && m.currentView == pipelineStepLogView, key.Matches(msg, m.keys.PrevMatch) && m.currentView == pipelineStepLogView:
+			if m.activePane == branchPane {
+				delta := 1
+				if key.Matches(msg, m.keys.PrevMatch) {
+					delta = -1
 				}
+				moveLogMatch(&m, delta)
+			}
 
-				m.loading = true
-				m.message = fmt.Sprintf("Loading PR #%d diff...", selectedPR.ID)
-				return m, loadPullRequestDiff(m.client, m.selectedRepoSlug, selectedPR.ID)
+		case key.Matches(msg, m.keys.NewSchedule) && m.currentView == schedulesView:
+			if !m.filterMode && m.activePane == branchPane && m.selectedRepoSlug != "" {
+				startNewSchedule(&m)
 			}
 
-		case "a":
-			if !m.filterMode && m.activePane == branchPane && m.currentView == prView && len(m.getFilteredPRs()) > 0 {
-				selectedPR := m.getFilteredPRs()[m.prCursor]
-				return m, approvePullRequest(m.client, m.selectedRepoSlug, selectedPR.ID)
+		case key.Matches(msg, m.keys.NewTag) && m.currentView == tagsView:
+			if !m.filterMode && m.activePane == branchPane && m.selectedRepoSlug != "" {
+				startNewTag(&m)
 			}
 
-		case "u":
-			if !m.filterMode && m.activePane == branchPane && m.currentView == prView && len(m.getFilteredPRs()) > 0 {
-				selectedPR := m.getFilteredPRs()[m.prCursor]
-				return m, unapprovePullRequest(m.client, m.selectedRepoSlug, selectedPR.ID)
+		case key.Matches(msg, m.keys.NewBranch) && m.currentView == branchesView:
+			if !m.filterMode && m.activePane == branchPane && m.selectedRepoSlug != "" {
+				startNewBranch(&m)
+			}
+
+		case key.Matches(msg, m.keys.NewIssue):
+			if !m.filterMode && m.activePane == branchPane && (m.currentView == issuesView || m.currentView == issueDetailView) {
+				return m, newIssue(&m)
+			}
+
+		case key.Matches(msg, m.keys.Merge):
+			if !m.filterMode && m.activePane == branchPane && (m.currentView == prView || m.currentView == prDetailView) {
+				confirmMerge(&m)
+			}
+
+		case key.Matches(msg, m.keys.Decline):
+			if !m.filterMode && m.activePane == branchPane && (m.currentView == prView || m.currentView == prDetailView || m.currentView == feedView) {
+				confirmStateChange(&m, "decline")
+			}
+
+		case key.Matches(msg, m.keys.Reopen):
+			if !m.filterMode && m.activePane == branchPane && (m.currentView == prView || m.currentView == prDetailView || m.currentView == feedView) {
+				confirmStateChange(&m, "reopen")
+			}
+
+		case key.Matches(msg, m.keys.StepCommands) && m.currentView == pipelineStepsView:
+			if !m.filterMode && m.activePane == branchPane && len(m.pipelineSteps) > 0 && m.selectedPipelineUUID != "" {
+				return m, openStepCommands(&m)
+			}
+
+		case key.Matches(msg, m.keys.Artifacts) && m.currentView == pipelineStepsView:
+			if !m.filterMode && m.activePane == branchPane && len(m.pipelineSteps) > 0 && m.selectedPipelineUUID != "" {
+				return m, openArtifacts(&m)
+			}
+
+		case key.Matches(msg, m.keys.LineComment) && m.currentView == prDiffView:
+			if !m.filterMode && m.activePane == branchPane && !m.loading {
+				startInlineComment(&m)
+			}
+
+		case key.Matches(msg, m.keys.Compare):
+			if !m.filterMode && m.activePane == branchPane && m.currentView == branchesView {
+				return m, pickCompareBranch(&m)
+			}
+
+		case key.Matches(msg, m.keys.NewPullRequest):
+			if !m.filterMode && m.activePane == branchPane && m.currentView == branchesView {
+				return m, newPullRequest(&m)
+			}
+
+		case key.Matches(msg, m.keys.Upload):
+			if !m.filterMode && m.activePane == branchPane && m.currentView == downloadsView {
+				startUpload(&m)
+			}
+
+		case key.Matches(msg, m.keys.Rerun) && m.currentView == deploymentsView:
+			if !m.filterMode && m.activePane == branchPane {
+				return m, redeploy(&m)
+			}
+
+		case key.Matches(msg, m.keys.Rerun):
+			if !m.filterMode && m.activePane == branchPane && m.currentView == pipelinesView {
+				confirmRerun(&m)
+			}
+
+		case key.Matches(msg, m.keys.Revert):
+			if !m.filterMode && m.activePane == branchPane && (m.currentView == prView || m.currentView == feedView) {
+				confirmRevert(&m)
+			}
+
+		case key.Matches(msg, m.keys.ToggleSchedule) && m.currentView == schedulesView:
+			if !m.filterMode && m.activePane == branchPane && !m.loading {
+				return m, toggleSchedule(&m)
+			}
+
+		case key.Matches(msg, m.keys.Worktree):
+			if m.activePane == branchPane && m.currentView == prView {
+				return m, reviewInWorktree(&m)
 			}
 
-		case "v":
-			if !m.filterMode && m.activePane == branchPane && m.currentView == prCommitsView {
+		case key.Matches(msg, m.keys.View):
+			if !m.filterMode && m.activePane == branchPane && m.showsCommitList() {
 				if m.selectedCommitHash == "" {
 					m.message = "Select a commit first"
 					return m, nil
@@ -982,57 +2623,275 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if len(ref) > 12 {
 					ref = ref[:12]
 				}
-				return m, openLogInEditor(m.prCommitDiff, "commit-"+ref)
+				return m, openDiff(m.diffPager, m.viewer, m.prCommitDiff, "commit-"+ref)
+			}
+			if !m.filterMode && m.activePane == branchPane && m.currentView == prDiffView && len(m.prDiffLines) > 0 {
+				return m, openDiff(m.diffPager, m.viewer, strings.Join(m.prDiffLines, "\n")+"\n", fmt.Sprintf("pr-%d-diff", m.prDiffPR.ID))
 			}
 			if !m.filterMode && m.activePane == branchPane && m.currentView == pipelineStepLogView && !m.loading {
-				return m, openLogInEditor(m.pipelineStepLog, m.selectedStepName)
+				return m, openLogInEditor(m.viewer, m.pipelineStepLog, m.selectedStepName)
+			}
+
+		case key.Matches(msg, m.keys.Wrap):
+			if !m.filterMode && m.activePane == branchPane && m.currentView == pipelineStepLogView {
+				m.logWrap = !m.logWrap
+				m.logHOffset = 0
+			}
+
+		case key.Matches(msg, m.keys.Comments):
+			if !m.filterMode && m.activePane == branchPane && m.currentView == prDetailView {
+				return m, openPullRequestComments(&m)
+			}
+			if !m.filterMode && m.activePane == branchPane && m.currentView == prCommentsView && m.prComments != nil {
+				startComment(&m, m.openPullRequest, domain.CommentAnchor{})
+			}
+
+		case key.Matches(msg, m.keys.Task):
+			if !m.filterMode && m.activePane == branchPane && m.currentView == prDetailView && m.prTasks != nil {
+				return m, toggleSelectedTask(&m)
+			}
+			if !m.filterMode && m.activePane == branchPane && m.currentView == prCommentsView && m.prComments != nil {
+				return m, taskFromSelectedComment(&m)
 			}
 
-		case "r":
+		case key.Matches(msg, m.keys.Schedules) && m.currentView == pipelinesView:
 			if !m.filterMode && m.activePane == branchPane && m.selectedRepoSlug != "" {
-				switch m.currentView {
-				case branchesView:
-					m.loading = true
-					m.branches = nil
-					m.branchCursor = 0
-					return m, loadBranches(m.client, m.selectedRepoSlug)
-				case prView:
-					m.loading = true
-					m.pullRequests = nil
-					m.prCursor = 0
-					return m, loadPullRequests(m.client, m.selectedRepoSlug)
-				case prCommitsView:
-					if m.selectedPullRequestID > 0 {
-						m.loading = true
-						m.prCommits = nil
-						m.prCommitCursor = 0
-						m.prCommitChanges = nil
-						m.prCommitDiff = ""
-						m.selectedCommitHash = ""
-						m.prCommitChangesCache = make(map[string][]domain.CommitChange)
-						m.prCommitDiffCache = make(map[string]string)
-						return m, loadPullRequestCommits(m.client, m.selectedRepoSlug, m.selectedPullRequestID)
-					}
-				case pipelinesView:
-					m.loading = true
-					m.pipelines = nil
-					m.pipelineCursor = 0
-					return m, loadPipelines(m.client, m.selectedRepoSlug)
-				case pipelineStepsView:
-					if m.selectedPipelineUUID != "" {
-						m.loading = true
-						m.pipelineSteps = nil
-						m.pipelineStepCursor = 0
-						return m, loadPipelineSteps(m.client, m.selectedRepoSlug, m.selectedPipelineUUID)
-					}
+				return m, openSchedules(&m)
+			}
+
+		case key.Matches(msg, m.keys.PRState):
+			if !m.filterMode && m.activePane == branchPane && m.currentView == prView {
+				return m, cyclePullRequestState(&m)
+			}
+
+		case key.Matches(msg, m.keys.PageUp, m.keys.PageDown):
+			if !m.filterMode && m.activePane == branchPane && m.currentView == prDiffView {
+				_, _, visible := m.prDiffSize()
+				if key.Matches(msg, m.keys.PageUp) {
+					visible = -visible
+				}
+				moveDiffCursor(&m, visible)
+			}
+
+		case key.Matches(msg, m.keys.ScrollLeft, m.keys.ScrollRight):
+			if !m.filterMode && m.activePane == branchPane {
+				delta := horizontalScrollStep
+				if key.Matches(msg, m.keys.ScrollLeft) {
+					delta = -horizontalScrollStep
+				}
+				if m.currentView == pipelineStepLogView && !m.logWrap {
+					m.logHOffset = max(0, m.logHOffset+delta)
+				} else if m.showsCommitList() || m.currentView == prDiffView {
+					m.diffHOffset = max(0, m.diffHOffset+delta)
 				}
 			}
+
+		case key.Matches(msg, m.keys.Refresh):
+			if wait := m.client.RateLimit().Cooldown(); wait > 0 {
+				return m, queueRefresh(&m, wait)
+			}
+			return m, refreshView(&m)
+
+		case key.Matches(msg, m.keys.RefreshAll):
+			if wait := m.client.RateLimit().Cooldown(); wait > 0 {
+				return m, queueRefresh(&m, wait)
+			}
+			return m, refreshAll(&m)
 		}
 	}
 
 	return m, nil
 }
 
+// refreshView reloads the data of the current view, bypassing the response
+// cache.
+func refreshView(m *AppModel) tea.Cmd {
+	if !m.filterMode && m.activePane == branchPane && m.currentView == watchView {
+		return refreshWatchList(*m)
+	}
+	if !m.filterMode && m.activePane == branchPane && m.currentView == homeView {
+		if m.currentUser.UUID == "" {
+			return openHome(m)
+		}
+		refreshViewContext(m)
+		m.homeCursor = 0
+		return loadHome(m)
+	}
+	if !m.filterMode && m.activePane == branchPane && m.currentView == feedView {
+		return loadFeed(m, refreshViewContext(m))
+	}
+	if !m.filterMode && m.activePane == branchPane && m.currentView == projectsView {
+		m.projectsPending = true
+		return forView(m, loadProjects(refreshViewContext(m), m.client))
+	}
+	if !m.filterMode && m.activePane == repoPane && m.project.Key != "" {
+		m.projectRepositories = nil
+		return loadProjectRepositories(refreshViewContext(m), m.client, m.project)
+	}
+	if !m.filterMode && m.activePane == branchPane && m.currentView == staleApprovalsView {
+		if m.currentUser.UUID == "" {
+			return openStaleApprovals(m)
+		}
+		m.staleApprovalCursor = 0
+		return loadAllStaleApprovals(m, refreshViewContext(m))
+	}
+	if !m.filterMode && m.activePane == branchPane && m.currentView == myPRsView {
+		if m.currentUser.UUID == "" {
+			return openMyPRs(m)
+		}
+		return loadMyPRs(m, refreshViewContext(m))
+	}
+	if !m.filterMode && m.activePane == branchPane && m.selectedRepoSlug != "" {
+		switch m.currentView {
+		case branchesView:
+			ctx := refreshViewContext(m)
+			m.loading = true
+			m.branches = nil
+			m.branchCursor = 0
+			return forView(m, loadBranches(ctx, m.client, m.selectedRepoSlug))
+		case prView:
+			ctx := refreshViewContext(m)
+			m.loading = true
+			m.pullRequests = nil
+			m.prCursor = 0
+			m.commitStatuses = make(map[string][]domain.BuildStatus)
+			return forView(m, loadPullRequests(ctx, m.client, m.selectedRepoSlug, m.pullRequestState()))
+		case prCommitsView:
+			if m.selectedPullRequestID > 0 {
+				ctx := refreshViewContext(m)
+				m.loading = true
+				m.prCommits = nil
+				m.prCommitCursor = 0
+				m.prCommitChanges = nil
+				m.prCommitDiff = ""
+				m.selectedCommitHash = ""
+				m.prCommitChangesCache = make(map[string][]domain.CommitChange)
+				m.prCommitDiffCache = make(map[string]string)
+				return forView(m, loadPullRequestCommits(ctx, m.client, m.selectedRepoSlug, m.selectedPullRequestID))
+			}
+		case branchCommitsView:
+			return reloadBranchCommits(m, refreshViewContext(m))
+		case branchCompareView:
+			return reloadComparison(m, refreshViewContext(m))
+		case pipelinesView:
+			ctx := refreshViewContext(m)
+			m.loading = true
+			m.pipelines = nil
+			m.pipelineCursor = 0
+			return forView(m, loadPipelines(ctx, m.client, m.selectedRepoSlug))
+		case pipelineStepsView:
+			if m.selectedPipelineUUID != "" {
+				ctx := refreshViewContext(m)
+				m.loading = true
+				m.pipelineSteps = nil
+				m.pipelineStepCursor = 0
+				return forView(m, loadPipelineSteps(ctx, m.client, m.selectedRepoSlug, m.selectedPipelineUUID))
+			}
+		case stepCommandsView:
+			ctx := refreshViewContext(m)
+			m.loading = true
+			return forView(m, loadStepCommands(ctx, m.client, m.selectedRepoSlug, m.selectedPipelineUUID, m.commandsStep.UUID))
+		case schedulesView:
+			ctx := refreshViewContext(m)
+			m.loading = true
+			m.schedules = nil
+			m.scheduleCursor = 0
+			return forView(m, loadSchedules(ctx, m.client, m.selectedRepoSlug))
+		case artifactsView:
+			ctx := refreshViewContext(m)
+			m.loading = true
+			m.artifacts = nil
+			m.artifactCursor = 0
+			return forView(m, loadArtifacts(ctx, m.client, m.selectedRepoSlug, m.selectedPipelineUUID, m.artifactsStepUUID))
+		case issuesView:
+			ctx := refreshViewContext(m)
+			m.loading = true
+			m.issues = nil
+			m.issueCursor = 0
+			return forView(m, loadIssues(ctx, m.client, m.selectedRepoSlug))
+		case downloadsView:
+			ctx := refreshViewContext(m)
+			m.loading = true
+			m.downloads = nil
+			m.downloadCursor = 0
+			return forView(m, loadDownloads(ctx, m.client, m.selectedRepoSlug))
+		case tagsView:
+			ctx := refreshViewContext(m)
+			m.loading = true
+			m.tags = nil
+			m.tagCursor = 0
+			return forView(m, loadTags(ctx, m.client, m.selectedRepoSlug))
+		case deploymentsView:
+			ctx := refreshViewContext(m)
+			m.loading = true
+			m.environments = nil
+			m.environmentCursor = 0
+			return forView(m, loadDeployments(ctx, m.client, m.selectedRepoSlug))
+		case issueDetailView:
+			if m.openIssue.ID > 0 {
+				ctx := refreshViewContext(m)
+				m.loading = true
+				m.issueComments = nil
+				return forView(m, loadIssueComments(ctx, m.client, m.selectedRepoSlug, m.openIssue.ID))
+			}
+		case prDetailView:
+			if m.openPullRequest.ID > 0 {
+				return loadPullRequestDetail(m, true)
+			}
+		case prDiffView:
+			if m.prDiffPR.ID > 0 {
+				return loadOpenPullRequestDiff(m, refreshViewContext(m))
+			}
+		case prCommentsView:
+			if m.openPullRequest.ID > 0 {
+				return reloadPullRequestComments(m, refreshViewContext(m))
+			}
+		}
+	}
+	return nil
+}
+
+// refreshAll refreshes the current view like refreshView and reloads the
+// branches, pull requests and pipelines of the selected repository in
+// parallel, dropping what was prefetched or cached for it. Lists of other
+// tabs wait in the prefetch cache until their tab opens.
+func refreshAll(m *AppModel) tea.Cmd {
+	slug := m.selectedRepoSlug
+	if m.filterMode || slug == "" {
+		return refreshView(m)
+	}
+
+	cache := m.prefetch
+	delete(cache.branches, slug)
+	delete(cache.pullRequests, slug)
+	delete(cache.pipelines, slug)
+	for key := range cache.commits {
+		if strings.HasPrefix(key, slug+"#") {
+			delete(cache.commits, key)
+		}
+	}
+	clearMergeChecks(m, slug)
+	delete(m.mergeRules, slug)
+
+	current := m.activePane == branchPane && !m.filterMode
+	cmds := []tea.Cmd{refreshView(m)}
+	ctx := bitbucket.WithoutCache(m.ctx)
+	if !current || m.currentView != branchesView {
+		cache.inFlight["branches:"+slug] = true
+		cmds = append(cmds, prefetchBranches(ctx, m.client, slug))
+	}
+	if !current || m.currentView != prView {
+		cache.inFlight["prs:"+slug] = true
+		cmds = append(cmds, prefetchPullRequests(ctx, m.client, slug))
+	}
+	if !current || m.currentView != pipelinesView {
+		cache.inFlight["pipelines:"+slug] = true
+		cmds = append(cmds, prefetchPipelines(ctx, m.client, slug))
+	}
+	m.message = fmt.Sprintf("Refreshing branches, pull requests and pipelines of %s", m.selectedRepo)
+	return tea.Batch(cmds...)
+}
+
 func (m AppModel) View() string {
 	if m.width == 0 || m.height == 0 {
 		return "Loading..."
@@ -1041,7 +2900,16 @@ func (m AppModel) View() string {
 	showRepoPane := m.currentView == noSelection || m.activePane == repoPane
 
 	var content string
-	if showRepoPane {
+	if m.searchMode {
+		content = m.renderSearchOverlay()
+	} else if currentTheme.plain {
+		// One pane at a time, so the screen reads top to bottom.
+		pane := m.renderRightPane()
+		if showRepoPane {
+			pane = m.renderRepoPane()
+		}
+		content = lipgloss.JoinVertical(lipgloss.Left, m.announcement(), pane)
+	} else if showRepoPane {
 		leftPane := m.renderRepoPane()
 
 		var rightPane string
@@ -1060,26 +2928,28 @@ func (m AppModel) View() string {
 		content = m.renderRightPane()
 	}
 
-	helpText := "j/k/↑/↓: navigate  enter: select repo  /: filter  q: quit"
-	if m.currentView != noSelection && m.activePane == branchPane {
-		helpText = "h/l: switch tabs  esc: back  j/k/↑/↓: navigate  r: refresh  /: filter  q: quit"
-	}
-	if m.currentView == prView && m.activePane == branchPane {
-		helpText = "h/l: switch tabs  enter: view commits  a/u: approve/unapprove  esc: back  j/k/↑/↓: navigate  d: open diff o: open in browser  r: refresh  /: filter  q: quit"
-	}
-	if m.currentView == prCommitsView && m.activePane == branchPane {
-		helpText = "esc: back to PRs  j/k/↑/↓: select commit  v: open diff in nvim/less  r: refresh  q: quit"
-	}
-	if m.currentView == pipelinesView && m.activePane == branchPane {
-		helpText = "h/l: switch tabs  enter: view steps  esc: back  j/k/↑/↓: navigate  r: refresh  /: filter  q: quit"
-	}
-	if m.currentView == pipelineStepsView && m.activePane == branchPane {
-		helpText = "enter: view logs  esc: back to pipelines  j/k/↑/↓: navigate  r: refresh  q: quit"
-	}
-	if m.currentView == pipelineStepLogView && m.activePane == branchPane {
-		helpText = "v: open in nvim/less  esc: back to steps  j/k/↑/↓: scroll logs  q: quit"
-	}
-	if m.filterMode {
+	helpText := m.help.View(m.helpKeys())
+	if m.pendingChord != "" {
+		helpText = activePaneStyle.Render(m.keys.chordHelp(m.pendingChord))
+	} else if m.searchMode {
+		helpText = tr("↑/↓: select result  enter: jump  esc: close search")
+	} else if m.jumpMode {
+		helpText = activePaneStyle.Render(jumpPrompt(m))
+	} else if m.logSearchMode {
+		helpText = activePaneStyle.Render(logSearchPrompt(m))
+	} else if m.uploadMode {
+		helpText = activePaneStyle.Render(trf("Upload file: %s  (esc: cancel, enter: upload)", m.uploadPath))
+	} else if m.commentMode {
+		helpText = activePaneStyle.Render(commentPrompt(m))
+	} else if m.newBranchStep != "" {
+		helpText = activePaneStyle.Render(newBranchPrompt(m))
+	} else if m.deleteBranchCandidate != "" {
+		helpText = activePaneStyle.Render(deleteBranchPrompt(m))
+	} else if m.newTagStep != "" {
+		helpText = activePaneStyle.Render(newTagPrompt(m))
+	} else if m.newScheduleStep != "" {
+		helpText = activePaneStyle.Render(newSchedulePrompt(m))
+	} else if m.filterMode {
 		currentFilter := m.repoFilterQuery
 		if m.activePane == branchPane {
 			if m.currentView == branchesView {
@@ -1088,19 +2958,32 @@ func (m AppModel) View() string {
 				currentFilter = m.prFilterQuery
 			} else if m.currentView == pipelinesView {
 				currentFilter = m.pipelineFilterQuery
+			} else if m.currentView == issuesView {
+				currentFilter = m.issueFilterQuery
+			} else if m.currentView == downloadsView {
+				currentFilter = m.downloadFilterQuery
+			} else if m.currentView == tagsView {
+				currentFilter = m.tagFilterQuery
 			}
 		}
-		helpText = fmt.Sprintf("Filter: %s  (esc: cancel, enter: apply)", currentFilter)
+		helpText = trf("Filter: %s  (esc: cancel, enter: apply)", currentFilter)
 		helpText = activePaneStyle.Render(helpText)
+	} else if m.compareHead != "" && m.currentView == branchesView {
+		helpText = activePaneStyle.Render(comparePrompt(m))
 	} else if m.message != "" {
 		helpText = messageStyle.Render(m.message)
 	}
 
+	footer := helpStyle.Render(helpText)
+	if rateLimit := m.renderRateLimit(); rateLimit != "" {
+		footer = lipgloss.JoinHorizontal(lipgloss.Top, footer, "  ", rateLimit)
+	}
+
 	fullContent := lipgloss.JoinVertical(
 		lipgloss.Left,
 		content,
 		"",
-		helpStyle.Render(helpText),
+		footer,
 	)
 
 	return fullContent
@@ -1111,7 +2994,7 @@ func (m AppModel) renderRightPane() string {
 		return m.renderBranchPane()
 	} else if m.currentView == prView {
 		return m.renderPRPane()
-	} else if m.currentView == prCommitsView {
+	} else if m.showsCommitList() {
 		return m.renderPRCommitsPane()
 	} else if m.currentView == pipelinesView {
 		return m.renderPipelinePane()
@@ -1119,36 +3002,46 @@ func (m AppModel) renderRightPane() string {
 		return m.renderPipelineStepsPane()
 	} else if m.currentView == pipelineStepLogView {
 		return m.renderPipelineStepLogPane()
+	} else if m.currentView == stepCommandsView {
+		return m.renderStepCommandsPane()
+	} else if m.currentView == artifactsView {
+		return m.renderArtifactsPane()
+	} else if m.currentView == schedulesView {
+		return m.renderSchedulesPane()
+	} else if m.currentView == deploymentsView {
+		return m.renderDeploymentsPane()
+	} else if m.currentView == watchView {
+		return m.renderWatchPane()
+	} else if m.currentView == inspectorView {
+		return m.renderInspectorPane()
+	} else if m.currentView == homeView {
+		return m.renderHomePane()
+	} else if m.currentView == feedView {
+		return m.renderFeedPane()
+	} else if m.currentView == staleApprovalsView {
+		return m.renderStaleApprovalsPane()
+	} else if m.currentView == myPRsView {
+		return m.renderMyPRsPane()
+	} else if m.currentView == projectsView {
+		return m.renderProjectsPane()
+	} else if m.currentView == issuesView {
+		return m.renderIssuesPane()
+	} else if m.currentView == issueDetailView {
+		return m.renderIssueDetailPane()
+	} else if m.currentView == prDetailView {
+		return m.renderPRDetailPane()
+	} else if m.currentView == prDiffView {
+		return m.renderPRDiffPane()
+	} else if m.currentView == prCommentsView {
+		return m.renderPRCommentsPane()
+	} else if m.currentView == downloadsView {
+		return m.renderDownloadsPane()
+	} else if m.currentView == tagsView {
+		return m.renderTagsPane()
 	}
 	return ""
 }
 
-func (m AppModel) renderRightTabs() string {
-	baseTab := lipgloss.NewStyle().Padding(0, 2)
-
-	activeTab := baseTab.
-		Foreground(lipgloss.Color("0")).
-		Background(lipgloss.Color("42")).
-		Bold(true)
-
-	inactiveTab := baseTab.
-		Foreground(lipgloss.Color("241"))
-
-	prsTab := inactiveTab.Render("Pull Requests")
-	branchesTab := inactiveTab.Render("Branches")
-	pipelinesTab := inactiveTab.Render("Pipelines")
-
-	if m.currentView == prView || m.currentView == prCommitsView {
-		prsTab = activeTab.Render("Pull Requests")
-	} else if m.currentView == branchesView {
-		branchesTab = activeTab.Render("Branches")
-	} else if m.currentView == pipelinesView || m.currentView == pipelineStepsView || m.currentView == pipelineStepLogView {
-		pipelinesTab = activeTab.Render("Pipelines")
-	}
-
-	return lipgloss.JoinHorizontal(lipgloss.Top, prsTab, branchesTab, pipelinesTab)
-}
-
 func (m AppModel) renderRepoPane() string {
 	paneWidth := (m.width - 10) / 3
 	if paneWidth < 20 {
@@ -1160,9 +3053,15 @@ func (m AppModel) renderRepoPane() string {
 		availableHeight = 5
 	}
 
-	title := "Repositories"
+	title := tr("Repositories")
+	if m.project.Key != "" {
+		title = trf("%s (esc: all)", m.project.Name)
+	}
 	if m.repoFilterQuery != "" {
-		title = fmt.Sprintf("Repositories [/%s]", m.repoFilterQuery)
+		title = fmt.Sprintf("%s [/%s]", title, m.repoFilterQuery)
+	}
+	if label := cacheLabel(m.reposCachedAt, !m.loading); label != "" {
+		title = fmt.Sprintf("%s (%s)", title, label)
 	}
 	if m.activePane == repoPane {
 		title = activePaneStyle.Render(title)
@@ -1174,14 +3073,14 @@ func (m AppModel) renderRepoPane() string {
 	items = append(items, title)
 	items = append(items, "")
 
-	if m.loading && len(m.repositories) == 0 {
-		items = append(items, m.spinner.View()+" Loading...")
-	} else if len(m.repositories) == 0 {
-		items = append(items, "No repositories")
+	if (m.loading || m.project.Key != "") && m.scopedRepositories() == nil {
+		items = append(items, m.renderSkeletonRows(paneWidth, availableHeight-2)...)
+	} else if len(m.scopedRepositories()) == 0 {
+		items = append(items, tr("No repositories"))
 	} else {
 		filtered := m.getFilteredRepos()
 		if len(filtered) == 0 {
-			items = append(items, "No matches")
+			items = append(items, tr("No matches"))
 		} else {
 			start, end := m.calculateWindow(m.repoCursor, len(filtered), availableHeight-2)
 
@@ -1191,14 +3090,14 @@ func (m AppModel) renderRepoPane() string {
 				if m.activePane == repoPane && i == m.repoCursor {
 					cursor = cursorStyle.Render(">")
 				}
-				items = append(items, fmt.Sprintf("%s %s", cursor, repo.Name))
+				items = append(items, fmt.Sprintf("%s %s%s", cursor, currentTheme.icon(iconRepo), repo.Name))
 			}
 
 			if start > 0 {
-				items[1] = inactivePaneStyle.Render("  ↑ more")
+				items[1] = inactivePaneStyle.Render(moreAbove())
 			}
 			if end < len(filtered) {
-				items = append(items, inactivePaneStyle.Render("  ↓ more"))
+				items = append(items, inactivePaneStyle.Render(moreBelow()))
 			}
 		}
 	}
@@ -1207,7 +3106,8 @@ func (m AppModel) renderRepoPane() string {
 	style := lipgloss.NewStyle().
 		Width(paneWidth).
 		Height(availableHeight).
-		Padding(0, 1)
+		Padding(0, 1).
+		Faint(m.fadingIn())
 
 	return style.Render(content)
 }
@@ -1232,15 +3132,16 @@ func (m AppModel) renderBranchPane() string {
 		availableHeight = 5
 	}
 
-	title := "Branches"
+	title := tr("Branches")
 	if m.selectedRepo != "" {
 		title = fmt.Sprintf("(%s)", m.selectedRepo)
 	}
 	if m.branchFilterQuery != "" {
 		title = fmt.Sprintf("[/%s]", m.branchFilterQuery)
 	}
+	title = m.withCacheLabel(title)
 	if !showRepoPane {
-		title = fmt.Sprintf("%s (esc: back)", title)
+		title = trf("%s (esc: back)", title)
 	}
 
 	if m.activePane == branchPane {
@@ -1255,13 +3156,13 @@ func (m AppModel) renderBranchPane() string {
 	items = append(items, "")
 
 	if m.loading && m.activePane == branchPane {
-		items = append(items, m.spinner.View()+" Loading...")
+		items = append(items, m.renderSkeletonRows(paneWidth, availableHeight-3)...)
 	} else if len(m.branches) == 0 {
-		items = append(items, "← Select a repo")
+		items = append(items, tr("← Select a repo"))
 	} else {
 		filtered := m.getFilteredBranches()
 		if len(filtered) == 0 {
-			items = append(items, "No matches")
+			items = append(items, tr("No matches"))
 		} else {
 			start, end := m.calculateWindow(m.branchCursor, len(filtered), availableHeight-3)
 
@@ -1271,14 +3172,18 @@ func (m AppModel) renderBranchPane() string {
 				if m.activePane == branchPane && i == m.branchCursor {
 					cursor = cursorStyle.Render(">")
 				}
-				items = append(items, fmt.Sprintf("%s %s", cursor, branch.Name))
+				name := branch.Name
+				if m.isCheckedOut(branch.Name) {
+					name = checkedOutStyle.Render(name)
+				}
+				items = append(items, fmt.Sprintf("%s %s%s%s", cursor, currentTheme.icon(iconBranch), name, m.checkedOutMarker(branch.Name)))
 			}
 
 			if start > 0 {
-				items[2] = inactivePaneStyle.Render("  ↑ more")
+				items[2] = inactivePaneStyle.Render(moreAbove())
 			}
 			if end < len(filtered) {
-				items = append(items, inactivePaneStyle.Render("  ↓ more"))
+				items = append(items, inactivePaneStyle.Render(moreBelow()))
 			}
 		}
 	}
@@ -1287,7 +3192,8 @@ func (m AppModel) renderBranchPane() string {
 	style := lipgloss.NewStyle().
 		Width(paneWidth).
 		Height(availableHeight).
-		Padding(0, 1)
+		Padding(0, 1).
+		Faint(m.fadingIn())
 
 	return style.Render(content)
 }
@@ -1312,15 +3218,17 @@ func (m AppModel) renderPRPane() string {
 		availableHeight = 5
 	}
 
-	title := "Pull Requests"
+	title := tr("Pull Requests")
 	if m.selectedRepo != "" {
 		title = fmt.Sprintf("(%s)", m.selectedRepo)
 	}
 	if m.prFilterQuery != "" {
 		title = fmt.Sprintf("[/%s]", m.prFilterQuery)
 	}
+	title = fmt.Sprintf("%s [%s]", title, tr(m.pullRequestState()))
+	title = m.withCacheLabel(title)
 	if !showRepoPane {
-		title = fmt.Sprintf("%s (esc: back)", title)
+		title = trf("%s (esc: back)", title)
 	}
 
 	if m.activePane == branchPane {
@@ -1335,15 +3243,16 @@ func (m AppModel) renderPRPane() string {
 	items = append(items, "")
 
 	if m.loading && m.activePane == branchPane && m.currentView == prView {
-		items = append(items, m.spinner.View()+" Loading...")
+		items = append(items, m.renderSkeletonRows(paneWidth, availableHeight-3)...)
 	} else if len(m.pullRequests) == 0 {
-		items = append(items, "No pull requests")
+		items = append(items, tr("No pull requests"))
 	} else {
 		filtered := m.getFilteredPRs()
 		if len(filtered) == 0 {
-			items = append(items, "No matches")
+			items = append(items, tr("No matches"))
 		} else {
-			visiblePRRows := (availableHeight - 3) / 2
+			// One line is kept for the merge checks of the selected PR.
+			visiblePRRows := (availableHeight - 4) / 2
 			if visiblePRRows < 1 {
 				visiblePRRows = 1
 			}
@@ -1359,26 +3268,41 @@ func (m AppModel) renderPRPane() string {
 				leftBorder := renderPRLeftBorder(pr)
 
 				authorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("99"))
-				author := authorStyle.Render(fmt.Sprintf("@%s", pr.Author))
+				authorName := userLabel(m.currentUser, pr.AuthorUUID, pr.Author)
+				author := avatar(pr.Author) + " " + authorStyle.Render(fmt.Sprintf("@%s", authorName))
 
-				const cursorIDStateAuthorPadding = 40
-				maxTitleWidth := paneWidth - cursorIDStateAuthorPadding - len(pr.Author)
+				const cursorIDStateAuthorPadding = 43
+				maxTitleWidth := paneWidth - cursorIDStateAuthorPadding - len(authorName)
+				if m.isCheckedOut(pr.SourceBranch) {
+					maxTitleWidth -= len(checkedOutLabel) + 1
+				}
+				builds := buildIndicator(m.commitStatuses[pr.SourceCommit])
+				if builds != "" {
+					maxTitleWidth -= lipgloss.Width(builds) + 1
+				}
 				prTitle := pr.Title
 				if len(prTitle) > maxTitleWidth {
 					prTitle = prTitle[:maxTitleWidth-3] + "..."
 				}
 
-				mainLine := fmt.Sprintf("%s %s #%d", leftBorder, cursor, pr.ID)
+				mainLine := fmt.Sprintf("%s %s %s#%d", leftBorder, cursor, currentTheme.icon(iconPullRequest), pr.ID)
+				mainLine += m.checkedOutMarker(pr.SourceBranch)
 				if stateBadge != "" {
 					mainLine = fmt.Sprintf("%s %s", mainLine, stateBadge)
 				}
+				if builds != "" {
+					mainLine = fmt.Sprintf("%s %s", mainLine, builds)
+				}
 				mainLine = fmt.Sprintf("%s %s %s", mainLine, author, prTitle)
 				items = append(items, mainLine)
 
-				if len(pr.ApproverNames) > 0 {
-					approversText := fmt.Sprintf("%s   approvers: %s", leftBorder, renderApproverNames(pr.ApproverNames))
+				if approvers := approverLabels(m.currentUser, pr); len(approvers) > 0 {
+					approversText := fmt.Sprintf("%s   approvers: %s", leftBorder, renderApproverNames(approvers))
 					items = append(items, approversText)
 				}
+				if m.activePane == branchPane && i == m.prCursor {
+					items = append(items, fmt.Sprintf("%s   %s", leftBorder, m.renderMergeChecks(pr)))
+				}
 
 				if i < end-1 {
 					items = append(items, "")
@@ -1386,10 +3310,10 @@ func (m AppModel) renderPRPane() string {
 			}
 
 			if start > 0 {
-				items[2] = inactivePaneStyle.Render("  ↑ more")
+				items[2] = inactivePaneStyle.Render(moreAbove())
 			}
 			if end < len(filtered) {
-				items = append(items, inactivePaneStyle.Render("  ↓ more"))
+				items = append(items, inactivePaneStyle.Render(moreBelow()))
 			}
 		}
 	}
@@ -1398,7 +3322,8 @@ func (m AppModel) renderPRPane() string {
 	style := lipgloss.NewStyle().
 		Width(paneWidth).
 		Height(availableHeight).
-		Padding(0, 1)
+		Padding(0, 1).
+		Faint(m.fadingIn())
 
 	return style.Render(content)
 }
@@ -1423,16 +3348,21 @@ func (m AppModel) renderPipelinePane() string {
 		availableHeight = 5
 	}
 
-	title := "Pipelines"
+	title := tr("Pipelines")
 	if m.selectedRepo != "" {
 		title = fmt.Sprintf("(%s)", m.selectedRepo)
 	}
-	title = fmt.Sprintf("%s [develop/staging/main/master]", title)
+	if m.allPipelineBranches {
+		title = trf("%s [all branches]", title)
+	} else {
+		title = fmt.Sprintf("%s [%s]", title, strings.Join(m.trackedBranches, "/"))
+	}
 	if m.pipelineFilterQuery != "" {
 		title = fmt.Sprintf("%s [/%s]", title, m.pipelineFilterQuery)
 	}
+	title = m.withCacheLabel(title)
 	if !showRepoPane {
-		title = fmt.Sprintf("%s (esc: back)", title)
+		title = trf("%s (esc: back)", title)
 	}
 
 	if m.activePane == branchPane {
@@ -1447,16 +3377,16 @@ func (m AppModel) renderPipelinePane() string {
 	items = append(items, "")
 
 	if m.loading && m.activePane == branchPane && m.currentView == pipelinesView {
-		items = append(items, m.spinner.View()+" Loading...")
+		items = append(items, m.renderSkeletonRows(paneWidth, availableHeight-3)...)
 	} else if len(m.pipelines) == 0 {
-		items = append(items, "No pipelines")
+		items = append(items, tr("No pipelines"))
 	} else {
 		filtered := m.getFilteredPipelines()
 		if len(filtered) == 0 {
 			if m.pipelineFilterQuery == "" {
-				items = append(items, "No pipelines for tracked branches")
+				items = append(items, tr("No pipelines for tracked branches"))
 			} else {
-				items = append(items, "No matches")
+				items = append(items, tr("No matches"))
 			}
 		} else {
 			start, end := m.calculateWindow(m.pipelineCursor, len(filtered), availableHeight-3)
@@ -1475,22 +3405,27 @@ func (m AppModel) renderPipelinePane() string {
 				duration := pipelineDuration(pipeline.StartedOn, pipeline.CompletedOn)
 				ago := timeAgo(pipeline.CompletedOn)
 
-				line := fmt.Sprintf("%s #%d %s %s %s created: %s", cursor, pipeline.BuildNumber, branch, stateBadge, resultBadge, created)
+				commit := inactivePaneStyle.Width(12).Render(shortHash(pipeline.CommitHash))
+
+				line := fmt.Sprintf("%s #%d %s %s %s %s created: %s", cursor, pipeline.BuildNumber, branch, commit, stateBadge, resultBadge, created)
 				if duration != "" {
 					line = fmt.Sprintf("%s duration: %s", line, duration)
 				}
 				if ago != "" {
 					line = fmt.Sprintf("%s completed: %s", line, ago)
 				}
+				if trigger := pipelineTrigger(pipeline); trigger != "" {
+					line = fmt.Sprintf("%s %s", line, inactivePaneStyle.Render(trigger))
+				}
 
 				items = append(items, line)
 			}
 
 			if start > 0 {
-				items[2] = inactivePaneStyle.Render("  ↑ more")
+				items[2] = inactivePaneStyle.Render(moreAbove())
 			}
 			if end < len(filtered) {
-				items = append(items, inactivePaneStyle.Render("  ↓ more"))
+				items = append(items, inactivePaneStyle.Render(moreBelow()))
 			}
 		}
 	}
@@ -1499,7 +3434,8 @@ func (m AppModel) renderPipelinePane() string {
 	style := lipgloss.NewStyle().
 		Width(paneWidth).
 		Height(availableHeight).
-		Padding(0, 1)
+		Padding(0, 1).
+		Faint(m.fadingIn())
 
 	return style.Render(content)
 }
@@ -1524,15 +3460,15 @@ func (m AppModel) renderPipelineStepsPane() string {
 		availableHeight = 5
 	}
 
-	title := "Pipeline Steps"
+	title := tr("Pipeline Steps")
 	if m.selectedRepo != "" {
-		title = fmt.Sprintf("Pipeline Steps (%s)", m.selectedRepo)
+		title = trf("Pipeline Steps (%s)", m.selectedRepo)
 	}
 	if m.selectedPipelineRef != "" {
 		title = fmt.Sprintf("%s %s", title, m.selectedPipelineRef)
 	}
 	if !showRepoPane {
-		title = fmt.Sprintf("%s (esc: back)", title)
+		title = trf("%s (esc: back)", title)
 	}
 
 	if m.activePane == branchPane {
@@ -1544,14 +3480,17 @@ func (m AppModel) renderPipelineStepsPane() string {
 	var items []string
 	items = append(items, m.renderRightTabs())
 	items = append(items, title)
+	header := m.pipelineHeader(paneWidth - 2)
+	items = append(items, header...)
 	items = append(items, "")
+	listHeight := availableHeight - 3 - len(header)
 
 	if m.loading && m.currentView == pipelineStepsView {
-		items = append(items, m.spinner.View()+" Loading...")
+		items = append(items, m.renderSkeletonRows(paneWidth, listHeight)...)
 	} else if len(m.pipelineSteps) == 0 {
-		items = append(items, "No steps")
+		items = append(items, tr("No steps"))
 	} else {
-		start, end := m.calculateWindow(m.pipelineStepCursor, len(m.pipelineSteps), availableHeight-3)
+		start, end := m.calculateWindow(m.pipelineStepCursor, len(m.pipelineSteps), listHeight)
 		for i := start; i < end; i++ {
 			step := m.pipelineSteps[i]
 			cursor := " "
@@ -1570,10 +3509,10 @@ func (m AppModel) renderPipelineStepsPane() string {
 		}
 
 		if start > 0 {
-			items[2] = inactivePaneStyle.Render("  ↑ more")
+			items[2+len(header)] = inactivePaneStyle.Render(moreAbove())
 		}
 		if end < len(m.pipelineSteps) {
-			items = append(items, inactivePaneStyle.Render("  ↓ more"))
+			items = append(items, inactivePaneStyle.Render(moreBelow()))
 		}
 	}
 
@@ -1581,86 +3520,21 @@ func (m AppModel) renderPipelineStepsPane() string {
 	style := lipgloss.NewStyle().
 		Width(paneWidth).
 		Height(availableHeight).
-		Padding(0, 1)
+		Padding(0, 1).
+		Faint(m.fadingIn())
 
 	return style.Render(content)
 }
 
-func (m AppModel) renderPipelineStepLogPane() string {
-	showRepoPane := m.currentView == noSelection || m.activePane == repoPane
-
-	paneWidth := m.width - 4
-	if showRepoPane {
-		repoPaneWidth := (m.width - 10) / 3
-		if repoPaneWidth < 20 {
-			repoPaneWidth = 20
-		}
-		paneWidth = m.width - repoPaneWidth - 10
-	}
-	if paneWidth < 30 {
-		paneWidth = 30
-	}
-
-	availableHeight := m.height - 6
-	if availableHeight < 5 {
-		availableHeight = 5
-	}
-
-	title := "Pipeline Logs"
-	if m.selectedRepo != "" {
-		title = fmt.Sprintf("Pipeline Logs (%s)", m.selectedRepo)
-	}
-	if m.selectedPipelineRef != "" {
-		title = fmt.Sprintf("%s %s", title, m.selectedPipelineRef)
-	}
-	if m.selectedStepName != "" {
-		title = fmt.Sprintf("%s - %s", title, m.selectedStepName)
-	}
-	if !showRepoPane {
-		title = fmt.Sprintf("%s (esc: back)", title)
-	}
-
-	if m.activePane == branchPane {
-		title = activePaneStyle.Render(title)
-	} else {
-		title = inactivePaneStyle.Render(title)
+func (m AppModel) pullRequestURL(pr domain.PullRequest) string {
+	prURL := strings.TrimSpace(pr.URL)
+	if !strings.HasPrefix(prURL, "https://") && !strings.HasPrefix(prURL, "http://") {
+		prURL = ""
 	}
-
-	var items []string
-	items = append(items, m.renderRightTabs())
-	items = append(items, title)
-	items = append(items, "")
-
-	if m.loading && m.currentView == pipelineStepLogView {
-		items = append(items, m.spinner.View()+" Loading...")
-	} else if len(m.pipelineStepLogLines) == 0 {
-		items = append(items, "No logs")
-	} else {
-		start, end := m.calculateWindow(m.pipelineStepLogCursor, len(m.pipelineStepLogLines), availableHeight-3)
-		for i := start; i < end; i++ {
-			line := m.pipelineStepLogLines[i]
-			cursor := " "
-			if m.activePane == branchPane && i == m.pipelineStepLogCursor {
-				cursor = cursorStyle.Render(">")
-			}
-			items = append(items, fmt.Sprintf("%s %s", cursor, line))
-		}
-
-		if start > 0 {
-			items[2] = inactivePaneStyle.Render("  ↑ more")
-		}
-		if end < len(m.pipelineStepLogLines) {
-			items = append(items, inactivePaneStyle.Render("  ↓ more"))
-		}
+	if prURL == "" && pr.ID > 0 && m.workspace != "" && m.selectedRepoSlug != "" {
+		prURL = fmt.Sprintf("https://bitbucket.org/%s/%s/pull-requests/%d", m.workspace, m.selectedRepoSlug, pr.ID)
 	}
-
-	content := strings.Join(items, "\n")
-	style := lipgloss.NewStyle().
-		Width(paneWidth).
-		Height(availableHeight).
-		Padding(0, 1)
-
-	return style.Render(content)
+	return prURL
 }
 
 func formatPRState(state string, draft bool) string {
@@ -1668,23 +3542,26 @@ func formatPRState(state string, draft bool) string {
 	case "open":
 		return ""
 	case "merged":
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("99")).Render("[MERGED]")
+		return currentTheme.badge(currentTheme.neutral, "✓", "MERGED")
 	case "declined":
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("[DECLINED]")
+		return currentTheme.badge(currentTheme.failure, "✗", "DECLINED")
 	case "superseded":
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render("[SUPERSEDED]")
+		return currentTheme.badge(currentTheme.muted, "○", "SUPERSEDED")
 	default:
 		return fmt.Sprintf("[%s]", strings.ToUpper(state))
 	}
 }
 
 func renderPRLeftBorder(pr domain.PullRequest) string {
+	if currentTheme.plain {
+		return ""
+	}
 	state := strings.ToLower(strings.TrimSpace(pr.State))
 	if state == "open" {
 		if pr.Draft {
-			return lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render("▌")
+			return lipgloss.NewStyle().Foreground(lipgloss.Color(currentTheme.muted)).Render("▌")
 		}
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("42")).Render("▌")
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(currentTheme.success)).Render("▌")
 	}
 
 	return lipgloss.NewStyle().Foreground(lipgloss.Color("238")).Render("▌")
@@ -1717,15 +3594,15 @@ func approverColor(name string) string {
 func formatPipelineState(state string) string {
 	switch strings.ToLower(strings.TrimSpace(state)) {
 	case "completed":
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("99")).Render("[COMPLETED]")
+		return currentTheme.badge(currentTheme.neutral, "■", "COMPLETED")
 	case "in_progress":
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("220")).Render("[RUNNING]")
+		return currentTheme.badge(currentTheme.running, "●", "RUNNING")
 	case "pending":
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render("[PENDING]")
+		return currentTheme.badge(currentTheme.warning, "◐", "PENDING")
 	case "paused":
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render("[PAUSED]")
+		return currentTheme.badge(currentTheme.warning, "◐", "PAUSED")
 	case "error":
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("[ERROR]")
+		return currentTheme.badge(currentTheme.failure, "✗", "ERROR")
 	default:
 		return fmt.Sprintf("[%s]", strings.ToUpper(state))
 	}
@@ -1734,15 +3611,15 @@ func formatPipelineState(state string) string {
 func formatPipelineResult(result string) string {
 	switch strings.ToLower(strings.TrimSpace(result)) {
 	case "successful", "success":
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("42")).Render("[SUCCESS]")
+		return currentTheme.badge(currentTheme.success, "✓", "SUCCESS")
 	case "failed", "error":
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Render("[FAILED]")
+		return currentTheme.badge(currentTheme.failure, "✗", "FAILED")
 	case "stopped":
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render("[STOPPED]")
+		return currentTheme.badge(currentTheme.warning, "■", "STOPPED")
 	case "expired":
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render("[EXPIRED]")
+		return currentTheme.badge(currentTheme.muted, "○", "EXPIRED")
 	case "":
-		return lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render("[N/A]")
+		return currentTheme.badge(currentTheme.muted, "", "N/A")
 	default:
 		return fmt.Sprintf("[%s]", strings.ToUpper(result))
 	}
@@ -1766,9 +3643,11 @@ func selectedRunningPipelineUUID(m AppModel) string {
 	return selected.UUID
 }
 
+// isPipelineRunning reports whether pipeline is still going, counting a
+// pending one that has yet to start.
 func isPipelineRunning(pipeline domain.Pipeline) bool {
 	state := strings.ToLower(strings.TrimSpace(pipeline.State))
-	return state == "in_progress" || state == "running"
+	return state == "in_progress" || state == "running" || state == "pending"
 }
 
 func shortTimestamp(value string) string {
@@ -1781,7 +3660,7 @@ func shortTimestamp(value string) string {
 		return value
 	}
 
-	return t.Local().Format("2006-01-02 15:04")
+	return t.Local().Format(timestampLayout)
 }
 
 func pipelineDuration(startedOn, completedOn string) string {
@@ -1828,46 +3707,42 @@ func timeAgo(completedOn string) string {
 
 	elapsed := time.Now().UTC().Sub(completedAt)
 	if elapsed < time.Minute {
-		return "just now"
+		return tr("just now")
 	}
 
 	if elapsed < time.Hour {
 		minutes := int(elapsed.Minutes())
 		if minutes == 1 {
-			return "1 min ago"
+			return tr("1 min ago")
 		}
-		return fmt.Sprintf("%d mins ago", minutes)
+		return trf("%d mins ago", minutes)
 	}
 
 	if elapsed < 24*time.Hour {
 		hours := int(elapsed.Hours())
 		if hours == 1 {
-			return "1 hr ago"
+			return tr("1 hr ago")
 		}
-		return fmt.Sprintf("%d hrs ago", hours)
+		return trf("%d hrs ago", hours)
 	}
 
 	days := int(elapsed.Hours() / 24)
 	if days == 1 {
-		return "1 day ago"
+		return tr("1 day ago")
 	}
-	return fmt.Sprintf("%d days ago", days)
+	return trf("%d days ago", days)
 }
 
 func (m AppModel) getFilteredRepos() []domain.Repository {
 	if m.repoFilterQuery == "" {
-		return m.repositories
+		return m.scopedRepositories()
 	}
 
-	var filtered []domain.Repository
 	query := strings.ToLower(m.repoFilterQuery)
-	for _, repo := range m.repositories {
-		if strings.Contains(strings.ToLower(repo.Name), query) ||
-			strings.Contains(strings.ToLower(repo.Slug), query) {
-			filtered = append(filtered, repo)
-		}
-	}
-	return filtered
+	return cachedFilter(m.filters, "repos", query, m.scopedRepositories(), func(repo domain.Repository) bool {
+		return strings.Contains(strings.ToLower(repo.Name), query) ||
+			strings.Contains(strings.ToLower(repo.Slug), query)
+	})
 }
 
 func (m AppModel) getFilteredBranches() []domain.Branch {
@@ -1875,14 +3750,10 @@ func (m AppModel) getFilteredBranches() []domain.Branch {
 		return m.branches
 	}
 
-	var filtered []domain.Branch
 	query := strings.ToLower(m.branchFilterQuery)
-	for _, branch := range m.branches {
-		if strings.Contains(strings.ToLower(branch.Name), query) {
-			filtered = append(filtered, branch)
-		}
-	}
-	return filtered
+	return cachedFilter(m.filters, "branches", query, m.branches, func(branch domain.Branch) bool {
+		return strings.Contains(strings.ToLower(branch.Name), query)
+	})
 }
 
 func (m AppModel) getFilteredPRs() []domain.PullRequest {
@@ -1890,55 +3761,63 @@ func (m AppModel) getFilteredPRs() []domain.PullRequest {
 		return m.pullRequests
 	}
 
-	var filtered []domain.PullRequest
 	query := strings.ToLower(m.prFilterQuery)
-	for _, pr := range m.pullRequests {
-		if strings.Contains(strings.ToLower(pr.Title), query) ||
+	return cachedFilter(m.filters, "pullRequests", query, m.pullRequests, func(pr domain.PullRequest) bool {
+		return strings.Contains(strings.ToLower(pr.Title), query) ||
 			strings.Contains(strings.ToLower(pr.Author), query) ||
-			strings.Contains(strings.ToLower(pr.SourceBranch), query) {
-			filtered = append(filtered, pr)
-		}
-	}
-	return filtered
+			strings.Contains(strings.ToLower(pr.SourceBranch), query)
+	})
 }
 
 func (m AppModel) getFilteredPipelines() []domain.Pipeline {
 	query := strings.ToLower(m.pipelineFilterQuery)
-	if query == "" {
-		var tracked []domain.Pipeline
-		for _, pipeline := range m.pipelines {
-			if isTrackedPipelineBranch(pipeline.BranchName) {
-				tracked = append(tracked, pipeline)
-			}
-		}
-		return tracked
+	list := "pipelines"
+	if m.allPipelineBranches {
+		list = "allPipelines"
 	}
-
-	var filtered []domain.Pipeline
-	for _, pipeline := range m.pipelines {
-		if !isTrackedPipelineBranch(pipeline.BranchName) {
-			continue
+	return cachedFilter(m.filters, list, query, m.pipelines, func(pipeline domain.Pipeline) bool {
+		if !m.isTrackedPipelineBranch(pipeline.BranchName) {
+			return false
 		}
 
 		buildNumber := fmt.Sprintf("%d", pipeline.BuildNumber)
-		if strings.Contains(strings.ToLower(pipeline.State), query) ||
+		return strings.Contains(strings.ToLower(pipeline.State), query) ||
 			strings.Contains(strings.ToLower(pipeline.Result), query) ||
 			strings.Contains(strings.ToLower(buildNumber), query) ||
-			strings.Contains(strings.ToLower(pipeline.BranchName), query) {
-			filtered = append(filtered, pipeline)
+			strings.Contains(strings.ToLower(pipeline.BranchName), query)
+	})
+}
+
+// firstFailedStep returns the index of the first step that failed or errored,
+// or 0 when none did.
+func firstFailedStep(steps []domain.PipelineStep) int {
+	for i, step := range steps {
+		if result := strings.ToUpper(step.Result); result == "FAILED" || result == "ERROR" {
+			return i
 		}
 	}
-	return filtered
+	return 0
 }
 
-func isTrackedPipelineBranch(branchName string) bool {
-	branch := strings.ToLower(formatPipelineBranch(branchName))
-	switch branch {
-	case "develop", "staging", "main", "master":
+// defaultTrackedBranches are the branches whose pipelines are listed when
+// the profile sets no tracked_branches.
+var defaultTrackedBranches = []string{"develop", "staging", "main", "master"}
+
+// isTrackedPipelineBranch reports whether the pipeline list shows the
+// pipelines of branchName: every branch's when all branches are toggled on,
+// otherwise those of branches matching a tracked name or pattern, ignoring
+// case.
+func (m AppModel) isTrackedPipelineBranch(branchName string) bool {
+	if m.allPipelineBranches {
 		return true
-	default:
-		return false
 	}
+	branch := strings.ToLower(formatPipelineBranch(branchName))
+	for _, pattern := range m.trackedBranches {
+		if ok, _ := path.Match(strings.ToLower(pattern), branch); ok {
+			return true
+		}
+	}
+	return false
 }
 
 func formatPipelineBranch(branchName string) string {
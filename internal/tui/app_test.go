@@ -0,0 +1,386 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"bitbucket-cli/internal/bitbucket"
+	"bitbucket-cli/internal/bitbucket/fake"
+	"bitbucket-cli/internal/config"
+	"bitbucket-cli/internal/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newTestApp returns the app on repository api of a fake workspace, sized
+// like a terminal, with state files going to a temporary home.
+func newTestApp(t *testing.T, client *fake.Client) AppModel {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	m := NewAppWithClient("acme", config.Config{}, client)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	m = updated.(AppModel)
+	m.selectedRepo, m.selectedRepoSlug = "api", "api"
+	return m
+}
+
+// run feeds the messages of cmd back into the model until nothing is left.
+// Commands still blocked after a moment, such as ticks, are dropped.
+func run(t *testing.T, m AppModel, cmd tea.Cmd) AppModel {
+	t.Helper()
+	if cmd == nil {
+		return m
+	}
+	done := make(chan tea.Msg, 1)
+	go func() { done <- cmd() }()
+	var msg tea.Msg
+	select {
+	case msg = <-done:
+	case <-time.After(50 * time.Millisecond):
+		return m
+	}
+	switch msg := msg.(type) {
+	case nil:
+		return m
+	case tea.BatchMsg:
+		for _, cmd := range msg {
+			m = run(t, m, cmd)
+		}
+		return m
+	default:
+		updated, next := m.Update(msg)
+		return run(t, updated.(AppModel), next)
+	}
+}
+
+func press(t *testing.T, m AppModel, keys ...string) AppModel {
+	t.Helper()
+	for _, k := range keys {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		switch k {
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case "down":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		}
+		updated, cmd := m.Update(msg)
+		m = run(t, updated.(AppModel), cmd)
+	}
+	return m
+}
+
+func testPullRequests() []domain.PullRequest {
+	return []domain.PullRequest{
+		{ID: 12, RepoSlug: "api", Title: "Add login", State: "OPEN", SourceBranch: "feature/login", DestBranch: "main"},
+		{ID: 31, RepoSlug: "api", Title: "Fix crash", State: "OPEN", SourceBranch: "fix/crash", DestBranch: "main"},
+		{ID: 35, RepoSlug: "api", Title: "Bump deps", State: "OPEN", SourceBranch: "deps", DestBranch: "main"},
+	}
+}
+
+func TestApprovePullRequest(t *testing.T) {
+	client := fake.NewClient()
+	client.User = domain.User{UUID: "{me}", DisplayName: "Me"}
+	client.PullRequests["api"] = testPullRequests()
+	m := newTestApp(t, client)
+
+	m = run(t, m, openTab(&m, tabIndex(prView)))
+	if len(m.pullRequests) != 3 {
+		t.Fatalf("loaded %d pull requests, want 3", len(m.pullRequests))
+	}
+	m = press(t, m, "down", "a")
+
+	if _, ok := client.ApprovedOn[fake.PullRequestKey("api", 31)]; !ok {
+		t.Fatalf("PR #31 was not approved; calls: %v", client.Calls)
+	}
+	if m.message != "Approved PR #31" {
+		t.Errorf("message = %q, want Approved PR #31", m.message)
+	}
+	if m.pendingActions != 0 {
+		t.Errorf("pendingActions = %d after the approval finished", m.pendingActions)
+	}
+}
+
+func TestFailedActionIsNotPending(t *testing.T) {
+	client := fake.NewClient()
+	client.PullRequests["api"] = testPullRequests()
+	client.Errors["ApprovePullRequest"] = bitbucket.ErrForbidden
+	m := newTestApp(t, client)
+
+	m = run(t, m, openTab(&m, tabIndex(prView)))
+	m = press(t, m, "a")
+	if !strings.HasPrefix(m.message, "Error updating approval") {
+		t.Errorf("message = %q, want the approval error", m.message)
+	}
+	if m.pendingActions != 0 {
+		t.Errorf("pendingActions = %d after the approval failed", m.pendingActions)
+	}
+}
+
+func TestLoadErrorIsShown(t *testing.T) {
+	client := fake.NewClient()
+	client.Errors["ListPullRequestsByState"] = bitbucket.ErrNotFound
+	m := newTestApp(t, client)
+
+	m = run(t, m, openTab(&m, tabIndex(prView)))
+	if !strings.HasPrefix(m.message, "Error loading pull requests") {
+		t.Errorf("message = %q, want the load error", m.message)
+	}
+	if m.loading {
+		t.Error("still loading after the error")
+	}
+}
+
+func TestDigitsJumpInPullRequests(t *testing.T) {
+	client := fake.NewClient()
+	client.PullRequests["api"] = testPullRequests()
+	m := newTestApp(t, client)
+	m = run(t, m, openTab(&m, tabIndex(prView)))
+
+	m = press(t, m, "3", "5", "enter")
+	if m.currentView != prView || m.getFilteredPRs()[m.prCursor].ID != 35 {
+		t.Fatalf("view %v with cursor on #%d, want the PR list on #35", m.currentView, m.getFilteredPRs()[m.prCursor].ID)
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2"), Alt: true})
+	m = run(t, updated.(AppModel), cmd)
+	if m.currentView != branchesView {
+		t.Fatalf("alt+2 opened %v, want the branches tab", m.currentView)
+	}
+	m = press(t, m, "1")
+	if m.currentView != prView || m.getFilteredPRs()[m.prCursor].ID != 35 {
+		t.Errorf("back on pull requests with cursor on #%d, want #35 kept", m.getFilteredPRs()[m.prCursor].ID)
+	}
+}
+
+func TestFilterFollowsPipelineUpdates(t *testing.T) {
+	client := fake.NewClient()
+	client.Pipelines["api"] = []domain.Pipeline{
+		{UUID: "{1}", BuildNumber: 1, BranchName: "main", State: "IN_PROGRESS"},
+		{UUID: "{2}", BuildNumber: 2, BranchName: "main", State: "COMPLETED", Result: "SUCCESSFUL"},
+	}
+	m := newTestApp(t, client)
+	m = run(t, m, openTab(&m, tabIndex(pipelinesView)))
+	m.pipelineFilterQuery = "in_progress"
+	if got := m.getFilteredPipelines(); len(got) != 1 || got[0].BuildNumber != 1 {
+		t.Fatalf("filtered = %+v, want the running pipeline", got)
+	}
+
+	updated, _ := m.Update(pipelinePolledMsg{pipeline: domain.Pipeline{UUID: "{1}", BuildNumber: 1, BranchName: "main", State: "COMPLETED", Result: "FAILED"}})
+	m = updated.(AppModel)
+	if got := m.getFilteredPipelines(); len(got) != 0 {
+		t.Errorf("filtered = %+v after the pipeline finished, want none", got)
+	}
+}
+
+func TestRemappedChords(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	cfg := config.Config{KeyBindings: map[string][]string{
+		"goto_tags":      {"t t"},
+		"goto_pipelines": {"g l"},
+		"goto_issues":    {"i"},
+	}}
+	m := NewAppWithClient("acme", cfg, fake.NewClient())
+	if m.message != "Unknown key bindings in config: goto_issues" {
+		t.Errorf("message = %q, want goto_issues reported", m.message)
+	}
+	m.selectedRepo, m.selectedRepoSlug = "api", "api"
+
+	m = press(t, m, "t", "t")
+	if m.currentView != tagsView {
+		t.Fatalf("t t opened %v, want the tags tab", m.currentView)
+	}
+	m = press(t, m, "g", "l")
+	if m.currentView != pipelinesView {
+		t.Fatalf("g l opened %v, want the pipelines tab", m.currentView)
+	}
+	m = press(t, m, "g", "p")
+	if m.message != "Unknown key sequence: g p" {
+		t.Errorf("message = %q after the old chord", m.message)
+	}
+}
+
+func TestDownloadIsSaved(t *testing.T) {
+	client := fake.NewClient()
+	client.Downloads["api"] = []domain.Download{{Name: "app.zip"}, {Name: "gone.zip"}}
+	client.DownloadContents["api/app.zip"] = []byte("zip")
+	m := newTestApp(t, client)
+	m.exportDir = t.TempDir()
+	m = run(t, m, openTab(&m, tabIndex(downloadsView)))
+
+	m = press(t, m, "enter")
+	path := filepath.Join(m.exportDir, "app.zip")
+	if content, err := os.ReadFile(path); err != nil || string(content) != "zip" {
+		t.Fatalf("saved %q, %v; want the download's content", content, err)
+	}
+
+	m = press(t, m, "down", "enter")
+	if !strings.HasPrefix(m.message, "Download error") {
+		t.Errorf("message = %q, want the download error", m.message)
+	}
+	if _, err := os.Stat(filepath.Join(m.exportDir, "gone.zip")); !os.IsNotExist(err) {
+		t.Errorf("failed download left a file behind: %v", err)
+	}
+}
+
+func TestBackingOutOfALoadingViewStopsLoading(t *testing.T) {
+	client := fake.NewClient()
+	client.Pipelines["api"] = []domain.Pipeline{{UUID: "{1}", BuildNumber: 1, BranchName: "main", State: "COMPLETED", Result: "SUCCESSFUL"}}
+	client.PullRequests["api"] = testPullRequests()
+	m := newTestApp(t, client)
+	m = run(t, m, openTab(&m, tabIndex(pipelinesView)))
+
+	// The steps arrive only after esc has gone back to the pipelines.
+	updated, pending := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = press(t, updated.(AppModel), "esc")
+	m = run(t, m, pending)
+	if m.currentView != pipelinesView || m.loading {
+		t.Errorf("view %v, loading %v after backing out of the steps; want the pipelines, loaded", m.currentView, m.loading)
+	}
+
+	m = run(t, m, openTab(&m, tabIndex(prView)))
+	pending = openPullRequestCommits(&m, m.pullRequests[0])
+	m = press(t, m, "esc")
+	m = run(t, m, pending)
+	if m.currentView != prView || m.loading {
+		t.Errorf("view %v, loading %v after backing out of the commits; want the pull requests, loaded", m.currentView, m.loading)
+	}
+}
+
+func TestLeavingAViewBeforeItsResultsStopsLoading(t *testing.T) {
+	opens := map[string]func(m *AppModel) tea.Cmd{
+		"artifacts":      openArtifacts,
+		"step commands":  openStepCommands,
+		"schedules":      openSchedules,
+		"branch commits": openBranchCommits,
+	}
+	for name, open := range opens {
+		t.Run(name, func(t *testing.T) {
+			m := newTestApp(t, fake.NewClient())
+			m.loading = false
+			m.branches = []domain.Branch{{Name: "main"}}
+			m.currentView = pipelineStepsView
+			m.selectedPipelineUUID = "{1}"
+			m.pipelineSteps = []domain.PipelineStep{{UUID: "{s}", Name: "build"}}
+
+			pending := open(&m)
+			m = press(t, m, "esc")
+			m = run(t, m, pending)
+			if m.loading {
+				t.Errorf("still loading in view %v after leaving the %s", m.currentView, name)
+			}
+		})
+	}
+}
+
+func TestWatchedPipelineOfAnotherRepository(t *testing.T) {
+	client := fake.NewClient()
+	client.Pipelines["api"] = []domain.Pipeline{{UUID: "{1}", BuildNumber: 1, State: "COMPLETED"}}
+	client.Pipelines["web"] = []domain.Pipeline{{UUID: "{7}", BuildNumber: 7, State: "COMPLETED"}}
+	m := newTestApp(t, client)
+	m = run(t, m, openTab(&m, tabIndex(pipelinesView)))
+
+	m.watchItems = []config.WatchItem{{Kind: config.WatchPipeline, RepoSlug: "web", ID: 7, UUID: "{7}"}}
+	m.currentView = watchView
+	m = run(t, m, openWatchedItem(&m))
+	m = press(t, m, "esc")
+	if m.currentView != pipelinesView || len(m.pipelines) != 1 || m.pipelines[0].BuildNumber != 7 {
+		t.Fatalf("view %v with pipelines %+v after leaving web's pipeline; want web's pipelines", m.currentView, m.pipelines)
+	}
+}
+
+func TestOpenFeedEvent(t *testing.T) {
+	client := fake.NewClient()
+	client.Pipelines["api"] = []domain.Pipeline{{UUID: "{1}", BuildNumber: 1, State: "COMPLETED"}}
+	client.Pipelines["web"] = []domain.Pipeline{{UUID: "{7}", BuildNumber: 7, State: "COMPLETED", Result: "FAILED"}}
+	m := newTestApp(t, client)
+	m = run(t, m, openTab(&m, tabIndex(pipelinesView)))
+	m.currentView = feedView
+	now := time.Now().UTC().Format(time.RFC3339)
+	m.feedPullRequests = map[string][]domain.PullRequest{"web": {{ID: 4, State: "MERGED", UpdatedOn: now}}}
+	m.feedPipelines = map[string][]domain.Pipeline{"web": {{UUID: "{7}", BuildNumber: 7, BranchName: "main", Result: "FAILED", CompletedOn: now}}}
+
+	// Merged pull requests only open in the browser, which is not run here.
+	m.feedCursor = slices.IndexFunc(m.feedEvents(), func(event feedEvent) bool { return event.kind == "merged" })
+	openFeedEvent(&m)
+	if m.selectedRepoSlug != "api" || m.currentView != feedView {
+		t.Errorf("repository %q, view %v after opening a merged pull request; want api, the feed", m.selectedRepoSlug, m.currentView)
+	}
+
+	m.feedCursor = slices.IndexFunc(m.feedEvents(), func(event feedEvent) bool { return event.kind == "failed" })
+	m = run(t, m, openFeedEvent(&m))
+	m = press(t, m, "esc")
+	if m.selectedRepoSlug != "web" || len(m.pipelines) != 1 || m.pipelines[0].BuildNumber != 7 {
+		t.Errorf("repository %q with pipelines %+v after leaving the failed pipeline; want web's", m.selectedRepoSlug, m.pipelines)
+	}
+}
+
+func TestJumpPollsOnlyANewlySelectedRunningPipeline(t *testing.T) {
+	client := fake.NewClient()
+	client.Pipelines["api"] = []domain.Pipeline{
+		{UUID: "{1}", BuildNumber: 1, BranchName: "main", State: "IN_PROGRESS"},
+		{UUID: "{12}", BuildNumber: 12, BranchName: "main", State: "IN_PROGRESS"},
+	}
+	m := newTestApp(t, client)
+	m = run(t, m, openTab(&m, tabIndex(pipelinesView)))
+	m.pipelineCursor = 0
+
+	for _, step := range []struct {
+		key  tea.KeyMsg
+		poll bool
+	}{
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("1")}, false},
+		{tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2")}, true},
+		{tea.KeyMsg{Type: tea.KeyEnter}, false},
+	} {
+		updated, cmd := m.Update(step.key)
+		m = updated.(AppModel)
+		if (cmd != nil) != step.poll {
+			t.Errorf("after %q: polling %v, want %v", step.key, cmd != nil, step.poll)
+		}
+	}
+}
+
+func TestApprovalsOfANamesake(t *testing.T) {
+	me := domain.User{UUID: "{me}", DisplayName: "Alex Kim"}
+	pr := domain.PullRequest{
+		AuthorUUID: "{other}", Author: "Alex Kim",
+		Approved: true, Approvals: 1, ApproverNames: []string{"Alex Kim"},
+		Participants: []domain.Participant{{UUID: "{other}", Name: "Alex Kim", Approved: true}},
+	}
+	if got := userLabel(me, pr.AuthorUUID, pr.Author); got != "Alex Kim" {
+		t.Errorf("namesake's pull request labelled %q, want their name", got)
+	}
+
+	applyApproval(&pr, me, false)
+	if pr.Approvals != 1 || len(pr.ApproverNames) != 1 || !pr.Participants[0].Approved {
+		t.Fatalf("unapproving without an approval changed %+v", pr)
+	}
+	applyApproval(&pr, me, true)
+	if got := approverLabels(me, pr); pr.Approvals != 2 || len(got) != 2 || got[0] != "Alex Kim" || got[1] != meLabel {
+		t.Errorf("approvers %v, %d approvals after approving; want the namesake and me, 2", got, pr.Approvals)
+	}
+	applyApproval(&pr, me, false)
+	if got := approverLabels(me, pr); pr.Approvals != 1 || len(got) != 1 || got[0] != "Alex Kim" {
+		t.Errorf("approvers %v, %d approvals after unapproving; want the namesake, 1", got, pr.Approvals)
+	}
+}
+
+func TestSearchDropsResultsOfAnEarlierSearch(t *testing.T) {
+	client := fake.NewClient()
+	client.Branches["api"] = []domain.Branch{{Name: "main"}}
+	m := newTestApp(t, client)
+
+	stale := openSearch(&m)
+	m = press(t, m, "esc")
+	m = run(t, m, openSearch(&m))
+	m = run(t, m, stale)
+	if m.searchPending != 0 {
+		t.Errorf("%d search loads pending after both searches finished, want 0", m.searchPending)
+	}
+}
@@ -0,0 +1,201 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"bitbucket-cli/internal/bitbucket"
+	"bitbucket-cli/internal/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// staleApproval is an open pull request the user approved before some of its
+// commits were pushed.
+type staleApproval struct {
+	pr         domain.PullRequest
+	approvedOn time.Time
+	// newCommits are the commits dated after the approval, newest first.
+	newCommits []domain.Commit
+}
+
+type staleApprovalsLoadedMsg struct {
+	repoSlug string
+	stale    []staleApproval
+	err      error
+}
+
+// loadStaleApprovals finds the pull requests of repoSlug the user approved
+// and keeps those with commits newer than the approval. Pull requests not
+// updated since the approval are skipped without fetching their commits.
+func loadStaleApprovals(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug, userUUID string) tea.Cmd {
+	return func() tea.Msg {
+		approvals, err := client.ListApprovedPullRequests(ctx, repoSlug, userUUID)
+		if err != nil {
+			return staleApprovalsLoadedMsg{repoSlug: repoSlug, err: err}
+		}
+
+		var stale []staleApproval
+		for _, approval := range approvals {
+			approvedOn, err := time.Parse(time.RFC3339, approval.ParticipatedOn)
+			if err != nil {
+				continue
+			}
+			if updatedOn, err := time.Parse(time.RFC3339, approval.PullRequest.UpdatedOn); err == nil && !updatedOn.After(approvedOn) {
+				continue
+			}
+
+			commits, err := client.ListPullRequestCommits(ctx, repoSlug, approval.PullRequest.ID)
+			if err != nil {
+				return staleApprovalsLoadedMsg{repoSlug: repoSlug, err: err}
+			}
+			var newCommits []domain.Commit
+			for _, commit := range commits {
+				if date, err := time.Parse(time.RFC3339, commit.Date); err == nil && date.After(approvedOn) {
+					newCommits = append(newCommits, commit)
+				}
+			}
+			if len(newCommits) > 0 {
+				pr := approval.PullRequest
+				pr.RepoSlug = repoSlug
+				stale = append(stale, staleApproval{pr: pr, approvedOn: approvedOn, newCommits: newCommits})
+			}
+		}
+		return staleApprovalsLoadedMsg{repoSlug: repoSlug, stale: stale}
+	}
+}
+
+// openStaleApprovals shows the pull requests in the favorite repositories
+// whose approval by the user predates their latest commits.
+func openStaleApprovals(m *AppModel) tea.Cmd {
+	m.activePane = branchPane
+	m.currentView = staleApprovalsView
+	m.staleApprovals = nil
+	m.staleApprovalCursor = 0
+	ctx := newViewContext(m)
+	if m.currentUser.UUID == "" {
+		m.staleApprovalsPending = 1
+		return loadCurrentUser(m.ctx, m.client)
+	}
+	return loadAllStaleApprovals(m, ctx)
+}
+
+func loadAllStaleApprovals(m *AppModel, ctx context.Context) tea.Cmd {
+	favorites := m.homeFavorites()
+	if len(favorites) == 0 {
+		m.staleApprovalsPending = 0
+		m.message = "No favorite repositories to check approvals in"
+		return nil
+	}
+
+	m.staleApprovalErrors = nil
+	cmds := make([]tea.Cmd, 0, len(favorites))
+	for _, slug := range favorites {
+		cmds = append(cmds, forView(m, loadStaleApprovals(ctx, m.client, slug, m.currentUser.UUID)))
+	}
+	m.staleApprovalsPending = len(cmds)
+	return tea.Batch(cmds...)
+}
+
+// addStaleApprovals replaces one repository's results, keeping the pull
+// requests with the most recent new commits first.
+func addStaleApprovals(m *AppModel, repoSlug string, stale []staleApproval) {
+	m.staleApprovals = slices.DeleteFunc(m.staleApprovals, func(s staleApproval) bool {
+		return s.pr.RepoSlug == repoSlug
+	})
+	m.staleApprovals = append(m.staleApprovals, stale...)
+	sort.SliceStable(m.staleApprovals, func(i, j int) bool {
+		return m.staleApprovals[i].newCommits[0].Date > m.staleApprovals[j].newCommits[0].Date
+	})
+	m.staleApprovalCursor = min(m.staleApprovalCursor, max(len(m.staleApprovals)-1, 0))
+}
+
+func (m AppModel) selectedStaleApproval() (staleApproval, bool) {
+	if m.currentView != staleApprovalsView || m.activePane != branchPane || m.staleApprovalCursor >= len(m.staleApprovals) {
+		return staleApproval{}, false
+	}
+	return m.staleApprovals[m.staleApprovalCursor], true
+}
+
+// openStaleApproval opens the selected pull request in its repository's pull
+// request tab, to review and approve it again.
+func openStaleApproval(m *AppModel) tea.Cmd {
+	stale, ok := m.selectedStaleApproval()
+	if !ok {
+		return nil
+	}
+	return openPullRequestInRepo(m, stale.pr)
+}
+
+func (m AppModel) renderStaleApprovalsPane() string {
+	paneWidth := m.width - 4
+	if paneWidth < 30 {
+		paneWidth = 30
+	}
+
+	availableHeight := m.height - 6
+	if availableHeight < 5 {
+		availableHeight = 5
+	}
+
+	title := trf("Stale approvals (%d) (esc: back)", len(m.staleApprovals))
+	if m.staleApprovalsPending > 0 {
+		title = fmt.Sprintf("%s %s", title, m.spinner.View())
+	}
+	items := []string{activePaneStyle.Render(title), ""}
+
+	if len(m.staleApprovals) == 0 {
+		if m.staleApprovalsPending > 0 {
+			items = append(items, m.renderSkeletonRows(paneWidth, availableHeight-2)...)
+		} else {
+			items = append(items, tr("No pull request you approved has changed since."))
+		}
+	} else {
+		repoStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(currentTheme.accent)).Width(20)
+		authorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(currentTheme.neutral))
+		warningStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(currentTheme.warning))
+		rows := (availableHeight - 2 - len(m.staleApprovalErrors)) / 2
+		start, end := m.calculateWindow(m.staleApprovalCursor, len(m.staleApprovals), max(rows, 1))
+		for i := start; i < end; i++ {
+			stale := m.staleApprovals[i]
+			cursor := " "
+			if i == m.staleApprovalCursor {
+				cursor = cursorStyle.Render(">")
+			}
+			author := avatar(stale.pr.Author) + " " + authorStyle.Render("@"+userLabel(m.currentUser, stale.pr.AuthorUUID, stale.pr.Author))
+			items = append(items, fmt.Sprintf("%s %s PR #%d %s %s", cursor, repoStyle.Render(stale.pr.RepoSlug), stale.pr.ID, author, stale.pr.Title))
+
+			commits := "1 new commit"
+			if len(stale.newCommits) > 1 {
+				commits = fmt.Sprintf("%d new commits", len(stale.newCommits))
+			}
+			subject, _, _ := strings.Cut(strings.TrimSpace(stale.newCommits[0].Message), "\n")
+			detail := fmt.Sprintf("%s since you approved %s, latest %s", commits, timeAgo(stale.approvedOn.Format(time.RFC3339)), timeAgo(stale.newCommits[0].Date))
+			items = append(items, fmt.Sprintf("    %s %s", warningStyle.Render(detail), inactivePaneStyle.Render(subject)))
+		}
+
+		if start > 0 {
+			items[1] = inactivePaneStyle.Render(moreAbove())
+		}
+		if end < len(m.staleApprovals) {
+			items = append(items, inactivePaneStyle.Render(moreBelow()))
+		}
+	}
+
+	for _, err := range m.staleApprovalErrors {
+		items = append(items, messageStyle.Render(err))
+	}
+
+	content := strings.Join(items, "\n")
+	style := lipgloss.NewStyle().
+		Width(paneWidth).
+		Height(availableHeight).
+		Padding(0, 1)
+
+	return style.Render(content)
+}
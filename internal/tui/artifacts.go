@@ -0,0 +1,228 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"bitbucket-cli/internal/bitbucket"
+	"bitbucket-cli/internal/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// artifactProgressInterval is how often the status bar shows how far a
+// download has got.
+const artifactProgressInterval = 250 * time.Millisecond
+
+type artifactsLoadedMsg struct {
+	stepUUID  string
+	artifacts []domain.PipelineArtifact
+	err       error
+}
+
+type artifactSavedMsg struct {
+	path string
+	err  error
+}
+
+type artifactProgressTickMsg struct{}
+
+// artifactDownload is an artifact being saved. written is updated by the
+// command writing the file and read when the status bar is drawn.
+type artifactDownload struct {
+	name    string
+	size    int64
+	written atomic.Int64
+}
+
+// Write counts the bytes written to the file for the progress.
+func (d *artifactDownload) Write(p []byte) (int, error) {
+	d.written.Add(int64(len(p)))
+	return len(p), nil
+}
+
+func loadArtifacts(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug, pipelineUUID, stepUUID string) tea.Cmd {
+	return func() tea.Msg {
+		artifacts, err := client.ListPipelineArtifacts(ctx, repoSlug, pipelineUUID, stepUUID)
+		return artifactsLoadedMsg{stepUUID: stepUUID, artifacts: artifacts, err: err}
+	}
+}
+
+// openArtifacts lists the artifacts of the highlighted step.
+func openArtifacts(m *AppModel) tea.Cmd {
+	step := m.pipelineSteps[m.pipelineStepCursor]
+	if step.UUID == "" {
+		m.message = "Selected step has no UUID"
+		return nil
+	}
+	m.selectedStepName = step.Name
+	if m.selectedStepName == "" {
+		m.selectedStepName = step.UUID
+	}
+	m.currentView = artifactsView
+	m.artifactsStepUUID = step.UUID
+	m.artifacts = nil
+	m.artifactCursor = 0
+	ctx := newViewContext(m)
+	m.loading = true
+	return forView(m, loadArtifacts(ctx, m.client, m.selectedRepoSlug, m.selectedPipelineUUID, step.UUID))
+}
+
+// closeArtifacts goes back to the steps of the pipeline. A download keeps
+// running.
+func closeArtifacts(m *AppModel) {
+	m.currentView = pipelineStepsView
+	m.artifactsStepUUID = ""
+	m.artifacts = nil
+	m.artifactCursor = 0
+}
+
+// saveArtifact streams an artifact into dir under its base name. An existing
+// file is left alone and reported instead; a download that fails halfway is
+// removed.
+func saveArtifact(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug, pipelineUUID, stepUUID string, artifact domain.PipelineArtifact, dir string, progress *artifactDownload) tea.Cmd {
+	path := filepath.Join(dir, filepath.Base(artifact.Path))
+	return func() tea.Msg {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return artifactSavedMsg{path: path, err: err}
+		}
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if errors.Is(err, fs.ErrExist) {
+			return artifactSavedMsg{path: path, err: fmt.Errorf("%s already exists", path)}
+		}
+		if err != nil {
+			return artifactSavedMsg{path: path, err: err}
+		}
+		err = client.DownloadPipelineArtifact(ctx, repoSlug, pipelineUUID, stepUUID, artifact.UUID, io.MultiWriter(file, progress))
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(path)
+		}
+		return artifactSavedMsg{path: path, err: err}
+	}
+}
+
+func artifactProgressTick() tea.Cmd {
+	return tea.Tick(artifactProgressInterval, func(time.Time) tea.Msg {
+		return artifactProgressTickMsg{}
+	})
+}
+
+// downloadArtifact saves the highlighted artifact to the export directory,
+// one download at a time, showing its progress in the status bar.
+func downloadArtifact(m *AppModel) tea.Cmd {
+	if m.artifactCursor >= len(m.artifacts) {
+		return nil
+	}
+	if m.artifactDownload != nil {
+		m.message = fmt.Sprintf("Already downloading %s", m.artifactDownload.name)
+		return nil
+	}
+	artifact := m.artifacts[m.artifactCursor]
+	m.artifactDownload = &artifactDownload{name: filepath.Base(artifact.Path), size: artifact.Size}
+	m.message = m.artifactProgress()
+	save := saveArtifact(m.ctx, m.client, m.selectedRepoSlug, m.selectedPipelineUUID, m.artifactsStepUUID, artifact, m.exportDir, m.artifactDownload)
+	return tea.Batch(startAction(m, save), artifactProgressTick())
+}
+
+// artifactProgress describes the running download, e.g. "Downloading
+// app.zip: 4.2 MB of 10.0 MB (42%)".
+func (m AppModel) artifactProgress() string {
+	download := m.artifactDownload
+	written := download.written.Load()
+	if download.size <= 0 {
+		return fmt.Sprintf("Downloading %s: %s", download.name, formatSize(written))
+	}
+	percent := min(written*100/download.size, 100)
+	return fmt.Sprintf("Downloading %s: %s of %s (%d%%)", download.name, formatSize(written), formatSize(download.size), percent)
+}
+
+func (m AppModel) renderArtifactsPane() string {
+	showRepoPane := m.currentView == noSelection || m.activePane == repoPane
+
+	paneWidth := m.width - 4
+	if showRepoPane {
+		repoPaneWidth := (m.width - 10) / 3
+		if repoPaneWidth < 20 {
+			repoPaneWidth = 20
+		}
+		paneWidth = m.width - repoPaneWidth - 10
+	}
+	if paneWidth < 30 {
+		paneWidth = 30
+	}
+
+	availableHeight := m.height - 6
+	if availableHeight < 5 {
+		availableHeight = 5
+	}
+
+	title := tr("Artifacts")
+	if m.selectedRepo != "" {
+		title = trf("Artifacts (%s)", m.selectedRepo)
+	}
+	if m.selectedPipelineRef != "" {
+		title = fmt.Sprintf("%s %s", title, m.selectedPipelineRef)
+	}
+	if m.selectedStepName != "" {
+		title = fmt.Sprintf("%s - %s", title, m.selectedStepName)
+	}
+	if !showRepoPane {
+		title = trf("%s (esc: back)", title)
+	}
+
+	if m.activePane == branchPane {
+		title = activePaneStyle.Render(title)
+	} else {
+		title = inactivePaneStyle.Render(title)
+	}
+
+	var items []string
+	items = append(items, m.renderRightTabs())
+	items = append(items, title)
+	items = append(items, "")
+
+	if m.loading && m.currentView == artifactsView {
+		items = append(items, m.renderSkeletonRows(paneWidth, availableHeight-3)...)
+	} else if len(m.artifacts) == 0 {
+		items = append(items, tr("No artifacts"))
+	} else {
+		start, end := m.calculateWindow(m.artifactCursor, len(m.artifacts), availableHeight-3)
+		for i := start; i < end; i++ {
+			artifact := m.artifacts[i]
+			cursor := " "
+			if m.activePane == branchPane && i == m.artifactCursor {
+				cursor = cursorStyle.Render(">")
+			}
+			details := fmt.Sprintf("%9s  %s", formatSize(artifact.Size), shortTimestamp(artifact.CreatedOn))
+			items = append(items, fmt.Sprintf("%s %-40s %s", cursor, artifact.Path, inactivePaneStyle.Render(details)))
+		}
+
+		if start > 0 {
+			items[2] = inactivePaneStyle.Render(moreAbove())
+		}
+		if end < len(m.artifacts) {
+			items = append(items, inactivePaneStyle.Render(moreBelow()))
+		}
+	}
+
+	content := strings.Join(items, "\n")
+	style := lipgloss.NewStyle().
+		Width(paneWidth).
+		Height(availableHeight).
+		Padding(0, 1).
+		Faint(m.fadingIn())
+
+	return style.Render(content)
+}
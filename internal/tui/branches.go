@@ -0,0 +1,323 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"bitbucket-cli/internal/bitbucket"
+	"bitbucket-cli/internal/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// branchCommitLimit is how many of a branch's newest commits its commit view
+// loads.
+const branchCommitLimit = 30
+
+// loadBranchCommits reports the commits with prCommitsLoadedMsg, as the
+// branch commit view shares the pull request commit list and its diff split.
+func loadBranchCommits(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug, branch string) tea.Cmd {
+	return func() tea.Msg {
+		commits, err := client.ListBranchCommits(ctx, repoSlug, branch, branchCommitLimit)
+		return prCommitsLoadedMsg{commits: commits, err: err}
+	}
+}
+
+// openBranchCommits shows the newest commits of the highlighted branch.
+func openBranchCommits(m *AppModel) tea.Cmd {
+	filtered := m.getFilteredBranches()
+	if m.branchCursor >= len(filtered) {
+		return nil
+	}
+	m.commitsBranch = filtered[m.branchCursor].Name
+	m.currentView = branchCommitsView
+	return reloadBranchCommits(m, newViewContext(m))
+}
+
+// reloadBranchCommits drops the shown commits and their cached diffs and
+// loads the branch's commits again.
+func reloadBranchCommits(m *AppModel, ctx context.Context) tea.Cmd {
+	m.loading = true
+	m.prCommits = nil
+	m.prCommitCursor = 0
+	m.prCommitChanges = nil
+	m.prCommitDiff = ""
+	m.selectedCommitHash = ""
+	m.prCommitChangesCache = make(map[string][]domain.CommitChange)
+	m.prCommitDiffCache = make(map[string]string)
+	return forView(m, loadBranchCommits(ctx, m.client, m.selectedRepoSlug, m.commitsBranch))
+}
+
+// closeBranchCommits goes back to the branch list from the commits of a
+// branch or a comparison.
+func closeBranchCommits(m *AppModel) {
+	m.currentView = branchesView
+	m.prCommits = nil
+	m.prCommitCursor = 0
+	m.prCommitChanges = nil
+	m.prCommitDiff = ""
+	m.selectedCommitHash = ""
+	m.diffHOffset = 0
+	m.diffHunkCursor = 0
+}
+
+// compareLimit caps the commits counted on each side of a comparison, so two
+// long-diverged branches do not page through their whole history.
+const compareLimit = 500
+
+// branchComparison is what the compare view shows: the commits of head that
+// base lacks, and how many commits base has that head lacks. aheadMore and
+// behindMore are set when a side has more than compareLimit commits.
+type branchComparison struct {
+	head       string
+	base       string
+	ahead      []domain.Commit
+	behind     int
+	aheadMore  bool
+	behindMore bool
+}
+
+type branchComparedMsg struct {
+	comparison branchComparison
+	err        error
+}
+
+func compareBranches(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug, head, base string) tea.Cmd {
+	return func() tea.Msg {
+		comparison := branchComparison{head: head, base: base}
+		var err error
+		comparison.ahead, comparison.aheadMore, err = client.ListCommitsBetween(ctx, repoSlug, head, base, compareLimit)
+		if err != nil {
+			return branchComparedMsg{err: err}
+		}
+		behind, behindMore, err := client.ListCommitsBetween(ctx, repoSlug, base, head, compareLimit)
+		comparison.behind, comparison.behindMore = len(behind), behindMore
+		return branchComparedMsg{comparison: comparison, err: err}
+	}
+}
+
+// pickCompareBranch takes the highlighted branch as the branch to compare,
+// then as the base to compare it with, and opens the comparison.
+func pickCompareBranch(m *AppModel) tea.Cmd {
+	filtered := m.getFilteredBranches()
+	if m.branchCursor >= len(filtered) {
+		return nil
+	}
+	name := filtered[m.branchCursor].Name
+	if m.compareHead == "" {
+		m.compareHead = name
+		return nil
+	}
+	if name == m.compareHead {
+		return nil
+	}
+	m.comparison = branchComparison{head: m.compareHead, base: name}
+	m.compareHead = ""
+	m.currentView = branchCompareView
+	return reloadComparison(m, newViewContext(m))
+}
+
+// reloadComparison drops the shown commits and their cached diffs and
+// compares the branches again.
+func reloadComparison(m *AppModel, ctx context.Context) tea.Cmd {
+	m.loading = true
+	m.prCommits = nil
+	m.prCommitCursor = 0
+	m.prCommitChanges = nil
+	m.prCommitDiff = ""
+	m.selectedCommitHash = ""
+	m.prCommitChangesCache = make(map[string][]domain.CommitChange)
+	m.prCommitDiffCache = make(map[string]string)
+	return forView(m, compareBranches(ctx, m.client, m.selectedRepoSlug, m.comparison.head, m.comparison.base))
+}
+
+// comparePrompt is the help line while the base branch is picked.
+func comparePrompt(m AppModel) string {
+	return trf("Compare %s with: move to the base branch and press =  (esc: cancel)", m.compareHead)
+}
+
+// comparisonTitle sums the comparison up, e.g. "feature vs main: 3 ahead,
+// 12 behind".
+func (m AppModel) comparisonTitle() string {
+	count := func(n int, more bool) string {
+		if more {
+			return fmt.Sprintf("%d+", n)
+		}
+		return fmt.Sprint(n)
+	}
+	c := m.comparison
+	if m.loading {
+		return trf("%s vs %s", c.head, c.base)
+	}
+	return trf("%s vs %s: %s ahead, %s behind", c.head, c.base, count(len(c.ahead), c.aheadMore), count(c.behind, c.behindMore))
+}
+
+type branchCreatedMsg struct {
+	repoSlug string
+	branch   domain.Branch
+	source   string
+	err      error
+}
+
+func createBranch(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug, name, source, target string) tea.Cmd {
+	return func() tea.Msg {
+		branch, err := client.CreateBranch(ctx, repoSlug, name, target)
+		return branchCreatedMsg{repoSlug: repoSlug, branch: branch, source: source, err: err}
+	}
+}
+
+// startNewBranch opens the prompt for a new branch, starting from the
+// highlighted branch unless another branch or a commit is typed.
+func startNewBranch(m *AppModel) {
+	m.newBranchStep = "name"
+	m.newBranchName = ""
+	m.newBranchSource = ""
+	if filtered := m.getFilteredBranches(); m.branchCursor < len(filtered) {
+		m.newBranchSource = filtered[m.branchCursor].Name
+	}
+}
+
+// addBranch adds a created branch to the list and moves the cursor to it
+// when the filter shows it.
+func addBranch(m *AppModel, branch domain.Branch) {
+	m.branches = append(m.branches, branch)
+	for i, shown := range m.getFilteredBranches() {
+		if shown.Name == branch.Name {
+			m.branchCursor = i
+		}
+	}
+}
+
+// newBranchPrompt is the help line while a new branch is typed.
+func newBranchPrompt(m AppModel) string {
+	if m.newBranchStep == "source" {
+		return trf("Create %s from branch or commit: %s  (esc: cancel, enter: create)", m.newBranchName, m.newBranchSource)
+	}
+	return trf("New branch name: %s  (esc: cancel, enter: next)", m.newBranchName)
+}
+
+// handleNewBranchKey edits the name, then the source of the new branch, and
+// creates it on the second enter.
+func handleNewBranchKey(m AppModel, msg tea.KeyMsg) (AppModel, tea.Cmd) {
+	field := &m.newBranchName
+	if m.newBranchStep == "source" {
+		field = &m.newBranchSource
+	}
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.newBranchStep = ""
+	case tea.KeyEnter:
+		if m.newBranchStep == "name" {
+			m.newBranchName = strings.TrimSpace(m.newBranchName)
+			if m.newBranchName != "" {
+				m.newBranchStep = "source"
+			}
+			return m, nil
+		}
+		m.newBranchStep = ""
+		source := strings.TrimSpace(m.newBranchSource)
+		if source == "" {
+			return m, nil
+		}
+		target := source
+		for _, branch := range m.branches {
+			if branch.Name == source && branch.Target.Hash != "" {
+				target = branch.Target.Hash
+			}
+		}
+		m.message = fmt.Sprintf("Creating branch %s from %s...", m.newBranchName, source)
+		return m, startAction(&m, createBranch(m.ctx, m.client, m.selectedRepoSlug, m.newBranchName, source, target))
+	case tea.KeyBackspace:
+		if runes := []rune(*field); len(runes) > 0 {
+			*field = string(runes[:len(runes)-1])
+		}
+	case tea.KeySpace:
+		// Branch names cannot contain spaces; commit hashes neither.
+	case tea.KeyRunes:
+		*field += string(msg.Runes)
+	}
+	return m, nil
+}
+
+type branchDeletedMsg struct {
+	repoSlug string
+	name     string
+	err      error
+}
+
+func deleteBranch(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug, name string) tea.Cmd {
+	return func() tea.Msg {
+		err := client.DeleteBranch(ctx, repoSlug, name)
+		return branchDeletedMsg{repoSlug: repoSlug, name: name, err: err}
+	}
+}
+
+// isProtectedBranch reports whether deleting name deserves the extra
+// warning: the repository's main branch or one of the usual long-lived ones.
+func (m AppModel) isProtectedBranch(name string) bool {
+	switch name {
+	case "main", "master", "develop":
+		return true
+	}
+	for _, repo := range m.repositories {
+		if repo.Slug == m.selectedRepoSlug && repo.Mainbranch == name {
+			return true
+		}
+	}
+	return false
+}
+
+// confirmDeleteBranch opens the prompt where the highlighted branch's name
+// has to be typed to delete it.
+func confirmDeleteBranch(m *AppModel) {
+	filtered := m.getFilteredBranches()
+	if m.branchCursor >= len(filtered) {
+		return
+	}
+	m.deleteBranchCandidate = filtered[m.branchCursor].Name
+	m.deleteBranchText = ""
+}
+
+// deleteBranchPrompt is the help line while the branch name is typed.
+func deleteBranchPrompt(m AppModel) string {
+	prompt := trf("Type %s to delete it: %s  (esc: cancel, enter: delete)", m.deleteBranchCandidate, m.deleteBranchText)
+	if m.isProtectedBranch(m.deleteBranchCandidate) {
+		prompt = trf("WARNING: %s is a main branch. %s", m.deleteBranchCandidate, prompt)
+	}
+	return prompt
+}
+
+// handleDeleteBranchKey edits the typed name and deletes the branch on enter
+// when it matches.
+func handleDeleteBranchKey(m AppModel, msg tea.KeyMsg) (AppModel, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.deleteBranchCandidate = ""
+	case tea.KeyEnter:
+		name := m.deleteBranchCandidate
+		m.deleteBranchCandidate = ""
+		if strings.TrimSpace(m.deleteBranchText) != name {
+			m.message = "The name did not match; nothing was deleted"
+			return m, nil
+		}
+		m.message = fmt.Sprintf("Deleting branch %s...", name)
+		return m, startAction(&m, deleteBranch(m.ctx, m.client, m.selectedRepoSlug, name))
+	case tea.KeyBackspace:
+		if runes := []rune(m.deleteBranchText); len(runes) > 0 {
+			m.deleteBranchText = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes:
+		m.deleteBranchText += string(msg.Runes)
+	}
+	return m, nil
+}
+
+// removeBranch drops a deleted branch from the list.
+func removeBranch(m *AppModel, name string) {
+	m.branches = slices.DeleteFunc(m.branches, func(branch domain.Branch) bool {
+		return branch.Name == name
+	})
+	m.branchCursor = keepCursor(m.branchCursor, len(m.getFilteredBranches()))
+}
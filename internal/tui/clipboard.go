@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Values of the clipboard config key. Auto uses a local clipboard utility
+// and falls back to OSC 52 when none works, as over SSH.
+const (
+	clipboardAuto   = "auto"
+	clipboardSystem = "system"
+	clipboardOSC52  = "osc52"
+)
+
+type clipboardCopiedMsg struct {
+	label string
+	// terminal is set when the text went to the terminal's clipboard via
+	// OSC 52, which cannot confirm it arrived.
+	terminal bool
+	err      error
+}
+
+func copyToClipboard(mode, text, label string) tea.Cmd {
+	return func() tea.Msg {
+		if mode == clipboardOSC52 {
+			return clipboardCopiedMsg{label: label, terminal: true, err: writeOSC52(os.Stdout, text)}
+		}
+
+		err := copyWithUtility(text)
+		if err != nil && mode != clipboardSystem {
+			return clipboardCopiedMsg{label: label, terminal: true, err: writeOSC52(os.Stdout, text)}
+		}
+		return clipboardCopiedMsg{label: label, err: err}
+	}
+}
+
+// copyWithUtility pipes text into the first clipboard command that works.
+func copyWithUtility(text string) error {
+	var commands [][]string
+	switch runtime.GOOS {
+	case "darwin":
+		commands = [][]string{{"pbcopy"}}
+	case "windows":
+		commands = [][]string{{"clip"}}
+	default:
+		commands = [][]string{
+			{"wl-copy"},
+			{"xclip", "-selection", "clipboard"},
+			{"xsel", "--clipboard", "--input"},
+			{"clip.exe"},
+		}
+	}
+
+	var lastErr error
+	for _, parts := range commands {
+		if _, err := exec.LookPath(parts[0]); err != nil {
+			lastErr = err
+			continue
+		}
+
+		cmd := exec.Command(parts[0], parts[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		if err := cmd.Run(); err != nil {
+			lastErr = fmt.Errorf("%s failed: %w", parts[0], err)
+			continue
+		}
+
+		return nil
+	}
+
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no clipboard utility found")
+	}
+	return lastErr
+}
+
+// writeOSC52 asks the terminal to put text on the clipboard, which works
+// wherever the terminal runs, including at the far end of an SSH session.
+// Inside tmux or screen the sequence is wrapped so it reaches the outer
+// terminal; tmux also needs "set -g allow-passthrough on".
+func writeOSC52(w io.Writer, text string) error {
+	sequence := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\a"
+	switch {
+	case os.Getenv("TMUX") != "":
+		sequence = "\x1bPtmux;" + strings.ReplaceAll(sequence, "\x1b", "\x1b\x1b") + "\x1b\\"
+	case strings.HasPrefix(os.Getenv("TERM"), "screen"):
+		sequence = "\x1bP" + sequence + "\x1b\\"
+	}
+	_, err := io.WriteString(w, sequence)
+	return err
+}
@@ -0,0 +1,54 @@
+package tui
+
+import (
+	"bitbucket-cli/internal/gitrepo"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// checkedOutLabel marks the branch checked out in the local clone, and the
+// pull requests from it.
+const checkedOutLabel = "(checked out)"
+
+var checkedOutStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("42")).Bold(true)
+
+type localBranchMsg struct {
+	branch string
+}
+
+// WithLocalClone tells the app it runs inside a clone of one of the
+// workspace's repositories, so the checked-out branch can be highlighted.
+func (m AppModel) WithLocalClone(clone gitrepo.Clone) AppModel {
+	m.localClone = clone
+	return m
+}
+
+// loadLocalBranch re-reads the checked-out branch, which may have changed
+// since startup. It runs git, so it is kept off the update loop.
+func loadLocalBranch(m *AppModel) tea.Cmd {
+	if m.localClone.Root == "" || m.selectedRepoSlug != m.localClone.Repo {
+		return nil
+	}
+	root := m.localClone.Root
+	return func() tea.Msg {
+		return localBranchMsg{branch: gitrepo.CurrentBranch(root)}
+	}
+}
+
+// isCheckedOut reports whether branch of the selected repository is the one
+// checked out locally.
+func (m AppModel) isCheckedOut(branch string) bool {
+	return branch != "" &&
+		m.localClone.Branch == branch &&
+		m.localClone.Repo != "" &&
+		m.selectedRepoSlug == m.localClone.Repo
+}
+
+// checkedOutMarker returns the label appended to rows for branch, or "".
+func (m AppModel) checkedOutMarker(branch string) string {
+	if !m.isCheckedOut(branch) {
+		return ""
+	}
+	return " " + checkedOutStyle.Render(checkedOutLabel)
+}
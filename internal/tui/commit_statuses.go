@@ -0,0 +1,94 @@
+package tui
+
+import (
+	"context"
+	"strings"
+
+	"bitbucket-cli/internal/bitbucket"
+	"bitbucket-cli/internal/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type commitStatusesLoadedMsg struct {
+	hash     string
+	statuses []domain.BuildStatus
+	err      error
+}
+
+func loadCommitStatuses(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug, hash string) tea.Cmd {
+	return func() tea.Msg {
+		statuses, err := client.ListCommitStatuses(ctx, repoSlug, hash)
+		return commitStatusesLoadedMsg{hash: hash, statuses: statuses, err: err}
+	}
+}
+
+// buildsSettled reports whether every build of a commit has finished. A
+// commit without builds may still get some, so it is not settled.
+func buildsSettled(statuses []domain.BuildStatus) bool {
+	for _, status := range statuses {
+		if strings.EqualFold(status.State, "INPROGRESS") {
+			return false
+		}
+	}
+	return len(statuses) > 0
+}
+
+// loadSourceStatuses fetches the build statuses of the source commits of
+// prs, skipping those already loading and those whose builds have all
+// finished, as a commit's builds only change again when rerun.
+func loadSourceStatuses(m *AppModel, repoSlug string, prs []domain.PullRequest) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, pr := range prs {
+		hash := pr.SourceCommit
+		if hash == "" || m.prefetch.inFlight["statuses:"+hash] || buildsSettled(m.commitStatuses[hash]) {
+			continue
+		}
+		m.prefetch.inFlight["statuses:"+hash] = true
+		cmds = append(cmds, loadCommitStatuses(m.ctx, m.client, repoSlug, hash))
+	}
+	return tea.Batch(cmds...)
+}
+
+// storeCommitStatuses keeps the statuses of a commit. A failed load is kept
+// as nil, which the list shows as no builds, and is retried with the next
+// list.
+func storeCommitStatuses(m *AppModel, msg commitStatusesLoadedMsg) {
+	delete(m.prefetch.inFlight, "statuses:"+msg.hash)
+	if msg.err != nil {
+		m.commitStatuses[msg.hash] = nil
+		return
+	}
+	if msg.statuses == nil {
+		msg.statuses = []domain.BuildStatus{}
+	}
+	m.commitStatuses[msg.hash] = msg.statuses
+}
+
+// buildIndicator sums up the builds of a commit in one mark: ✗ when one
+// failed or was stopped, ● while one runs and ✓ when all passed. It is empty
+// when no build reported on the commit or the statuses are not loaded yet.
+func buildIndicator(statuses []domain.BuildStatus) string {
+	if len(statuses) == 0 {
+		return ""
+	}
+	color, symbol, text := currentTheme.success, "✓", "builds passed"
+	for _, status := range statuses {
+		switch strings.ToUpper(status.State) {
+		case "FAILED", "STOPPED":
+			color, symbol, text = currentTheme.failure, "✗", "builds failed"
+		case "INPROGRESS":
+			if symbol == "✓" {
+				color, symbol, text = currentTheme.running, "●", "builds running"
+			}
+		}
+		if symbol == "✗" {
+			break
+		}
+	}
+	if currentTheme.plain {
+		return tr(text)
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render(symbol)
+}
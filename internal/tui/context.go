@@ -0,0 +1,125 @@
+package tui
+
+import (
+	"context"
+	"errors"
+
+	"bitbucket-cli/internal/bitbucket"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// viewResultMsg is the result of a command started for a view, tagged with
+// the view generation it was started in.
+type viewResultMsg struct {
+	gen int
+	msg tea.Msg
+}
+
+// newViewContext cancels the requests of the view being left and returns the
+// context for the requests of the next one. It also starts a new view
+// generation, so results still on their way for the old view are discarded;
+// as they will never finish the old view's loading, it ends here. Views set
+// loading again after calling it.
+func newViewContext(m *AppModel) context.Context {
+	if m.cancelView != nil {
+		m.cancelView()
+	}
+	m.loading = false
+	m.viewGen++
+	m.viewCtx, m.cancelView = context.WithCancel(m.ctx)
+	return m.viewCtx
+}
+
+// refreshViewContext is newViewContext for an explicit refresh: its requests
+// bypass the client's response cache.
+func refreshViewContext(m *AppModel) context.Context {
+	m.viewCtx = bitbucket.WithoutCache(newViewContext(m))
+	return m.viewCtx
+}
+
+// forView tags the result of cmd with the current view generation. Call it
+// after newViewContext, since the generation is read when forView runs.
+func forView(m *AppModel, cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	gen := m.viewGen
+	return func() tea.Msg {
+		return viewResultMsg{gen: gen, msg: cmd()}
+	}
+}
+
+// unwrapViewResult unwraps a viewResultMsg. ok is false when the result
+// belongs to a view generation that has since been left and must be dropped;
+// newViewContext already ended that generation's loading.
+func unwrapViewResult(m *AppModel, msg tea.Msg) (tea.Msg, bool) {
+	result, wrapped := msg.(viewResultMsg)
+	if !wrapped {
+		return msg, true
+	}
+	return result.msg, result.gen == m.viewGen
+}
+
+// canceledResult reports whether msg is the result of a request cancelled by
+// navigating away. Such results are dropped instead of touching the new view.
+func canceledResult(msg tea.Msg) bool {
+	var err error
+	switch msg := msg.(type) {
+	case branchesLoadedMsg:
+		err = msg.err
+	case pullRequestsLoadedMsg:
+		err = msg.err
+	case prCommitsLoadedMsg:
+		err = msg.err
+	case branchComparedMsg:
+		err = msg.err
+	case prCommitChangesLoadedMsg:
+		err = msg.err
+	case prCommitDiffLoadedMsg:
+		err = msg.err
+	case prDiffLoadedMsg:
+		err = msg.err
+	case pipelinesLoadedMsg:
+		err = msg.err
+	case pipelineStepsLoadedMsg:
+		err = msg.err
+	case pipelineHeaderLoadedMsg:
+		err = msg.err
+	case pipelineStepLogLoadedMsg:
+		err = msg.err
+	case stepLogFollowedMsg:
+		err = msg.err
+	case stepCommandsLoadedMsg:
+		err = msg.err
+	case artifactsLoadedMsg:
+		err = msg.err
+	case schedulesLoadedMsg:
+		err = msg.err
+	case deploymentsLoadedMsg:
+		err = msg.err
+	case pipelinePolledMsg:
+		err = msg.err
+	case homeMyPRsLoadedMsg:
+		err = msg.err
+	case homeReviewPRsLoadedMsg:
+		err = msg.err
+	case homePipelineLoadedMsg:
+		err = msg.err
+	case feedPullRequestsLoadedMsg:
+		err = msg.err
+	case feedPipelinesLoadedMsg:
+		err = msg.err
+	case staleApprovalsLoadedMsg:
+		err = msg.err
+	case downloadsLoadedMsg:
+		err = msg.err
+	case tagsLoadedMsg:
+		err = msg.err
+	case projectsLoadedMsg:
+		err = msg.err
+	case projectRepositoriesLoadedMsg:
+		err = msg.err
+	}
+	return errors.Is(err, context.Canceled)
+}
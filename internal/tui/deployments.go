@@ -0,0 +1,232 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"bitbucket-cli/internal/bitbucket"
+	"bitbucket-cli/internal/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// environmentTypeOrder lists environments in the order releases go through
+// them.
+var environmentTypeOrder = map[string]int{"test": 0, "staging": 1, "production": 2}
+
+// environmentStatus is an environment with its latest deployment, zero when
+// none of the listed deployments went to it. The fields are exported for the
+// snapshot.
+type environmentStatus struct {
+	Environment domain.Environment
+	Deployment  domain.Deployment
+}
+
+type deploymentsLoadedMsg struct {
+	repoSlug     string
+	environments []environmentStatus
+	err          error
+}
+
+func loadDeployments(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug string) tea.Cmd {
+	return func() tea.Msg {
+		environments, err := client.ListEnvironments(ctx, repoSlug)
+		if err != nil {
+			return deploymentsLoadedMsg{repoSlug: repoSlug, err: err}
+		}
+		deployments, err := client.ListDeployments(ctx, repoSlug)
+		if err != nil {
+			return deploymentsLoadedMsg{repoSlug: repoSlug, err: err}
+		}
+		return deploymentsLoadedMsg{repoSlug: repoSlug, environments: latestDeployments(environments, deployments)}
+	}
+}
+
+// latestDeployments pairs each environment with its most recent deployment
+// and orders them test, staging, production. A deployment waiting for its
+// step has not started and counts from when its release was created.
+func latestDeployments(environments []domain.Environment, deployments []domain.Deployment) []environmentStatus {
+	latest := make(map[string]domain.Deployment)
+	for _, deployment := range deployments {
+		current, ok := latest[deployment.EnvironmentUUID]
+		if !ok || deploymentTime(deployment) > deploymentTime(current) {
+			latest[deployment.EnvironmentUUID] = deployment
+		}
+	}
+
+	statuses := make([]environmentStatus, 0, len(environments))
+	for _, environment := range environments {
+		statuses = append(statuses, environmentStatus{Environment: environment, Deployment: latest[environment.UUID]})
+	}
+	sort.SliceStable(statuses, func(i, j int) bool {
+		a, b := statuses[i].Environment, statuses[j].Environment
+		if order, other := environmentTypeOrder[strings.ToLower(a.Type)], environmentTypeOrder[strings.ToLower(b.Type)]; order != other {
+			return order < other
+		}
+		return a.Rank < b.Rank
+	})
+	return statuses
+}
+
+func deploymentTime(deployment domain.Deployment) string {
+	if deployment.StartedOn != "" {
+		return deployment.StartedOn
+	}
+	return deployment.CreatedOn
+}
+
+// isDeploymentWaiting reports whether a deployment's step is paused, waiting
+// to be run.
+func isDeploymentWaiting(deployment domain.Deployment) bool {
+	return strings.EqualFold(deployment.State, "undeployed")
+}
+
+// deploymentPipeline is the pipeline a deployment ran in, numbered after its
+// release when the release is named "#<build number>".
+func deploymentPipeline(deployment domain.Deployment) domain.Pipeline {
+	buildNumber, _ := strconv.Atoi(strings.TrimPrefix(deployment.Release, "#"))
+	return domain.Pipeline{UUID: deployment.PipelineUUID, BuildNumber: buildNumber, CommitHash: deployment.CommitHash}
+}
+
+// redeploy acts on the highlighted environment's latest deployment. For a
+// completed one it offers to run its pipeline again, after a y/n answer; the
+// API reruns every step of it, not only the deployment. Bitbucket's API
+// cannot run a paused step, so a waiting deployment is promoted from its
+// pipeline's page, which is opened in the browser.
+func redeploy(m *AppModel) tea.Cmd {
+	if m.environmentCursor >= len(m.environments) {
+		return nil
+	}
+	status := m.environments[m.environmentCursor]
+	deployment := status.Deployment
+	if deployment.PipelineUUID == "" {
+		m.message = fmt.Sprintf("Nothing was deployed to %s", status.Environment.Name)
+		return nil
+	}
+	pipeline := deploymentPipeline(deployment)
+	if isDeploymentWaiting(deployment) {
+		url := m.selectedItemURL()
+		if url == "" {
+			m.message = fmt.Sprintf("Pipeline #%d waits for its step to %s to be run in Bitbucket", pipeline.BuildNumber, status.Environment.Name)
+			return nil
+		}
+		m.message = fmt.Sprintf("Opening pipeline #%d to run its paused step to %s", pipeline.BuildNumber, status.Environment.Name)
+		return openURL(m.browserCommand, url)
+	}
+	m.rerunCandidate = pipeline
+	m.message = fmt.Sprintf("Run pipeline #%d again, all its steps, which deployed %s to %s? (y/n)", pipeline.BuildNumber, shortHash(deployment.CommitHash), status.Environment.Name)
+	return nil
+}
+
+// deploymentURL is the page of the pipeline a deployment ran in, or of the
+// repository's deployments when the pipeline is unknown.
+func deploymentURL(repoURL string, deployment domain.Deployment) string {
+	if pipeline := deploymentPipeline(deployment); pipeline.BuildNumber > 0 {
+		return fmt.Sprintf("%s/pipelines/results/%d", repoURL, pipeline.BuildNumber)
+	}
+	return repoURL + "/deployments"
+}
+
+// formatDeploymentState is the badge of a deployment: its result once
+// completed, else whether it runs or waits.
+func formatDeploymentState(deployment domain.Deployment) string {
+	switch strings.ToLower(deployment.State) {
+	case "":
+		return currentTheme.badge(currentTheme.muted, "○", "NONE")
+	case "completed":
+		return formatPipelineResult(deployment.Result)
+	case "undeployed":
+		return formatPipelineState("paused")
+	default:
+		return formatPipelineState(deployment.State)
+	}
+}
+
+func (m AppModel) renderDeploymentsPane() string {
+	showRepoPane := m.currentView == noSelection || m.activePane == repoPane
+
+	paneWidth := m.width - 4
+	if showRepoPane {
+		repoPaneWidth := (m.width - 10) / 3
+		if repoPaneWidth < 20 {
+			repoPaneWidth = 20
+		}
+		paneWidth = m.width - repoPaneWidth - 10
+	}
+	if paneWidth < 30 {
+		paneWidth = 30
+	}
+
+	availableHeight := m.height - 6
+	if availableHeight < 5 {
+		availableHeight = 5
+	}
+
+	title := tr("Deployments")
+	if m.selectedRepo != "" {
+		title = fmt.Sprintf("(%s)", m.selectedRepo)
+	}
+	title = m.withCacheLabel(title)
+	if !showRepoPane {
+		title = trf("%s (esc: back)", title)
+	}
+
+	if m.activePane == branchPane {
+		title = activePaneStyle.Render(title)
+	} else {
+		title = inactivePaneStyle.Render(title)
+	}
+
+	var items []string
+	items = append(items, m.renderRightTabs())
+	items = append(items, title)
+	items = append(items, "")
+
+	if m.loading && m.currentView == deploymentsView {
+		items = append(items, m.renderSkeletonRows(paneWidth, availableHeight-3)...)
+	} else if len(m.environments) == 0 {
+		items = append(items, tr("No deployment environments"))
+	} else {
+		start, end := m.calculateWindow(m.environmentCursor, len(m.environments), availableHeight-3)
+
+		typeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("99"))
+		for i := start; i < end; i++ {
+			status := m.environments[i]
+			deployment := status.Deployment
+			cursor := " "
+			if m.activePane == branchPane && i == m.environmentCursor {
+				cursor = cursorStyle.Render(">")
+			}
+			line := fmt.Sprintf("%s %-16s %s", cursor, status.Environment.Name, typeStyle.Render(fmt.Sprintf("%-10s", tr(status.Environment.Type))))
+			line = fmt.Sprintf("%s %s", line, formatDeploymentState(deployment))
+			if deployment.UUID != "" {
+				line = fmt.Sprintf("%s %s %s", line, deployment.Release, cursorStyle.Render(shortHash(deployment.CommitHash)))
+				if ago := timeAgo(deploymentTime(deployment)); ago != "" {
+					line = fmt.Sprintf("%s  %s", line, inactivePaneStyle.Render(ago))
+				}
+			}
+			items = append(items, ansi.Truncate(line, paneWidth-2, "…"))
+		}
+
+		if start > 0 {
+			items[2] = inactivePaneStyle.Render(moreAbove())
+		}
+		if end < len(m.environments) {
+			items = append(items, inactivePaneStyle.Render(moreBelow()))
+		}
+	}
+
+	content := strings.Join(items, "\n")
+	style := lipgloss.NewStyle().
+		Width(paneWidth).
+		Height(availableHeight).
+		Padding(0, 1).
+		Faint(m.fadingIn())
+
+	return style.Render(content)
+}
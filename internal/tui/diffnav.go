@@ -0,0 +1,150 @@
+package tui
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type fileEditedMsg struct {
+	path string
+	err  error
+}
+
+// diffHunk is one "@@" section of a unified diff.
+type diffHunk struct {
+	// path is the file in the new version, relative to the repository root.
+	path string
+	// line is the first added line of the hunk in the new version, or its
+	// first line when it only removes.
+	line int
+	// offset is the index of the "@@" header among the diff's lines.
+	offset int
+}
+
+// parseDiffHunks finds the hunks of a unified git diff. Hunks of deleted
+// files are skipped, since there is nothing to open.
+func parseDiffHunks(diff string) []diffHunk {
+	var hunks []diffHunk
+	path := ""
+	inHunk, foundAdded := false, false
+	newLine := 0
+	for i, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "+++ "):
+			path = strings.TrimPrefix(strings.TrimPrefix(line, "+++ "), "b/")
+			if path == "/dev/null" {
+				path = ""
+			}
+			inHunk = false
+		case strings.HasPrefix(line, "@@ "):
+			start, ok := hunkNewStart(line)
+			inHunk = ok && path != ""
+			if !inHunk {
+				continue
+			}
+			hunks = append(hunks, diffHunk{path: path, line: start, offset: i})
+			foundAdded = false
+			newLine = start
+		case !inHunk:
+		case strings.HasPrefix(line, "+"):
+			if !foundAdded {
+				hunks[len(hunks)-1].line = newLine
+				foundAdded = true
+			}
+			newLine++
+		case strings.HasPrefix(line, " "):
+			newLine++
+		}
+	}
+	return hunks
+}
+
+// hunkNewStart reads the new-file start line from a header such as
+// "@@ -10,6 +12,8 @@ func main() {".
+func hunkNewStart(header string) (int, bool) {
+	for _, field := range strings.Fields(header) {
+		if rest, ok := strings.CutPrefix(field, "+"); ok {
+			start, _, _ := strings.Cut(rest, ",")
+			n, err := strconv.Atoi(start)
+			return max(n, 1), err == nil
+		}
+	}
+	return 0, false
+}
+
+// selectedDiffHunk returns the hunk under the hunk cursor of the commit diff.
+func (m AppModel) selectedDiffHunk() (diffHunk, []diffHunk, bool) {
+	hunks := parseDiffHunks(m.prCommitDiff)
+	if len(hunks) == 0 {
+		return diffHunk{}, nil, false
+	}
+	cursor := min(max(m.diffHunkCursor, 0), len(hunks)-1)
+	return hunks[cursor], hunks, true
+}
+
+// moveDiffHunk moves the hunk cursor of the commit diff by delta.
+func moveDiffHunk(m *AppModel, delta int) {
+	hunks := parseDiffHunks(m.prCommitDiff)
+	if len(hunks) == 0 {
+		return
+	}
+	m.diffHunkCursor = min(max(m.diffHunkCursor+delta, 0), len(hunks)-1)
+}
+
+// openDiffHunkInEditor opens the file of the selected hunk at its line in the
+// local clone. The clone may be on another revision, so the line is a best
+// guess.
+func openDiffHunkInEditor(m *AppModel) tea.Cmd {
+	hunk, _, ok := m.selectedDiffHunk()
+	if !ok {
+		m.message = "No hunk selected"
+		return nil
+	}
+	if m.localClone.Root == "" || m.selectedRepoSlug != m.localClone.Repo {
+		m.message = fmt.Sprintf("Start bitbucket-cli inside a clone of %s to open files", m.selectedRepoSlug)
+		return nil
+	}
+
+	path := filepath.Join(m.localClone.Root, filepath.FromSlash(hunk.path))
+	cmd, err := editorAtLine(m.editor, path, hunk.line)
+	if err != nil {
+		m.message = fmt.Sprintf("Editor error: %v", err)
+		return nil
+	}
+	cmd.Dir = m.localClone.Root
+	return tea.ExecProcess(cmd, func(execErr error) tea.Msg {
+		return fileEditedMsg{path: hunk.path, err: execErr}
+	})
+}
+
+// editorAtLine builds the command opening path at line. Most terminal
+// editors take "+line path"; a few GUI editors want "path:line".
+func editorAtLine(editor []string, path string, line int) (*exec.Cmd, error) {
+	if len(editor) == 0 {
+		for _, name := range []string{"nvim", "vi"} {
+			if _, err := exec.LookPath(name); err == nil {
+				editor = []string{name}
+				break
+			}
+		}
+		if len(editor) == 0 {
+			return nil, fmt.Errorf("none of nvim, vi is installed")
+		}
+	}
+
+	args := append([]string{}, editor[1:]...)
+	switch filepath.Base(editor[0]) {
+	case "code", "code-insiders", "codium":
+		args = append(args, "--goto", fmt.Sprintf("%s:%d", path, line))
+	case "subl", "zed":
+		args = append(args, fmt.Sprintf("%s:%d", path, line))
+	default:
+		args = append(args, fmt.Sprintf("+%d", line), path)
+	}
+	return exec.Command(editor[0], args...), nil
+}
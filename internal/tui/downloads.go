@@ -0,0 +1,240 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"bitbucket-cli/internal/bitbucket"
+	"bitbucket-cli/internal/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type downloadsLoadedMsg struct {
+	repoSlug  string
+	downloads []domain.Download
+	err       error
+}
+
+type downloadSavedMsg struct {
+	path string
+	err  error
+}
+
+type downloadUploadedMsg struct {
+	repoSlug string
+	name     string
+	err      error
+}
+
+func loadDownloads(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug string) tea.Cmd {
+	return func() tea.Msg {
+		downloads, err := client.ListDownloads(ctx, repoSlug)
+		return downloadsLoadedMsg{repoSlug: repoSlug, downloads: downloads, err: err}
+	}
+}
+
+// saveDownload streams a download into dir under its own name. An existing
+// file is left alone and reported instead; a failed download leaves nothing
+// behind.
+func saveDownload(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug, name, dir string) tea.Cmd {
+	path := filepath.Join(dir, filepath.Base(name))
+	return func() tea.Msg {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return downloadSavedMsg{path: path, err: err}
+		}
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if errors.Is(err, fs.ErrExist) {
+			return downloadSavedMsg{path: path, err: fmt.Errorf("%s already exists", path)}
+		}
+		if err != nil {
+			return downloadSavedMsg{path: path, err: err}
+		}
+		err = client.GetDownload(ctx, repoSlug, name, file)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(path)
+		}
+		return downloadSavedMsg{path: path, err: err}
+	}
+}
+
+func uploadDownload(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug, path string) tea.Cmd {
+	name := filepath.Base(path)
+	return func() tea.Msg {
+		content, err := os.ReadFile(path)
+		if err == nil {
+			err = client.UploadDownload(ctx, repoSlug, name, content)
+		}
+		return downloadUploadedMsg{repoSlug: repoSlug, name: name, err: err}
+	}
+}
+
+// downloadSelected saves the highlighted download to the export directory.
+func downloadSelected(m *AppModel) tea.Cmd {
+	filtered := m.getFilteredDownloads()
+	if m.downloadCursor >= len(filtered) {
+		return nil
+	}
+	name := filtered[m.downloadCursor].Name
+	m.message = fmt.Sprintf("Downloading %s...", name)
+	return saveDownload(m.ctx, m.client, m.selectedRepoSlug, name, m.exportDir)
+}
+
+func startUpload(m *AppModel) {
+	m.uploadMode = true
+	m.uploadPath = ""
+}
+
+// handleUploadKey edits the path typed at the upload prompt and uploads the
+// file on enter.
+func handleUploadKey(m AppModel, msg tea.KeyMsg) (AppModel, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.uploadMode = false
+		m.uploadPath = ""
+	case tea.KeyEnter:
+		m.uploadMode = false
+		path := strings.TrimSpace(m.uploadPath)
+		m.uploadPath = ""
+		if path == "" {
+			return m, nil
+		}
+		if rest, ok := strings.CutPrefix(path, "~/"); ok {
+			if home, err := os.UserHomeDir(); err == nil {
+				path = filepath.Join(home, rest)
+			}
+		}
+		m.message = fmt.Sprintf("Uploading %s...", filepath.Base(path))
+		return m, startAction(&m, uploadDownload(m.ctx, m.client, m.selectedRepoSlug, path))
+	case tea.KeyBackspace:
+		if len(m.uploadPath) > 0 {
+			m.uploadPath = m.uploadPath[:len(m.uploadPath)-1]
+		}
+	case tea.KeySpace:
+		m.uploadPath += " "
+	case tea.KeyRunes:
+		m.uploadPath += string(msg.Runes)
+	}
+	return m, nil
+}
+
+func (m AppModel) getFilteredDownloads() []domain.Download {
+	if m.downloadFilterQuery == "" {
+		return m.downloads
+	}
+
+	query := strings.ToLower(m.downloadFilterQuery)
+	return cachedFilter(m.filters, "downloads", query, m.downloads, func(download domain.Download) bool {
+		return strings.Contains(strings.ToLower(download.Name), query)
+	})
+}
+
+// formatSize renders a byte count in binary units, e.g. "4.2 MB".
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	value, exp := float64(size)/unit, 0
+	for value >= unit && exp < 3 {
+		value /= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", value, "KMGT"[exp])
+}
+
+func (m AppModel) renderDownloadsPane() string {
+	showRepoPane := m.currentView == noSelection || m.activePane == repoPane
+
+	paneWidth := m.width - 4
+	if showRepoPane {
+		repoPaneWidth := (m.width - 10) / 3
+		if repoPaneWidth < 20 {
+			repoPaneWidth = 20
+		}
+		paneWidth = m.width - repoPaneWidth - 10
+	}
+	if paneWidth < 30 {
+		paneWidth = 30
+	}
+
+	availableHeight := m.height - 6
+	if availableHeight < 5 {
+		availableHeight = 5
+	}
+
+	title := tr("Downloads")
+	if m.selectedRepo != "" {
+		title = fmt.Sprintf("(%s)", m.selectedRepo)
+	}
+	if m.downloadFilterQuery != "" {
+		title = fmt.Sprintf("[/%s]", m.downloadFilterQuery)
+	}
+	title = m.withCacheLabel(title)
+	if !showRepoPane {
+		title = trf("%s (esc: back)", title)
+	}
+
+	if m.activePane == branchPane {
+		title = activePaneStyle.Render(title)
+	} else {
+		title = inactivePaneStyle.Render(title)
+	}
+
+	var items []string
+	items = append(items, m.renderRightTabs())
+	items = append(items, title)
+	items = append(items, "")
+
+	if m.loading && m.activePane == branchPane && m.currentView == downloadsView {
+		items = append(items, m.renderSkeletonRows(paneWidth, availableHeight-3)...)
+	} else if len(m.downloads) == 0 {
+		items = append(items, tr("No downloads"))
+	} else {
+		filtered := m.getFilteredDownloads()
+		if len(filtered) == 0 {
+			items = append(items, tr("No matches"))
+		} else {
+			start, end := m.calculateWindow(m.downloadCursor, len(filtered), availableHeight-3)
+
+			uploaderStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("99"))
+			for i := start; i < end; i++ {
+				download := filtered[i]
+				cursor := " "
+				if m.activePane == branchPane && i == m.downloadCursor {
+					cursor = cursorStyle.Render(">")
+				}
+				details := fmt.Sprintf("%9s  %s  %d downloads", formatSize(download.Size), shortTimestamp(download.CreatedOn), download.Downloads)
+				line := fmt.Sprintf("%s %-40s %s", cursor, download.Name, inactivePaneStyle.Render(details))
+				if download.Uploader != "" {
+					line = fmt.Sprintf("%s %s", line, uploaderStyle.Render("@"+authorLabel(m.currentUser, download.Uploader)))
+				}
+				items = append(items, line)
+			}
+
+			if start > 0 {
+				items[2] = inactivePaneStyle.Render(moreAbove())
+			}
+			if end < len(filtered) {
+				items = append(items, inactivePaneStyle.Render(moreBelow()))
+			}
+		}
+	}
+
+	content := strings.Join(items, "\n")
+	style := lipgloss.NewStyle().
+		Width(paneWidth).
+		Height(availableHeight).
+		Padding(0, 1)
+
+	return style.Render(content)
+}
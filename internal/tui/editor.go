@@ -0,0 +1,154 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// textEditedMsg carries the text written in the external editor. purpose tells
+// the caller which flow (PR description, comment, ...) asked for it.
+type textEditedMsg struct {
+	purpose string
+	text    string
+	err     error
+}
+
+// resolveCommand picks the configured command, then the first set environment
+// variable. An empty result means the built-in fallbacks apply.
+func resolveCommand(configured string, envVars ...string) []string {
+	if fields := strings.Fields(configured); len(fields) > 0 {
+		return fields
+	}
+	for _, name := range envVars {
+		if fields := strings.Fields(os.Getenv(name)); len(fields) > 0 {
+			return fields
+		}
+	}
+	return nil
+}
+
+// externalCommand builds the command to run for filePath, using preferred when
+// set and otherwise the first installed fallback.
+func externalCommand(preferred []string, filePath string, fallbacks ...string) (*exec.Cmd, error) {
+	if len(preferred) > 0 {
+		args := append(append([]string{}, preferred[1:]...), filePath)
+		return exec.Command(preferred[0], args...), nil
+	}
+
+	for _, name := range fallbacks {
+		if _, err := exec.LookPath(name); err == nil {
+			return exec.Command(name, filePath), nil
+		}
+	}
+	return nil, fmt.Errorf("none of %s is installed", strings.Join(fallbacks, ", "))
+}
+
+func writeTempFile(pattern, content string) (string, error) {
+	tmpFile, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", err
+	}
+
+	filePath := tmpFile.Name()
+	if _, err := tmpFile.WriteString(content); err != nil {
+		_ = tmpFile.Close()
+		_ = os.Remove(filePath)
+		return "", err
+	}
+	if err := tmpFile.Close(); err != nil {
+		_ = os.Remove(filePath)
+		return "", err
+	}
+	return filePath, nil
+}
+
+func openLogInEditor(viewer []string, logContent, stepName string) tea.Cmd {
+	content := logContent
+	if strings.TrimSpace(content) == "" {
+		content = "No log output returned for this step."
+	}
+
+	title := "pipeline-log"
+	if strings.TrimSpace(stepName) != "" {
+		title = strings.ReplaceAll(strings.TrimSpace(stepName), " ", "-")
+	}
+
+	filePath, err := writeTempFile(fmt.Sprintf("bb-%s-*.log", title), content)
+	if err != nil {
+		return func() tea.Msg { return editorClosedMsg{err: err} }
+	}
+
+	cmd, err := externalCommand(viewer, filePath, "nvim", "less")
+	if err != nil {
+		_ = os.Remove(filePath)
+		return func() tea.Msg { return editorClosedMsg{err: err} }
+	}
+
+	return tea.ExecProcess(cmd, func(execErr error) tea.Msg {
+		_ = os.Remove(filePath)
+		return editorClosedMsg{err: execErr}
+	})
+}
+
+// openDiff shows a diff through pager, a shell command reading the diff on
+// stdin such as "delta" or "diff-so-fancy | less -R". Without a pager the diff
+// opens in the viewer like a log.
+func openDiff(pager string, viewer []string, diff, name string) tea.Cmd {
+	if strings.TrimSpace(pager) == "" {
+		return openLogInEditor(viewer, diff, name)
+	}
+
+	filePath, err := writeTempFile(fmt.Sprintf("bb-%s-*.diff", name), diff)
+	if err != nil {
+		return func() tea.Msg { return editorClosedMsg{err: err} }
+	}
+	file, err := os.Open(filePath)
+	if err != nil {
+		_ = os.Remove(filePath)
+		return func() tea.Msg { return editorClosedMsg{err: err} }
+	}
+
+	cmd := exec.Command("sh", "-c", pager)
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd.exe", "/c", pager)
+	}
+	cmd.Stdin = file
+
+	return tea.ExecProcess(cmd, func(execErr error) tea.Msg {
+		_ = file.Close()
+		_ = os.Remove(filePath)
+		if execErr != nil {
+			execErr = fmt.Errorf("diff pager: %w", execErr)
+		}
+		return editorClosedMsg{err: execErr}
+	})
+}
+
+// editText opens initial in the external editor and reports the saved text
+// back as a textEditedMsg once the editor exits.
+func editText(editor []string, purpose, initial string) tea.Cmd {
+	filePath, err := writeTempFile("bb-"+purpose+"-*.md", initial)
+	if err != nil {
+		return func() tea.Msg { return textEditedMsg{purpose: purpose, err: err} }
+	}
+
+	cmd, err := externalCommand(editor, filePath, "nvim", "vi")
+	if err != nil {
+		_ = os.Remove(filePath)
+		return func() tea.Msg { return textEditedMsg{purpose: purpose, err: err} }
+	}
+
+	return tea.ExecProcess(cmd, func(execErr error) tea.Msg {
+		defer os.Remove(filePath)
+		if execErr != nil {
+			return textEditedMsg{purpose: purpose, err: execErr}
+		}
+		data, readErr := os.ReadFile(filePath)
+		return textEditedMsg{purpose: purpose, text: string(data), err: readErr}
+	})
+}
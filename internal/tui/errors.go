@@ -0,0 +1,36 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"bitbucket-cli/internal/bitbucket"
+)
+
+// describeError turns client errors into guidance the user can act on instead
+// of the raw response body. Other errors are shown as they are.
+func describeError(err error) string {
+	var apiErr *bitbucket.APIError
+	if !errors.As(err, &apiErr) {
+		return err.Error()
+	}
+
+	switch {
+	case errors.Is(err, bitbucket.ErrUnauthorized):
+		return "authentication failed: check the credentials in your profile"
+	case errors.Is(err, bitbucket.ErrForbidden):
+		return fmt.Sprintf("access denied to %s: the token may lack the %s scope", apiErr.Endpoint, apiErr.Scope())
+	case errors.Is(err, bitbucket.ErrNotFound):
+		return fmt.Sprintf("%s not found: it may have been deleted or you lack access", apiErr.Endpoint)
+	case errors.Is(err, bitbucket.ErrRateLimited):
+		if apiErr.RetryAfter > 0 {
+			return fmt.Sprintf("rate limited by Bitbucket, try again in %s", apiErr.RetryAfter.Round(time.Second))
+		}
+		return "rate limited by Bitbucket, try again shortly"
+	case errors.Is(err, bitbucket.ErrServer):
+		return fmt.Sprintf("Bitbucket returned %d for %s, try again later", apiErr.StatusCode, apiErr.Endpoint)
+	default:
+		return apiErr.Error()
+	}
+}
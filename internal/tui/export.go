@@ -0,0 +1,92 @@
+package tui
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"bitbucket-cli/internal/domain"
+	"bitbucket-cli/internal/report"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type exportedMsg struct {
+	path string
+	err  error
+}
+
+// exportView writes what the current view shows to a Markdown file in the
+// export directory: the filtered pull request or pipeline list, or the
+// review summary of the pull request whose commits are open.
+func exportView(m *AppModel) tea.Cmd {
+	if m.activePane != branchPane || m.selectedRepoSlug == "" {
+		m.message = "Nothing to export here: open pull requests, pipelines or a pull request's commits"
+		return nil
+	}
+
+	repo := m.selectedRepoSlug
+	now := time.Now()
+	var kind string
+	var write func(io.Writer) error
+	switch m.currentView {
+	case prView:
+		prs := m.getFilteredPRs()
+		kind = "prs"
+		write = func(w io.Writer) error { return report.PullRequests(w, repo, prs, now) }
+	case pipelinesView:
+		pipelines := m.getFilteredPipelines()
+		kind = "pipelines"
+		write = func(w io.Writer) error { return report.Pipelines(w, repo, pipelines, now) }
+	case prCommitsView:
+		pr, loaded := m.loadedPullRequest(m.selectedPullRequestID)
+		commits := m.prCommits
+		ctx, client, id := m.ctx, m.client, m.selectedPullRequestID
+		kind = fmt.Sprintf("pr-%d", id)
+		write = func(w io.Writer) error {
+			// Pull requests opened from the home or watch view are not in
+			// the list, so their details are fetched.
+			if !loaded {
+				var err error
+				if pr, err = client.GetPullRequest(ctx, repo, id); err != nil {
+					return err
+				}
+			}
+			return report.Review(w, repo, pr, commits, now)
+		}
+	default:
+		m.message = "Nothing to export here: open pull requests, pipelines or a pull request's commits"
+		return nil
+	}
+
+	path := filepath.Join(m.exportDir, fmt.Sprintf("%s-%s-%s.md", repo, kind, now.Format("20060102-150405")))
+	return func() tea.Msg {
+		return exportedMsg{path: path, err: writeExport(path, write)}
+	}
+}
+
+// writeExport renders the report before creating the file, so a failed
+// export leaves nothing behind.
+func writeExport(path string, write func(io.Writer) error) error {
+	var buf bytes.Buffer
+	if err := write(&buf); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
+
+// loadedPullRequest looks up a pull request of the loaded list.
+func (m AppModel) loadedPullRequest(id int) (domain.PullRequest, bool) {
+	for _, pr := range m.pullRequests {
+		if pr.ID == id {
+			return pr, true
+		}
+	}
+	return domain.PullRequest{}, false
+}
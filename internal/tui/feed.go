@@ -0,0 +1,254 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"bitbucket-cli/internal/bitbucket"
+	"bitbucket-cli/internal/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// feedWindow is how far back the activity feed looks.
+const feedWindow = 7 * 24 * time.Hour
+
+type feedPullRequestsLoadedMsg struct {
+	repoSlug string
+	prs      []domain.PullRequest
+	err      error
+}
+
+type feedPipelinesLoadedMsg struct {
+	repoSlug  string
+	pipelines []domain.Pipeline
+	err       error
+}
+
+// feedEvent is one line of the activity feed: a pull request opened, merged
+// or declined, or a pipeline that failed on the main branch.
+type feedEvent struct {
+	at       time.Time
+	repoSlug string
+	kind     string
+	pr       domain.PullRequest
+	pipeline domain.Pipeline
+}
+
+func loadFeedPullRequests(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug string) tea.Cmd {
+	return func() tea.Msg {
+		prs, err := client.ListRecentPullRequests(ctx, repoSlug)
+		return feedPullRequestsLoadedMsg{repoSlug: repoSlug, prs: prs, err: err}
+	}
+}
+
+func loadFeedPipelines(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug string) tea.Cmd {
+	return func() tea.Msg {
+		pipelines, err := client.ListPipelines(ctx, repoSlug)
+		return feedPipelinesLoadedMsg{repoSlug: repoSlug, pipelines: pipelines, err: err}
+	}
+}
+
+// openFeed shows the activity feed of the favorite repositories, fetching
+// the recent pull requests and pipelines of each in parallel.
+func openFeed(m *AppModel) tea.Cmd {
+	m.activePane = branchPane
+	m.currentView = feedView
+	m.feedCursor = 0
+	m.feedPullRequests = make(map[string][]domain.PullRequest)
+	m.feedPipelines = make(map[string][]domain.Pipeline)
+	return loadFeed(m, newViewContext(m))
+}
+
+// loadFeed fetches the feed again. What is on screen stays until each
+// repository's results replace it.
+func loadFeed(m *AppModel, ctx context.Context) tea.Cmd {
+	favorites := m.homeFavorites()
+	if len(favorites) == 0 {
+		m.message = "No favorite repositories to build the feed from"
+		return nil
+	}
+
+	m.feedErrors = nil
+	cmds := make([]tea.Cmd, 0, 2*len(favorites))
+	for _, slug := range favorites {
+		cmds = append(cmds,
+			forView(m, loadFeedPullRequests(ctx, m.client, slug)),
+			forView(m, loadFeedPipelines(ctx, m.client, slug)),
+		)
+	}
+	m.feedPending = len(cmds)
+	return tea.Batch(cmds...)
+}
+
+// mainBranch returns the main branch of repoSlug, when the repository list
+// knows it.
+func (m AppModel) mainBranch(repoSlug string) string {
+	for _, repo := range m.repositories {
+		if repo.Slug == repoSlug {
+			return repo.Mainbranch
+		}
+	}
+	return ""
+}
+
+// feedEvents merges what the feed has loaded into one list, newest first,
+// leaving out anything older than feedWindow.
+func (m AppModel) feedEvents() []feedEvent {
+	since := time.Now().Add(-feedWindow)
+	var events []feedEvent
+	add := func(event feedEvent, timestamp string) {
+		at, err := time.Parse(time.RFC3339, timestamp)
+		if err != nil || at.Before(since) {
+			return
+		}
+		event.at = at
+		events = append(events, event)
+	}
+
+	for slug, prs := range m.feedPullRequests {
+		for _, pr := range prs {
+			switch strings.ToUpper(pr.State) {
+			case "OPEN":
+				add(feedEvent{repoSlug: slug, kind: "opened", pr: pr}, pr.CreatedOn)
+			case "MERGED":
+				add(feedEvent{repoSlug: slug, kind: "merged", pr: pr}, pr.UpdatedOn)
+			case "DECLINED":
+				add(feedEvent{repoSlug: slug, kind: "declined", pr: pr}, pr.UpdatedOn)
+			}
+		}
+	}
+
+	for slug, pipelines := range m.feedPipelines {
+		main := m.mainBranch(slug)
+		for _, pipeline := range pipelines {
+			branch := strings.TrimPrefix(pipeline.BranchName, "refs/heads/")
+			onMain := branch == main || (main == "" && (branch == "main" || branch == "master"))
+			result := strings.ToUpper(pipeline.Result)
+			if onMain && (result == "FAILED" || result == "ERROR") {
+				add(feedEvent{repoSlug: slug, kind: "failed", pipeline: pipeline}, pipeline.CompletedOn)
+			}
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].at.Equal(events[j].at) {
+			return events[i].at.After(events[j].at)
+		}
+		return events[i].repoSlug < events[j].repoSlug
+	})
+	return events
+}
+
+// clampFeedCursor keeps the cursor on an event as the feed changes.
+func clampFeedCursor(m *AppModel) {
+	m.feedCursor = min(m.feedCursor, max(len(m.feedEvents())-1, 0))
+}
+
+// openFeedEvent opens the pull request of the selected event in its tab, or
+// the steps of the failed pipeline.
+func openFeedEvent(m *AppModel) tea.Cmd {
+	events := m.feedEvents()
+	if m.feedCursor >= len(events) {
+		return nil
+	}
+	event := events[m.feedCursor]
+	if event.kind != "failed" && event.kind != "opened" {
+		// The pull request tab lists open pull requests only.
+		return openURL(m.browserCommand, event.pr.URL)
+	}
+
+	selectRepository(m, event.repoSlug)
+	if event.kind == "failed" {
+		m.activePane = branchPane
+		return openPipelineSteps(m, event.repoSlug, event.pipeline)
+	}
+	m.focusPullRequestID = event.pr.ID
+	return openTab(m, tabIndex(prView))
+}
+
+// feedEventURL is the web page of an event, for yanking and the browser.
+func (m AppModel) feedEventURL(event feedEvent) string {
+	if event.kind == "failed" {
+		return fmt.Sprintf("https://bitbucket.org/%s/%s/pipelines/results/%d", m.workspace, event.repoSlug, event.pipeline.BuildNumber)
+	}
+	return event.pr.URL
+}
+
+func (m AppModel) renderFeedPane() string {
+	paneWidth := m.width - 4
+	if paneWidth < 30 {
+		paneWidth = 30
+	}
+
+	availableHeight := m.height - 6
+	if availableHeight < 5 {
+		availableHeight = 5
+	}
+
+	title := trf("Activity, last %d days (esc: back)", int(feedWindow.Hours()/24))
+	if m.feedPending > 0 {
+		title = fmt.Sprintf("%s %s", title, m.spinner.View())
+	}
+	items := []string{activePaneStyle.Render(title), ""}
+
+	events := m.feedEvents()
+	if len(events) == 0 {
+		if m.feedPending > 0 {
+			items = append(items, m.renderSkeletonRows(paneWidth, availableHeight-2)...)
+		} else {
+			items = append(items, tr("Nothing happened in your favorite repositories."))
+		}
+	} else {
+		repoStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(currentTheme.accent)).Width(20)
+		start, end := m.calculateWindow(m.feedCursor, len(events), availableHeight-2-len(m.feedErrors))
+		for i := start; i < end; i++ {
+			event := events[i]
+			cursor := " "
+			if i == m.feedCursor {
+				cursor = cursorStyle.Render(">")
+			}
+			when := inactivePaneStyle.Render(fmt.Sprintf("%-12s", timeAgo(event.at.Format(time.RFC3339))))
+			items = append(items, fmt.Sprintf("%s %s %s %s", cursor, when, repoStyle.Render(event.repoSlug), m.renderFeedEvent(event)))
+		}
+
+		if start > 0 {
+			items[1] = inactivePaneStyle.Render(moreAbove())
+		}
+		if end < len(events) {
+			items = append(items, inactivePaneStyle.Render(moreBelow()))
+		}
+	}
+
+	for _, err := range m.feedErrors {
+		items = append(items, messageStyle.Render(err))
+	}
+
+	content := strings.Join(items, "\n")
+	style := lipgloss.NewStyle().
+		Width(paneWidth).
+		Height(availableHeight).
+		Padding(0, 1)
+
+	return style.Render(content)
+}
+
+func (m AppModel) renderFeedEvent(event feedEvent) string {
+	if event.kind == "failed" {
+		return fmt.Sprintf("%s pipeline #%d on %s", lipgloss.NewStyle().Foreground(lipgloss.Color(currentTheme.failure)).Render(fmt.Sprintf("%-8s", "failed")), event.pipeline.BuildNumber, renderPipelineBranchColumn(event.pipeline.BranchName))
+	}
+
+	verb := fmt.Sprintf("%-8s", event.kind)
+	switch event.kind {
+	case "merged":
+		verb = lipgloss.NewStyle().Foreground(lipgloss.Color(currentTheme.success)).Render(verb)
+	case "declined":
+		verb = lipgloss.NewStyle().Foreground(lipgloss.Color(currentTheme.muted)).Render(verb)
+	}
+	author := avatar(event.pr.Author) + " " + lipgloss.NewStyle().Foreground(lipgloss.Color(currentTheme.neutral)).Render("@"+userLabel(m.currentUser, event.pr.AuthorUUID, event.pr.Author))
+	return fmt.Sprintf("%s PR #%d %s %s", verb, event.pr.ID, author, event.pr.Title)
+}
@@ -0,0 +1,60 @@
+package tui
+
+// filterCache remembers which items of each list matched its filter, so that
+// moving through a filtered list of hundreds of repositories or pull
+// requests does not lowercase and match every item again on each keypress
+// and render. An entry is reused while the query and the list's backing
+// array and length stay the same; loading a list replaces the array. Code
+// that changes items in place must invalidate the list, as the entry would
+// keep matching on their old values.
+type filterCache struct {
+	entries map[string]filterEntry
+}
+
+type filterEntry struct {
+	query string
+	// first points at the list's first item and identifies its array.
+	first   any
+	length  int
+	matches []int
+}
+
+func newFilterCache() *filterCache {
+	return &filterCache{entries: make(map[string]filterEntry)}
+}
+
+// invalidate drops the entries of lists whose items were changed in place.
+func (c *filterCache) invalidate(lists ...string) {
+	for _, list := range lists {
+		delete(c.entries, list)
+	}
+}
+
+// cachedFilter returns the items of the named list that match, running match
+// only when the query or the list changed since the last call.
+func cachedFilter[T any](c *filterCache, list, query string, items []T, match func(T) bool) []T {
+	var first any
+	if len(items) > 0 {
+		first = &items[0]
+	}
+
+	entry, ok := c.entries[list]
+	if !ok || entry.query != query || entry.first != first || entry.length != len(items) {
+		entry = filterEntry{query: query, first: first, length: len(items)}
+		for i, item := range items {
+			if match(item) {
+				entry.matches = append(entry.matches, i)
+			}
+		}
+		c.entries[list] = entry
+	}
+
+	if len(entry.matches) == 0 {
+		return nil
+	}
+	filtered := make([]T, len(entry.matches))
+	for i, index := range entry.matches {
+		filtered[i] = items[index]
+	}
+	return filtered
+}
@@ -0,0 +1,259 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"bitbucket-cli/internal/bitbucket"
+	"bitbucket-cli/internal/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// homeFavoriteFallback is how many recently updated repositories stand in for
+// favorites when none are configured.
+const homeFavoriteFallback = 5
+
+type currentUserLoadedMsg struct {
+	user domain.User
+	err  error
+}
+
+type homeMyPRsLoadedMsg struct {
+	prs []domain.PullRequest
+	err error
+}
+
+type homeReviewPRsLoadedMsg struct {
+	repoSlug string
+	prs      []domain.PullRequest
+	err      error
+}
+
+type homePipelineLoadedMsg struct {
+	repoSlug string
+	pipeline domain.Pipeline
+	found    bool
+	err      error
+}
+
+type homeEntry struct {
+	pr       *domain.PullRequest
+	repoSlug string
+}
+
+func loadCurrentUser(ctx context.Context, client bitbucket.BitbucketAPI) tea.Cmd {
+	return func() tea.Msg {
+		user, err := client.GetCurrentUser(ctx)
+		return currentUserLoadedMsg{user: user, err: err}
+	}
+}
+
+func loadHomeMyPRs(ctx context.Context, client bitbucket.BitbucketAPI, userUUID string) tea.Cmd {
+	return func() tea.Msg {
+		prs, err := client.ListUserPullRequests(ctx, userUUID)
+		return homeMyPRsLoadedMsg{prs: prs, err: err}
+	}
+}
+
+func loadHomeReviewPRs(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug, userUUID string) tea.Cmd {
+	return func() tea.Msg {
+		prs, err := client.ListReviewPullRequests(ctx, repoSlug, userUUID)
+		return homeReviewPRsLoadedMsg{repoSlug: repoSlug, prs: prs, err: err}
+	}
+}
+
+func loadHomePipeline(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug string) tea.Cmd {
+	return func() tea.Msg {
+		pipelines, err := client.ListPipelines(ctx, repoSlug)
+		if err != nil || len(pipelines) == 0 {
+			return homePipelineLoadedMsg{repoSlug: repoSlug, err: err}
+		}
+		return homePipelineLoadedMsg{repoSlug: repoSlug, pipeline: pipelines[0], found: true}
+	}
+}
+
+// homeFavorites returns the configured favorite repositories, or the most
+// recently updated ones when none are configured.
+func (m AppModel) homeFavorites() []string {
+	if len(m.favorites) > 0 {
+		return m.favorites
+	}
+
+	var slugs []string
+	for i := 0; i < len(m.repositories) && i < homeFavoriteFallback; i++ {
+		slugs = append(slugs, m.repositories[i].Slug)
+	}
+	return slugs
+}
+
+// loadHome fires every dashboard request in parallel once both the current
+// user and the repository list are known.
+func loadHome(m *AppModel) tea.Cmd {
+	if m.currentUser.UUID == "" || (len(m.favorites) == 0 && len(m.repositories) == 0) {
+		return nil
+	}
+
+	m.homeMyPRs = nil
+	m.homeReviewPRs = nil
+	m.homePipelines = make(map[string]domain.Pipeline)
+
+	favorites := m.homeFavorites()
+	cmds := []tea.Cmd{loadHomeMyPRs(m.viewCtx, m.client, m.currentUser.UUID)}
+	for _, slug := range favorites {
+		cmds = append(cmds,
+			loadHomeReviewPRs(m.viewCtx, m.client, slug, m.currentUser.UUID),
+			loadHomePipeline(m.viewCtx, m.client, slug),
+		)
+	}
+	m.homePending = len(cmds)
+	for i := range cmds {
+		cmds[i] = forView(m, cmds[i])
+	}
+	return tea.Batch(cmds...)
+}
+
+func openHome(m *AppModel) tea.Cmd {
+	m.activePane = branchPane
+	m.currentView = homeView
+	m.homeCursor = 0
+	newViewContext(m)
+	if m.currentUser.UUID == "" {
+		m.homePending = 1
+		return loadCurrentUser(m.ctx, m.client)
+	}
+	return loadHome(m)
+}
+
+func (m AppModel) homeEntries() []homeEntry {
+	var entries []homeEntry
+	for i := range m.homeMyPRs {
+		entries = append(entries, homeEntry{pr: &m.homeMyPRs[i], repoSlug: m.homeMyPRs[i].RepoSlug})
+	}
+	for i := range m.homeReviewPRs {
+		entries = append(entries, homeEntry{pr: &m.homeReviewPRs[i], repoSlug: m.homeReviewPRs[i].RepoSlug})
+	}
+	for _, slug := range m.homeFavorites() {
+		entries = append(entries, homeEntry{repoSlug: slug})
+	}
+	return entries
+}
+
+// openHomeEntry opens the PR tab focused on the selected PR, or the pipelines
+// tab of the selected favorite repository.
+func openHomeEntry(m *AppModel) tea.Cmd {
+	entries := m.homeEntries()
+	if m.homeCursor >= len(entries) {
+		return nil
+	}
+	entry := entries[m.homeCursor]
+	if entry.repoSlug == "" {
+		m.message = "Unknown repository for selected item"
+		return nil
+	}
+
+	m.selectedRepoSlug = entry.repoSlug
+	m.selectedRepo = entry.repoSlug
+	for _, repo := range m.repositories {
+		if repo.Slug == entry.repoSlug {
+			m.selectedRepo = repo.Name
+		}
+	}
+
+	if entry.pr != nil {
+		m.focusPullRequestID = entry.pr.ID
+		return openTab(m, tabIndex(prView))
+	}
+	return openTab(m, tabIndex(pipelinesView))
+}
+
+func (m AppModel) renderHomePane() string {
+	paneWidth := m.width - 4
+	if paneWidth < 30 {
+		paneWidth = 30
+	}
+
+	availableHeight := m.height - 6
+	if availableHeight < 5 {
+		availableHeight = 5
+	}
+
+	workspace := m.workspace
+	if m.workspaceInfo.Name != "" {
+		workspace = m.workspaceInfo.Name
+	}
+	title := trf("Home (%s)", workspace)
+	if m.currentUser.DisplayName != "" {
+		title = trf("Home (%s, %s)", workspace, m.currentUser.DisplayName)
+	}
+	if m.homePending > 0 {
+		title = fmt.Sprintf("%s %s", title, m.spinner.View())
+	}
+
+	sectionStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(currentTheme.heading)).Bold(true)
+	entryIndex := 0
+	cursorLine := 0
+	var lines []string
+	addEntry := func(line string) {
+		cursor := " "
+		if entryIndex == m.homeCursor {
+			cursor = cursorStyle.Render(">")
+			cursorLine = len(lines)
+		}
+		lines = append(lines, fmt.Sprintf("%s %s", cursor, line))
+		entryIndex++
+	}
+
+	lines = append(lines, sectionStyle.Render(fmt.Sprintf("My open pull requests (%d)", len(m.homeMyPRs))))
+	if len(m.homeMyPRs) == 0 {
+		lines = append(lines, inactivePaneStyle.Render("  none"))
+	}
+	for _, pr := range m.homeMyPRs {
+		addEntry(renderHomePullRequest(pr, m.currentUser))
+	}
+
+	lines = append(lines, "", sectionStyle.Render(fmt.Sprintf("Awaiting my review (%d)", len(m.homeReviewPRs))))
+	if len(m.homeReviewPRs) == 0 {
+		lines = append(lines, inactivePaneStyle.Render("  none"))
+	}
+	for _, pr := range m.homeReviewPRs {
+		addEntry(renderHomePullRequest(pr, m.currentUser))
+	}
+
+	lines = append(lines, "", sectionStyle.Render("Favorite repositories"))
+	for _, slug := range m.homeFavorites() {
+		repo := lipgloss.NewStyle().Foreground(lipgloss.Color(currentTheme.accent)).Width(24).Render(slug)
+		status := inactivePaneStyle.Render("no pipelines")
+		if pipeline, ok := m.homePipelines[slug]; ok {
+			status = fmt.Sprintf("#%d %s %s %s %s", pipeline.BuildNumber, renderPipelineBranchColumn(pipeline.BranchName), formatPipelineState(pipeline.State), formatPipelineResult(pipeline.Result), timeAgo(pipeline.CompletedOn))
+		}
+		addEntry(fmt.Sprintf("%s %s", repo, status))
+	}
+
+	start, end := m.calculateWindow(cursorLine, len(lines), availableHeight-2)
+	items := []string{activePaneStyle.Render(title), ""}
+	items = append(items, lines[start:end]...)
+
+	content := strings.Join(items, "\n")
+	style := lipgloss.NewStyle().
+		Width(paneWidth).
+		Height(availableHeight).
+		Padding(0, 1)
+
+	return style.Render(content)
+}
+
+func renderHomePullRequest(pr domain.PullRequest, user domain.User) string {
+	repo := lipgloss.NewStyle().Foreground(lipgloss.Color(currentTheme.accent)).Width(24).Render(pr.RepoSlug)
+	author := avatar(pr.Author) + " " + lipgloss.NewStyle().Foreground(lipgloss.Color(currentTheme.neutral)).Render(fmt.Sprintf("@%s", userLabel(user, pr.AuthorUUID, pr.Author)))
+	return fmt.Sprintf("%s #%d %s %s (approvals: %d)", repo, pr.ID, author, pr.Title, pr.Approvals)
+}
+
+func sortPullRequestsByUpdated(prs []domain.PullRequest) {
+	sort.SliceStable(prs, func(i, j int) bool {
+		return prs[i].UpdatedOn > prs[j].UpdatedOn
+	})
+}
@@ -0,0 +1,111 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"bitbucket-cli/internal/bitbucket"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// toggleInspector opens the API request inspector over the current view, or
+// returns to that view.
+func toggleInspector(m *AppModel) {
+	if m.currentView == inspectorView {
+		closeInspector(m)
+		return
+	}
+	m.inspectorReturnView = m.currentView
+	m.inspectorReturnPane = m.activePane
+	m.inspectorCursor = 0
+	m.activePane = branchPane
+	m.currentView = inspectorView
+}
+
+func closeInspector(m *AppModel) {
+	m.currentView = m.inspectorReturnView
+	m.activePane = m.inspectorReturnPane
+}
+
+func (m AppModel) renderInspectorPane() string {
+	paneWidth := m.width - 4
+	if paneWidth < 30 {
+		paneWidth = 30
+	}
+
+	availableHeight := m.height - 6
+	if availableHeight < 10 {
+		availableHeight = 10
+	}
+
+	records := m.client.RecentRequests()
+	title := activePaneStyle.Render(fmt.Sprintf("API requests (%d, newest first)", len(records)))
+	items := []string{title, ""}
+	if len(records) == 0 {
+		items = append(items, tr("No requests yet"))
+		return lipgloss.NewStyle().Width(paneWidth).Height(availableHeight).Padding(0, 1).Render(strings.Join(items, "\n"))
+	}
+
+	cursor := m.inspectorCursor
+	if cursor >= len(records) {
+		cursor = len(records) - 1
+	}
+
+	listHeight := (availableHeight - 2) / 2
+	start, end := m.calculateWindow(cursor, len(records), listHeight)
+	for i := start; i < end; i++ {
+		prefix := " "
+		if i == cursor {
+			prefix = cursorStyle.Render(">")
+		}
+		items = append(items, ansi.Truncate(fmt.Sprintf("%s %s", prefix, renderRequestSummary(records[i])), paneWidth-2, "…"))
+	}
+
+	selected := records[cursor]
+	items = append(items, "", inactivePaneStyle.Render(strings.Repeat("─", paneWidth-2)))
+	detail := []string{selected.Method + " " + selected.URL}
+	if selected.Err != "" {
+		detail = append(detail, messageStyle.Render(selected.Err))
+	}
+	if selected.RequestBody != "" {
+		detail = append(detail, "Request:", selected.RequestBody)
+	}
+	detail = append(detail, "Response:", selected.ResponseBody)
+
+	detailLines := strings.Split(ansi.Hardwrap(strings.Join(detail, "\n"), paneWidth-2, true), "\n")
+	if room := availableHeight - len(items); room < len(detailLines) {
+		if room < 1 {
+			room = 1
+		}
+		detailLines = detailLines[:room]
+	}
+	items = append(items, detailLines...)
+
+	return lipgloss.NewStyle().Width(paneWidth).Height(availableHeight).Padding(0, 1).Render(strings.Join(items, "\n"))
+}
+
+func renderRequestSummary(record bitbucket.RequestRecord) string {
+	status := fmt.Sprintf("%d", record.Status)
+	switch {
+	case record.Cached:
+		status = "cache"
+	case record.Coalesced && record.Err == "":
+		status = "dedup"
+	case record.NotModified:
+		status = "304"
+	case record.Status == 0:
+		status = "ERR"
+	}
+
+	statusColor := currentTheme.success
+	if record.Err != "" {
+		statusColor = currentTheme.failure
+	} else if record.Cached || record.NotModified || record.Coalesced {
+		statusColor = currentTheme.muted
+	}
+	status = lipgloss.NewStyle().Foreground(lipgloss.Color(statusColor)).Width(5).Render(status)
+
+	return fmt.Sprintf("%s %-6s %s %6dms %s", record.Started.Format("15:04:05"), record.Method, status, record.Duration.Milliseconds(), record.URL)
+}
@@ -0,0 +1,415 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"bitbucket-cli/internal/bitbucket"
+	"bitbucket-cli/internal/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// issueEditPurpose tags the editor session that writes a new issue.
+const issueEditPurpose = "issue"
+
+type issuesLoadedMsg struct {
+	repoSlug string
+	issues   []domain.Issue
+	err      error
+}
+
+type issueCommentsLoadedMsg struct {
+	issueID  int
+	comments []domain.Comment
+	err      error
+}
+
+// issueUpdatedMsg reports a state or assignee change; change describes it
+// for the status line, e.g. "resolved".
+type issueUpdatedMsg struct {
+	issueID int
+	issue   domain.Issue
+	change  string
+	err     error
+}
+
+type issueCreatedMsg struct {
+	issue domain.Issue
+	err   error
+}
+
+func loadIssues(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug string) tea.Cmd {
+	return func() tea.Msg {
+		issues, err := client.ListIssues(ctx, repoSlug)
+		return issuesLoadedMsg{repoSlug: repoSlug, issues: issues, err: err}
+	}
+}
+
+func loadIssueComments(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug string, issueID int) tea.Cmd {
+	return func() tea.Msg {
+		comments, err := client.ListIssueComments(ctx, repoSlug, issueID)
+		return issueCommentsLoadedMsg{issueID: issueID, comments: comments, err: err}
+	}
+}
+
+func updateIssue(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug string, issueID int, update domain.IssueUpdate, change string) tea.Cmd {
+	return func() tea.Msg {
+		issue, err := client.UpdateIssue(ctx, repoSlug, issueID, update)
+		return issueUpdatedMsg{issueID: issueID, issue: issue, change: change, err: err}
+	}
+}
+
+func createIssue(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug string, input domain.NewIssue) tea.Cmd {
+	return func() tea.Msg {
+		issue, err := client.CreateIssue(ctx, repoSlug, input)
+		return issueCreatedMsg{issue: issue, err: err}
+	}
+}
+
+// issueLoadError explains a failed issue list, which for a repository
+// without the issue tracker is not an error worth the raw 404.
+func issueLoadError(err error) string {
+	if errors.Is(err, bitbucket.ErrNotFound) {
+		return "This repository has no issue tracker: enable it under Repository settings → Issue tracker"
+	}
+	return fmt.Sprintf("Error loading issues: %s", describeError(err))
+}
+
+// selectedIssue returns the issue the current view is about: the highlighted
+// one in the list, or the one open in the detail view.
+func (m AppModel) selectedIssue() (domain.Issue, bool) {
+	if m.activePane != branchPane {
+		return domain.Issue{}, false
+	}
+	switch m.currentView {
+	case issuesView:
+		if filtered := m.getFilteredIssues(); m.issueCursor < len(filtered) {
+			return filtered[m.issueCursor], true
+		}
+	case issueDetailView:
+		return m.openIssue, m.openIssue.ID > 0
+	}
+	return domain.Issue{}, false
+}
+
+// openIssueDetail shows the highlighted issue with its comments.
+func openIssueDetail(m *AppModel) tea.Cmd {
+	issue, ok := m.selectedIssue()
+	if !ok {
+		return nil
+	}
+	m.openIssue = issue
+	m.issueComments = nil
+	m.issueDetailOffset = 0
+	m.currentView = issueDetailView
+	ctx := newViewContext(m)
+	m.loading = true
+	return forView(m, loadIssueComments(ctx, m.client, m.selectedRepoSlug, issue.ID))
+}
+
+// setIssueState returns the chord action moving the selected issue to state.
+func setIssueState(state string) func(m *AppModel) tea.Cmd {
+	return func(m *AppModel) tea.Cmd {
+		issue, ok := m.selectedIssue()
+		if !ok {
+			m.message = "Select an issue first"
+			return nil
+		}
+		if strings.EqualFold(issue.State, state) {
+			m.message = fmt.Sprintf("Issue #%d is already %s", issue.ID, state)
+			return nil
+		}
+		return startAction(m, updateIssue(m.ctx, m.client, m.selectedRepoSlug, issue.ID, domain.IssueUpdate{State: state}, state))
+	}
+}
+
+// toggleIssueAssignee assigns the selected issue to the current user, or
+// unassigns it when it already is.
+func toggleIssueAssignee(m *AppModel) tea.Cmd {
+	issue, ok := m.selectedIssue()
+	if !ok {
+		return nil
+	}
+	if m.currentUser.AccountID == "" {
+		m.message = "Your account is still loading"
+		return nil
+	}
+
+	assignee, change := m.currentUser.AccountID, "assigned to you"
+	if issue.AssigneeAccountID == m.currentUser.AccountID {
+		assignee, change = "", "unassigned"
+	}
+	update := domain.IssueUpdate{Assignee: &assignee}
+	return startAction(m, updateIssue(m.ctx, m.client, m.selectedRepoSlug, issue.ID, update, change))
+}
+
+// applyIssueUpdate replaces the issue in the list and the detail view.
+func applyIssueUpdate(m *AppModel, issue domain.Issue) {
+	for i := range m.issues {
+		if m.issues[i].ID == issue.ID {
+			m.issues[i] = issue
+		}
+	}
+	m.filters.invalidate("issues")
+	if m.openIssue.ID == issue.ID {
+		m.openIssue = issue
+	}
+}
+
+// newIssue opens the editor for a new issue of the selected repository.
+func newIssue(m *AppModel) tea.Cmd {
+	if m.selectedRepoSlug == "" {
+		return nil
+	}
+	return editText(m.editor, issueEditPurpose, "")
+}
+
+// parseIssueText splits what was written in the editor into the title, its
+// first non-empty line, and the description below it.
+func parseIssueText(text string) domain.NewIssue {
+	text = strings.TrimSpace(text)
+	title, content, _ := strings.Cut(text, "\n")
+	return domain.NewIssue{Title: strings.TrimSpace(title), Content: strings.TrimSpace(content)}
+}
+
+// submitIssue files the issue written in the editor.
+func submitIssue(m *AppModel, msg textEditedMsg) tea.Cmd {
+	if msg.err != nil {
+		m.message = fmt.Sprintf("Editor error: %v", msg.err)
+		return nil
+	}
+	input := parseIssueText(msg.text)
+	if input.Title == "" {
+		m.message = "Issue not created: the title is empty"
+		return nil
+	}
+	m.message = "Creating issue..."
+	return startAction(m, createIssue(m.ctx, m.client, m.selectedRepoSlug, input))
+}
+
+func (m AppModel) getFilteredIssues() []domain.Issue {
+	if m.issueFilterQuery == "" {
+		return m.issues
+	}
+
+	query := strings.ToLower(m.issueFilterQuery)
+	return cachedFilter(m.filters, "issues", query, m.issues, func(issue domain.Issue) bool {
+		return strings.Contains(strings.ToLower(issue.Title), query) ||
+			strings.Contains(strings.ToLower(issue.State), query) ||
+			strings.Contains(strings.ToLower(issue.Kind), query) ||
+			strings.Contains(strings.ToLower(issue.Assignee), query)
+	})
+}
+
+func (m AppModel) issueURL(issue domain.Issue) string {
+	if strings.HasPrefix(issue.URL, "https://") || strings.HasPrefix(issue.URL, "http://") {
+		return issue.URL
+	}
+	if m.workspace == "" || m.selectedRepoSlug == "" {
+		return ""
+	}
+	return fmt.Sprintf("https://bitbucket.org/%s/%s/issues/%d", m.workspace, m.selectedRepoSlug, issue.ID)
+}
+
+func formatIssueState(state string) string {
+	switch strings.ToLower(strings.TrimSpace(state)) {
+	case "new":
+		return currentTheme.badge(currentTheme.running, "●", "NEW")
+	case "open":
+		return currentTheme.badge(currentTheme.success, "●", "OPEN")
+	case "on hold":
+		return currentTheme.badge(currentTheme.warning, "◐", "ON HOLD")
+	case "resolved", "closed":
+		return currentTheme.badge(currentTheme.neutral, "✓", strings.ToUpper(state))
+	case "invalid", "duplicate", "wontfix":
+		return currentTheme.badge(currentTheme.muted, "○", strings.ToUpper(state))
+	default:
+		return fmt.Sprintf("[%s]", strings.ToUpper(state))
+	}
+}
+
+func (m AppModel) renderIssuesPane() string {
+	showRepoPane := m.currentView == noSelection || m.activePane == repoPane
+
+	paneWidth := m.width - 4
+	if showRepoPane {
+		repoPaneWidth := (m.width - 10) / 3
+		if repoPaneWidth < 20 {
+			repoPaneWidth = 20
+		}
+		paneWidth = m.width - repoPaneWidth - 10
+	}
+	if paneWidth < 30 {
+		paneWidth = 30
+	}
+
+	availableHeight := m.height - 6
+	if availableHeight < 5 {
+		availableHeight = 5
+	}
+
+	title := tr("Issues")
+	if m.selectedRepo != "" {
+		title = fmt.Sprintf("(%s)", m.selectedRepo)
+	}
+	if m.issueFilterQuery != "" {
+		title = fmt.Sprintf("[/%s]", m.issueFilterQuery)
+	}
+	title = m.withCacheLabel(title)
+	if !showRepoPane {
+		title = trf("%s (esc: back)", title)
+	}
+
+	if m.activePane == branchPane {
+		title = activePaneStyle.Render(title)
+	} else {
+		title = inactivePaneStyle.Render(title)
+	}
+
+	var items []string
+	items = append(items, m.renderRightTabs())
+	items = append(items, title)
+	items = append(items, "")
+
+	if m.loading && m.activePane == branchPane && m.currentView == issuesView {
+		items = append(items, m.renderSkeletonRows(paneWidth, availableHeight-3)...)
+	} else if len(m.issues) == 0 {
+		items = append(items, tr("No issues"))
+	} else {
+		filtered := m.getFilteredIssues()
+		if len(filtered) == 0 {
+			items = append(items, tr("No matches"))
+		} else {
+			start, end := m.calculateWindow(m.issueCursor, len(filtered), availableHeight-3)
+
+			assigneeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("99"))
+			for i := start; i < end; i++ {
+				issue := filtered[i]
+				cursor := " "
+				if m.activePane == branchPane && i == m.issueCursor {
+					cursor = cursorStyle.Render(">")
+				}
+				line := fmt.Sprintf("%s #%d %s %s %s", cursor, issue.ID, formatIssueState(issue.State), inactivePaneStyle.Render(issue.Kind), issue.Title)
+				if issue.Assignee != "" {
+					line = fmt.Sprintf("%s %s", line, assigneeStyle.Render("@"+authorLabel(m.currentUser, issue.Assignee)))
+				}
+				items = append(items, line)
+			}
+
+			if start > 0 {
+				items[2] = inactivePaneStyle.Render(moreAbove())
+			}
+			if end < len(filtered) {
+				items = append(items, inactivePaneStyle.Render(moreBelow()))
+			}
+		}
+	}
+
+	content := strings.Join(items, "\n")
+	style := lipgloss.NewStyle().
+		Width(paneWidth).
+		Height(availableHeight).
+		Padding(0, 1).
+		Faint(m.fadingIn())
+
+	return style.Render(content)
+}
+
+// issueDetailLines lays out the open issue and its comments, wrapped to
+// width, for the scrolling detail view.
+func (m AppModel) issueDetailLines(width int) []string {
+	issue := m.openIssue
+	wrap := lipgloss.NewStyle().Width(width)
+
+	assignee := "unassigned"
+	if issue.Assignee != "" {
+		assignee = authorLabel(m.currentUser, issue.Assignee)
+	}
+	lines := []string{
+		fmt.Sprintf("%s %s · %s · reported by %s, %s", formatIssueState(issue.State), issue.Kind, issue.Priority, authorLabel(m.currentUser, issue.Reporter), shortTimestamp(issue.CreatedOn)),
+		fmt.Sprintf("Assignee: %s", assignee),
+		"",
+	}
+
+	content := strings.TrimSpace(issue.Content)
+	if content == "" {
+		content = inactivePaneStyle.Render("No description")
+	}
+	lines = append(lines, strings.Split(wrap.Render(content), "\n")...)
+
+	lines = append(lines, "", activePaneStyle.Render(fmt.Sprintf("Comments (%d)", len(m.issueComments))))
+	if m.loading {
+		lines = append(lines, inactivePaneStyle.Render("Loading comments..."))
+	}
+	authorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("99"))
+	for _, comment := range m.issueComments {
+		header := fmt.Sprintf("%s %s", authorStyle.Render("@"+authorLabel(m.currentUser, comment.Author)), inactivePaneStyle.Render(shortTimestamp(comment.CreatedOn)))
+		lines = append(lines, "", header)
+		lines = append(lines, strings.Split(wrap.Render(strings.TrimSpace(comment.Content)), "\n")...)
+	}
+	return lines
+}
+
+// issueDetailSize returns the width and height of the issue detail pane and
+// the number of lines that fit in it.
+func (m AppModel) issueDetailSize() (paneWidth, availableHeight, visible int) {
+	showRepoPane := m.currentView == noSelection || m.activePane == repoPane
+
+	paneWidth = m.width - 4
+	if showRepoPane {
+		repoPaneWidth := (m.width - 10) / 3
+		if repoPaneWidth < 20 {
+			repoPaneWidth = 20
+		}
+		paneWidth = m.width - repoPaneWidth - 10
+	}
+	if paneWidth < 30 {
+		paneWidth = 30
+	}
+
+	availableHeight = m.height - 6
+	if availableHeight < 5 {
+		availableHeight = 5
+	}
+	return paneWidth, availableHeight, availableHeight - 3
+}
+
+// maxIssueDetailOffset is how far the detail view scrolls: until the last
+// line is at the bottom.
+func (m AppModel) maxIssueDetailOffset() int {
+	paneWidth, _, visible := m.issueDetailSize()
+	return max(0, len(m.issueDetailLines(paneWidth-2))-visible)
+}
+
+func (m AppModel) renderIssueDetailPane() string {
+	paneWidth, availableHeight, visible := m.issueDetailSize()
+
+	title := trf("Issue #%d: %s", m.openIssue.ID, m.openIssue.Title)
+	if m.activePane == branchPane {
+		title = trf("%s (esc: back)", title)
+	}
+
+	items := []string{m.renderRightTabs(), activePaneStyle.Render(title), ""}
+
+	lines := m.issueDetailLines(paneWidth - 2)
+	offset := min(m.issueDetailOffset, max(0, len(lines)-visible))
+	end := min(len(lines), offset+visible)
+	items = append(items, lines[offset:end]...)
+	if offset > 0 {
+		items[3] = inactivePaneStyle.Render(moreAbove())
+	}
+	if end < len(lines) {
+		items[len(items)-1] = inactivePaneStyle.Render(moreBelow())
+	}
+
+	style := lipgloss.NewStyle().
+		Width(paneWidth).
+		Height(availableHeight).
+		Padding(0, 1)
+
+	return style.Render(strings.Join(items, "\n"))
+}
@@ -0,0 +1,142 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func canJumpToNumber(m AppModel) bool {
+	return m.activePane == branchPane && (m.currentView == prView || m.currentView == pipelinesView || m.currentView == issuesView)
+}
+
+func isDigitKey(key string) bool {
+	return len(key) == 1 && key[0] >= '0' && key[0] <= '9'
+}
+
+func canJumpToLine(m AppModel) bool {
+	return m.activePane == branchPane && m.currentView == pipelineStepLogView && len(m.pipelineStepLogLines) > 0
+}
+
+func jumpPrompt(m AppModel) string {
+	if m.currentView == pipelineStepLogView {
+		return fmt.Sprintf("Go to line: :%s  (esc: cancel, enter: confirm)", m.jumpQuery)
+	}
+	return fmt.Sprintf("Jump to: #%s  (esc: cancel, enter: confirm)", m.jumpQuery)
+}
+
+func startJump(m *AppModel, initial string) {
+	m.jumpMode = true
+	m.jumpQuery = initial
+	jumpToNumber(m)
+}
+
+func handleJumpKey(m AppModel, msg tea.KeyMsg) (AppModel, tea.Cmd) {
+	running := selectedRunningPipelineUUID(m)
+	switch msg.String() {
+	case "esc":
+		m.jumpMode = false
+		m.jumpQuery = ""
+		return m, nil
+
+	case "enter":
+		m.jumpMode = false
+		if m.jumpQuery != "" && !jumpToNumber(&m) {
+			m.message = "No item #" + m.jumpQuery
+			if m.currentView == pipelineStepLogView {
+				m.message = "No line " + m.jumpQuery
+			}
+		}
+		m.jumpQuery = ""
+
+	case "backspace":
+		if len(m.jumpQuery) > 0 {
+			m.jumpQuery = m.jumpQuery[:len(m.jumpQuery)-1]
+			jumpToNumber(&m)
+		}
+
+	default:
+		if key := msg.String(); isDigitKey(key) {
+			m.jumpQuery += key
+			jumpToNumber(&m)
+		}
+	}
+
+	// A running pipeline already under the cursor is being polled.
+	if selected := selectedRunningPipelineUUID(m); selected != "" && selected != running {
+		return m, pollPipelineUpdates(m.client)
+	}
+	return m, nil
+}
+
+// jumpToNumber moves the cursor to the PR ID, build number, issue ID or log
+// line typed so far. For IDs an exact match wins; otherwise the first item
+// whose number starts with the typed digits is selected so the list follows
+// along while typing.
+func jumpToNumber(m *AppModel) bool {
+	query := strings.TrimSpace(m.jumpQuery)
+	if query == "" {
+		return false
+	}
+
+	if m.currentView == pipelineStepLogView {
+		line, err := strconv.Atoi(query)
+		if err != nil || line < 1 || line > len(m.pipelineStepLogLines) {
+			return false
+		}
+		m.pipelineStepLogCursor = line - 1
+		return true
+	}
+
+	var numbers []int
+	switch m.currentView {
+	case prView:
+		for _, pr := range m.getFilteredPRs() {
+			numbers = append(numbers, pr.ID)
+		}
+	case pipelinesView:
+		for _, pipeline := range m.getFilteredPipelines() {
+			numbers = append(numbers, pipeline.BuildNumber)
+		}
+	case issuesView:
+		for _, issue := range m.getFilteredIssues() {
+			numbers = append(numbers, issue.ID)
+		}
+	default:
+		return false
+	}
+
+	index := matchNumber(numbers, query)
+	if index < 0 {
+		return false
+	}
+
+	switch m.currentView {
+	case prView:
+		m.prCursor = index
+	case issuesView:
+		m.issueCursor = index
+	default:
+		m.pipelineCursor = index
+	}
+	return true
+}
+
+func matchNumber(numbers []int, query string) int {
+	if target, err := strconv.Atoi(query); err == nil {
+		for i, number := range numbers {
+			if number == target {
+				return i
+			}
+		}
+	}
+
+	for i, number := range numbers {
+		if strings.HasPrefix(strconv.Itoa(number), query) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,607 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// keyMap holds every single-key binding. Bindings can be remapped per profile
+// with "key.<name> = k1,k2" entries, and the help line is built from the
+// bindings that apply to the current view.
+type keyMap struct {
+	Up          key.Binding
+	Down        key.Binding
+	ScrollLeft  key.Binding
+	ScrollRight key.Binding
+	Select      key.Binding
+	Back        key.Binding
+	PrevTab     key.Binding
+	NextTab     key.Binding
+	Tabs        key.Binding
+	Filter      key.Binding
+	Search      key.Binding
+	Refresh     key.Binding
+	RefreshAll  key.Binding
+	Jump        key.Binding
+	GotoLine    key.Binding
+	Home        key.Binding
+	Watch       key.Binding
+	Pin         key.Binding
+	Branches    key.Binding
+	PullReqs    key.Binding
+	OpenBrowser key.Binding
+	Diff        key.Binding
+	Approve     key.Binding
+	Unapprove   key.Binding
+	Worktree    key.Binding
+	Assign      key.Binding
+	Export      key.Binding
+	NewIssue    key.Binding
+	Upload      key.Binding
+	Revert      key.Binding
+	NextHunk    key.Binding
+	PrevHunk    key.Binding
+	EditFile    key.Binding
+	View        key.Binding
+	Wrap        key.Binding
+	LineNumbers key.Binding
+	LogColors   key.Binding
+	Chords      key.Binding
+	Inspector   key.Binding
+	Feed        key.Binding
+	Projects    key.Binding
+	Help        key.Binding
+	Quit        key.Binding
+	// NewPullRequest opens a pull request from the highlighted branch.
+	NewPullRequest key.Binding
+	Merge          key.Binding
+	Decline        key.Binding
+	Reopen         key.Binding
+	PageUp         key.Binding
+	PageDown       key.Binding
+	Comments       key.Binding
+	LineComment    key.Binding
+	Task           key.Binding
+	PRState        key.Binding
+	MyPRs          key.Binding
+	NewBranch      key.Binding
+	DeleteBranch   key.Binding
+	Compare        key.Binding
+	NewTag         key.Binding
+	DeleteTag      key.Binding
+	Rerun          key.Binding
+	AllBranches    key.Binding
+	NextMatch      key.Binding
+	PrevMatch      key.Binding
+	StepCommands   key.Binding
+	Artifacts      key.Binding
+	Download       key.Binding
+	Schedules      key.Binding
+	NewSchedule    key.Binding
+	DeleteSchedule key.Binding
+	ToggleSchedule key.Binding
+
+	// Sequences are the two-key chords such as "g p". Chords holds their
+	// first keys, which put the model into a pending state.
+	Sequences []chordBinding
+}
+
+func defaultKeyMap() keyMap {
+	return keyMap{
+		Up:          key.NewBinding(key.WithKeys("k", "up"), key.WithHelp("k/↑", "up")),
+		Down:        key.NewBinding(key.WithKeys("j", "down"), key.WithHelp("j/↓", "down")),
+		ScrollLeft:  key.NewBinding(key.WithKeys("left"), key.WithHelp("←", "scroll left")),
+		ScrollRight: key.NewBinding(key.WithKeys("right"), key.WithHelp("→", "scroll right")),
+		Select:      key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "select")),
+		Back:        key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "back")),
+		PrevTab:     key.NewBinding(key.WithKeys("h"), key.WithHelp("h", "prev tab")),
+		NextTab:     key.NewBinding(key.WithKeys("l"), key.WithHelp("l", "next tab")),
+		Tabs:        key.NewBinding(key.WithKeys("1", "2", "3", "4", "5", "6", "7", "alt+1", "alt+2", "alt+3", "alt+4", "alt+5", "alt+6", "alt+7"), key.WithHelp("1-7", "open tab")),
+		Filter:      key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "filter")),
+		Search:      key.NewBinding(key.WithKeys("ctrl+f"), key.WithHelp("ctrl+f", "search")),
+		Refresh:     key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "refresh")),
+		RefreshAll:  key.NewBinding(key.WithKeys("R"), key.WithHelp("R", "refresh all tabs")),
+		Jump:        key.NewBinding(key.WithKeys("#"), key.WithHelp("#", "jump to number")),
+		GotoLine:    key.NewBinding(key.WithKeys(":"), key.WithHelp(":n", "go to line")),
+		Home:        key.NewBinding(key.WithKeys("H"), key.WithHelp("H", "home")),
+		Watch:       key.NewBinding(key.WithKeys("W"), key.WithHelp("W", "watching")),
+		Feed:        key.NewBinding(key.WithKeys("F"), key.WithHelp("F", "activity feed")),
+		Projects:    key.NewBinding(key.WithKeys("P"), key.WithHelp("P", "projects")),
+		Pin:         key.NewBinding(key.WithKeys("*"), key.WithHelp("*", "pin/unpin")),
+		Branches:    key.NewBinding(key.WithKeys("b"), key.WithHelp("b", "branches")),
+		PullReqs:    key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "pull requests")),
+		OpenBrowser: key.NewBinding(key.WithKeys("o"), key.WithHelp("o", "open in browser")),
+		Diff:        key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "open diff")),
+		Approve:     key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "approve")),
+		Unapprove:   key.NewBinding(key.WithKeys("u"), key.WithHelp("u", "unapprove")),
+		Worktree:    key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "review in worktree")),
+		Assign:      key.NewBinding(key.WithKeys("A"), key.WithHelp("A", "assign to me")),
+		Export:      key.NewBinding(key.WithKeys("X"), key.WithHelp("X", "export to Markdown")),
+		NewIssue:    key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "new issue")),
+		Upload:      key.NewBinding(key.WithKeys("U"), key.WithHelp("U", "upload file")),
+		Revert:      key.NewBinding(key.WithKeys("V"), key.WithHelp("V", "revert merged PR")),
+		NextHunk:    key.NewBinding(key.WithKeys("]"), key.WithHelp("]", "next hunk")),
+		PrevHunk:    key.NewBinding(key.WithKeys("["), key.WithHelp("[", "prev hunk")),
+		EditFile:    key.NewBinding(key.WithKeys("E"), key.WithHelp("E", "edit file at line")),
+		View:        key.NewBinding(key.WithKeys("v"), key.WithHelp("v", "open in viewer")),
+		Wrap:        key.NewBinding(key.WithKeys("w"), key.WithHelp("w", "toggle wrap")),
+		LineNumbers: key.NewBinding(key.WithKeys("L"), key.WithHelp("L", "line numbers")),
+		LogColors:   key.NewBinding(key.WithKeys("K"), key.WithHelp("K", "toggle colors")),
+		Chords:      key.NewBinding(key.WithKeys("g", "y", "s"), key.WithHelp("g/y/s", "go to/yank/set state")),
+		Inspector:   key.NewBinding(key.WithKeys("D"), key.WithHelp("D", "request inspector")),
+		Help:        key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "more keys")),
+		Quit:        key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
+
+		NewPullRequest: key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "create pull request")),
+		Merge:          key.NewBinding(key.WithKeys("m"), key.WithHelp("m", "merge")),
+		Decline:        key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "decline")),
+		Reopen:         key.NewBinding(key.WithKeys("O"), key.WithHelp("O", "reopen declined PR")),
+		PageUp:         key.NewBinding(key.WithKeys("pgup", "ctrl+u"), key.WithHelp("pgup", "page up")),
+		PageDown:       key.NewBinding(key.WithKeys("pgdown", "ctrl+d"), key.WithHelp("pgdown", "page down")),
+		Comments:       key.NewBinding(key.WithKeys("C"), key.WithHelp("C", "comments")),
+		LineComment:    key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "comment on line")),
+		Task:           key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "task")),
+		PRState:        key.NewBinding(key.WithKeys("S"), key.WithHelp("S", "cycle state")),
+		MyPRs:          key.NewBinding(key.WithKeys("M"), key.WithHelp("M", "my pull requests")),
+		NewBranch:      key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "new branch")),
+		DeleteBranch:   key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "delete branch")),
+		Compare:        key.NewBinding(key.WithKeys("="), key.WithHelp("=", "compare branches")),
+		NewTag:         key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "new tag")),
+		DeleteTag:      key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "delete tag")),
+		Rerun:          key.NewBinding(key.WithKeys("T"), key.WithHelp("T", "rerun pipeline")),
+		AllBranches:    key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "all branches")),
+		NextMatch:      key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "next match")),
+		PrevMatch:      key.NewBinding(key.WithKeys("N"), key.WithHelp("N", "prev match")),
+		StepCommands:   key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "step commands")),
+		Artifacts:      key.NewBinding(key.WithKeys("f"), key.WithHelp("f", "artifacts")),
+		Download:       key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "download")),
+		Schedules:      key.NewBinding(key.WithKeys("S"), key.WithHelp("S", "schedules")),
+		NewSchedule:    key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "new schedule")),
+		DeleteSchedule: key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "delete schedule")),
+		ToggleSchedule: key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "pause/resume")),
+
+		Sequences: defaultChords(),
+	}
+}
+
+// bindingsByName maps the config names of remappable bindings to the fields.
+func (k *keyMap) bindingsByName() map[string]*key.Binding {
+	bindings := map[string]*key.Binding{
+		"up":            &k.Up,
+		"down":          &k.Down,
+		"scroll_left":   &k.ScrollLeft,
+		"scroll_right":  &k.ScrollRight,
+		"select":        &k.Select,
+		"back":          &k.Back,
+		"prev_tab":      &k.PrevTab,
+		"next_tab":      &k.NextTab,
+		"filter":        &k.Filter,
+		"search":        &k.Search,
+		"refresh":       &k.Refresh,
+		"refresh_all":   &k.RefreshAll,
+		"jump":          &k.Jump,
+		"goto_line":     &k.GotoLine,
+		"home":          &k.Home,
+		"watch":         &k.Watch,
+		"feed":          &k.Feed,
+		"projects":      &k.Projects,
+		"pin":           &k.Pin,
+		"branches":      &k.Branches,
+		"pull_requests": &k.PullReqs,
+		"open_browser":  &k.OpenBrowser,
+		"diff":          &k.Diff,
+		"approve":       &k.Approve,
+		"unapprove":     &k.Unapprove,
+		"worktree":      &k.Worktree,
+		"assign":        &k.Assign,
+		"export":        &k.Export,
+		"new_issue":     &k.NewIssue,
+		"new_pr":        &k.NewPullRequest,
+		"merge":         &k.Merge,
+		"decline":       &k.Decline,
+		"reopen":        &k.Reopen,
+		"page_up":       &k.PageUp,
+		"page_down":     &k.PageDown,
+		"comments":      &k.Comments,
+		"line_comment":  &k.LineComment,
+		"task":          &k.Task,
+		"pr_state":      &k.PRState,
+		"my_prs":        &k.MyPRs,
+		"new_branch":    &k.NewBranch,
+		"delete_branch": &k.DeleteBranch,
+		"compare":       &k.Compare,
+		"new_tag":       &k.NewTag,
+		"delete_tag":    &k.DeleteTag,
+		"rerun":         &k.Rerun,
+		"all_branches":  &k.AllBranches,
+		"next_match":    &k.NextMatch,
+		"prev_match":    &k.PrevMatch,
+		"step_commands": &k.StepCommands,
+		"artifacts":     &k.Artifacts,
+		"download":      &k.Download,
+		"upload":        &k.Upload,
+		"revert":        &k.Revert,
+		"next_hunk":     &k.NextHunk,
+		"prev_hunk":     &k.PrevHunk,
+		"edit_file":     &k.EditFile,
+		"view":          &k.View,
+		"wrap":          &k.Wrap,
+		"line_numbers":  &k.LineNumbers,
+		"log_colors":    &k.LogColors,
+		"help":          &k.Help,
+		"inspector":     &k.Inspector,
+		"quit":          &k.Quit,
+
+		"schedules":       &k.Schedules,
+		"new_schedule":    &k.NewSchedule,
+		"delete_schedule": &k.DeleteSchedule,
+		"toggle_schedule": &k.ToggleSchedule,
+	}
+	for i := range k.Sequences {
+		bindings[k.Sequences[i].name] = &k.Sequences[i].binding
+	}
+	return bindings
+}
+
+// applyKeyRemaps replaces the keys of the named bindings, keeping their help
+// description, and collects the first keys of the chords into Chords.
+// Unknown names, and chords remapped to anything but two keys, are reported
+// back.
+func (k *keyMap) applyKeyRemaps(remaps map[string][]string) []string {
+	bindings := k.bindingsByName()
+	var unknown []string
+	for name, keys := range remaps {
+		binding, ok := bindings[name]
+		if !ok || len(keys) == 0 || (k.isChord(name) && !allSequences(keys)) {
+			unknown = append(unknown, name)
+			continue
+		}
+		binding.SetKeys(keys...)
+		binding.SetHelp(strings.Join(keys, "/"), binding.Help().Desc)
+	}
+
+	var prefixes []string
+	for _, chord := range k.Sequences {
+		for _, sequence := range chord.binding.Keys() {
+			prefix, _, _ := strings.Cut(sequence, " ")
+			if !slices.Contains(prefixes, prefix) {
+				prefixes = append(prefixes, prefix)
+			}
+		}
+	}
+	k.Chords.SetKeys(prefixes...)
+	k.Chords.SetHelp(strings.Join(prefixes, "/"), k.Chords.Help().Desc)
+	return unknown
+}
+
+// isChord reports whether name is the config name of a chord.
+func (k *keyMap) isChord(name string) bool {
+	return slices.ContainsFunc(k.Sequences, func(chord chordBinding) bool {
+		return chord.name == name
+	})
+}
+
+// allSequences reports whether every key is a sequence of two keys.
+func allSequences(keys []string) bool {
+	for _, sequence := range keys {
+		if len(strings.Fields(sequence)) != 2 {
+			return false
+		}
+	}
+	return true
+}
+
+// translateHelp replaces the help descriptions with their translations.
+func (k *keyMap) translateHelp() {
+	bindings := k.bindingsByName()
+	bindings["chords"], bindings["tabs"] = &k.Chords, &k.Tabs
+	for _, binding := range bindings {
+		binding.SetHelp(binding.Help().Key, tr(binding.Help().Desc))
+	}
+}
+
+// contextKeyMap implements help.KeyMap for the bindings of one view.
+type contextKeyMap struct {
+	short []key.Binding
+	full  [][]key.Binding
+}
+
+func (c contextKeyMap) ShortHelp() []key.Binding  { return c.short }
+func (c contextKeyMap) FullHelp() [][]key.Binding { return c.full }
+
+func withHelp(binding key.Binding, desc string) key.Binding {
+	binding.SetHelp(binding.Help().Key, tr(desc))
+	return binding
+}
+
+// helpKeys returns the bindings available in the current view.
+func (m AppModel) helpKeys() contextKeyMap {
+	k := m.keys
+	nav := []key.Binding{k.Up, k.Down}
+	global := []key.Binding{k.Search, k.Home, k.Watch, k.Feed, k.MyPRs, k.Projects, k.Chords, k.Help, k.Quit}
+
+	if m.activePane == repoPane || m.currentView == noSelection {
+		actions := []key.Binding{withHelp(k.Select, "open repo"), k.PullReqs, k.Branches, k.Tabs, k.Filter}
+		return contextKeyMap{
+			short: append(append(append([]key.Binding{}, nav...), actions...), k.Quit, k.Help),
+			full:  [][]key.Binding{nav, actions, global},
+		}
+	}
+
+	var actions []key.Binding
+	switch m.currentView {
+	case prView:
+		actions = []key.Binding{withHelp(k.Select, "view details"), k.Approve, k.Unapprove, k.Merge, k.Decline, k.Reopen, k.Diff, k.Worktree, k.Revert, k.OpenBrowser, k.Export, k.Jump, k.Pin, k.PRState, k.Refresh, k.Filter}
+	case prDetailView:
+		actions = []key.Binding{withHelp(k.Select, "view commits"), withHelp(k.Down, "scroll"), withHelp(k.NextHunk, "next task"), withHelp(k.PrevHunk, "prev task"), withHelp(k.Task, "resolve task"), k.Comments, k.Diff, k.Approve, k.Unapprove, k.Merge, k.Decline, k.Reopen, k.OpenBrowser, k.Refresh}
+	case prCommentsView:
+		actions = []key.Binding{withHelp(k.Comments, "write comment"), withHelp(k.Down, "scroll"), withHelp(k.NextHunk, "next comment"), withHelp(k.PrevHunk, "prev comment"), withHelp(k.Task, "task from comment"), k.OpenBrowser, k.Refresh}
+	case prDiffView:
+		actions = []key.Binding{k.LineComment, k.PageDown, k.PageUp, k.NextHunk, k.PrevHunk, withHelp(k.ScrollLeft, "scroll diff"), k.ScrollRight, withHelp(k.View, "open diff in viewer"), k.OpenBrowser, k.Refresh}
+	case prCommitsView:
+		actions = []key.Binding{withHelp(k.View, "open diff in viewer"), k.NextHunk, k.PrevHunk, k.EditFile, withHelp(k.Export, "export review summary"), withHelp(k.ScrollLeft, "scroll diff"), k.ScrollRight, k.Refresh}
+	case branchCommitsView, branchCompareView:
+		actions = []key.Binding{withHelp(k.View, "open diff in viewer"), k.NextHunk, k.PrevHunk, k.EditFile, withHelp(k.ScrollLeft, "scroll diff"), k.ScrollRight, k.OpenBrowser, k.Refresh}
+	case branchesView:
+		actions = []key.Binding{withHelp(k.Select, "view commits"), k.Compare, k.NewBranch, k.DeleteBranch, k.NewPullRequest, k.Refresh, k.Filter}
+	case pipelinesView:
+		actions = []key.Binding{withHelp(k.Select, "view steps"), k.Rerun, k.AllBranches, k.Schedules, k.Export, k.Jump, k.Pin, k.Refresh, k.Filter}
+	case schedulesView:
+		actions = []key.Binding{k.NewSchedule, k.ToggleSchedule, k.DeleteSchedule, k.Refresh}
+	case pipelineStepsView:
+		actions = []key.Binding{withHelp(k.Select, "view logs"), k.StepCommands, k.Artifacts, k.Refresh}
+	case artifactsView:
+		actions = []key.Binding{k.Download, k.Refresh}
+	case stepCommandsView:
+		actions = []key.Binding{withHelp(k.Select, "view log of command"), k.Refresh}
+	case pipelineStepLogView:
+		actions = []key.Binding{withHelp(k.Filter, "search"), k.NextMatch, k.PrevMatch, withHelp(k.NextHunk, "next error"), withHelp(k.PrevHunk, "prev error"), k.View, k.Wrap, k.LineNumbers, k.LogColors, k.GotoLine, k.ScrollLeft, k.ScrollRight}
+	case homeView:
+		actions = []key.Binding{withHelp(k.Select, "open"), k.Refresh, k.Watch}
+	case watchView:
+		actions = []key.Binding{withHelp(k.Select, "open"), withHelp(k.Pin, "unpin"), k.Refresh}
+	case feedView:
+		actions = []key.Binding{withHelp(k.Select, "open"), k.OpenBrowser, k.Revert, k.Decline, k.Reopen, withHelp(k.Feed, "close feed"), k.Refresh}
+	case projectsView:
+		actions = []key.Binding{withHelp(k.Select, "open project"), k.OpenBrowser, withHelp(k.Projects, "close projects"), k.Refresh}
+	case staleApprovalsView:
+		actions = []key.Binding{withHelp(k.Select, "review"), k.OpenBrowser, k.Refresh}
+	case myPRsView:
+		actions = []key.Binding{withHelp(k.Select, "open"), k.OpenBrowser, withHelp(k.MyPRs, "close my pull requests"), k.Refresh}
+	case issuesView:
+		actions = []key.Binding{withHelp(k.Select, "view issue"), k.NewIssue, k.Assign, k.OpenBrowser, k.Jump, k.Refresh, k.Filter}
+	case downloadsView:
+		actions = []key.Binding{withHelp(k.Select, "download"), k.Upload, k.OpenBrowser, k.Refresh, k.Filter}
+	case tagsView:
+		actions = []key.Binding{k.NewTag, k.DeleteTag, k.OpenBrowser, k.Refresh, k.Filter}
+	case deploymentsView:
+		actions = []key.Binding{withHelp(k.Rerun, "rerun pipeline"), k.OpenBrowser, k.Refresh}
+	case issueDetailView:
+		actions = []key.Binding{withHelp(k.Down, "scroll"), k.Assign, k.NewIssue, k.OpenBrowser, k.Refresh}
+	case inspectorView:
+		actions = []key.Binding{withHelp(k.Inspector, "close inspector")}
+	}
+
+	tabs := []key.Binding{k.PrevTab, k.NextTab, k.Tabs, k.RefreshAll}
+	short := append([]key.Binding{}, actions...)
+	if isTabRoot(m.currentView) {
+		short = append(short, k.PrevTab, k.NextTab)
+	}
+	short = append(short, k.Back, k.Quit, k.Help)
+
+	return contextKeyMap{
+		short: short,
+		full:  [][]key.Binding{append(nav, k.Back), actions, tabs, global},
+	}
+}
+
+// chordBinding is a two-key sequence such as "g p". The first key puts the
+// model into a pending state and the second one runs the action. Chords are
+// remapped by name like single keys, with both keys in one entry:
+// key.goto_pipelines = g p,g l.
+type chordBinding struct {
+	name    string
+	binding key.Binding
+	action  func(m *AppModel) tea.Cmd
+}
+
+func newChord(name, keys, help string, action func(m *AppModel) tea.Cmd) chordBinding {
+	return chordBinding{name: name, binding: key.NewBinding(key.WithKeys(keys), key.WithHelp(keys, help)), action: action}
+}
+
+func defaultChords() []chordBinding {
+	return []chordBinding{
+		newChord("goto_repositories", "g r", "go to repositories", goToRepositories),
+		newChord("goto_pull_requests", "g P", "go to pull requests", func(m *AppModel) tea.Cmd { return goToTab(m, prView) }),
+		newChord("goto_branches", "g b", "go to branches", func(m *AppModel) tea.Cmd { return goToTab(m, branchesView) }),
+		newChord("goto_pipelines", "g p", "go to pipelines", func(m *AppModel) tea.Cmd { return goToTab(m, pipelinesView) }),
+		newChord("goto_issues", "g i", "go to issues", func(m *AppModel) tea.Cmd { return goToTab(m, issuesView) }),
+		newChord("goto_downloads", "g d", "go to downloads", func(m *AppModel) tea.Cmd { return goToTab(m, downloadsView) }),
+		newChord("goto_tags", "g t", "go to tags", func(m *AppModel) tea.Cmd { return goToTab(m, tagsView) }),
+		newChord("goto_deployments", "g e", "go to deployments", func(m *AppModel) tea.Cmd { return goToTab(m, deploymentsView) }),
+		newChord("goto_feed", "g f", "go to activity feed", openFeed),
+		newChord("goto_stale_approvals", "g a", "go to stale approvals", openStaleApprovals),
+		newChord("goto_my_prs", "g m", "go to my pull requests", openMyPRs),
+		newChord("yank_url", "y u", "yank URL", yankURL),
+		newChord("yank_hash", "y h", "yank commit hash", yankHash),
+		newChord("state_new", "s n", "new", setIssueState("new")),
+		newChord("state_open", "s o", "open", setIssueState("open")),
+		newChord("state_on_hold", "s h", "on hold", setIssueState("on hold")),
+		newChord("state_resolved", "s r", "resolved", setIssueState("resolved")),
+		newChord("state_invalid", "s i", "invalid", setIssueState("invalid")),
+		newChord("state_duplicate", "s d", "duplicate", setIssueState("duplicate")),
+		newChord("state_wontfix", "s w", "wontfix", setIssueState("wontfix")),
+		newChord("state_closed", "s c", "closed", setIssueState("closed")),
+	}
+}
+
+func handleChordKey(m AppModel, msg tea.KeyMsg) (AppModel, tea.Cmd) {
+	sequence := m.pendingChord + " " + msg.String()
+	m.pendingChord = ""
+	for _, chord := range m.keys.Sequences {
+		if slices.Contains(chord.binding.Keys(), sequence) {
+			cmd := chord.action(&m)
+			return m, cmd
+		}
+	}
+	if msg.String() != "esc" {
+		m.message = fmt.Sprintf("Unknown key sequence: %s", sequence)
+	}
+	return m, nil
+}
+
+func (k keyMap) chordHelp(prefix string) string {
+	var parts []string
+	for _, chord := range k.Sequences {
+		var seconds []string
+		for _, sequence := range chord.binding.Keys() {
+			if second, ok := strings.CutPrefix(sequence, prefix+" "); ok {
+				seconds = append(seconds, second)
+			}
+		}
+		if len(seconds) > 0 {
+			parts = append(parts, fmt.Sprintf("%s: %s", strings.Join(seconds, "/"), chord.binding.Help().Desc))
+		}
+	}
+	return fmt.Sprintf("%s-  %s  esc: %s", prefix, strings.Join(parts, "  "), tr("cancel"))
+}
+
+func goToRepositories(m *AppModel) tea.Cmd {
+	m.activePane = repoPane
+	m.currentView = noSelection
+	return nil
+}
+
+func goToTab(m *AppModel, view viewMode) tea.Cmd {
+	if m.selectedRepoSlug == "" {
+		if m.activePane == repoPane && len(m.getFilteredRepos()) > 0 {
+			return openTabForSelectedRepo(m, tabIndex(view))
+		}
+		m.message = "Select a repository first"
+		return nil
+	}
+	return openTab(m, tabIndex(view))
+}
+
+func yankURL(m *AppModel) tea.Cmd {
+	url := m.selectedItemURL()
+	if url == "" {
+		m.message = "Nothing with a URL is selected"
+		return nil
+	}
+	return copyToClipboard(m.clipboard, url, "URL")
+}
+
+func yankHash(m *AppModel) tea.Cmd {
+	hash := ""
+	if m.activePane == branchPane {
+		switch m.currentView {
+		case prCommitsView, branchCommitsView, branchCompareView:
+			hash = m.selectedCommitHash
+		case branchesView:
+			if filtered := m.getFilteredBranches(); m.branchCursor < len(filtered) {
+				hash = filtered[m.branchCursor].Target.Hash
+			}
+		case tagsView:
+			if filtered := m.getFilteredTags(); m.tagCursor < len(filtered) {
+				hash = filtered[m.tagCursor].Target.Hash
+			}
+		case deploymentsView:
+			if m.environmentCursor < len(m.environments) {
+				hash = m.environments[m.environmentCursor].Deployment.CommitHash
+			}
+		}
+	}
+	if hash == "" {
+		m.message = "No commit selected"
+		return nil
+	}
+	return copyToClipboard(m.clipboard, hash, "commit hash")
+}
+
+// selectedItemURL returns the web URL of whatever is highlighted.
+func (m AppModel) selectedItemURL() string {
+	if m.workspace == "" {
+		return ""
+	}
+
+	if m.activePane == repoPane {
+		repos := m.getFilteredRepos()
+		if m.repoCursor < len(repos) {
+			return fmt.Sprintf("https://bitbucket.org/%s/%s", m.workspace, repos[m.repoCursor].Slug)
+		}
+		return ""
+	}
+
+	if stale, ok := m.selectedStaleApproval(); ok {
+		return stale.pr.URL
+	}
+	if pr, ok := m.selectedMyPR(); ok {
+		return pr.URL
+	}
+	if m.currentView == projectsView {
+		if m.projectCursor < len(m.projects) {
+			return m.projectURL(m.projects[m.projectCursor])
+		}
+		return ""
+	}
+	if m.currentView == feedView {
+		if events := m.feedEvents(); m.feedCursor < len(events) {
+			return m.feedEventURL(events[m.feedCursor])
+		}
+		return ""
+	}
+
+	if m.selectedRepoSlug == "" {
+		return ""
+	}
+	repoURL := fmt.Sprintf("https://bitbucket.org/%s/%s", m.workspace, m.selectedRepoSlug)
+
+	switch m.currentView {
+	case prView:
+		if filtered := m.getFilteredPRs(); m.prCursor < len(filtered) {
+			return m.pullRequestURL(filtered[m.prCursor])
+		}
+	case prDetailView, prCommentsView:
+		return m.pullRequestURL(m.openPullRequest)
+	case prDiffView:
+		if url := m.pullRequestURL(m.prDiffPR); url != "" {
+			return url + "/diff"
+		}
+	case prCommitsView, branchCommitsView, branchCompareView:
+		if m.selectedCommitHash != "" {
+			return fmt.Sprintf("%s/commits/%s", repoURL, m.selectedCommitHash)
+		}
+	case branchesView:
+		if filtered := m.getFilteredBranches(); m.branchCursor < len(filtered) {
+			return fmt.Sprintf("%s/branch/%s", repoURL, filtered[m.branchCursor].Name)
+		}
+	case pipelinesView:
+		if filtered := m.getFilteredPipelines(); m.pipelineCursor < len(filtered) {
+			return fmt.Sprintf("%s/pipelines/results/%d", repoURL, filtered[m.pipelineCursor].BuildNumber)
+		}
+	case downloadsView:
+		return repoURL + "/downloads/"
+	case tagsView:
+		if filtered := m.getFilteredTags(); m.tagCursor < len(filtered) {
+			return fmt.Sprintf("%s/src/%s", repoURL, filtered[m.tagCursor].Name)
+		}
+	case deploymentsView:
+		if m.environmentCursor < len(m.environments) {
+			return deploymentURL(repoURL, m.environments[m.environmentCursor].Deployment)
+		}
+	case pipelineStepsView, pipelineStepLogView, stepCommandsView, artifactsView:
+		if m.selectedPipelineRef != "" {
+			return fmt.Sprintf("%s/pipelines/results/%s", repoURL, strings.TrimPrefix(m.selectedPipelineRef, "#"))
+		}
+	case issuesView, issueDetailView:
+		if issue, ok := m.selectedIssue(); ok {
+			return m.issueURL(issue)
+		}
+	}
+	return repoURL
+}
@@ -0,0 +1,153 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"bitbucket-cli/internal/bitbucket"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// liveDebounce gathers the bursts of webhook deliveries a single push or
+// merge causes into one refresh.
+const liveDebounce = 2 * time.Second
+
+// Kinds of live updates, matching the webhook package's event kinds.
+const (
+	LivePush        = "push"
+	LivePullRequest = "pullrequest"
+	LivePipeline    = "pipeline"
+)
+
+type liveEventMsg struct {
+	kind      string
+	workspace string
+	repoSlug  string
+}
+
+type liveRefreshMsg struct {
+	seq int
+}
+
+type autoRefreshTickMsg struct{}
+
+// LiveUpdate is sent to the running program when something changed on
+// Bitbucket, e.g. by a webhook listener. kind is one of the Live constants.
+func LiveUpdate(kind, workspace, repoSlug string) tea.Msg {
+	return liveEventMsg{kind: kind, workspace: workspace, repoSlug: repoSlug}
+}
+
+// liveViews lists the views showing data that an event kind changes.
+var liveViews = map[string][]viewMode{
+	LivePush:        {branchesView, tagsView, pipelinesView, homeView},
+	LivePullRequest: {prView, homeView, watchView, feedView, staleApprovalsView},
+	LivePipeline:    {prView, pipelinesView, pipelineStepsView, stepCommandsView, deploymentsView, homeView, watchView, feedView},
+}
+
+// handleLiveEvent schedules a quiet refresh when the event concerns what is
+// on screen. Refreshes are debounced, so only the last of a burst runs.
+func handleLiveEvent(m *AppModel, msg liveEventMsg) tea.Cmd {
+	if msg.workspace != "" && !strings.EqualFold(msg.workspace, m.workspace) {
+		return nil
+	}
+	if !liveEventShown(*m, msg) {
+		return nil
+	}
+	m.liveSeq++
+	seq := m.liveSeq
+	return tea.Tick(liveDebounce, func(time.Time) tea.Msg {
+		return liveRefreshMsg{seq: seq}
+	})
+}
+
+func liveEventShown(m AppModel, msg liveEventMsg) bool {
+	affected := false
+	for _, view := range liveViews[msg.kind] {
+		if view == m.currentView {
+			affected = true
+		}
+	}
+	if !affected || m.activePane != branchPane {
+		return false
+	}
+	switch m.currentView {
+	case homeView:
+		for _, slug := range m.homeFavorites() {
+			if slug == msg.repoSlug {
+				return true
+			}
+		}
+		// The user's own pull requests may live in any repository.
+		return msg.kind == LivePullRequest
+	case feedView, staleApprovalsView:
+		return slices.Contains(m.homeFavorites(), msg.repoSlug)
+	case myPRsView:
+		return msg.kind == LivePullRequest
+	case watchView:
+		for _, item := range m.watchItems {
+			if item.RepoSlug == msg.repoSlug {
+				return true
+			}
+		}
+		return false
+	default:
+		return m.selectedRepoSlug == msg.repoSlug
+	}
+}
+
+// scheduleAutoRefresh starts the next tick of the auto_refresh polling used
+// when no webhook listener is running.
+func scheduleAutoRefresh(m AppModel) tea.Cmd {
+	if m.autoRefresh <= 0 {
+		return nil
+	}
+	return tea.Tick(m.autoRefresh, func(time.Time) tea.Msg {
+		return autoRefreshTickMsg{}
+	})
+}
+
+// liveRefresh reloads the current view in the background, keeping the list
+// and cursor on screen until the fresh data arrives.
+func liveRefresh(m *AppModel) tea.Cmd {
+	if m.filterMode || m.activePane != branchPane || m.client.RateLimit().Cooldown() > 0 {
+		return nil
+	}
+	ctx := bitbucket.WithoutCache(m.viewCtx)
+	switch m.currentView {
+	case homeView:
+		return loadHome(m)
+	case feedView:
+		return loadFeed(m, ctx)
+	case staleApprovalsView:
+		if m.currentUser.UUID != "" {
+			return loadAllStaleApprovals(m, ctx)
+		}
+	case myPRsView:
+		if m.currentUser.UUID != "" {
+			return loadMyPRs(m, ctx)
+		}
+	case watchView:
+		return refreshWatchList(*m)
+	case branchesView:
+		m.quietReload = true
+		return forView(m, loadBranches(ctx, m.client, m.selectedRepoSlug))
+	case tagsView:
+		return forView(m, loadTags(ctx, m.client, m.selectedRepoSlug))
+	case prView:
+		m.quietReload = true
+		return forView(m, loadPullRequests(ctx, m.client, m.selectedRepoSlug, m.pullRequestState()))
+	case pipelinesView:
+		return forView(m, loadPipelines(ctx, m.client, m.selectedRepoSlug))
+	case pipelineStepsView:
+		if m.selectedPipelineUUID != "" {
+			return forView(m, loadPipelineSteps(ctx, m.client, m.selectedRepoSlug, m.selectedPipelineUUID))
+		}
+	case stepCommandsView:
+		return forView(m, loadStepCommands(ctx, m.client, m.selectedRepoSlug, m.selectedPipelineUUID, m.commandsStep.UUID))
+	case deploymentsView:
+		return forView(m, loadDeployments(ctx, m.client, m.selectedRepoSlug))
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package tui
+
+import (
+	"bufio"
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// bundledCatalogs are the translations shipped with the binary, one
+// locales/<locale>.txt per locale.
+//
+//go:embed locales/*.txt
+var bundledCatalogs embed.FS
+
+// messages maps the English UI strings to the selected locale. It is nil for
+// English, and strings without a translation stay English.
+var messages map[string]string
+
+// tr returns the translation of an English UI string.
+func tr(s string) string {
+	if translated, ok := messages[s]; ok {
+		return translated
+	}
+	return s
+}
+
+// trf translates format, then formats it like fmt.Sprintf. Translations
+// keep the verbs of the English format in the same order.
+func trf(format string, args ...any) string {
+	return fmt.Sprintf(tr(format), args...)
+}
+
+// loadCatalog reads the catalog of locale: the bundled one, if any, with
+// ~/.config/bitbucket-cli/locales/<locale>.txt on top. An empty locale or
+// "en" means English.
+func loadCatalog(locale string) (map[string]string, error) {
+	locale = strings.TrimSpace(locale)
+	if locale == "" || strings.EqualFold(locale, "en") {
+		return nil, nil
+	}
+	if strings.ContainsAny(locale, `/\.`) {
+		return nil, fmt.Errorf("invalid locale %q", locale)
+	}
+
+	catalog := make(map[string]string)
+	found := false
+	if file, err := bundledCatalogs.Open("locales/" + locale + ".txt"); err == nil {
+		err = readCatalog(file, catalog)
+		file.Close()
+		if err != nil {
+			return nil, err
+		}
+		found = true
+	}
+
+	if home, err := os.UserHomeDir(); err == nil {
+		path := filepath.Join(home, ".config", "bitbucket-cli", "locales", locale+".txt")
+		file, err := os.Open(path)
+		switch {
+		case err == nil:
+			err = readCatalog(file, catalog)
+			file.Close()
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", path, err)
+			}
+			found = true
+		case !errors.Is(err, fs.ErrNotExist):
+			return nil, err
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("no translation for locale %q", locale)
+	}
+	return catalog, nil
+}
+
+// readCatalog adds the "English = translation" lines of r to catalog. Blank
+// lines and lines starting with # are skipped.
+func readCatalog(r io.Reader, catalog map[string]string) error {
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		english, translated, ok := strings.Cut(text, " = ")
+		if !ok {
+			return fmt.Errorf("line %d: expected \"English = translation\"", line)
+		}
+		catalog[strings.TrimSpace(english)] = strings.TrimSpace(translated)
+	}
+	return scanner.Err()
+}
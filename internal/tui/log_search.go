@@ -0,0 +1,148 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// logMatchStyle highlights the log lines matching the search.
+var logMatchStyle = lipgloss.NewStyle().Reverse(true)
+
+// startLogSearch opens the search prompt of the log view. Cancelling it puts
+// the cursor back where it was.
+func startLogSearch(m *AppModel) {
+	m.logSearchMode = true
+	m.logSearchQuery = ""
+	m.logSearchOrigin = m.pipelineStepLogCursor
+	m.logMatches = nil
+}
+
+// clearLogSearch forgets the search when another log is shown.
+func clearLogSearch(m *AppModel) {
+	m.logSearchMode = false
+	m.logSearchQuery = ""
+	m.logMatches = nil
+}
+
+// findLogMatches returns the indexes of the lines containing query, ignoring
+// case.
+func findLogMatches(lines []string, query string) []int {
+	if query == "" {
+		return nil
+	}
+	query = strings.ToLower(query)
+	var matches []int
+	for i, line := range lines {
+		if strings.Contains(strings.ToLower(line), query) {
+			matches = append(matches, i)
+		}
+	}
+	return matches
+}
+
+func updateLogMatches(m *AppModel) {
+	m.logMatches = findLogMatches(m.pipelineStepLogLines, m.logSearchQuery)
+}
+
+// handleLogSearchKey edits the query, moving the cursor to the first match
+// from where the search started as it is typed.
+func handleLogSearchKey(m AppModel, msg tea.KeyMsg) (AppModel, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		clearLogSearch(&m)
+		m.pipelineStepLogCursor = m.logSearchOrigin
+		return m, nil
+	case tea.KeyEnter:
+		m.logSearchMode = false
+		if m.logSearchQuery != "" && len(m.logMatches) == 0 {
+			m.message = fmt.Sprintf("No lines match %q", m.logSearchQuery)
+			clearLogSearch(&m)
+		}
+		return m, nil
+	case tea.KeyBackspace:
+		if runes := []rune(m.logSearchQuery); len(runes) > 0 {
+			m.logSearchQuery = string(runes[:len(runes)-1])
+		}
+	case tea.KeySpace:
+		m.logSearchQuery += " "
+	case tea.KeyRunes:
+		m.logSearchQuery += string(msg.Runes)
+	default:
+		return m, nil
+	}
+
+	updateLogMatches(&m)
+	m.pipelineStepLogCursor = m.logSearchOrigin
+	if len(m.logMatches) > 0 {
+		m.pipelineStepLogCursor, _ = nextLogMatch(m.logMatches, m.logSearchOrigin-1, 1)
+	}
+	return m, nil
+}
+
+// nextLogMatch returns the first match after line (delta 1) or before it
+// (delta -1), wrapping around the ends of the log; wrapped tells whether it
+// did. matches must not be empty.
+func nextLogMatch(matches []int, line, delta int) (next int, wrapped bool) {
+	if delta > 0 {
+		i := sort.SearchInts(matches, line+1)
+		if i < len(matches) {
+			return matches[i], false
+		}
+		return matches[0], true
+	}
+	i := sort.SearchInts(matches, line)
+	if i > 0 {
+		return matches[i-1], false
+	}
+	return matches[len(matches)-1], true
+}
+
+// moveLogMatch moves the log cursor to the next (delta 1) or previous (delta
+// -1) line matching the search.
+func moveLogMatch(m *AppModel, delta int) {
+	if m.logSearchQuery == "" {
+		m.message = "No search; press / to search the log"
+		return
+	}
+	if len(m.logMatches) == 0 {
+		m.message = fmt.Sprintf("No lines match %q", m.logSearchQuery)
+		return
+	}
+	line, wrapped := nextLogMatch(m.logMatches, m.pipelineStepLogCursor, delta)
+	m.pipelineStepLogCursor = line
+	if wrapped && delta > 0 {
+		m.message = "Search wrapped to the top"
+	} else if wrapped {
+		m.message = "Search wrapped to the bottom"
+	}
+}
+
+// isLogMatch reports whether the log line at index matches the search.
+func (m AppModel) isLogMatch(index int) bool {
+	i := sort.SearchInts(m.logMatches, index)
+	return i < len(m.logMatches) && m.logMatches[i] == index
+}
+
+// logSearchStatus is the match counter of the log title, e.g. "/timeout
+// 3/17" with the cursor on the third of 17 matching lines.
+func (m AppModel) logSearchStatus() string {
+	switch i := sort.SearchInts(m.logMatches, m.pipelineStepLogCursor); {
+	case len(m.logMatches) == 0:
+		return trf("/%s: no matches", m.logSearchQuery)
+	case i < len(m.logMatches) && m.logMatches[i] == m.pipelineStepLogCursor:
+		return fmt.Sprintf("/%s %d/%d", m.logSearchQuery, i+1, len(m.logMatches))
+	case len(m.logMatches) == 1:
+		return trf("/%s: 1 match", m.logSearchQuery)
+	default:
+		return trf("/%s: %d matches", m.logSearchQuery, len(m.logMatches))
+	}
+}
+
+// logSearchPrompt is the help line while the search is typed.
+func logSearchPrompt(m AppModel) string {
+	return trf("Search log: %s  (esc: cancel, enter: confirm)", m.logSearchQuery)
+}
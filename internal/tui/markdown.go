@@ -0,0 +1,69 @@
+package tui
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	markdownBold       = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+	markdownInlineCode = regexp.MustCompile("`([^`]+)`")
+	markdownHeading    = regexp.MustCompile(`^#{1,6}\s+`)
+	markdownBullet     = regexp.MustCompile(`^\s*[-*+]\s+`)
+)
+
+// renderMarkdown lays out a pull request description or comment written in
+// Bitbucket's markdown, wrapped to width. It covers what descriptions use
+// most: headings, bullet lists, quotes, fenced code and **bold** or `code`
+// spans. Everything else is shown as written.
+func renderMarkdown(text string, width int) []string {
+	bold := lipgloss.NewStyle().Bold(true)
+	code := lipgloss.NewStyle().Foreground(lipgloss.Color(currentTheme.muted))
+	wrapTo := func(text string, width int) []string {
+		text = markdownBold.ReplaceAllStringFunc(text, func(match string) string {
+			return bold.Render(markdownBold.FindStringSubmatch(match)[1])
+		})
+		text = markdownInlineCode.ReplaceAllStringFunc(text, func(match string) string {
+			return code.Render(markdownInlineCode.FindStringSubmatch(match)[1])
+		})
+		return strings.Split(lipgloss.NewStyle().Width(max(width, 1)).Render(text), "\n")
+	}
+	bulletGlyph, quoteMark := "•", "│ "
+	if currentTheme.plain {
+		bulletGlyph, quoteMark = "-", "> "
+	}
+
+	var lines []string
+	fenced := false
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			fenced = !fenced
+			continue
+		}
+		switch {
+		case fenced:
+			lines = append(lines, code.Render("  "+line))
+		case strings.TrimSpace(line) == "":
+			lines = append(lines, "")
+		case markdownHeading.MatchString(line):
+			lines = append(lines, bold.Render(markdownHeading.ReplaceAllString(line, "")))
+		case markdownBullet.MatchString(line):
+			for i, wrapped := range wrapTo(markdownBullet.ReplaceAllString(line, ""), width-2) {
+				if i == 0 {
+					lines = append(lines, bulletGlyph+" "+wrapped)
+				} else {
+					lines = append(lines, "  "+wrapped)
+				}
+			}
+		case strings.HasPrefix(line, ">"):
+			for _, wrapped := range wrapTo(strings.TrimSpace(strings.TrimPrefix(line, ">")), width-2) {
+				lines = append(lines, code.Render(quoteMark)+wrapped)
+			}
+		default:
+			lines = append(lines, wrapTo(line, width)...)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,233 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"bitbucket-cli/internal/bitbucket"
+	"bitbucket-cli/internal/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// mergeRules are the merge checks configured on a repository. Only admins
+// may read them; for everyone else readable is false and the checks fall
+// back to what blocks a merge in general.
+type mergeRules struct {
+	restrictions []domain.BranchRestriction
+	readable     bool
+}
+
+type mergeChecksLoadedMsg struct {
+	repoSlug string
+	key      string
+	// rules is nil when the repository's rules were already known.
+	rules    *mergeRules
+	statuses []domain.BuildStatus
+	err      error
+}
+
+// mergeCheck is one condition for merging a pull request. pending marks
+// builds still running, which neither pass nor fail yet.
+type mergeCheck struct {
+	label   string
+	passed  bool
+	pending bool
+}
+
+// loadMergeChecks fetches the build statuses of a pull request, and the merge
+// rules of its repository unless they are known already.
+func loadMergeChecks(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug string, pullRequestID int, needRules bool) tea.Cmd {
+	return func() tea.Msg {
+		msg := mergeChecksLoadedMsg{repoSlug: repoSlug, key: pullRequestKey(repoSlug, pullRequestID)}
+		if needRules {
+			restrictions, err := client.ListBranchRestrictions(ctx, repoSlug)
+			switch {
+			case errors.Is(err, bitbucket.ErrForbidden):
+				msg.rules = &mergeRules{}
+			case err != nil:
+				msg.err = err
+				return msg
+			default:
+				msg.rules = &mergeRules{restrictions: restrictions, readable: true}
+			}
+		}
+		msg.statuses, msg.err = client.ListPullRequestStatuses(ctx, repoSlug, pullRequestID)
+		return msg
+	}
+}
+
+// prefetchMergeChecks loads the merge checks of the selected pull request
+// when they are not loaded or loading yet.
+func prefetchMergeChecks(m *AppModel) tea.Cmd {
+	prs := m.getFilteredPRs()
+	if m.prCursor >= len(prs) || m.selectedRepoSlug == "" {
+		return nil
+	}
+	key := pullRequestKey(m.selectedRepoSlug, prs[m.prCursor].ID)
+	if _, ok := m.prStatuses[key]; ok || m.prefetch.inFlight["checks:"+key] {
+		return nil
+	}
+	m.prefetch.inFlight["checks:"+key] = true
+	_, haveRules := m.mergeRules[m.selectedRepoSlug]
+	return loadMergeChecks(m.ctx, m.client, m.selectedRepoSlug, prs[m.prCursor].ID, !haveRules)
+}
+
+// clearMergeChecks drops the build statuses of repoSlug's pull requests, so
+// they are fetched again after the list reloads.
+func clearMergeChecks(m *AppModel, repoSlug string) {
+	for key := range m.prStatuses {
+		if strings.HasPrefix(key, repoSlug+"#") {
+			delete(m.prStatuses, key)
+			delete(m.mergeCheckErrors, key)
+		}
+	}
+}
+
+func storeMergeChecks(m *AppModel, msg mergeChecksLoadedMsg) {
+	delete(m.prefetch.inFlight, "checks:"+msg.key)
+	if msg.err != nil {
+		m.prStatuses[msg.key] = nil
+		m.mergeCheckErrors[msg.key] = msg.err
+		return
+	}
+	delete(m.mergeCheckErrors, msg.key)
+	if msg.rules != nil {
+		m.mergeRules[msg.repoSlug] = *msg.rules
+	}
+	if msg.statuses == nil {
+		msg.statuses = []domain.BuildStatus{}
+	}
+	m.prStatuses[msg.key] = msg.statuses
+}
+
+// restrictionApplies reports whether a rule covers branch. Rules on a
+// branching model type (e.g. "production") cannot be resolved here and are
+// left out.
+func restrictionApplies(restriction domain.BranchRestriction, branch string) bool {
+	if restriction.BranchMatchKind != "glob" {
+		return false
+	}
+	matched, err := path.Match(restriction.Pattern, branch)
+	return err == nil && matched
+}
+
+// computeMergeChecks lists the conditions for merging pr. With readable rules
+// these are the repository's merge checks for the destination branch;
+// otherwise the approvals, tasks, change requests and builds that usually
+// gate a merge.
+func computeMergeChecks(pr domain.PullRequest, rules mergeRules, statuses []domain.BuildStatus) []mergeCheck {
+	successful, failed, running := 0, 0, 0
+	for _, status := range statuses {
+		switch strings.ToUpper(status.State) {
+		case "SUCCESSFUL":
+			successful++
+		case "FAILED":
+			failed++
+		case "INPROGRESS":
+			running++
+		}
+	}
+
+	tasks := mergeCheck{label: "no open tasks", passed: pr.TaskCount == 0}
+	if pr.TaskCount == 1 {
+		tasks.label = "1 open task"
+	} else if pr.TaskCount > 1 {
+		tasks.label = fmt.Sprintf("%d open tasks", pr.TaskCount)
+	}
+	changes := mergeCheck{label: "no changes requested", passed: pr.ChangesRequested == 0}
+	if pr.ChangesRequested > 0 {
+		changes.label = fmt.Sprintf("changes requested by %d", pr.ChangesRequested)
+	}
+	builds := func(required int) mergeCheck {
+		check := mergeCheck{label: fmt.Sprintf("builds %d/%d", successful, required), passed: successful >= required && failed == 0 && running == 0}
+		if required == 0 {
+			check.label = fmt.Sprintf("%d builds passed", successful)
+		}
+		if failed > 0 {
+			check.label += fmt.Sprintf(", %d failed", failed)
+		}
+		if running > 0 {
+			check.label += fmt.Sprintf(", %d running", running)
+			check.pending = failed == 0
+		}
+		return check
+	}
+
+	if !rules.readable {
+		approvals := mergeCheck{label: "1 approval", passed: pr.Approvals > 0}
+		if pr.Approvals != 1 {
+			approvals.label = fmt.Sprintf("%d approvals", pr.Approvals)
+		}
+		checks := []mergeCheck{approvals, tasks}
+		if pr.ChangesRequested > 0 {
+			checks = append(checks, changes)
+		}
+		if len(statuses) > 0 {
+			checks = append(checks, builds(0))
+		}
+		return checks
+	}
+
+	var checks []mergeCheck
+	for _, restriction := range rules.restrictions {
+		if !restrictionApplies(restriction, pr.DestBranch) {
+			continue
+		}
+		switch restriction.Kind {
+		case "require_approvals_to_merge":
+			checks = append(checks, mergeCheck{label: fmt.Sprintf("approvals %d/%d", pr.Approvals, restriction.Value), passed: pr.Approvals >= restriction.Value})
+		case "require_passing_builds_to_merge":
+			checks = append(checks, builds(restriction.Value))
+		case "require_tasks_to_be_completed":
+			checks = append(checks, tasks)
+		case "require_no_changes_requested":
+			checks = append(checks, changes)
+		}
+	}
+	return checks
+}
+
+// renderMergeChecks is the line shown under the selected pull request.
+func (m AppModel) renderMergeChecks(pr domain.PullRequest) string {
+	key := pullRequestKey(m.selectedRepoSlug, pr.ID)
+	if err, ok := m.mergeCheckErrors[key]; ok {
+		return "checks: " + messageStyle.Render(describeError(err))
+	}
+	statuses, ok := m.prStatuses[key]
+	rules, haveRules := m.mergeRules[m.selectedRepoSlug]
+	if !ok || !haveRules {
+		return "checks: " + m.spinner.View()
+	}
+
+	checks := computeMergeChecks(pr, rules, statuses)
+	if len(checks) == 0 {
+		return inactivePaneStyle.Render(fmt.Sprintf("checks: no merge checks on %s", pr.DestBranch))
+	}
+	parts := make([]string, 0, len(checks))
+	for _, check := range checks {
+		color, glyph, word := currentTheme.failure, "✗", "blocked"
+		switch {
+		case check.pending:
+			color, glyph, word = currentTheme.running, "◐", "pending"
+		case check.passed:
+			color, glyph, word = currentTheme.success, "✓", "met"
+		}
+		if nerd, ok := nerdGlyphs[glyph]; ok && currentTheme.nerd {
+			glyph = nerd
+		}
+		if currentTheme.plain {
+			glyph = word + ":"
+		}
+		parts = append(parts, lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render(glyph+" "+check.label))
+	}
+	line := "checks: " + strings.Join(parts, "  ")
+	if !rules.readable {
+		line += inactivePaneStyle.Render("  (merge check settings need repository admin)")
+	}
+	return line
+}
@@ -0,0 +1,185 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"bitbucket-cli/internal/bitbucket"
+	"bitbucket-cli/internal/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type myAuthoredPRsLoadedMsg struct {
+	prs []domain.PullRequest
+	err error
+}
+
+type myReviewPRsLoadedMsg struct {
+	repoSlug string
+	prs      []domain.PullRequest
+	err      error
+}
+
+func loadMyAuthoredPRs(ctx context.Context, client bitbucket.BitbucketAPI, userUUID string) tea.Cmd {
+	return func() tea.Msg {
+		prs, err := client.ListUserPullRequests(ctx, userUUID)
+		return myAuthoredPRsLoadedMsg{prs: prs, err: err}
+	}
+}
+
+func loadMyReviewPRs(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug, userUUID string) tea.Cmd {
+	return func() tea.Msg {
+		prs, err := client.ListReviewPullRequests(ctx, repoSlug, userUUID)
+		return myReviewPRsLoadedMsg{repoSlug: repoSlug, prs: prs, err: err}
+	}
+}
+
+// openMyPRs shows the open pull requests the user authored or is asked to
+// review, across every repository of the workspace.
+func openMyPRs(m *AppModel) tea.Cmd {
+	m.activePane = branchPane
+	m.currentView = myPRsView
+	m.myAuthoredPRs = nil
+	m.myReviewPRs = nil
+	m.myPRsCursor = 0
+	ctx := newViewContext(m)
+	if m.currentUser.UUID == "" {
+		m.myPRsPending = 1
+		return loadCurrentUser(m.ctx, m.client)
+	}
+	return loadMyPRs(m, ctx)
+}
+
+// loadMyPRs fetches the authored pull requests with one workspace request
+// and the review requests with one request per repository, as Bitbucket can
+// only filter by reviewer within a repository. What is on screen stays until
+// each result replaces it.
+func loadMyPRs(m *AppModel, ctx context.Context) tea.Cmd {
+	m.myPRsErrors = nil
+	cmds := []tea.Cmd{forView(m, loadMyAuthoredPRs(ctx, m.client, m.currentUser.UUID))}
+	for _, repo := range m.repositories {
+		cmds = append(cmds, forView(m, loadMyReviewPRs(ctx, m.client, repo.Slug, m.currentUser.UUID)))
+	}
+	if len(m.repositories) == 0 {
+		m.message = "No repositories loaded to look for review requests in"
+	}
+	m.myPRsPending = len(cmds)
+	return tea.Batch(cmds...)
+}
+
+// addMyReviewPRs replaces one repository's review requests, keeping the most
+// recently updated first.
+func addMyReviewPRs(m *AppModel, repoSlug string, prs []domain.PullRequest) {
+	m.myReviewPRs = slices.DeleteFunc(m.myReviewPRs, func(pr domain.PullRequest) bool {
+		return pr.RepoSlug == repoSlug
+	})
+	m.myReviewPRs = append(m.myReviewPRs, prs...)
+	sortPullRequestsByUpdated(m.myReviewPRs)
+	m.myPRsCursor = min(m.myPRsCursor, max(len(m.myPRsEntries())-1, 0))
+}
+
+// myPRsEntries lists the authored pull requests, then the review requests.
+func (m AppModel) myPRsEntries() []domain.PullRequest {
+	return append(append([]domain.PullRequest{}, m.myAuthoredPRs...), m.myReviewPRs...)
+}
+
+func (m AppModel) selectedMyPR() (domain.PullRequest, bool) {
+	entries := m.myPRsEntries()
+	if m.currentView != myPRsView || m.activePane != branchPane || m.myPRsCursor >= len(entries) {
+		return domain.PullRequest{}, false
+	}
+	return entries[m.myPRsCursor], true
+}
+
+// openMyPR opens the selected pull request in its repository's pull request
+// tab.
+func openMyPR(m *AppModel) tea.Cmd {
+	pr, ok := m.selectedMyPR()
+	if !ok {
+		return nil
+	}
+	if pr.RepoSlug == "" {
+		m.message = "Unknown repository for selected item"
+		return nil
+	}
+	return openPullRequestInRepo(m, pr)
+}
+
+// openPullRequestInRepo opens pr's repository on the pull request tab with
+// the cursor on pr.
+func openPullRequestInRepo(m *AppModel, pr domain.PullRequest) tea.Cmd {
+	m.selectedRepoSlug = pr.RepoSlug
+	m.selectedRepo = pr.RepoSlug
+	for _, repo := range m.repositories {
+		if repo.Slug == pr.RepoSlug {
+			m.selectedRepo = repo.Name
+		}
+	}
+	m.focusPullRequestID = pr.ID
+	return openTab(m, tabIndex(prView))
+}
+
+func (m AppModel) renderMyPRsPane() string {
+	paneWidth := m.width - 4
+	if paneWidth < 30 {
+		paneWidth = 30
+	}
+
+	availableHeight := m.height - 6
+	if availableHeight < 5 {
+		availableHeight = 5
+	}
+
+	title := tr("My pull requests (esc: back)")
+	if m.myPRsPending > 0 {
+		title = fmt.Sprintf("%s %s", title, m.spinner.View())
+	}
+
+	sectionStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("220")).Bold(true)
+	entryIndex := 0
+	cursorLine := 0
+	var lines []string
+	addSection := func(heading string, prs []domain.PullRequest) {
+		lines = append(lines, sectionStyle.Render(heading))
+		if len(prs) == 0 {
+			lines = append(lines, inactivePaneStyle.Render("  "+tr("none")))
+		}
+		for _, pr := range prs {
+			cursor := " "
+			if entryIndex == m.myPRsCursor {
+				cursor = cursorStyle.Render(">")
+				cursorLine = len(lines)
+			}
+			line := fmt.Sprintf("%s %s %s", cursor, renderHomePullRequest(pr, m.currentUser), inactivePaneStyle.Render(timeAgo(pr.UpdatedOn)))
+			lines = append(lines, line)
+			entryIndex++
+		}
+	}
+	addSection(trf("Authored by me (%d)", len(m.myAuthoredPRs)), m.myAuthoredPRs)
+	lines = append(lines, "")
+	addSection(trf("Needs my review (%d)", len(m.myReviewPRs)), m.myReviewPRs)
+
+	items := []string{activePaneStyle.Render(title), ""}
+	visible := max(availableHeight-2-len(m.myPRsErrors), 1)
+	if m.myPRsPending > 0 && m.myAuthoredPRs == nil && m.myReviewPRs == nil {
+		items = append(items, m.renderSkeletonRows(paneWidth, visible)...)
+	} else {
+		start, end := m.calculateWindow(cursorLine, len(lines), visible)
+		items = append(items, lines[start:end]...)
+	}
+	for _, err := range m.myPRsErrors {
+		items = append(items, messageStyle.Render(err))
+	}
+
+	content := strings.Join(items, "\n")
+	style := lipgloss.NewStyle().
+		Width(paneWidth).
+		Height(availableHeight).
+		Padding(0, 1)
+
+	return style.Render(content)
+}
@@ -0,0 +1,76 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"bitbucket-cli/internal/domain"
+	"bitbucket-cli/internal/notify"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// longActionThreshold is how long an action has to take before its result is
+// also sent as a notification, on the assumption the user looked away.
+const longActionThreshold = 10 * time.Second
+
+type notificationFailedMsg struct {
+	err error
+}
+
+// actionDoneMsg wraps the result of a command started with startAction.
+type actionDoneMsg struct {
+	msg  tea.Msg
+	took time.Duration
+}
+
+func sendNotification(n notify.Notifier, title, body string) tea.Cmd {
+	if n == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		if err := n.Notify(title, body); err != nil {
+			return notificationFailedMsg{err: err}
+		}
+		return nil
+	}
+}
+
+// isPipelineCompleted reports whether pipeline has reached its final state.
+func isPipelineCompleted(pipeline domain.Pipeline) bool {
+	return strings.EqualFold(strings.TrimSpace(pipeline.State), "completed")
+}
+
+// notifyPipelineFinished notifies when a pipeline seen unfinished before has
+// completed since.
+func notifyPipelineFinished(m *AppModel, repoSlug string, previous, current domain.Pipeline) tea.Cmd {
+	if previous.UUID == "" || isPipelineCompleted(previous) || !isPipelineCompleted(current) {
+		return nil
+	}
+	result := strings.ToLower(current.Result)
+	if result == "" {
+		result = "completed"
+	}
+	title := fmt.Sprintf("Pipeline #%d %s", current.BuildNumber, result)
+	body := fmt.Sprintf("%s %s", repoSlug, formatPipelineBranch(current.BranchName))
+	return sendNotification(m.notifier, title, body)
+}
+
+// notifyNewReviewRequests notifies about pull requests of repoSlug waiting for
+// review that were not there on the previous load. The first load of a
+// repository only records what is there.
+func notifyNewReviewRequests(m *AppModel, repoSlug string, prs []domain.PullRequest) tea.Cmd {
+	previous, loadedBefore := m.seenReviewRequests[repoSlug]
+	seen := make(map[int]bool, len(prs))
+	var cmds []tea.Cmd
+	for _, pr := range prs {
+		seen[pr.ID] = true
+		if loadedBefore && !previous[pr.ID] {
+			title := fmt.Sprintf("Review requested: %s #%d", repoSlug, pr.ID)
+			cmds = append(cmds, sendNotification(m.notifier, title, fmt.Sprintf("%s by %s", pr.Title, pr.Author)))
+		}
+	}
+	m.seenReviewRequests[repoSlug] = seen
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,84 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"bitbucket-cli/internal/config"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// snapshotName names the on-disk copy of a list, e.g. "prs-acme-api".
+func (m AppModel) snapshotName(kind, repoSlug string) string {
+	if repoSlug == "" {
+		return fmt.Sprintf("%s-%s", kind, m.workspace)
+	}
+	return fmt.Sprintf("%s-%s-%s", kind, m.workspace, repoSlug)
+}
+
+// saveSnapshot writes a freshly loaded list to the disk cache in the
+// background. Failures only cost the offline copy, so they are ignored.
+func saveSnapshot(name string, v any) tea.Cmd {
+	return func() tea.Msg {
+		_ = config.SaveSnapshot(name, v)
+		return nil
+	}
+}
+
+// showSnapshot fills target with the cached copy of a list so it can be shown
+// while the fresh one loads. It reports whether a non-empty copy was found.
+func showSnapshot[T any](m *AppModel, name string, target *[]T) bool {
+	var cached []T
+	savedAt, err := config.LoadSnapshot(name, &cached)
+	if err != nil || savedAt.IsZero() || len(cached) == 0 {
+		return false
+	}
+
+	*target = cached
+	m.cachedAt = savedAt
+	m.cacheOffline = false
+	return true
+}
+
+// keepCursor clamps cursor into a list of length n. Lists replacing a cached
+// copy keep the cursor where the user left it instead of jumping to the top.
+func keepCursor(cursor, n int) int {
+	if cursor >= n {
+		cursor = n - 1
+	}
+	if cursor < 0 {
+		cursor = 0
+	}
+	return cursor
+}
+
+// cacheLabel describes data served from the disk cache, e.g. "cached, 5 min
+// old". It is empty for fresh data.
+func cacheLabel(cachedAt time.Time, offline bool) string {
+	if cachedAt.IsZero() {
+		return ""
+	}
+
+	label := "cached, just now"
+	if age := int(time.Since(cachedAt).Minutes()); age > 0 {
+		label = fmt.Sprintf("cached, %d min old", age)
+	}
+	if offline {
+		label += ", offline"
+	}
+	return label
+}
+
+// withCacheLabel appends the cache label of the right pane list to title,
+// with the spinner while fresh data is still on its way.
+func (m AppModel) withCacheLabel(title string) string {
+	label := cacheLabel(m.cachedAt, m.cacheOffline)
+	if label == "" {
+		return title
+	}
+	if !m.cacheOffline {
+		label = fmt.Sprintf("%s %s", label, m.spinner.View())
+	}
+	return fmt.Sprintf("%s (%s)", title, label)
+}
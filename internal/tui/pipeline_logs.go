@@ -0,0 +1,452 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"bitbucket-cli/internal/bitbucket"
+	"bitbucket-cli/internal/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// logMark classifies a log line for highlighting.
+type logMark uint8
+
+const (
+	logPlain logMark = iota
+	logWarning
+	logError
+)
+
+var (
+	// logErrorPattern matches the usual ways builds report a failure: error
+	// and failure words, Go panics, Python tracebacks and non-zero exit codes.
+	logErrorPattern   = regexp.MustCompile(`(?i)\b(error|fatal|fail|failed|failure)\b|^\s*panic:|Traceback \(most recent call last\)|exit (code|status):? *[1-9]|exited with (code )?[1-9]`)
+	logWarningPattern = regexp.MustCompile(`(?i)\b(warn|warning|deprecated)\b`)
+	// logEscapePattern matches the escape sequences test runners write: CSI
+	// sequences (colors, cursor movement, erasing), OSC sequences such as
+	// titles and hyperlinks, and two-byte escapes.
+	logEscapePattern = regexp.MustCompile(`\x1b(\[[0-?]*[ -/]*[@-~]|\][^\x07\x1b]*(\x07|\x1b\\)|[@-Z\\-_])`)
+)
+
+// logLines splits a log into the lines the log view shows: plain, without
+// any escape sequence, for marking, searching and the colors toggle, and
+// colored, keeping only the color sequences and ending in a reset so colors
+// do not bleed into the next line. Of a line rewritten with carriage returns,
+// like a progress bar, the last version is kept.
+func logLines(log string) (plain, colored []string) {
+	for _, line := range strings.Split(log, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if i := strings.LastIndexByte(line, '\r'); i >= 0 {
+			line = line[i+1:]
+		}
+		// A log fetched while the step runs can end mid-sequence.
+		if i := strings.LastIndexByte(line, '\x1b'); i >= 0 && !logEscapePattern.MatchString(line[i:]) {
+			line = line[:i]
+		}
+		hasColor := false
+		line = logEscapePattern.ReplaceAllStringFunc(line, func(seq string) string {
+			if strings.HasPrefix(seq, "\x1b[") && strings.HasSuffix(seq, "m") {
+				hasColor = true
+				return seq
+			}
+			return ""
+		})
+		if hasColor {
+			colored = append(colored, line+ansi.ResetStyle)
+		} else {
+			colored = append(colored, line)
+		}
+		plain = append(plain, ansi.Strip(line))
+	}
+	return plain, colored
+}
+
+// markLogLines classifies every line once when the log loads, so rendering
+// and jumping only look the marks up.
+func markLogLines(lines []string) []logMark {
+	marks := make([]logMark, len(lines))
+	for i, line := range lines {
+		switch {
+		case logErrorPattern.MatchString(line):
+			marks[i] = logError
+		case logWarningPattern.MatchString(line):
+			marks[i] = logWarning
+		}
+	}
+	return marks
+}
+
+// moveLogMark moves the log cursor to the next (delta 1) or previous (delta
+// -1) highlighted line.
+func moveLogMark(m *AppModel, delta int) {
+	for i := m.pipelineStepLogCursor + delta; i >= 0 && i < len(m.logMarks); i += delta {
+		if m.logMarks[i] != logPlain {
+			m.pipelineStepLogCursor = i
+			return
+		}
+	}
+	if errors, warnings := m.logMarkCounts(); errors+warnings == 0 {
+		m.message = "No errors or warnings in this log"
+	} else if delta > 0 {
+		m.message = "No more errors or warnings below"
+	} else {
+		m.message = "No more errors or warnings above"
+	}
+}
+
+func (m AppModel) logMarkCounts() (errors, warnings int) {
+	for _, mark := range m.logMarks {
+		switch mark {
+		case logError:
+			errors++
+		case logWarning:
+			warnings++
+		}
+	}
+	return errors, warnings
+}
+
+// logMarkSummary is e.g. "2 errors, 1 warning".
+func logMarkSummary(errors, warnings int) string {
+	var parts []string
+	switch {
+	case errors == 1:
+		parts = append(parts, tr("1 error"))
+	case errors > 1:
+		parts = append(parts, trf("%d errors", errors))
+	}
+	switch {
+	case warnings == 1:
+		parts = append(parts, tr("1 warning"))
+	case warnings > 1:
+		parts = append(parts, trf("%d warnings", warnings))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// logRows renders the log line at index into display rows: one clipped row
+// without wrapping, or as many rows as the line needs with wrapping on.
+func (m AppModel) logRows(index, width int) []string {
+	line := m.pipelineStepLogLines[index]
+	if !m.logPlain && index < len(m.logColorLines) {
+		line = m.logColorLines[index]
+	}
+	if !m.logWrap {
+		return []string{scrollLine(line, m.logHOffset, width)}
+	}
+	return strings.Split(ansi.Hardwrap(line, width, true), "\n")
+}
+
+// scrollLine returns the width columns of line starting at offset, marking
+// clipped content on either side.
+func scrollLine(line string, offset, width int) string {
+	if offset <= 0 {
+		return ansi.Truncate(line, width, "…")
+	}
+	if ansi.StringWidth(line) <= offset {
+		return ""
+	}
+	return "…" + ansi.Truncate(ansi.Cut(line, offset+1, ansi.StringWidth(line)), width-1, "…")
+}
+
+// logWindow returns the range of logical lines to draw so the cursor line
+// stays visible. With wrapping the window is measured in display rows, which
+// keeps the cursor anchored to the same logical line when wrapping toggles.
+func (m AppModel) logWindow(height, width int) (int, int) {
+	total := len(m.pipelineStepLogLines)
+	if !m.logWrap {
+		return m.calculateWindow(m.pipelineStepLogCursor, total, height)
+	}
+
+	cursor := m.pipelineStepLogCursor
+	used := len(m.logRows(cursor, width))
+	start, end := cursor, cursor+1
+
+	for start > 0 {
+		rows := len(m.logRows(start-1, width))
+		if used+rows > height/2 {
+			break
+		}
+		used += rows
+		start--
+	}
+	for end < total {
+		rows := len(m.logRows(end, width))
+		if used+rows > height {
+			break
+		}
+		used += rows
+		end++
+	}
+	for start > 0 {
+		rows := len(m.logRows(start-1, width))
+		if used+rows > height {
+			break
+		}
+		used += rows
+		start--
+	}
+
+	return start, end
+}
+
+// logFollowInterval is how often the log of a running step is fetched again.
+const logFollowInterval = 3 * time.Second
+
+type stepLogFollowTickMsg struct {
+	stepUUID string
+}
+
+// stepLogFollowedMsg carries what a followed step logged past offset and the
+// pipeline's steps as they were just before the log was fetched.
+type stepLogFollowedMsg struct {
+	stepUUID string
+	offset   int64
+	steps    []domain.PipelineStep
+	log      string
+	err      error
+}
+
+// isStepDone reports whether step has completed, so its log no longer grows.
+func isStepDone(step domain.PipelineStep) bool {
+	return strings.EqualFold(step.State, "COMPLETED")
+}
+
+// followStepLog fetches the steps, then the log past offset, so a step seen
+// completed has its whole log fetched. A step that has not logged anything
+// yet has no log, which is not an error.
+func followStepLog(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug, pipelineUUID, stepUUID string, offset int64) tea.Cmd {
+	return func() tea.Msg {
+		steps, err := client.ListPipelineSteps(ctx, repoSlug, pipelineUUID)
+		if err != nil {
+			return stepLogFollowedMsg{stepUUID: stepUUID, offset: offset, err: err}
+		}
+		log, err := client.GetPipelineStepLogFrom(ctx, repoSlug, pipelineUUID, stepUUID, offset)
+		if errors.Is(err, bitbucket.ErrNotFound) {
+			err = nil
+		}
+		return stepLogFollowedMsg{stepUUID: stepUUID, offset: offset, steps: steps, log: log, err: err}
+	}
+}
+
+func followStepLogTick(m *AppModel, stepUUID string) tea.Cmd {
+	return forView(m, tea.Tick(logFollowInterval, func(time.Time) tea.Msg {
+		return stepLogFollowTickMsg{stepUUID: stepUUID}
+	}))
+}
+
+// fetchFollowedLog asks for what the followed step logged since the last
+// fetch.
+func fetchFollowedLog(m *AppModel) tea.Cmd {
+	offset := int64(len(m.pipelineStepLog))
+	return forView(m, followStepLog(bitbucket.WithoutCache(m.viewCtx), m.client, m.selectedRepoSlug, m.selectedPipelineUUID, m.followStepUUID, offset))
+}
+
+// appendStepLog adds the text a followed step logged. The last line may have
+// been cut mid-line, even mid-escape sequence, so it is split again from its
+// start in the raw log together with the new text. A cursor on the last line
+// stays on the last line, like tail -f.
+func appendStepLog(m *AppModel, text string) {
+	if text == "" {
+		return
+	}
+	atEnd := m.pipelineStepLogCursor >= len(m.pipelineStepLogLines)-1
+	last := max(len(m.pipelineStepLogLines)-1, 0)
+	start := strings.LastIndexByte(m.pipelineStepLog, '\n') + 1
+	m.pipelineStepLog += text
+	plain, colored := logLines(m.pipelineStepLog[start:])
+	m.pipelineStepLogLines = append(m.pipelineStepLogLines[:last], plain...)
+	m.logColorLines = append(m.logColorLines[:min(last, len(m.logColorLines))], colored...)
+	m.logMarks = append(m.logMarks[:min(last, len(m.logMarks))], markLogLines(plain)...)
+	if atEnd {
+		m.pipelineStepLogCursor = len(m.pipelineStepLogLines) - 1
+	}
+	if m.logSearchQuery != "" {
+		updateLogMatches(m)
+	}
+}
+
+// applyFollowedLog appends a followed step's new output and keeps following
+// until the step has completed.
+func applyFollowedLog(m *AppModel, msg stepLogFollowedMsg) tea.Cmd {
+	if msg.stepUUID != m.followStepUUID || msg.offset != int64(len(m.pipelineStepLog)) {
+		return nil
+	}
+	if m.loading {
+		m.finishLoading()
+	}
+	if msg.err != nil {
+		m.message = fmt.Sprintf("Error following pipeline log: %s", describeError(msg.err))
+		return followStepLogTick(m, msg.stepUUID)
+	}
+
+	m.pipelineSteps = msg.steps
+	m.pipelineStepCursor = keepCursor(m.pipelineStepCursor, len(msg.steps))
+	appendStepLog(m, msg.log)
+	for _, step := range msg.steps {
+		if step.UUID == msg.stepUUID && !isStepDone(step) {
+			return followStepLogTick(m, msg.stepUUID)
+		}
+	}
+
+	m.followStepUUID = ""
+	if strings.TrimSpace(m.pipelineStepLog) == "" {
+		m.pipelineStepLogLines = []string{"No log output returned for this step."}
+		m.logColorLines = nil
+		m.logMarks = markLogLines(m.pipelineStepLogLines)
+	}
+	m.message = fmt.Sprintf("Step %s finished", m.selectedStepName)
+	return nil
+}
+
+func (m AppModel) renderPipelineStepLogPane() string {
+	showRepoPane := m.currentView == noSelection || m.activePane == repoPane
+
+	paneWidth := m.width - 4
+	if showRepoPane {
+		repoPaneWidth := (m.width - 10) / 3
+		if repoPaneWidth < 20 {
+			repoPaneWidth = 20
+		}
+		paneWidth = m.width - repoPaneWidth - 10
+	}
+	if paneWidth < 30 {
+		paneWidth = 30
+	}
+
+	availableHeight := m.height - 6
+	if availableHeight < 5 {
+		availableHeight = 5
+	}
+
+	title := tr("Pipeline Logs")
+	if m.selectedRepo != "" {
+		title = trf("Pipeline Logs (%s)", m.selectedRepo)
+	}
+	if m.selectedPipelineRef != "" {
+		title = fmt.Sprintf("%s %s", title, m.selectedPipelineRef)
+	}
+	if m.selectedStepName != "" {
+		title = fmt.Sprintf("%s - %s", title, m.selectedStepName)
+	}
+	if m.followStepUUID != "" {
+		title = trf("%s [live]", title)
+	}
+	if m.logPlain {
+		title = trf("%s [no colors]", title)
+	}
+	if m.logWrap {
+		title = trf("%s [wrap]", title)
+	} else if m.logHOffset > 0 {
+		title = trf("%s [col %d]", title, m.logHOffset+1)
+	}
+	if !showRepoPane {
+		title = trf("%s (esc: back)", title)
+	}
+
+	if m.activePane == branchPane {
+		title = activePaneStyle.Render(title)
+	} else {
+		title = inactivePaneStyle.Render(title)
+	}
+	if errors, warnings := m.logMarkCounts(); errors+warnings > 0 {
+		title = fmt.Sprintf("%s %s", title, inactivePaneStyle.Render(logMarkSummary(errors, warnings)+" "+tr("(]/[ to jump)")))
+	}
+	if m.logSearchQuery != "" {
+		title = fmt.Sprintf("%s %s", title, inactivePaneStyle.Render(m.logSearchStatus()))
+	}
+
+	var items []string
+	items = append(items, m.renderRightTabs())
+	items = append(items, title)
+	items = append(items, "")
+
+	if m.loading && m.currentView == pipelineStepLogView {
+		items = append(items, m.renderSkeletonRows(paneWidth, availableHeight-3)...)
+	} else if len(m.pipelineStepLogLines) == 0 && m.followStepUUID != "" {
+		items = append(items, tr("Waiting for log output..."))
+	} else if len(m.pipelineStepLogLines) == 0 {
+		items = append(items, tr("No logs"))
+	} else {
+		rowsHeight := availableHeight - 3
+		lineWidth := paneWidth - 4
+		if lineWidth < 10 {
+			lineWidth = 10
+		}
+
+		gutterWidth := 0
+		if m.logLineNumbers {
+			gutterWidth = len(fmt.Sprintf("%d", len(m.pipelineStepLogLines)))
+			lineWidth -= gutterWidth + 3
+			if lineWidth < 10 {
+				lineWidth = 10
+			}
+		}
+
+		errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(currentTheme.failure))
+		warningStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(currentTheme.warning))
+		start, end := m.logWindow(rowsHeight, lineWidth)
+		var rows []string
+		for i := start; i < end; i++ {
+			cursor := " "
+			if m.activePane == branchPane && i == m.pipelineStepLogCursor {
+				cursor = cursorStyle.Render(">")
+			}
+			for j, row := range m.logRows(i, lineWidth) {
+				if j > 0 {
+					cursor = " "
+				}
+				if m.isLogMatch(i) {
+					row = logMatchStyle.Render(row)
+				} else if i < len(m.logMarks) {
+					switch m.logMarks[i] {
+					case logError:
+						row = errorStyle.Render(row)
+					case logWarning:
+						row = warningStyle.Render(row)
+					}
+				}
+				if m.logLineNumbers {
+					gutter := strings.Repeat(" ", gutterWidth)
+					if j == 0 {
+						gutter = fmt.Sprintf("%*d", gutterWidth, i+1)
+					}
+					separator := " │"
+					if currentTheme.plain {
+						separator = ":"
+					}
+					row = inactivePaneStyle.Render(gutter+separator) + " " + row
+				}
+				rows = append(rows, fmt.Sprintf("%s %s", cursor, row))
+			}
+		}
+		if len(rows) > rowsHeight {
+			rows = rows[:rowsHeight]
+		}
+		items = append(items, rows...)
+
+		if start > 0 {
+			items[2] = inactivePaneStyle.Render(moreAbove())
+		}
+		if end < len(m.pipelineStepLogLines) {
+			items = append(items, inactivePaneStyle.Render(moreBelow()))
+		}
+	}
+
+	content := strings.Join(items, "\n")
+	style := lipgloss.NewStyle().
+		Width(paneWidth).
+		Height(availableHeight).
+		Padding(0, 1).
+		Faint(m.fadingIn())
+
+	return style.Render(content)
+}
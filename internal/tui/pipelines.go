@@ -0,0 +1,165 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"bitbucket-cli/internal/bitbucket"
+	"bitbucket-cli/internal/config"
+	"bitbucket-cli/internal/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+type pipelineHeaderLoadedMsg struct {
+	pipeline domain.Pipeline
+	err      error
+}
+
+// loadPipelineHeader completes what the steps view shows above the steps:
+// the pipeline itself when only its UUID and build number are known, and
+// the message of its commit, which the pipeline API may leave out.
+func loadPipelineHeader(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug string, pipeline domain.Pipeline) tea.Cmd {
+	return func() tea.Msg {
+		if pipeline.CommitHash == "" {
+			loaded, err := client.GetPipeline(ctx, repoSlug, pipeline.UUID)
+			if err != nil {
+				return pipelineHeaderLoadedMsg{err: err}
+			}
+			pipeline = loaded
+		}
+		if pipeline.CommitMessage == "" && pipeline.CommitHash != "" {
+			commit, err := client.GetCommit(ctx, repoSlug, pipeline.CommitHash)
+			if err != nil {
+				return pipelineHeaderLoadedMsg{err: err}
+			}
+			pipeline.CommitMessage = commit.Message
+		}
+		return pipelineHeaderLoadedMsg{pipeline: pipeline}
+	}
+}
+
+// openPipelineSteps shows the steps of pipeline below its commit and
+// trigger.
+func openPipelineSteps(m *AppModel, repoSlug string, pipeline domain.Pipeline) tea.Cmd {
+	m.selectedPipelineRef = fmt.Sprintf("#%d", pipeline.BuildNumber)
+	m.selectedPipelineUUID = pipeline.UUID
+	m.openPipeline = pipeline
+	m.currentView = pipelineStepsView
+	ctx := newViewContext(m)
+	m.loading = true
+	m.pipelineSteps = nil
+	m.pipelineStepCursor = 0
+	cmds := []tea.Cmd{forView(m, loadPipelineSteps(ctx, m.client, repoSlug, pipeline.UUID))}
+	if pipeline.CommitHash == "" || pipeline.CommitMessage == "" {
+		cmds = append(cmds, forView(m, loadPipelineHeader(ctx, m.client, repoSlug, pipeline)))
+	}
+	return tea.Batch(cmds...)
+}
+
+// pipelineTrigger says how a pipeline started, e.g. "push by Jane Doe".
+func pipelineTrigger(pipeline domain.Pipeline) string {
+	switch {
+	case pipeline.Trigger == "":
+		return ""
+	case pipeline.Creator == "" || pipeline.Trigger == "schedule":
+		return tr(pipeline.Trigger)
+	default:
+		return trf("%s by %s", tr(pipeline.Trigger), pipeline.Creator)
+	}
+}
+
+// pipelineHeader is the commit and trigger shown above the steps, one line
+// each, left out while unknown.
+func (m AppModel) pipelineHeader(width int) []string {
+	pipeline := m.openPipeline
+	var lines []string
+	if pipeline.CommitHash != "" {
+		subject, _, _ := strings.Cut(strings.TrimSpace(pipeline.CommitMessage), "\n")
+		line := fmt.Sprintf("%s %s", cursorStyle.Render(shortHash(pipeline.CommitHash)), subject)
+		lines = append(lines, ansi.Truncate(line, width, "…"))
+	}
+	details := []string{formatPipelineBranch(pipeline.BranchName)}
+	if trigger := pipelineTrigger(pipeline); trigger != "" {
+		details = append(details, trigger)
+	}
+	if ago := timeAgo(pipeline.CreatedOn); ago != "" {
+		details = append(details, ago)
+	}
+	if pipeline.CommitHash != "" || pipeline.Trigger != "" {
+		lines = append(lines, inactivePaneStyle.Render(ansi.Truncate(strings.Join(details, " · "), width, "…")))
+	}
+	return lines
+}
+
+type pipelineRerunMsg struct {
+	repoSlug string
+	previous int
+	pipeline domain.Pipeline
+	err      error
+}
+
+func rerunPipeline(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug string, previous domain.Pipeline) tea.Cmd {
+	return func() tea.Msg {
+		pipeline, err := client.RerunPipeline(ctx, repoSlug, previous.UUID)
+		return pipelineRerunMsg{repoSlug: repoSlug, previous: previous.BuildNumber, pipeline: pipeline, err: err}
+	}
+}
+
+// confirmRerun asks before running the highlighted pipeline's target again.
+func confirmRerun(m *AppModel) {
+	filtered := m.getFilteredPipelines()
+	if m.pipelineCursor >= len(filtered) {
+		return
+	}
+	m.rerunCandidate = filtered[m.pipelineCursor]
+	m.message = fmt.Sprintf("Run pipeline #%d on %s again? (y/n)", m.rerunCandidate.BuildNumber, formatPipelineBranch(m.rerunCandidate.BranchName))
+}
+
+func handleRerunKey(m AppModel, msg tea.KeyMsg) (AppModel, tea.Cmd) {
+	pipeline := m.rerunCandidate
+	m.rerunCandidate = domain.Pipeline{}
+	if msg.String() != "y" {
+		return m, nil
+	}
+	m.message = fmt.Sprintf("Starting pipeline #%d again...", pipeline.BuildNumber)
+	return m, startAction(&m, rerunPipeline(m.ctx, m.client, m.selectedRepoSlug, pipeline))
+}
+
+// showRerunPipeline puts the new pipeline at the top of the list with the
+// cursor on it, so it is polled while it runs, and reloads the list.
+func showRerunPipeline(m *AppModel, msg pipelineRerunMsg) tea.Cmd {
+	if m.currentView != pipelinesView || m.selectedRepoSlug != msg.repoSlug {
+		return nil
+	}
+	m.pipelines = append([]domain.Pipeline{msg.pipeline}, m.pipelines...)
+	m.pipelineFilterQuery = ""
+	m.pipelineCursor = 0
+	return forView(m, loadPipelines(bitbucket.WithoutCache(m.viewCtx), m.client, msg.repoSlug))
+}
+
+// toggleAllPipelineBranches switches the pipeline list between the tracked
+// branches and all of them, keeping the cursor on the selected pipeline when
+// it stays listed, and remembers the choice for the next run.
+func toggleAllPipelineBranches(m *AppModel) tea.Cmd {
+	var selected string
+	if filtered := m.getFilteredPipelines(); m.pipelineCursor < len(filtered) {
+		selected = filtered[m.pipelineCursor].UUID
+	}
+	m.allPipelineBranches = !m.allPipelineBranches
+	m.pipelineCursor = 0
+	for i, pipeline := range m.getFilteredPipelines() {
+		if pipeline.UUID == selected {
+			m.pipelineCursor = i
+		}
+	}
+	if err := config.SaveViewState(m.workspace, config.ViewState{AllPipelineBranches: m.allPipelineBranches}); err != nil {
+		m.message = fmt.Sprintf("Error saving view state: %v", err)
+	}
+	if selectedRunningPipelineUUID(*m) != "" {
+		return pollPipelineUpdates(m.client)
+	}
+	return nil
+}
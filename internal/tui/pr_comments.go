@@ -0,0 +1,226 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"bitbucket-cli/internal/bitbucket"
+	"bitbucket-cli/internal/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type pullRequestCommentsLoadedMsg struct {
+	id       int
+	comments []domain.Comment
+	err      error
+}
+
+type pullRequestCommentedMsg struct {
+	id      int
+	comment domain.Comment
+	err     error
+}
+
+func loadPullRequestComments(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug string, pullRequestID int) tea.Cmd {
+	return func() tea.Msg {
+		comments, err := client.ListPullRequestComments(ctx, repoSlug, pullRequestID)
+		return pullRequestCommentsLoadedMsg{id: pullRequestID, comments: comments, err: err}
+	}
+}
+
+// commentOnPullRequest posts a comment on the pull request, inline when the
+// anchor names a file.
+func commentOnPullRequest(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug string, pullRequestID int, content string, anchor domain.CommentAnchor) tea.Cmd {
+	return func() tea.Msg {
+		var comment domain.Comment
+		var err error
+		if anchor.Path != "" {
+			comment, err = client.CreatePullRequestInlineComment(ctx, repoSlug, pullRequestID, content, anchor)
+		} else {
+			comment, err = client.CreatePullRequestComment(ctx, repoSlug, pullRequestID, content)
+		}
+		if comment.Anchor.Path == "" {
+			comment.Anchor = anchor
+		}
+		return pullRequestCommentedMsg{id: pullRequestID, comment: comment, err: err}
+	}
+}
+
+// openPullRequestComments shows the comment threads of the pull request in
+// the detail view.
+func openPullRequestComments(m *AppModel) tea.Cmd {
+	if m.openPullRequest.ID == 0 {
+		return nil
+	}
+	m.currentView = prCommentsView
+	return reloadPullRequestComments(m, newViewContext(m))
+}
+
+func reloadPullRequestComments(m *AppModel, ctx context.Context) tea.Cmd {
+	m.loading = true
+	m.prComments = nil
+	m.prCommentsOffset = 0
+	m.prCommentCursor = 0
+	return forView(m, loadPullRequestComments(ctx, m.client, m.openPullRequest.RepoSlug, m.openPullRequest.ID))
+}
+
+// startComment opens the prompt for a comment on pr, on the diff line of
+// anchor or, for an empty anchor, on the whole pull request.
+func startComment(m *AppModel, pr domain.PullRequest, anchor domain.CommentAnchor) {
+	m.commentMode = true
+	m.commentText = ""
+	m.commentPR = pr
+	m.commentAnchor = anchor
+}
+
+// commentPrompt is the help line while a comment is typed.
+func commentPrompt(m AppModel) string {
+	if m.commentAnchor.Path != "" {
+		return trf("Comment on %s:%d: %s  (esc: cancel, enter: post)", m.commentAnchor.Path, anchorLine(m.commentAnchor), m.commentText)
+	}
+	return trf("Comment: %s  (esc: cancel, enter: post)", m.commentText)
+}
+
+// handleCommentKey edits the comment typed at the prompt and posts it on
+// enter.
+func handleCommentKey(m AppModel, msg tea.KeyMsg) (AppModel, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.commentMode = false
+		m.commentText = ""
+	case tea.KeyEnter:
+		m.commentMode = false
+		text := strings.TrimSpace(m.commentText)
+		m.commentText = ""
+		if text == "" {
+			return m, nil
+		}
+		pr := m.commentPR
+		m.message = fmt.Sprintf("Commenting on PR #%d...", pr.ID)
+		return m, startAction(&m, commentOnPullRequest(m.ctx, m.client, pr.RepoSlug, pr.ID, text, m.commentAnchor))
+	case tea.KeyBackspace:
+		if runes := []rune(m.commentText); len(runes) > 0 {
+			m.commentText = string(runes[:len(runes)-1])
+		}
+	case tea.KeySpace:
+		m.commentText += " "
+	case tea.KeyRunes:
+		m.commentText += string(msg.Runes)
+	}
+	return m, nil
+}
+
+// commentThreads orders comments as threads: each top-level comment followed
+// by its replies, depth first, with the depth of each comment. Replies whose
+// parent is missing are shown as top-level comments.
+func commentThreads(comments []domain.Comment) ([]domain.Comment, []int) {
+	known := make(map[int]bool, len(comments))
+	for _, comment := range comments {
+		known[comment.ID] = true
+	}
+	replies := make(map[int][]domain.Comment)
+	var roots []domain.Comment
+	for _, comment := range comments {
+		if comment.ParentID != 0 && known[comment.ParentID] {
+			replies[comment.ParentID] = append(replies[comment.ParentID], comment)
+		} else {
+			roots = append(roots, comment)
+		}
+	}
+
+	var ordered []domain.Comment
+	var depths []int
+	var walk func(comment domain.Comment, depth int)
+	walk = func(comment domain.Comment, depth int) {
+		ordered = append(ordered, comment)
+		depths = append(depths, depth)
+		for _, reply := range replies[comment.ID] {
+			walk(reply, depth+1)
+		}
+	}
+	for _, root := range roots {
+		walk(root, 0)
+	}
+	return ordered, depths
+}
+
+// prCommentLines lays out the comment threads, replies indented under the
+// comment they answer, wrapped to width. It also returns the line each
+// comment starts on, in thread order.
+func (m AppModel) prCommentLines(width int) ([]string, []int) {
+	if m.prComments == nil {
+		return []string{inactivePaneStyle.Render(tr("Loading comments..."))}, nil
+	}
+	if len(m.prComments) == 0 {
+		return []string{inactivePaneStyle.Render(tr("No comments"))}, nil
+	}
+
+	authorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("99"))
+	comments, depths := commentThreads(m.prComments)
+	var lines []string
+	starts := make([]int, 0, len(comments))
+	for i, comment := range comments {
+		indent := strings.Repeat("  ", min(depths[i], 4))
+		if depths[i] == 0 && i > 0 {
+			lines = append(lines, "")
+		}
+		starts = append(starts, len(lines))
+		gutter := " "
+		if i == m.prCommentCursor && m.activePane == branchPane {
+			gutter = cursorStyle.Render(">")
+		}
+		if comment.Deleted {
+			lines = append(lines, gutter+indent+inactivePaneStyle.Render(tr("(deleted)")))
+			continue
+		}
+		header := fmt.Sprintf("%s %s", authorStyle.Render("@"+authorLabel(m.currentUser, comment.Author)), inactivePaneStyle.Render(shortTimestamp(comment.CreatedOn)))
+		if comment.Anchor.Path != "" {
+			header += inactivePaneStyle.Render(fmt.Sprintf(" %s:%d", comment.Anchor.Path, anchorLine(comment.Anchor)))
+		}
+		lines = append(lines, gutter+indent+header)
+		for _, line := range renderMarkdown(strings.TrimSpace(comment.Content), width-len(indent)-1) {
+			lines = append(lines, " "+indent+line)
+		}
+	}
+	return lines, starts
+}
+
+// maxPRCommentsOffset is how far the comments scroll: until the last line is
+// at the bottom.
+func (m AppModel) maxPRCommentsOffset() int {
+	paneWidth, _, visible := m.issueDetailSize()
+	lines, _ := m.prCommentLines(paneWidth - 2)
+	return max(0, len(lines)-visible)
+}
+
+func (m AppModel) renderPRCommentsPane() string {
+	paneWidth, availableHeight, visible := m.issueDetailSize()
+
+	title := trf("PR #%d comments (%d)", m.openPullRequest.ID, len(m.prComments))
+	if m.activePane == branchPane {
+		title = trf("%s (esc: back)", title)
+	}
+
+	items := []string{m.renderRightTabs(), activePaneStyle.Render(title), ""}
+
+	lines, _ := m.prCommentLines(paneWidth - 2)
+	offset := min(m.prCommentsOffset, max(0, len(lines)-visible))
+	end := min(len(lines), offset+visible)
+	items = append(items, lines[offset:end]...)
+	if offset > 0 {
+		items[3] = inactivePaneStyle.Render(moreAbove())
+	}
+	if end < len(lines) {
+		items[len(items)-1] = inactivePaneStyle.Render(moreBelow())
+	}
+
+	style := lipgloss.NewStyle().
+		Width(paneWidth).
+		Height(availableHeight).
+		Padding(0, 1)
+
+	return style.Render(strings.Join(items, "\n"))
+}
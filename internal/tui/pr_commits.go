@@ -1,39 +1,120 @@
 package tui
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	"bitbucket-cli/internal/bitbucket"
 	"bitbucket-cli/internal/domain"
+	"bitbucket-cli/internal/gitrepo"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
-func loadPullRequestCommits(client *bitbucket.Client, repoSlug string, pullRequestID int) tea.Cmd {
+func loadPullRequestCommits(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug string, pullRequestID int) tea.Cmd {
 	return func() tea.Msg {
-		commits, err := client.ListPullRequestCommits(repoSlug, pullRequestID)
+		commits, err := client.ListPullRequestCommits(ctx, repoSlug, pullRequestID)
 		return prCommitsLoadedMsg{commits: commits, err: err}
 	}
 }
 
-func loadCommitChanges(client *bitbucket.Client, repoSlug, commitHash string) tea.Cmd {
+func loadCommitChanges(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug, commitHash string) tea.Cmd {
 	return func() tea.Msg {
-		changes, err := client.ListCommitChanges(repoSlug, commitHash)
+		changes, err := client.ListCommitChanges(ctx, repoSlug, commitHash)
 		return prCommitChangesLoadedMsg{hash: commitHash, changes: changes, err: err}
 	}
 }
 
-func loadCommitDiff(client *bitbucket.Client, repoSlug, commitHash string) tea.Cmd {
+func loadCommitDiff(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug, commitHash string) tea.Cmd {
 	return func() tea.Msg {
-		diff, err := client.GetCommitDiff(repoSlug, commitHash)
+		diff, err := client.GetCommitDiff(ctx, repoSlug, commitHash)
 		return prCommitDiffLoadedMsg{hash: commitHash, diff: diff, err: err}
 	}
 }
 
+// commitSignaturesMsg carries the signature status of the loaded commits
+// that the local clone has.
+type commitSignaturesMsg struct {
+	signatures map[string]gitrepo.Signature
+}
+
+// verifyCommitSignatures checks the signatures of commits with the local
+// clone's git and gpg, which is only possible when the app runs inside a
+// clone of the selected repository. It runs git, so it is kept off the update
+// loop.
+func verifyCommitSignatures(m *AppModel, commits []domain.Commit) tea.Cmd {
+	if m.localClone.Root == "" || m.selectedRepoSlug != m.localClone.Repo || len(commits) == 0 {
+		return nil
+	}
+	root := m.localClone.Root
+	hashes := make([]string, 0, len(commits))
+	for _, commit := range commits {
+		hashes = append(hashes, commit.Hash)
+	}
+	return forView(m, func() tea.Msg {
+		return commitSignaturesMsg{signatures: gitrepo.VerifySignatures(root, hashes)}
+	})
+}
+
+// signatureBadge marks a commit or tag row with its signature status. A row
+// whose status is not known, the zero Signature, stays unmarked.
+func signatureBadge(signature gitrepo.Signature) string {
+	switch signature.Status {
+	case "":
+		return ""
+	case "verified":
+		return currentTheme.badge(currentTheme.success, "✓", "SIGNED")
+	case "unverified":
+		return currentTheme.badge(currentTheme.warning, "◐", "UNVERIFIED")
+	case "bad":
+		return currentTheme.badge(currentTheme.failure, "✗", "BAD SIGNATURE")
+	default:
+		return currentTheme.badge(currentTheme.muted, "○", "UNSIGNED")
+	}
+}
+
+// signatureLine describes the selected commit's signature for the details
+// pane.
+func (m AppModel) signatureLine(hash string) string {
+	if m.localClone.Root == "" || m.selectedRepoSlug != m.localClone.Repo {
+		return tr("signature: unknown (start inside a clone to verify)")
+	}
+	signature, ok := m.commitSignatures[hash]
+	if !ok {
+		if m.commitSignatures == nil {
+			return tr("signature: checking...")
+		}
+		return tr("signature: unknown (commit not in local clone; git fetch)")
+	}
+	if signature.Signer != "" {
+		return trf("signature: %s (%s)", tr(signature.Status), signature.Signer)
+	}
+	return trf("signature: %s", tr(signature.Status))
+}
+
+// showCommits fills the commit list, selecting the first commit and loading
+// its diff.
+func showCommits(m *AppModel, commits []domain.Commit) tea.Cmd {
+	m.prCommits = commits
+	m.prCommitCursor = 0
+	m.prCommitChanges = nil
+	m.prCommitDiff = ""
+	m.selectedCommitHash = ""
+	m.commitSignatures = nil
+	return tea.Batch(updateSelectedCommitDetails(m), verifyCommitSignatures(m, commits))
+}
+
+// showsCommitList reports whether the current view is a commit list with the
+// diff split: a pull request's commits, a branch's or those of a branch
+// comparison.
+func (m AppModel) showsCommitList() bool {
+	return m.currentView == prCommitsView || m.currentView == branchCommitsView || m.currentView == branchCompareView
+}
+
 func updateSelectedCommitDetails(m *AppModel) tea.Cmd {
-	if m.currentView != prCommitsView || m.activePane != branchPane || len(m.prCommits) == 0 {
+	if !m.showsCommitList() || m.activePane != branchPane || len(m.prCommits) == 0 {
 		m.selectedCommitHash = ""
 		m.prCommitChanges = nil
 		m.prCommitDiff = ""
@@ -48,6 +129,9 @@ func updateSelectedCommitDetails(m *AppModel) tea.Cmd {
 
 	selected := m.prCommits[m.prCommitCursor]
 	hash := strings.TrimSpace(selected.Hash)
+	if hash != m.selectedCommitHash {
+		m.diffHunkCursor = 0
+	}
 	m.selectedCommitHash = hash
 	if hash == "" {
 		m.prCommitChanges = nil
@@ -81,14 +165,14 @@ func updateSelectedCommitDetails(m *AppModel) tea.Cmd {
 
 	if !hasChanges && !hasDiff {
 		return tea.Batch(
-			loadCommitChanges(m.client, m.selectedRepoSlug, hash),
-			loadCommitDiff(m.client, m.selectedRepoSlug, hash),
+			forView(m, loadCommitChanges(m.viewCtx, m.client, m.selectedRepoSlug, hash)),
+			forView(m, loadCommitDiff(m.viewCtx, m.client, m.selectedRepoSlug, hash)),
 		)
 	}
 	if !hasChanges {
-		return loadCommitChanges(m.client, m.selectedRepoSlug, hash)
+		return forView(m, loadCommitChanges(m.viewCtx, m.client, m.selectedRepoSlug, hash))
 	}
-	return loadCommitDiff(m.client, m.selectedRepoSlug, hash)
+	return forView(m, loadCommitDiff(m.viewCtx, m.client, m.selectedRepoSlug, hash))
 }
 
 func (m AppModel) renderPRCommitsPane() string {
@@ -111,12 +195,17 @@ func (m AppModel) renderPRCommitsPane() string {
 		availableHeight = 5
 	}
 
-	title := fmt.Sprintf("PR #%d commits", m.selectedPullRequestID)
+	title := trf("PR #%d commits", m.selectedPullRequestID)
 	if strings.TrimSpace(m.selectedPullRequest) != "" {
-		title = fmt.Sprintf("PR #%d commits (%s)", m.selectedPullRequestID, m.selectedPullRequest)
+		title = trf("PR #%d commits (%s)", m.selectedPullRequestID, m.selectedPullRequest)
+	}
+	if m.currentView == branchCommitsView {
+		title = trf("Branch %s: last %d commits", m.commitsBranch, branchCommitLimit)
+	} else if m.currentView == branchCompareView {
+		title = m.comparisonTitle()
 	}
 	if !showRepoPane {
-		title = fmt.Sprintf("%s (esc: back)", title)
+		title = trf("%s (esc: back)", title)
 	}
 
 	title = activePaneStyle.Render(title)
@@ -144,8 +233,8 @@ func (m AppModel) renderPRCommitsPane() string {
 	listItems = append(listItems, "Commits")
 	listItems = append(listItems, "")
 
-	if m.loading && m.activePane == branchPane && m.currentView == prCommitsView {
-		listItems = append(listItems, m.spinner.View()+" Loading...")
+	if m.loading && m.activePane == branchPane && m.showsCommitList() {
+		listItems = append(listItems, m.renderSkeletonRows(listWidth, listContentHeight)...)
 	} else if len(m.prCommits) == 0 {
 		listItems = append(listItems, "No commits")
 	} else {
@@ -164,13 +253,18 @@ func (m AppModel) renderPRCommitsPane() string {
 			}
 
 			message := strings.Split(commit.Message, "\n")[0]
-			author := strings.TrimSpace(commit.Author)
+			author := authorLabel(m.currentUser, strings.TrimSpace(commit.Author))
 			if author == "" {
 				author = "unknown"
 			}
 
-			const rowPadding = 20
-			maxMessageWidth := listWidth - rowPadding - len(author)
+			age := timeAgo(commit.Date)
+			badge := signatureBadge(m.commitSignatures[commit.Hash])
+			if badge != "" {
+				badge += " "
+			}
+			const rowPadding = 25
+			maxMessageWidth := listWidth - rowPadding - len(author) - len(age) - lipgloss.Width(badge)
 			if maxMessageWidth < 8 {
 				maxMessageWidth = 8
 			}
@@ -178,19 +272,27 @@ func (m AppModel) renderPRCommitsPane() string {
 				message = message[:maxMessageWidth-3] + "..."
 			}
 
-			authorText := lipgloss.NewStyle().Foreground(lipgloss.Color("99")).Render(fmt.Sprintf("@%s", author))
-			listItems = append(listItems, fmt.Sprintf("%s %s %s %s", cursor, hash, authorText, message))
+			authorText := avatar(commit.Author) + " " + lipgloss.NewStyle().Foreground(lipgloss.Color("99")).Render(fmt.Sprintf("@%s", author))
+			listItems = append(listItems, fmt.Sprintf("%s %s%s %s %s %s", cursor, badge, hash, authorText, message, inactivePaneStyle.Render(age)))
 		}
 
 		if start > 0 {
-			listItems[1] = inactivePaneStyle.Render("  ↑ more")
+			listItems[1] = inactivePaneStyle.Render(moreAbove())
 		}
 		if end < len(m.prCommits) {
-			listItems = append(listItems, inactivePaneStyle.Render("  ↓ more"))
+			listItems = append(listItems, inactivePaneStyle.Render(moreBelow()))
 		}
 	}
 
-	detailsItems := []string{lipgloss.NewStyle().Foreground(lipgloss.Color("220")).Render("Diff"), ""}
+	diffTitle := "Diff"
+	hunk, hunks, hasHunks := m.selectedDiffHunk()
+	if hasHunks {
+		diffTitle = fmt.Sprintf("Diff [hunk %d/%d %s:%d]", min(max(m.diffHunkCursor, 0), len(hunks)-1)+1, len(hunks), hunk.path, hunk.line)
+	}
+	if m.diffHOffset > 0 {
+		diffTitle = trf("%s [col %d]", diffTitle, m.diffHOffset+1)
+	}
+	detailsItems := []string{lipgloss.NewStyle().Foreground(lipgloss.Color("220")).Render(diffTitle), ""}
 	if m.selectedCommitHash == "" {
 		detailsItems = append(detailsItems, "Select a commit")
 	} else {
@@ -199,6 +301,7 @@ func (m AppModel) renderPRCommitsPane() string {
 			hash = hash[:12]
 		}
 		detailsItems = append(detailsItems, fmt.Sprintf("commit %s", hash))
+		detailsItems = append(detailsItems, m.signatureLine(m.selectedCommitHash))
 		if _, ok := m.prCommitChangesCache[m.selectedCommitHash]; ok {
 			detailsItems = append(detailsItems, fmt.Sprintf("files changed: %d", len(m.prCommitChanges)))
 		}
@@ -210,6 +313,11 @@ func (m AppModel) renderPRCommitsPane() string {
 			detailsItems = append(detailsItems, "No textual diff")
 		} else {
 			lines := strings.Split(m.prCommitDiff, "\n")
+			// Start at the selected hunk once the user has moved past the
+			// first one, keeping the file header above the first hunk.
+			if hasHunks && m.diffHunkCursor > 0 {
+				lines = lines[hunk.offset:]
+			}
 			maxRows := availableHeight - 8
 			if maxRows < 1 {
 				maxRows = 1
@@ -220,11 +328,7 @@ func (m AppModel) renderPRCommitsPane() string {
 			}
 
 			for i := 0; i < len(lines) && i < maxRows; i++ {
-				line := lines[i]
-				if len(line) > maxLineWidth {
-					line = line[:maxLineWidth-3] + "..."
-				}
-				detailsItems = append(detailsItems, line)
+				detailsItems = append(detailsItems, scrollLine(lines[i], m.diffHOffset, maxLineWidth))
 			}
 			if len(lines) > maxRows {
 				detailsItems = append(detailsItems, inactivePaneStyle.Render(fmt.Sprintf("  +%d more diff lines", len(lines)-maxRows)))
@@ -240,7 +344,8 @@ func (m AppModel) renderPRCommitsPane() string {
 	style := lipgloss.NewStyle().
 		Width(paneWidth).
 		Height(availableHeight).
-		Padding(0, 1)
+		Padding(0, 1).
+		Faint(m.fadingIn())
 
 	return style.Render(content)
 }
@@ -0,0 +1,322 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"bitbucket-cli/internal/bitbucket"
+	"bitbucket-cli/internal/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+type pullRequestLoadedMsg struct {
+	pr  domain.PullRequest
+	err error
+}
+
+func loadPullRequest(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug string, pullRequestID int) tea.Cmd {
+	return func() tea.Msg {
+		pr, err := client.GetPullRequest(ctx, repoSlug, pullRequestID)
+		if pr.RepoSlug == "" {
+			pr.RepoSlug = repoSlug
+		}
+		return pullRequestLoadedMsg{pr: pr, err: err}
+	}
+}
+
+type pullRequestActivityLoadedMsg struct {
+	id       int
+	activity []domain.PullRequestActivity
+	err      error
+}
+
+func loadPullRequestActivity(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug string, pullRequestID int) tea.Cmd {
+	return func() tea.Msg {
+		activity, err := client.ListPullRequestActivity(ctx, repoSlug, pullRequestID)
+		return pullRequestActivityLoadedMsg{id: pullRequestID, activity: activity, err: err}
+	}
+}
+
+// openPullRequestDetail shows the highlighted pull request with its
+// description, reviewers, builds and recent activity.
+func openPullRequestDetail(m *AppModel) tea.Cmd {
+	filtered := m.getFilteredPRs()
+	if m.prCursor >= len(filtered) {
+		return nil
+	}
+	m.openPullRequest = filtered[m.prCursor]
+	m.openPullRequest.RepoSlug = m.selectedRepoSlug
+	m.prDetailOffset = 0
+	m.currentView = prDetailView
+	return loadPullRequestDetail(m, false)
+}
+
+// loadPullRequestDetail fetches the open pull request again, as the list may
+// be stale, with its tasks, activity and, unless they are known already, its build
+// statuses. A refresh bypasses the response cache and reloads the statuses.
+func loadPullRequestDetail(m *AppModel, refresh bool) tea.Cmd {
+	pr := m.openPullRequest
+	key := pullRequestKey(pr.RepoSlug, pr.ID)
+	ctx, checksCtx := newViewContext(m), m.ctx
+	if refresh {
+		ctx, checksCtx = refreshViewContext(m), bitbucket.WithoutCache(m.ctx)
+		delete(m.prStatuses, key)
+		delete(m.mergeCheckErrors, key)
+	}
+	m.loading = true
+	m.prActivity = nil
+	m.prTasks = nil
+	m.prTaskCursor = 0
+	cmds := []tea.Cmd{
+		forView(m, loadPullRequest(ctx, m.client, pr.RepoSlug, pr.ID)),
+		forView(m, loadPullRequestActivity(ctx, m.client, pr.RepoSlug, pr.ID)),
+		forView(m, loadPullRequestTasks(ctx, m.client, pr.RepoSlug, pr.ID)),
+	}
+	if _, ok := m.prStatuses[key]; !ok && !m.prefetch.inFlight["checks:"+key] {
+		m.prefetch.inFlight["checks:"+key] = true
+		_, haveRules := m.mergeRules[pr.RepoSlug]
+		cmds = append(cmds, loadMergeChecks(checksCtx, m.client, pr.RepoSlug, pr.ID, !haveRules))
+	}
+	if refresh {
+		delete(m.commitStatuses, pr.SourceCommit)
+	}
+	cmds = append(cmds, loadSourceStatuses(m, pr.RepoSlug, []domain.PullRequest{pr}))
+	return tea.Batch(cmds...)
+}
+
+// openPullRequestCommits shows the commits of pr.
+func openPullRequestCommits(m *AppModel, pr domain.PullRequest) tea.Cmd {
+	m.selectedPullRequestID = pr.ID
+	m.selectedPullRequest = pr.Title
+	m.prCommitChangesCache = make(map[string][]domain.CommitChange)
+	m.prCommitDiffCache = make(map[string]string)
+	m.currentView = prCommitsView
+	ctx := newViewContext(m)
+	m.loading = true
+	m.prCommits = nil
+	m.prCommitCursor = 0
+	m.prCommitChanges = nil
+	m.prCommitDiff = ""
+	m.selectedCommitHash = ""
+	if cmd, ok := usePrefetchedCommits(m, pr.ID); ok {
+		return cmd
+	}
+	return forView(m, loadPullRequestCommits(ctx, m.client, m.selectedRepoSlug, pr.ID))
+}
+
+// applyOpenPullRequest replaces the pull request in the list and the detail
+// view.
+func applyOpenPullRequest(m *AppModel, pr domain.PullRequest) {
+	for i := range m.pullRequests {
+		if m.pullRequests[i].ID == pr.ID {
+			m.pullRequests[i] = pr
+		}
+	}
+	m.filters.invalidate("pullRequests")
+	if m.openPullRequest.ID == pr.ID {
+		m.openPullRequest = pr
+	}
+}
+
+// approvalMark renders whether a participant approved or asked for changes.
+func approvalMark(participant domain.Participant) string {
+	switch {
+	case participant.Approved && currentTheme.plain:
+		return tr("(approved)")
+	case participant.Approved:
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(currentTheme.success)).Render("✓")
+	case participant.State == "changes_requested" && currentTheme.plain:
+		return tr("(changes requested)")
+	case participant.State == "changes_requested":
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(currentTheme.failure)).Render("✗")
+	case currentTheme.plain:
+		return ""
+	}
+	return inactivePaneStyle.Render("·")
+}
+
+// prDetailLines lays out the open pull request, wrapped to width, for the
+// scrolling detail view. It also returns the line the first task is on.
+func (m AppModel) prDetailLines(width int) ([]string, int) {
+	pr := m.openPullRequest
+	wrap := lipgloss.NewStyle().Width(width)
+
+	state := formatPRState(pr.State, pr.Draft)
+	if state == "" {
+		state = currentTheme.badge(currentTheme.success, "", "OPEN")
+	}
+	lines := []string{
+		trf("%s by %s, %s", state, userLabel(m.currentUser, pr.AuthorUUID, pr.Author), shortTimestamp(pr.CreatedOn)),
+		wrap.Render(fmt.Sprintf("%s → %s", pr.SourceBranch, pr.DestBranch)),
+		"",
+	}
+
+	if description := strings.TrimSpace(pr.Description); description != "" {
+		lines = append(lines, renderMarkdown(description, width)...)
+	} else {
+		lines = append(lines, inactivePaneStyle.Render(tr("No description")))
+	}
+
+	lines = append(lines, "", activePaneStyle.Render(trf("Reviewers (%d of %d approved)", countApprovedReviewers(pr.Participants), countReviewers(pr.Participants))))
+
+	var others []domain.Participant
+	for _, participant := range pr.Participants {
+		if !participant.Reviewer {
+			others = append(others, participant)
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("  %s %s", approvalMark(participant), userLabel(m.currentUser, participant.UUID, participant.Name)))
+	}
+	if countReviewers(pr.Participants) == 0 {
+		lines = append(lines, inactivePaneStyle.Render("  "+tr("No reviewers")))
+	}
+	if len(others) > 0 {
+		lines = append(lines, "", activePaneStyle.Render(tr("Participants")))
+		for _, participant := range others {
+			lines = append(lines, fmt.Sprintf("  %s %s", approvalMark(participant), userLabel(m.currentUser, participant.UUID, participant.Name)))
+		}
+	}
+
+	lines = append(lines, "", activePaneStyle.Render(trf("Tasks (%d of %d resolved)", len(m.prTasks)-countOpenTasks(m.prTasks), len(m.prTasks))))
+	taskStart := len(lines)
+	lines = append(lines, m.taskLines(width)...)
+
+	// The builds listed are those of the source commit, which tell whether
+	// what would be merged is green; the merge checks count the builds of
+	// every commit of the pull request, as Bitbucket does.
+	key := pullRequestKey(pr.RepoSlug, pr.ID)
+	buildsTitle := tr("Builds")
+	statuses, ok := m.prStatuses[key]
+	failed := m.mergeCheckErrors[key] != nil
+	if pr.SourceCommit != "" {
+		buildsTitle = trf("Builds on %s", shortHash(pr.SourceCommit))
+		statuses, ok = m.commitStatuses[pr.SourceCommit]
+		failed = ok && statuses == nil
+	}
+	lines = append(lines, "", activePaneStyle.Render(buildsTitle))
+	switch {
+	case !ok:
+		lines = append(lines, inactivePaneStyle.Render("  "+tr("Loading builds...")))
+	case failed && pr.SourceCommit != "":
+		lines = append(lines, inactivePaneStyle.Render("  "+tr("Builds could not be loaded")))
+	case len(statuses) == 0 && !failed:
+		lines = append(lines, inactivePaneStyle.Render("  "+tr("No builds")))
+	}
+	for _, status := range statuses {
+		name := status.Name
+		if name == "" {
+			name = status.Key
+		}
+		lines = append(lines, fmt.Sprintf("  %s %s", formatBuildStatus(status.State), name))
+	}
+	if _, checksLoaded := m.prStatuses[key]; checksLoaded {
+		lines = append(lines, "  "+m.renderMergeChecks(pr))
+	}
+
+	lines = append(lines, "", activePaneStyle.Render(tr("Activity")))
+	switch {
+	case m.prActivity == nil:
+		lines = append(lines, inactivePaneStyle.Render("  "+tr("Loading activity...")))
+	case len(m.prActivity) == 0:
+		lines = append(lines, inactivePaneStyle.Render("  "+tr("No activity")))
+	}
+	for _, event := range m.prActivity {
+		lines = append(lines, "  "+m.describeActivity(event)+" "+inactivePaneStyle.Render(timeAgo(event.Date)))
+		if event.Kind == "comment" {
+			comment, _, _ := strings.Cut(strings.TrimSpace(event.Detail), "\n")
+			lines = append(lines, inactivePaneStyle.Render("    "+ansi.Truncate(comment, width-4, "…")))
+		}
+	}
+	return lines, taskStart
+}
+
+// formatBuildStatus renders the state of a commit's build status.
+func formatBuildStatus(state string) string {
+	if strings.EqualFold(state, "INPROGRESS") {
+		return formatPipelineState("in_progress")
+	}
+	return formatPipelineResult(state)
+}
+
+// describeActivity is the line shown for an event in the detail view.
+func (m AppModel) describeActivity(event domain.PullRequestActivity) string {
+	author := authorLabel(m.currentUser, event.Author)
+	switch event.Kind {
+	case "approval":
+		return trf("%s approved", author)
+	case "changes_requested":
+		return trf("%s requested changes", author)
+	case "comment":
+		return trf("%s commented", author)
+	}
+	switch strings.ToUpper(event.Detail) {
+	case "MERGED":
+		return trf("%s merged the pull request", author)
+	case "DECLINED":
+		return trf("%s declined the pull request", author)
+	}
+	return trf("%s updated the pull request", author)
+}
+
+// countReviewers returns how many participants are reviewers.
+func countReviewers(participants []domain.Participant) int {
+	count := 0
+	for _, participant := range participants {
+		if participant.Reviewer {
+			count++
+		}
+	}
+	return count
+}
+
+// countApprovedReviewers returns how many reviewers approved.
+func countApprovedReviewers(participants []domain.Participant) int {
+	count := 0
+	for _, participant := range participants {
+		if participant.Approved && participant.Reviewer {
+			count++
+		}
+	}
+	return count
+}
+
+// maxPRDetailOffset is how far the detail view scrolls: until the last line
+// is at the bottom.
+func (m AppModel) maxPRDetailOffset() int {
+	paneWidth, _, visible := m.issueDetailSize()
+	lines, _ := m.prDetailLines(paneWidth - 2)
+	return max(0, len(lines)-visible)
+}
+
+func (m AppModel) renderPRDetailPane() string {
+	paneWidth, availableHeight, visible := m.issueDetailSize()
+
+	title := trf("PR #%d: %s", m.openPullRequest.ID, m.openPullRequest.Title)
+	if m.activePane == branchPane {
+		title = trf("%s (esc: back)", title)
+	}
+
+	items := []string{m.renderRightTabs(), activePaneStyle.Render(title), ""}
+
+	lines, _ := m.prDetailLines(paneWidth - 2)
+	offset := min(m.prDetailOffset, max(0, len(lines)-visible))
+	end := min(len(lines), offset+visible)
+	items = append(items, lines[offset:end]...)
+	if offset > 0 {
+		items[3] = inactivePaneStyle.Render(moreAbove())
+	}
+	if end < len(lines) {
+		items[len(items)-1] = inactivePaneStyle.Render(moreBelow())
+	}
+
+	style := lipgloss.NewStyle().
+		Width(paneWidth).
+		Height(availableHeight).
+		Padding(0, 1)
+
+	return style.Render(strings.Join(items, "\n"))
+}
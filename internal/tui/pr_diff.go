@@ -0,0 +1,323 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"bitbucket-cli/internal/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// openPullRequestDiff shows the whole diff of the pull request under the
+// cursor, returning to the current view on esc.
+func openPullRequestDiff(m *AppModel) tea.Cmd {
+	pr, ok := m.pullRequestUnderCursor()
+	if !ok || strings.TrimSpace(pr.RepoSlug) == "" {
+		m.message = "Unable to load PR diff for selected PR"
+		return nil
+	}
+	m.prDiffPR = pr
+	m.prDiffFrom = m.currentView
+	m.currentView = prDiffView
+	return loadOpenPullRequestDiff(m, newViewContext(m))
+}
+
+func loadOpenPullRequestDiff(m *AppModel, ctx context.Context) tea.Cmd {
+	m.loading = true
+	m.prDiffLines = nil
+	m.prDiffComments = nil
+	m.prDiffCursor = 0
+	m.diffHOffset = 0
+	return tea.Batch(
+		forView(m, loadPullRequestDiff(ctx, m.client, m.prDiffPR.RepoSlug, m.prDiffPR.ID)),
+		forView(m, loadPullRequestComments(ctx, m.client, m.prDiffPR.RepoSlug, m.prDiffPR.ID)),
+	)
+}
+
+// closePullRequestDiff returns to the view the diff was opened from.
+func closePullRequestDiff(m *AppModel) {
+	m.currentView = m.prDiffFrom
+	m.prDiffPR = domain.PullRequest{}
+	m.prDiffLines = nil
+	m.prDiffComments = nil
+	m.prDiffCursor = 0
+	m.diffHOffset = 0
+}
+
+// moveDiffCursor moves the cursor of the diff by delta lines.
+func moveDiffCursor(m *AppModel, delta int) {
+	m.prDiffCursor = min(max(m.prDiffCursor+delta, 0), max(0, len(m.prDiffLines)-1))
+}
+
+// moveToPullRequestDiffHunk moves the cursor to the header of the next or
+// previous hunk.
+func moveToPullRequestDiffHunk(m *AppModel, delta int) {
+	hunks := parseDiffHunks(strings.Join(m.prDiffLines, "\n"))
+	target := -1
+	for _, hunk := range hunks {
+		if delta > 0 && hunk.offset > m.prDiffCursor {
+			target = hunk.offset
+			break
+		}
+		if delta < 0 && hunk.offset < m.prDiffCursor {
+			target = hunk.offset
+		}
+	}
+	if target >= 0 {
+		m.prDiffCursor = target
+	}
+}
+
+// diffAnchors returns where a comment on each line of a unified diff goes.
+// Lines outside hunks, which cannot be commented on, get an empty anchor.
+func diffAnchors(lines []string) []domain.CommentAnchor {
+	anchors := make([]domain.CommentAnchor, len(lines))
+	oldPath, newPath := "", ""
+	inHunk := false
+	oldLine, newLine := 0, 0
+	for i, line := range lines {
+		path := newPath
+		if path == "" {
+			path = oldPath
+		}
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			oldPath, newPath = "", ""
+			inHunk = false
+		case !inHunk && strings.HasPrefix(line, "--- "):
+			oldPath = diffHeaderPath(line, "--- ", "a/")
+		case !inHunk && strings.HasPrefix(line, "+++ "):
+			newPath = diffHeaderPath(line, "+++ ", "b/")
+		case strings.HasPrefix(line, "@@ "):
+			oldLine, newLine, inHunk = hunkStarts(line)
+		case !inHunk:
+		case strings.HasPrefix(line, "+"):
+			anchors[i] = domain.CommentAnchor{Path: path, To: newLine}
+			newLine++
+		case strings.HasPrefix(line, "-"):
+			anchors[i] = domain.CommentAnchor{Path: path, From: oldLine}
+			oldLine++
+		case strings.HasPrefix(line, " "):
+			anchors[i] = domain.CommentAnchor{Path: path, From: oldLine, To: newLine}
+			oldLine++
+			newLine++
+		}
+	}
+	return anchors
+}
+
+// diffHeaderPath reads the path of a "--- a/path" or "+++ b/path" line, empty
+// for /dev/null.
+func diffHeaderPath(line, marker, side string) string {
+	path := strings.TrimPrefix(strings.TrimPrefix(line, marker), side)
+	if path == "/dev/null" {
+		return ""
+	}
+	return path
+}
+
+// hunkStarts reads the old and new start lines from a header such as
+// "@@ -10,6 +12,8 @@ func main() {".
+func hunkStarts(header string) (int, int, bool) {
+	fields := strings.Fields(header)
+	if len(fields) < 3 {
+		return 0, 0, false
+	}
+	oldStart, _, _ := strings.Cut(strings.TrimPrefix(fields[1], "-"), ",")
+	from, err := strconv.Atoi(oldStart)
+	if err != nil {
+		return 0, 0, false
+	}
+	to, ok := hunkNewStart(header)
+	return from, to, ok
+}
+
+// anchorLine is the line number shown for an anchor.
+func anchorLine(anchor domain.CommentAnchor) int {
+	if anchor.To > 0 {
+		return anchor.To
+	}
+	return anchor.From
+}
+
+// anchorMatches reports whether a comment anchored at comment belongs on the
+// diff line anchored at line.
+func anchorMatches(comment, line domain.CommentAnchor) bool {
+	if comment.Path != line.Path {
+		return false
+	}
+	if comment.To > 0 {
+		return comment.To == line.To
+	}
+	return comment.From > 0 && comment.From == line.From
+}
+
+// startInlineComment opens the comment prompt for the line under the cursor.
+func startInlineComment(m *AppModel) {
+	anchors := diffAnchors(m.prDiffLines)
+	if m.prDiffCursor >= len(anchors) || anchors[m.prDiffCursor].Path == "" {
+		m.message = "Move to a changed or unchanged line of a hunk to comment on it"
+		return
+	}
+	startComment(m, m.prDiffPR, anchors[m.prDiffCursor])
+}
+
+// diffRow is a line of the diff pane: a line of the diff, or of a comment
+// shown under it when line is -1.
+type diffRow struct {
+	text string
+	line int
+}
+
+// prDiffRows lays out the diff with the inline comment threads under the
+// lines they are on. Comments on lines that are no longer in the diff are
+// left out; the comments view lists them.
+func (m AppModel) prDiffRows(width int) []diffRow {
+	anchors := diffAnchors(m.prDiffLines)
+	comments, depths := commentThreads(m.prDiffComments)
+	threads := make(map[int][]diffRow)
+	barStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(currentTheme.neutral))
+	authorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("99"))
+	at := -1
+	for i, comment := range comments {
+		if depths[i] == 0 {
+			at = -1
+			if comment.Anchor.Path == "" {
+				continue
+			}
+			for line, anchor := range anchors {
+				if anchorMatches(comment.Anchor, anchor) {
+					at = line
+					break
+				}
+			}
+		}
+		if at < 0 {
+			continue
+		}
+		indent := barStyle.Render("   │ ") + strings.Repeat("  ", min(depths[i], 4))
+		if comment.Deleted {
+			threads[at] = append(threads[at], diffRow{text: indent + inactivePaneStyle.Render(tr("(deleted)")), line: -1})
+			continue
+		}
+		header := fmt.Sprintf("%s %s", authorStyle.Render("@"+authorLabel(m.currentUser, comment.Author)), inactivePaneStyle.Render(shortTimestamp(comment.CreatedOn)))
+		threads[at] = append(threads[at], diffRow{text: indent + header, line: -1})
+		for _, text := range renderMarkdown(strings.TrimSpace(comment.Content), width-lipgloss.Width(indent)) {
+			threads[at] = append(threads[at], diffRow{text: indent + text, line: -1})
+		}
+	}
+
+	rows := make([]diffRow, 0, len(m.prDiffLines))
+	for i, line := range m.prDiffLines {
+		gutter := " "
+		if i == m.prDiffCursor && m.activePane == branchPane {
+			gutter = cursorStyle.Render(">")
+		}
+		rows = append(rows, diffRow{text: gutter + scrollLine(colorDiffLine(line), m.diffHOffset, width-1), line: i})
+		rows = append(rows, threads[i]...)
+	}
+	return rows
+}
+
+// diffStats counts the files and the added and removed lines of a unified
+// diff.
+func diffStats(lines []string) (files, added, removed int) {
+	for _, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			files++
+		case strings.HasPrefix(line, "+++ "), strings.HasPrefix(line, "--- "):
+		case strings.HasPrefix(line, "+"):
+			added++
+		case strings.HasPrefix(line, "-"):
+			removed++
+		}
+	}
+	return files, added, removed
+}
+
+// colorDiffLine colors a line of a unified diff: additions green, removals
+// red, hunk headers and file headers set apart.
+func colorDiffLine(line string) string {
+	color := ""
+	bold := false
+	switch {
+	case strings.HasPrefix(line, "diff --git "), strings.HasPrefix(line, "+++ "), strings.HasPrefix(line, "--- "):
+		bold = true
+	case strings.HasPrefix(line, "@@"):
+		color = currentTheme.neutral
+	case strings.HasPrefix(line, "+"):
+		color = currentTheme.success
+	case strings.HasPrefix(line, "-"):
+		color = currentTheme.failure
+	case strings.HasPrefix(line, "index "), strings.HasPrefix(line, "new file mode"), strings.HasPrefix(line, "deleted file mode"), strings.HasPrefix(line, "similarity index"), strings.HasPrefix(line, "rename "), strings.HasPrefix(line, "Binary files"):
+		color = currentTheme.muted
+	default:
+		return line
+	}
+	style := lipgloss.NewStyle().Bold(bold)
+	if color != "" {
+		style = style.Foreground(lipgloss.Color(color))
+	}
+	return style.Render(line)
+}
+
+// prDiffSize returns the width and height of the diff pane and the number of
+// rows that fit in it, between the summary line and the "more" marker.
+func (m AppModel) prDiffSize() (paneWidth, availableHeight, visible int) {
+	paneWidth, availableHeight, visible = m.issueDetailSize()
+	return paneWidth, availableHeight, max(1, visible-2)
+}
+
+func (m AppModel) renderPRDiffPane() string {
+	paneWidth, availableHeight, visible := m.prDiffSize()
+
+	title := trf("PR #%d diff: %s", m.prDiffPR.ID, m.prDiffPR.Title)
+	if m.diffHOffset > 0 {
+		title = trf("%s [col %d]", title, m.diffHOffset+1)
+	}
+	if m.activePane == branchPane {
+		title = trf("%s (esc: back)", title)
+	}
+	items := []string{m.renderRightTabs(), activePaneStyle.Render(title)}
+
+	switch {
+	case m.loading:
+		items = append(items, "", m.spinner.View()+" "+tr("Loading diff..."))
+	case len(m.prDiffLines) == 0:
+		items = append(items, "", tr("No textual diff"))
+	default:
+		files, added, removed := diffStats(m.prDiffLines)
+		items = append(items, inactivePaneStyle.Render(trf("%d files changed, +%d -%d", files, added, removed)), "")
+
+		rows := m.prDiffRows(paneWidth - 2)
+		cursorRow := 0
+		for i, row := range rows {
+			if row.line == m.prDiffCursor {
+				cursorRow = i
+				break
+			}
+		}
+		start, end := m.calculateWindow(cursorRow, len(rows), visible)
+		for _, row := range rows[start:end] {
+			items = append(items, row.text)
+		}
+		if start > 0 {
+			items[3] = inactivePaneStyle.Render(moreAbove())
+		}
+		if end < len(rows) {
+			items = append(items, inactivePaneStyle.Render(fmt.Sprintf("%s (%d%%)", moreBelow(), end*100/len(rows))))
+		}
+	}
+
+	style := lipgloss.NewStyle().
+		Width(paneWidth).
+		Height(availableHeight).
+		Padding(0, 1)
+
+	return style.Render(strings.Join(items, "\n"))
+}
@@ -0,0 +1,180 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"bitbucket-cli/internal/bitbucket"
+	"bitbucket-cli/internal/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+type pullRequestTasksLoadedMsg struct {
+	id    int
+	tasks []domain.Task
+	err   error
+}
+
+// pullRequestTaskUpdatedMsg reports a task created or resolved; created
+// tells which.
+type pullRequestTaskUpdatedMsg struct {
+	id      int
+	task    domain.Task
+	created bool
+	err     error
+}
+
+func loadPullRequestTasks(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug string, pullRequestID int) tea.Cmd {
+	return func() tea.Msg {
+		tasks, err := client.ListPullRequestTasks(ctx, repoSlug, pullRequestID)
+		return pullRequestTasksLoadedMsg{id: pullRequestID, tasks: tasks, err: err}
+	}
+}
+
+func createPullRequestTask(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug string, pullRequestID int, content string, commentID int) tea.Cmd {
+	return func() tea.Msg {
+		task, err := client.CreatePullRequestTask(ctx, repoSlug, pullRequestID, content, commentID)
+		return pullRequestTaskUpdatedMsg{id: pullRequestID, task: task, created: true, err: err}
+	}
+}
+
+func setPullRequestTaskResolved(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug string, pullRequestID, taskID int, resolved bool) tea.Cmd {
+	return func() tea.Msg {
+		task, err := client.SetPullRequestTaskResolved(ctx, repoSlug, pullRequestID, taskID, resolved)
+		if task.ID == 0 {
+			task.ID = taskID
+		}
+		return pullRequestTaskUpdatedMsg{id: pullRequestID, task: task, err: err}
+	}
+}
+
+// toggleSelectedTask resolves the task under the task cursor of the detail
+// view, or opens it again.
+func toggleSelectedTask(m *AppModel) tea.Cmd {
+	if m.prTaskCursor >= len(m.prTasks) {
+		m.message = "No task selected"
+		return nil
+	}
+	task := m.prTasks[m.prTaskCursor]
+	pr := m.openPullRequest
+	if task.Resolved {
+		m.message = fmt.Sprintf("Reopening task #%d...", task.ID)
+	} else {
+		m.message = fmt.Sprintf("Resolving task #%d...", task.ID)
+	}
+	return startAction(m, setPullRequestTaskResolved(m.ctx, m.client, pr.RepoSlug, pr.ID, task.ID, !task.Resolved))
+}
+
+// taskFromSelectedComment raises a task from the comment under the comment
+// cursor, with the comment's first line as its text.
+func taskFromSelectedComment(m *AppModel) tea.Cmd {
+	comments, _ := commentThreads(m.prComments)
+	if m.prCommentCursor >= len(comments) {
+		m.message = "No comment selected"
+		return nil
+	}
+	comment := comments[m.prCommentCursor]
+	content, _, _ := strings.Cut(strings.TrimSpace(comment.Content), "\n")
+	if comment.Deleted || content == "" {
+		m.message = "The comment is empty"
+		return nil
+	}
+	pr := m.openPullRequest
+	m.message = fmt.Sprintf("Creating a task on PR #%d...", pr.ID)
+	return startAction(m, createPullRequestTask(m.ctx, m.client, pr.RepoSlug, pr.ID, content, comment.ID))
+}
+
+// applyTask stores a created or changed task and recounts the open tasks of
+// the pull request, which the merge checks read.
+func applyTask(m *AppModel, msg pullRequestTaskUpdatedMsg) {
+	if msg.id != m.openPullRequest.ID || m.prTasks == nil {
+		return
+	}
+	replaced := false
+	for i := range m.prTasks {
+		if m.prTasks[i].ID == msg.task.ID {
+			m.prTasks[i] = msg.task
+			replaced = true
+		}
+	}
+	if !replaced {
+		m.prTasks = append(m.prTasks, msg.task)
+	}
+	pr := m.openPullRequest
+	pr.TaskCount = countOpenTasks(m.prTasks)
+	applyOpenPullRequest(m, pr)
+}
+
+// countOpenTasks returns how many tasks are unresolved.
+func countOpenTasks(tasks []domain.Task) int {
+	count := 0
+	for _, task := range tasks {
+		if !task.Resolved {
+			count++
+		}
+	}
+	return count
+}
+
+// moveTaskCursor moves the task cursor of the detail view, scrolling the
+// selected task into view.
+func moveTaskCursor(m *AppModel, delta int) {
+	if len(m.prTasks) == 0 {
+		return
+	}
+	m.prTaskCursor = min(max(m.prTaskCursor+delta, 0), len(m.prTasks)-1)
+	paneWidth, _, visible := m.issueDetailSize()
+	_, taskStart := m.prDetailLines(paneWidth - 2)
+	line := taskStart + m.prTaskCursor
+	m.prDetailOffset = min(max(m.prDetailOffset, line-visible+1), line)
+}
+
+// moveCommentCursor moves the comment cursor of the comments view, scrolling
+// the selected comment's header into view.
+func moveCommentCursor(m *AppModel, delta int) {
+	comments, _ := commentThreads(m.prComments)
+	if len(comments) == 0 {
+		return
+	}
+	m.prCommentCursor = min(max(m.prCommentCursor+delta, 0), len(comments)-1)
+	paneWidth, _, visible := m.issueDetailSize()
+	_, starts := m.prCommentLines(paneWidth - 2)
+	line := starts[m.prCommentCursor]
+	m.prCommentsOffset = min(max(m.prCommentsOffset, line-visible+1), line)
+}
+
+// taskLines renders the tasks for the detail view, the one under the task
+// cursor marked.
+func (m AppModel) taskLines(width int) []string {
+	if m.prTasks == nil {
+		return []string{inactivePaneStyle.Render("  " + tr("Loading tasks..."))}
+	}
+	if len(m.prTasks) == 0 {
+		return []string{inactivePaneStyle.Render("  " + tr("No tasks"))}
+	}
+	lines := make([]string, 0, len(m.prTasks))
+	for i, task := range m.prTasks {
+		cursor := " "
+		if i == m.prTaskCursor && m.activePane == branchPane {
+			cursor = cursorStyle.Render(">")
+		}
+		box, content := "☐", task.Content
+		if currentTheme.plain {
+			box = "[ ]"
+		}
+		if task.Resolved {
+			box = lipgloss.NewStyle().Foreground(lipgloss.Color(currentTheme.success)).Render("☑")
+			if currentTheme.plain {
+				box = "[x]"
+			}
+			content = inactivePaneStyle.Render(content)
+		}
+		line := fmt.Sprintf("%s %s %s", cursor, box, content)
+		lines = append(lines, ansi.Truncate(line, width, "…"))
+	}
+	return lines
+}
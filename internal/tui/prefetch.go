@@ -0,0 +1,242 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"bitbucket-cli/internal/bitbucket"
+	"bitbucket-cli/internal/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// prefetchDelay is how long the cursor has to rest on a repository or PR
+	// before its data is fetched in the background.
+	prefetchDelay = 300 * time.Millisecond
+	// prefetchTTL bounds how old prefetched data may be when it is used.
+	prefetchTTL = time.Minute
+)
+
+type prefetchTickMsg struct {
+	seq int
+}
+
+type prefetchedBranchesMsg struct {
+	repoSlug string
+	branches []domain.Branch
+	err      error
+}
+
+type prefetchedPullRequestsMsg struct {
+	repoSlug string
+	prs      []domain.PullRequest
+	err      error
+}
+
+type prefetchedPipelinesMsg struct {
+	repoSlug  string
+	pipelines []domain.Pipeline
+	err       error
+}
+
+// prefetchedCommitsMsg carries the commits of a PR together with the changes
+// and diff of its first commit, which is what the commits view shows first.
+type prefetchedCommitsMsg struct {
+	key     string
+	commits []domain.Commit
+	changes []domain.CommitChange
+	diff    string
+	err     error
+}
+
+type prefetchedEntry[T any] struct {
+	value     T
+	fetchedAt time.Time
+}
+
+type prefetchedCommits struct {
+	commits []domain.Commit
+	changes []domain.CommitChange
+	diff    string
+}
+
+// prefetchCache holds data fetched ahead of time. Entries are taken out when
+// used, so a later visit always loads fresh data.
+type prefetchCache struct {
+	branches     map[string]prefetchedEntry[[]domain.Branch]
+	pullRequests map[string]prefetchedEntry[[]domain.PullRequest]
+	pipelines    map[string]prefetchedEntry[[]domain.Pipeline]
+	commits      map[string]prefetchedEntry[prefetchedCommits]
+	inFlight     map[string]bool
+}
+
+func newPrefetchCache() *prefetchCache {
+	return &prefetchCache{
+		branches:     make(map[string]prefetchedEntry[[]domain.Branch]),
+		pullRequests: make(map[string]prefetchedEntry[[]domain.PullRequest]),
+		pipelines:    make(map[string]prefetchedEntry[[]domain.Pipeline]),
+		commits:      make(map[string]prefetchedEntry[prefetchedCommits]),
+		inFlight:     make(map[string]bool),
+	}
+}
+
+func takePrefetched[T any](entries map[string]prefetchedEntry[T], key string) (T, bool) {
+	entry, ok := entries[key]
+	delete(entries, key)
+	if !ok || time.Since(entry.fetchedAt) > prefetchTTL {
+		var zero T
+		return zero, false
+	}
+	return entry.value, true
+}
+
+func isFresh[T any](entries map[string]prefetchedEntry[T], key string) bool {
+	entry, ok := entries[key]
+	return ok && time.Since(entry.fetchedAt) <= prefetchTTL
+}
+
+func pullRequestKey(repoSlug string, pullRequestID int) string {
+	return fmt.Sprintf("%s#%d", repoSlug, pullRequestID)
+}
+
+// schedulePrefetch restarts the debounce timer. Only the tick matching the
+// latest sequence number triggers a fetch.
+func schedulePrefetch(m *AppModel) tea.Cmd {
+	m.prefetchSeq++
+	seq := m.prefetchSeq
+	return tea.Tick(prefetchDelay, func(time.Time) tea.Msg {
+		return prefetchTickMsg{seq: seq}
+	})
+}
+
+// prefetchSelection fetches the data behind the item under the cursor: the
+// branches and PRs of a repository, or the commits of a PR.
+func prefetchSelection(m *AppModel) tea.Cmd {
+	// Prefetching is background work; it pauses while a 429 cooldown runs.
+	if m.client.RateLimit().Cooldown() > 0 {
+		return nil
+	}
+	cache := m.prefetch
+	var cmds []tea.Cmd
+
+	switch {
+	case m.activePane == repoPane:
+		repos := m.getFilteredRepos()
+		if m.repoCursor >= len(repos) {
+			return nil
+		}
+		slug := repos[m.repoCursor].Slug
+		branchKey := "branches:" + slug
+		if !isFresh(cache.branches, slug) && !cache.inFlight[branchKey] {
+			cache.inFlight[branchKey] = true
+			cmds = append(cmds, prefetchBranches(m.ctx, m.client, slug))
+		}
+		prKey := "prs:" + slug
+		if !isFresh(cache.pullRequests, slug) && !cache.inFlight[prKey] {
+			cache.inFlight[prKey] = true
+			cmds = append(cmds, prefetchPullRequests(m.ctx, m.client, slug))
+		}
+
+	case m.currentView == prView:
+		prs := m.getFilteredPRs()
+		if m.prCursor >= len(prs) || m.selectedRepoSlug == "" {
+			return nil
+		}
+		key := pullRequestKey(m.selectedRepoSlug, prs[m.prCursor].ID)
+		if !isFresh(cache.commits, key) && !cache.inFlight[key] {
+			cache.inFlight[key] = true
+			cmds = append(cmds, prefetchCommits(m.ctx, m.client, m.selectedRepoSlug, prs[m.prCursor].ID))
+		}
+		cmds = append(cmds, prefetchMergeChecks(m))
+	}
+
+	return tea.Batch(cmds...)
+}
+
+func prefetchBranches(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug string) tea.Cmd {
+	return func() tea.Msg {
+		branches, err := client.ListBranches(ctx, repoSlug)
+		return prefetchedBranchesMsg{repoSlug: repoSlug, branches: branches, err: err}
+	}
+}
+
+func prefetchPullRequests(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug string) tea.Cmd {
+	return func() tea.Msg {
+		prs, err := client.ListPullRequests(ctx, repoSlug)
+		return prefetchedPullRequestsMsg{repoSlug: repoSlug, prs: prs, err: err}
+	}
+}
+
+func prefetchPipelines(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug string) tea.Cmd {
+	return func() tea.Msg {
+		pipelines, err := client.ListPipelines(ctx, repoSlug)
+		return prefetchedPipelinesMsg{repoSlug: repoSlug, pipelines: pipelines, err: err}
+	}
+}
+
+func prefetchCommits(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug string, pullRequestID int) tea.Cmd {
+	return func() tea.Msg {
+		msg := prefetchedCommitsMsg{key: pullRequestKey(repoSlug, pullRequestID)}
+		msg.commits, msg.err = client.ListPullRequestCommits(ctx, repoSlug, pullRequestID)
+		if msg.err != nil || len(msg.commits) == 0 || msg.commits[0].Hash == "" {
+			return msg
+		}
+		msg.changes, msg.err = client.ListCommitChanges(ctx, repoSlug, msg.commits[0].Hash)
+		if msg.err != nil {
+			return msg
+		}
+		msg.diff, msg.err = client.GetCommitDiff(ctx, repoSlug, msg.commits[0].Hash)
+		return msg
+	}
+}
+
+// storePrefetched records a finished prefetch. Failures are dropped silently;
+// the regular load reports them if the user actually opens the item.
+func storePrefetched(m *AppModel, msg tea.Msg) {
+	cache := m.prefetch
+	now := time.Now()
+
+	switch msg := msg.(type) {
+	case prefetchedBranchesMsg:
+		delete(cache.inFlight, "branches:"+msg.repoSlug)
+		if msg.err == nil {
+			cache.branches[msg.repoSlug] = prefetchedEntry[[]domain.Branch]{value: msg.branches, fetchedAt: now}
+		}
+	case prefetchedPullRequestsMsg:
+		delete(cache.inFlight, "prs:"+msg.repoSlug)
+		if msg.err == nil {
+			cache.pullRequests[msg.repoSlug] = prefetchedEntry[[]domain.PullRequest]{value: msg.prs, fetchedAt: now}
+		}
+	case prefetchedPipelinesMsg:
+		delete(cache.inFlight, "pipelines:"+msg.repoSlug)
+		if msg.err == nil {
+			cache.pipelines[msg.repoSlug] = prefetchedEntry[[]domain.Pipeline]{value: msg.pipelines, fetchedAt: now}
+		}
+	case prefetchedCommitsMsg:
+		delete(cache.inFlight, msg.key)
+		if msg.err == nil {
+			cache.commits[msg.key] = prefetchedEntry[prefetchedCommits]{
+				value:     prefetchedCommits{commits: msg.commits, changes: msg.changes, diff: msg.diff},
+				fetchedAt: now,
+			}
+		}
+	}
+}
+
+// usePrefetchedCommits fills the commits view from the prefetch cache. It
+// returns false when nothing usable was prefetched.
+func usePrefetchedCommits(m *AppModel, pullRequestID int) (tea.Cmd, bool) {
+	prefetched, ok := takePrefetched(m.prefetch.commits, pullRequestKey(m.selectedRepoSlug, pullRequestID))
+	if !ok {
+		return nil, false
+	}
+
+	if len(prefetched.commits) > 0 && prefetched.changes != nil {
+		hash := prefetched.commits[0].Hash
+		m.prCommitChangesCache[hash] = prefetched.changes
+		m.prCommitDiffCache[hash] = prefetched.diff
+	}
+	return func() tea.Msg { return prCommitsLoadedMsg{commits: prefetched.commits} }, true
+}
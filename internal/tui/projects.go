@@ -0,0 +1,152 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"bitbucket-cli/internal/bitbucket"
+	"bitbucket-cli/internal/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type projectsLoadedMsg struct {
+	projects []domain.Project
+	err      error
+}
+
+type projectRepositoriesLoadedMsg struct {
+	project domain.Project
+	repos   []domain.Repository
+	err     error
+}
+
+func loadProjects(ctx context.Context, client bitbucket.BitbucketAPI) tea.Cmd {
+	return func() tea.Msg {
+		_, projects, err := client.ListProjects(ctx)
+		return projectsLoadedMsg{projects: projects, err: err}
+	}
+}
+
+func loadProjectRepositories(ctx context.Context, client bitbucket.BitbucketAPI, project domain.Project) tea.Cmd {
+	return func() tea.Msg {
+		repos, err := client.ListProjectRepositories(ctx, project.RepositoriesURL)
+		return projectRepositoriesLoadedMsg{project: project, repos: repos, err: err}
+	}
+}
+
+// openProjects lists the workspace's projects. They are fetched once and
+// kept until refreshed with r.
+func openProjects(m *AppModel) tea.Cmd {
+	m.activePane = branchPane
+	m.currentView = projectsView
+	ctx := newViewContext(m)
+	if m.projects != nil {
+		return nil
+	}
+	m.projectsPending = true
+	return forView(m, loadProjects(ctx, m.client))
+}
+
+// openProject scopes the repository pane to the selected project, listing
+// the repositories behind the project's repositories link.
+func openProject(m *AppModel) tea.Cmd {
+	if m.projectCursor >= len(m.projects) {
+		return nil
+	}
+	m.project = m.projects[m.projectCursor]
+	m.projectRepositories = nil
+	m.activePane = repoPane
+	m.currentView = noSelection
+	m.repoCursor = 0
+	m.repoFilterQuery = ""
+	return loadProjectRepositories(newViewContext(m), m.client, m.project)
+}
+
+// leaveProject shows the repositories of the whole workspace again.
+func leaveProject(m *AppModel) {
+	m.project = domain.Project{}
+	m.projectRepositories = nil
+	m.repoCursor = 0
+}
+
+// scopedRepositories are the repositories the repository pane lists: those
+// of the open project, or all of them.
+func (m AppModel) scopedRepositories() []domain.Repository {
+	if m.project.Key != "" {
+		return m.projectRepositories
+	}
+	return m.repositories
+}
+
+func (m AppModel) projectURL(project domain.Project) string {
+	return fmt.Sprintf("https://bitbucket.org/%s/workspace/projects/%s", m.workspace, project.Key)
+}
+
+// projectRepositoryCounts counts the loaded repositories per project key.
+func (m AppModel) projectRepositoryCounts() map[string]int {
+	counts := make(map[string]int)
+	for _, repo := range m.repositories {
+		counts[repo.ProjectKey]++
+	}
+	return counts
+}
+
+func (m AppModel) renderProjectsPane() string {
+	paneWidth := m.width - 4
+	if paneWidth < 30 {
+		paneWidth = 30
+	}
+
+	availableHeight := m.height - 6
+	if availableHeight < 5 {
+		availableHeight = 5
+	}
+
+	title := trf("Projects (%d) (esc: back)", len(m.projects))
+	if m.projectsPending {
+		title = fmt.Sprintf("%s %s", title, m.spinner.View())
+	}
+	items := []string{activePaneStyle.Render(title), ""}
+
+	if len(m.projects) == 0 {
+		if m.projectsPending {
+			items = append(items, m.renderSkeletonRows(paneWidth, availableHeight-2)...)
+		} else {
+			items = append(items, tr("No projects"))
+		}
+	} else {
+		keyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("45")).Width(12)
+		counts := m.projectRepositoryCounts()
+		start, end := m.calculateWindow(m.projectCursor, len(m.projects), availableHeight-2)
+		for i := start; i < end; i++ {
+			project := m.projects[i]
+			cursor := " "
+			if i == m.projectCursor {
+				cursor = cursorStyle.Render(">")
+			}
+			repos := "1 repository"
+			if counts[project.Key] != 1 {
+				repos = fmt.Sprintf("%d repositories", counts[project.Key])
+			}
+			items = append(items, fmt.Sprintf("%s %s %-40s %s", cursor, keyStyle.Render(project.Key), project.Name, inactivePaneStyle.Render(repos)))
+		}
+
+		if start > 0 {
+			items[1] = inactivePaneStyle.Render(moreAbove())
+		}
+		if end < len(m.projects) {
+			items = append(items, inactivePaneStyle.Render(moreBelow()))
+		}
+	}
+
+	content := strings.Join(items, "\n")
+	style := lipgloss.NewStyle().
+		Width(paneWidth).
+		Height(availableHeight).
+		Padding(0, 1)
+
+	return style.Render(content)
+}
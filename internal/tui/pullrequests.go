@@ -0,0 +1,337 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"bitbucket-cli/internal/bitbucket"
+	"bitbucket-cli/internal/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// prStates are the states the pull request list cycles through, ALL showing
+// open, merged and declined ones together.
+var prStates = []string{"OPEN", "MERGED", "DECLINED", "ALL"}
+
+// pullRequestState is the state the pull request list shows.
+func (m AppModel) pullRequestState() string {
+	if m.prState == "" {
+		return "OPEN"
+	}
+	return m.prState
+}
+
+// pullRequestSnapshotName names the offline copy of repoSlug's pull requests
+// in the current state. Open ones keep the name they had before states could
+// be picked.
+func (m AppModel) pullRequestSnapshotName(repoSlug string) string {
+	if state := m.pullRequestState(); state != "OPEN" {
+		return m.snapshotName("prs-"+strings.ToLower(state), repoSlug)
+	}
+	return m.snapshotName("prs", repoSlug)
+}
+
+// cyclePullRequestState switches the pull request list to the next state and
+// loads it.
+func cyclePullRequestState(m *AppModel) tea.Cmd {
+	m.prState = prStates[(slices.Index(prStates, m.pullRequestState())+1)%len(prStates)]
+	m.pullRequests = nil
+	m.prCursor = 0
+	m.message = ""
+	ctx := newViewContext(m)
+	m.loading = !showSnapshot(m, m.pullRequestSnapshotName(m.selectedRepoSlug), &m.pullRequests)
+	return forView(m, loadPullRequests(ctx, m.client, m.selectedRepoSlug, m.pullRequestState()))
+}
+
+// pullRequestEditPurpose tags the editor session that writes a new pull
+// request.
+const pullRequestEditPurpose = "pull-request"
+
+type pullRequestCreatedMsg struct {
+	repoSlug string
+	pr       domain.PullRequest
+	err      error
+}
+
+type pullRequestMergedMsg struct {
+	repoSlug string
+	pr       domain.PullRequest
+	strategy string
+	err      error
+}
+
+// mergeStrategies are the strategies offered when merging, keyed by the
+// letter that picks them at the prompt.
+var mergeStrategies = map[string]string{
+	"m": "merge_commit",
+	"s": "squash",
+	"f": "fast_forward",
+}
+
+// pullRequestForm is what the editor is opened with for a new pull request:
+// the title on the first line, then the destination and reviewers fields,
+// then the description after a blank line.
+const pullRequestForm = "%s\nDestination: %s\nReviewers: \n\n"
+
+// newPullRequest opens the editor with a pull request form for the
+// highlighted branch, into the repository's main branch by default.
+func newPullRequest(m *AppModel) tea.Cmd {
+	filtered := m.getFilteredBranches()
+	if m.selectedRepoSlug == "" || m.branchCursor >= len(filtered) {
+		return nil
+	}
+	source := filtered[m.branchCursor].Name
+	dest := m.mainBranch(m.selectedRepoSlug)
+	if source == dest {
+		m.message = fmt.Sprintf("Select a branch other than %s", dest)
+		return nil
+	}
+	m.pullRequestSource = source
+	return editText(m.editor, pullRequestEditPurpose, fmt.Sprintf(pullRequestForm, source, dest))
+}
+
+// parsePullRequestText reads the form written in the editor. The first
+// non-empty line is the title, Destination: and Reviewers: lines right below
+// it are the fields, and the rest is the description. Reviewers are
+// separated by commas.
+func parsePullRequestText(text string) (input domain.NewPullRequest, reviewers []string) {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	input.Title = strings.TrimSpace(lines[0])
+	rest := lines[1:]
+	for len(rest) > 0 {
+		name, value, ok := strings.Cut(rest[0], ":")
+		if !ok {
+			break
+		}
+		value = strings.TrimSpace(value)
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "destination":
+			input.DestBranch = value
+		case "reviewers":
+			for _, reviewer := range strings.Split(value, ",") {
+				if reviewer = strings.TrimSpace(reviewer); reviewer != "" {
+					reviewers = append(reviewers, reviewer)
+				}
+			}
+		default:
+			input.Description = strings.TrimSpace(strings.Join(rest, "\n"))
+			return input, reviewers
+		}
+		rest = rest[1:]
+	}
+	input.Description = strings.TrimSpace(strings.Join(rest, "\n"))
+	return input, reviewers
+}
+
+// submitPullRequest opens the pull request written in the editor.
+func submitPullRequest(m *AppModel, msg textEditedMsg) tea.Cmd {
+	source := m.pullRequestSource
+	m.pullRequestSource = ""
+	if msg.err != nil {
+		m.message = fmt.Sprintf("Editor error: %v", msg.err)
+		return nil
+	}
+	input, reviewers := parsePullRequestText(msg.text)
+	if input.Title == "" {
+		m.message = "Pull request not created: the title is empty"
+		return nil
+	}
+	input.SourceBranch = source
+	m.message = fmt.Sprintf("Creating pull request from %s...", source)
+	return startAction(m, createPullRequest(m.ctx, m.client, m.selectedRepoSlug, input, reviewers))
+}
+
+// createPullRequest resolves the reviewers against the workspace members,
+// then opens the pull request.
+func createPullRequest(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug string, input domain.NewPullRequest, reviewers []string) tea.Cmd {
+	return func() tea.Msg {
+		if len(reviewers) > 0 {
+			members, err := client.ListWorkspaceMembers(ctx)
+			if err != nil {
+				return pullRequestCreatedMsg{repoSlug: repoSlug, err: err}
+			}
+			input.Reviewers, err = resolveReviewers(members, reviewers)
+			if err != nil {
+				return pullRequestCreatedMsg{repoSlug: repoSlug, err: err}
+			}
+		}
+		pr, err := client.CreatePullRequest(ctx, repoSlug, input)
+		return pullRequestCreatedMsg{repoSlug: repoSlug, pr: pr, err: err}
+	}
+}
+
+// resolveReviewers returns the account IDs of the named reviewers. A name is
+// matched against the nickname, then the display name, ignoring case; an
+// account ID or UUID is taken as is.
+func resolveReviewers(members []domain.User, names []string) ([]string, error) {
+	accountIDs := make([]string, 0, len(names))
+	for _, name := range names {
+		var matches []domain.User
+		for _, member := range members {
+			if member.AccountID == name || member.UUID == name || strings.EqualFold(member.Nickname, name) {
+				matches = []domain.User{member}
+				break
+			}
+			if strings.EqualFold(member.DisplayName, name) {
+				matches = append(matches, member)
+			}
+		}
+		switch len(matches) {
+		case 0:
+			return nil, fmt.Errorf("no workspace member named %q", name)
+		case 1:
+			accountIDs = append(accountIDs, matches[0].AccountID)
+		default:
+			return nil, fmt.Errorf("%d workspace members are named %q; use a nickname", len(matches), name)
+		}
+	}
+	return accountIDs, nil
+}
+
+// pullRequestUnderCursor returns the pull request of the activity feed event
+// or the pull request list entry under the cursor, or the one in the detail
+// view, with its repository.
+func (m AppModel) pullRequestUnderCursor() (domain.PullRequest, bool) {
+	if m.filterMode || m.activePane != branchPane {
+		return domain.PullRequest{}, false
+	}
+	var pr domain.PullRequest
+	switch m.currentView {
+	case feedView:
+		if events := m.feedEvents(); m.feedCursor < len(events) && events[m.feedCursor].pr.ID != 0 {
+			pr = events[m.feedCursor].pr
+			pr.RepoSlug = events[m.feedCursor].repoSlug
+		}
+	case prView:
+		if filtered := m.getFilteredPRs(); m.prCursor < len(filtered) {
+			pr = filtered[m.prCursor]
+			pr.RepoSlug = m.selectedRepoSlug
+		}
+	case prDetailView:
+		pr = m.openPullRequest
+	}
+	return pr, pr.ID != 0
+}
+
+// confirmMerge asks for the merge strategy of the selected pull request.
+func confirmMerge(m *AppModel) {
+	pr, ok := m.pullRequestUnderCursor()
+	if !ok {
+		return
+	}
+	if !strings.EqualFold(pr.State, "OPEN") {
+		m.message = fmt.Sprintf("PR #%d is %s", pr.ID, strings.ToLower(pr.State))
+		return
+	}
+	m.mergeCandidate = pr
+	m.mergeStrategy = ""
+	m.message = fmt.Sprintf("Merge PR #%d with (m)erge commit, (s)quash or (f)ast-forward?", pr.ID)
+}
+
+// handleMergeKey reads the strategy, then the y/n confirmation, and merges.
+// Any other key cancels.
+func handleMergeKey(m AppModel, msg tea.KeyMsg) (AppModel, tea.Cmd) {
+	pr := m.mergeCandidate
+	if m.mergeStrategy == "" {
+		strategy, ok := mergeStrategies[msg.String()]
+		if !ok {
+			m.mergeCandidate = domain.PullRequest{}
+			return m, nil
+		}
+		m.mergeStrategy = strategy
+		m.message = fmt.Sprintf("Merge PR #%d from %s into %s with %s? (y/n)", pr.ID, pr.SourceBranch, pr.DestBranch, strategy)
+		return m, nil
+	}
+
+	strategy := m.mergeStrategy
+	m.mergeCandidate = domain.PullRequest{}
+	m.mergeStrategy = ""
+	if msg.String() != "y" {
+		return m, nil
+	}
+	m.message = fmt.Sprintf("Merging PR #%d...", pr.ID)
+	return m, startAction(&m, mergePullRequest(m.ctx, m.client, pr.RepoSlug, pr.ID, strategy))
+}
+
+func mergePullRequest(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug string, pullRequestID int, strategy string) tea.Cmd {
+	return func() tea.Msg {
+		pr, err := client.MergePullRequest(ctx, repoSlug, pullRequestID, strategy)
+		if pr.ID == 0 {
+			pr.ID = pullRequestID
+		}
+		return pullRequestMergedMsg{repoSlug: repoSlug, pr: pr, strategy: strategy, err: err}
+	}
+}
+
+// reloadPullRequests drops what is known about repoSlug's pull requests
+// after one changed state, reloading the list in place when it is open.
+func reloadPullRequests(m *AppModel, repoSlug string) tea.Cmd {
+	delete(m.prefetch.pullRequests, repoSlug)
+	clearMergeChecks(m, repoSlug)
+	if m.activePane != branchPane || m.currentView != prView || m.selectedRepoSlug != repoSlug {
+		return nil
+	}
+	m.quietReload = true
+	return forView(m, loadPullRequests(bitbucket.WithoutCache(m.viewCtx), m.client, repoSlug, m.pullRequestState()))
+}
+
+type pullRequestStateChangedMsg struct {
+	repoSlug string
+	// change is "decline" or "reopen".
+	change string
+	id     int
+	pr     domain.PullRequest
+	err    error
+}
+
+// confirmStateChange asks before declining an open pull request or reopening
+// a declined one.
+func confirmStateChange(m *AppModel, change string) {
+	pr, ok := m.pullRequestUnderCursor()
+	if !ok {
+		return
+	}
+	want := map[string]string{"decline": "OPEN", "reopen": "DECLINED"}[change]
+	if !strings.EqualFold(pr.State, want) {
+		m.message = fmt.Sprintf("PR #%d is %s", pr.ID, strings.ToLower(pr.State))
+		return
+	}
+	m.stateCandidate = pr
+	m.stateChange = change
+	if change == "reopen" {
+		m.message = fmt.Sprintf("Reopen PR #%d as a new pull request from %s? (y/n)", pr.ID, pr.SourceBranch)
+		return
+	}
+	m.message = fmt.Sprintf("Decline PR #%d \"%s\"? (y/n)", pr.ID, pr.Title)
+}
+
+func handleStateChangeKey(m AppModel, msg tea.KeyMsg) (AppModel, tea.Cmd) {
+	pr, change := m.stateCandidate, m.stateChange
+	m.stateCandidate = domain.PullRequest{}
+	m.stateChange = ""
+	if msg.String() != "y" {
+		return m, nil
+	}
+	if change == "reopen" {
+		m.message = fmt.Sprintf("Reopening PR #%d...", pr.ID)
+	} else {
+		m.message = fmt.Sprintf("Declining PR #%d...", pr.ID)
+	}
+	return m, startAction(&m, changePullRequestState(m.ctx, m.client, pr.RepoSlug, pr.ID, change))
+}
+
+func changePullRequestState(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug string, pullRequestID int, change string) tea.Cmd {
+	return func() tea.Msg {
+		var pr domain.PullRequest
+		var err error
+		if change == "reopen" {
+			pr, err = client.ReopenPullRequest(ctx, repoSlug, pullRequestID)
+		} else {
+			pr, err = client.DeclinePullRequest(ctx, repoSlug, pullRequestID)
+		}
+		return pullRequestStateChangedMsg{repoSlug: repoSlug, change: change, id: pullRequestID, pr: pr, err: err}
+	}
+}
@@ -0,0 +1,85 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"bitbucket-cli/internal/bitbucket"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// cooldownEndedMsg fires when a 429 cooldown should be over.
+type cooldownEndedMsg struct{}
+
+// pollInterval stretches pipelinePollInterval as the API quota runs low, so
+// background polling backs off well before requests start failing with 429.
+// During a cooldown after a 429 it waits the cooldown out first.
+func pollInterval(client bitbucket.BitbucketAPI) time.Duration {
+	limit := client.RateLimit()
+	switch {
+	case limit.Cooldown() > 0:
+		return limit.Cooldown() + pipelinePollInterval
+	case !limit.Known():
+		return pipelinePollInterval
+	case limit.Fraction() < 0.05:
+		return pipelinePollInterval * 8
+	case limit.NearLimit || limit.Fraction() < 0.2:
+		return pipelinePollInterval * 3
+	default:
+		return pipelinePollInterval
+	}
+}
+
+// queueRefresh holds an explicit refresh until the cooldown after a 429 is
+// over, rather than sending a request that would fail again.
+func queueRefresh(m *AppModel, wait time.Duration) tea.Cmd {
+	m.message = fmt.Sprintf("Rate limited: refresh will run in %s", wait.Round(time.Second))
+	if m.refreshQueued {
+		return nil
+	}
+	m.refreshQueued = true
+	return tea.Tick(wait, func(time.Time) tea.Msg {
+		return cooldownEndedMsg{}
+	})
+}
+
+// runQueuedRefresh runs the refresh queued by queueRefresh, or waits again if
+// another 429 extended the cooldown.
+func runQueuedRefresh(m *AppModel) tea.Cmd {
+	if !m.refreshQueued {
+		return nil
+	}
+	m.refreshQueued = false
+	if wait := m.client.RateLimit().Cooldown(); wait > 0 {
+		return queueRefresh(m, wait)
+	}
+	m.message = ""
+	return refreshView(m)
+}
+
+// renderRateLimit shows the remaining API quota, highlighted once it is
+// running low, or a countdown while requests are held off after a 429. It is
+// empty until a response reported the quota.
+func (m AppModel) renderRateLimit() string {
+	limit := m.client.RateLimit()
+	if wait := limit.Cooldown(); wait > 0 {
+		text := fmt.Sprintf("Rate limited: resuming in %s", wait.Round(time.Second))
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(currentTheme.warning)).Bold(true).Render(text)
+	}
+	if !limit.Known() {
+		return ""
+	}
+
+	text := fmt.Sprintf("API %d/%d", limit.Remaining, limit.Limit)
+	if !limit.Reset.IsZero() && limit.Fraction() < 0.2 {
+		text = fmt.Sprintf("%s (resets in %s)", text, time.Until(limit.Reset).Round(time.Minute))
+	}
+
+	style := lipgloss.NewStyle().Foreground(lipgloss.Color(currentTheme.muted))
+	if limit.NearLimit || limit.Fraction() < 0.2 {
+		style = lipgloss.NewStyle().Foreground(lipgloss.Color(currentTheme.warning)).Bold(true)
+	}
+	return style.Render(text)
+}
@@ -0,0 +1,198 @@
+package tui
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"bitbucket-cli/internal/bitbucket"
+	"bitbucket-cli/internal/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxRevertFiles bounds the pull requests reverted through the API, which
+// takes up to three requests per file.
+const maxRevertFiles = 100
+
+type revertCreatedMsg struct {
+	reverted domain.PullRequest
+	pr       domain.PullRequest
+	err      error
+}
+
+// errRevertConflict means a file changed again after the merge, so the
+// revert needs a clone to resolve.
+var errRevertConflict = errors.New("changed since the merge")
+
+// revertBranchName is the branch the revert of pr is committed to.
+func revertBranchName(pr domain.PullRequest) string {
+	return fmt.Sprintf("revert-pr-%d", pr.ID)
+}
+
+// selectedMergedPullRequest returns the merged pull request under the
+// cursor: a merge in the activity feed or a merged pull request in the list.
+func (m AppModel) selectedMergedPullRequest() (domain.PullRequest, bool) {
+	pr, ok := m.pullRequestUnderCursor()
+	return pr, ok && strings.EqualFold(pr.State, "MERGED")
+}
+
+// confirmRevert asks before reverting the selected merged pull request.
+func confirmRevert(m *AppModel) {
+	pr, ok := m.selectedMergedPullRequest()
+	if !ok {
+		m.message = "Select a merged pull request to revert"
+		return
+	}
+	m.revertCandidate = pr
+	m.message = fmt.Sprintf("Revert PR #%d on %s with a new pull request from %s? (y/n)", pr.ID, pr.DestBranch, revertBranchName(pr))
+}
+
+func handleRevertConfirmKey(m AppModel, msg tea.KeyMsg) (AppModel, tea.Cmd) {
+	pr := m.revertCandidate
+	m.revertCandidate = domain.PullRequest{}
+	if msg.String() != "y" {
+		return m, nil
+	}
+	m.message = fmt.Sprintf("Reverting PR #%d...", pr.ID)
+	return m, startAction(&m, revertPullRequest(m.ctx, m.client, pr))
+}
+
+func revertPullRequest(ctx context.Context, client bitbucket.BitbucketAPI, pr domain.PullRequest) tea.Cmd {
+	return func() tea.Msg {
+		created, err := createRevert(ctx, client, pr)
+		return revertCreatedMsg{reverted: pr, pr: created, err: err}
+	}
+}
+
+// createRevert commits the inverse of pr's merge commit to a new branch off
+// the destination branch, then opens a pull request from it. Files are
+// restored whole, so a file changed again on the destination branch since
+// the merge stops the revert instead of dropping those changes.
+func createRevert(ctx context.Context, client bitbucket.BitbucketAPI, pr domain.PullRequest) (domain.PullRequest, error) {
+	repoSlug := pr.RepoSlug
+	if pr.MergeCommit == "" {
+		return domain.PullRequest{}, fmt.Errorf("PR #%d has no merge commit", pr.ID)
+	}
+	merge, err := client.GetCommit(ctx, repoSlug, pr.MergeCommit)
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+	if len(merge.Parents) == 0 {
+		return domain.PullRequest{}, fmt.Errorf("merge commit %s has no parent", shortHash(merge.Hash))
+	}
+	before := merge.Parents[0]
+
+	head, err := client.GetBranch(ctx, repoSlug, pr.DestBranch)
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+	branch := revertBranchName(pr)
+	if _, err := client.GetBranch(ctx, repoSlug, branch); err == nil {
+		return domain.PullRequest{}, fmt.Errorf("branch %s already exists", branch)
+	} else if !errors.Is(err, bitbucket.ErrNotFound) {
+		return domain.PullRequest{}, err
+	}
+
+	changes, err := client.ListCommitChanges(ctx, repoSlug, merge.Hash)
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+	if len(changes) > maxRevertFiles {
+		return domain.PullRequest{}, fmt.Errorf("PR #%d changed %d files; revert it in a clone", pr.ID, len(changes))
+	}
+
+	commit := domain.NewCommit{
+		Branch:  branch,
+		Parent:  head.Target.Hash,
+		Message: fmt.Sprintf("Revert \"%s\"\n\nThis reverts pull request #%d, merge commit %s.", pr.Title, pr.ID, merge.Hash),
+		Files:   make(map[string][]byte),
+	}
+	for _, change := range changes {
+		if err := revertChange(ctx, client, repoSlug, merge.Hash, before, head.Target.Hash, change, &commit); err != nil {
+			return domain.PullRequest{}, err
+		}
+	}
+	if err := client.CreateCommit(ctx, repoSlug, commit); err != nil {
+		return domain.PullRequest{}, err
+	}
+
+	return client.CreatePullRequest(ctx, repoSlug, domain.NewPullRequest{
+		Title:             fmt.Sprintf("Revert \"%s\"", pr.Title),
+		Description:       fmt.Sprintf("Reverts #%d (merge commit %s).", pr.ID, shortHash(merge.Hash)),
+		SourceBranch:      branch,
+		DestBranch:        pr.DestBranch,
+		CloseSourceBranch: true,
+	})
+}
+
+// revertChange adds to commit what undoes one file change of the merge:
+// added files are deleted and modified, removed or renamed ones restored
+// from before the merge.
+func revertChange(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug, merge, before, head string, change domain.CommitChange, commit *domain.NewCommit) error {
+	if err := checkUnchanged(ctx, client, repoSlug, merge, head, change); err != nil {
+		return err
+	}
+
+	switch change.Status {
+	case "added":
+		commit.Deleted = append(commit.Deleted, change.Path)
+		return nil
+	case "renamed":
+		commit.Deleted = append(commit.Deleted, change.Path)
+	}
+
+	path := change.Path
+	if change.OldPath != "" {
+		path = change.OldPath
+	}
+	content, err := client.GetFileContent(ctx, repoSlug, before, path)
+	if err != nil {
+		return err
+	}
+	commit.Files[path] = content
+	return nil
+}
+
+// checkUnchanged fails when the changed file differs between the merge
+// commit and the head of the destination branch, or when a file the merge
+// removed exists again.
+func checkUnchanged(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug, merge, head string, change domain.CommitChange) error {
+	if merge == head {
+		return nil
+	}
+	conflict := fmt.Errorf("%s %w; revert it in a clone", change.Path, errRevertConflict)
+	current, err := client.GetFileContent(ctx, repoSlug, head, change.Path)
+	if change.Status == "removed" {
+		if err == nil {
+			return conflict
+		}
+		if errors.Is(err, bitbucket.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	if errors.Is(err, bitbucket.ErrNotFound) {
+		return conflict
+	}
+	if err != nil {
+		return err
+	}
+	merged, err := client.GetFileContent(ctx, repoSlug, merge, change.Path)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(merged, current) {
+		return conflict
+	}
+	return nil
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
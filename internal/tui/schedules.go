@@ -0,0 +1,295 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"bitbucket-cli/internal/bitbucket"
+	"bitbucket-cli/internal/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// defaultCronPattern is offered for new schedules: every day at 02:00 UTC.
+const defaultCronPattern = "0 0 2 * * ? *"
+
+type schedulesLoadedMsg struct {
+	repoSlug  string
+	schedules []domain.PipelineSchedule
+	err       error
+}
+
+type scheduleCreatedMsg struct {
+	repoSlug string
+	schedule domain.PipelineSchedule
+	err      error
+}
+
+type scheduleUpdatedMsg struct {
+	repoSlug string
+	schedule domain.PipelineSchedule
+	err      error
+}
+
+type scheduleDeletedMsg struct {
+	repoSlug string
+	schedule domain.PipelineSchedule
+	err      error
+}
+
+func loadSchedules(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug string) tea.Cmd {
+	return func() tea.Msg {
+		schedules, err := client.ListPipelineSchedules(ctx, repoSlug)
+		return schedulesLoadedMsg{repoSlug: repoSlug, schedules: schedules, err: err}
+	}
+}
+
+func createSchedule(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug string, schedule domain.PipelineSchedule) tea.Cmd {
+	return func() tea.Msg {
+		created, err := client.CreatePipelineSchedule(ctx, repoSlug, schedule)
+		return scheduleCreatedMsg{repoSlug: repoSlug, schedule: created, err: err}
+	}
+}
+
+func setScheduleEnabled(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug string, schedule domain.PipelineSchedule, enabled bool) tea.Cmd {
+	return func() tea.Msg {
+		updated, err := client.SetPipelineScheduleEnabled(ctx, repoSlug, schedule.UUID, enabled)
+		if err != nil {
+			updated = schedule
+		}
+		return scheduleUpdatedMsg{repoSlug: repoSlug, schedule: updated, err: err}
+	}
+}
+
+func deleteSchedule(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug string, schedule domain.PipelineSchedule) tea.Cmd {
+	return func() tea.Msg {
+		err := client.DeletePipelineSchedule(ctx, repoSlug, schedule.UUID)
+		return scheduleDeletedMsg{repoSlug: repoSlug, schedule: schedule, err: err}
+	}
+}
+
+// openSchedules lists the pipeline schedules of the repository.
+func openSchedules(m *AppModel) tea.Cmd {
+	m.currentView = schedulesView
+	m.schedules = nil
+	m.scheduleCursor = 0
+	ctx := newViewContext(m)
+	m.loading = true
+	return forView(m, loadSchedules(ctx, m.client, m.selectedRepoSlug))
+}
+
+func closeSchedules(m *AppModel) {
+	m.currentView = pipelinesView
+	m.schedules = nil
+	m.scheduleCursor = 0
+}
+
+// scheduleLabel names a schedule in messages: its branch, and its custom
+// pipeline if it runs one.
+func scheduleLabel(schedule domain.PipelineSchedule) string {
+	if schedule.Pipeline != "" {
+		return fmt.Sprintf("%s (%s)", schedule.Branch, schedule.Pipeline)
+	}
+	return schedule.Branch
+}
+
+// startNewSchedule opens the prompt for a new schedule, on the repository's
+// main branch every night unless something else is typed.
+func startNewSchedule(m *AppModel) {
+	m.newScheduleStep = "branch"
+	m.newSchedule = domain.PipelineSchedule{CronPattern: defaultCronPattern}
+	for _, repo := range m.repositories {
+		if repo.Slug == m.selectedRepoSlug {
+			m.newSchedule.Branch = repo.Mainbranch
+		}
+	}
+}
+
+// newSchedulePrompt is the help line while a new schedule is typed.
+func newSchedulePrompt(m AppModel) string {
+	switch m.newScheduleStep {
+	case "cron":
+		return trf("Run %s at (cron, UTC, seconds first): %s  (esc: cancel, enter: next)", m.newSchedule.Branch, m.newSchedule.CronPattern)
+	case "pipeline":
+		return trf("Custom pipeline, empty for the branch's own: %s  (esc: cancel, enter: create)", m.newSchedule.Pipeline)
+	default:
+		return trf("Schedule pipelines of branch: %s  (esc: cancel, enter: next)", m.newSchedule.Branch)
+	}
+}
+
+// handleNewScheduleKey edits the branch, the cron pattern and the custom
+// pipeline of the new schedule in turn, and creates it on the last enter.
+func handleNewScheduleKey(m AppModel, msg tea.KeyMsg) (AppModel, tea.Cmd) {
+	field := &m.newSchedule.Branch
+	switch m.newScheduleStep {
+	case "cron":
+		field = &m.newSchedule.CronPattern
+	case "pipeline":
+		field = &m.newSchedule.Pipeline
+	}
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.newScheduleStep = ""
+	case tea.KeyEnter:
+		*field = strings.Join(strings.Fields(*field), " ")
+		switch {
+		case m.newScheduleStep == "branch" && *field != "":
+			m.newScheduleStep = "cron"
+		case m.newScheduleStep == "cron" && *field != "":
+			m.newScheduleStep = "pipeline"
+		case m.newScheduleStep == "pipeline":
+			m.newScheduleStep = ""
+			m.message = fmt.Sprintf("Scheduling %s at %s...", scheduleLabel(m.newSchedule), m.newSchedule.CronPattern)
+			return m, startAction(&m, createSchedule(m.ctx, m.client, m.selectedRepoSlug, m.newSchedule))
+		}
+	case tea.KeyBackspace:
+		if runes := []rune(*field); len(runes) > 0 {
+			*field = string(runes[:len(runes)-1])
+		}
+	case tea.KeySpace:
+		// Only cron patterns have spaces; branch and pipeline names cannot.
+		if m.newScheduleStep == "cron" {
+			*field += " "
+		}
+	case tea.KeyRunes:
+		*field += string(msg.Runes)
+	}
+	return m, nil
+}
+
+// toggleSchedule pauses the highlighted schedule, or resumes it if paused.
+func toggleSchedule(m *AppModel) tea.Cmd {
+	if m.scheduleCursor >= len(m.schedules) {
+		return nil
+	}
+	schedule := m.schedules[m.scheduleCursor]
+	if schedule.Enabled {
+		m.message = fmt.Sprintf("Pausing schedule of %s...", scheduleLabel(schedule))
+	} else {
+		m.message = fmt.Sprintf("Resuming schedule of %s...", scheduleLabel(schedule))
+	}
+	return startAction(m, setScheduleEnabled(m.ctx, m.client, m.selectedRepoSlug, schedule, !schedule.Enabled))
+}
+
+// replaceSchedule shows an updated schedule in place of the old one.
+func replaceSchedule(m *AppModel, updated domain.PipelineSchedule) {
+	for i, schedule := range m.schedules {
+		if schedule.UUID == updated.UUID {
+			m.schedules[i] = updated
+		}
+	}
+}
+
+// confirmDeleteSchedule asks before deleting the highlighted schedule.
+func confirmDeleteSchedule(m *AppModel) {
+	if m.scheduleCursor >= len(m.schedules) {
+		return
+	}
+	m.deleteScheduleCandidate = m.schedules[m.scheduleCursor]
+	m.message = fmt.Sprintf("Delete schedule of %s at %s? (y/n)", scheduleLabel(m.deleteScheduleCandidate), m.deleteScheduleCandidate.CronPattern)
+}
+
+func handleDeleteScheduleKey(m AppModel, msg tea.KeyMsg) (AppModel, tea.Cmd) {
+	schedule := m.deleteScheduleCandidate
+	m.deleteScheduleCandidate = domain.PipelineSchedule{}
+	if msg.String() != "y" {
+		return m, nil
+	}
+	m.message = fmt.Sprintf("Deleting schedule of %s...", scheduleLabel(schedule))
+	return m, startAction(&m, deleteSchedule(m.ctx, m.client, m.selectedRepoSlug, schedule))
+}
+
+// removeSchedule drops a deleted schedule from the list.
+func removeSchedule(m *AppModel, uuid string) {
+	m.schedules = slices.DeleteFunc(m.schedules, func(schedule domain.PipelineSchedule) bool {
+		return schedule.UUID == uuid
+	})
+	m.scheduleCursor = keepCursor(m.scheduleCursor, len(m.schedules))
+}
+
+func (m AppModel) renderSchedulesPane() string {
+	showRepoPane := m.currentView == noSelection || m.activePane == repoPane
+
+	paneWidth := m.width - 4
+	if showRepoPane {
+		repoPaneWidth := (m.width - 10) / 3
+		if repoPaneWidth < 20 {
+			repoPaneWidth = 20
+		}
+		paneWidth = m.width - repoPaneWidth - 10
+	}
+	if paneWidth < 30 {
+		paneWidth = 30
+	}
+
+	availableHeight := m.height - 6
+	if availableHeight < 5 {
+		availableHeight = 5
+	}
+
+	title := tr("Pipeline Schedules")
+	if m.selectedRepo != "" {
+		title = trf("Pipeline Schedules (%s)", m.selectedRepo)
+	}
+	if !showRepoPane {
+		title = trf("%s (esc: back)", title)
+	}
+
+	if m.activePane == branchPane {
+		title = activePaneStyle.Render(title)
+	} else {
+		title = inactivePaneStyle.Render(title)
+	}
+
+	var items []string
+	items = append(items, m.renderRightTabs())
+	items = append(items, title)
+	items = append(items, "")
+
+	if m.loading && m.currentView == schedulesView {
+		items = append(items, m.renderSkeletonRows(paneWidth, availableHeight-3)...)
+	} else if len(m.schedules) == 0 {
+		items = append(items, tr("No schedules"))
+	} else {
+		start, end := m.calculateWindow(m.scheduleCursor, len(m.schedules), availableHeight-3)
+
+		branchStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("99"))
+		for i := start; i < end; i++ {
+			schedule := m.schedules[i]
+			cursor := " "
+			if m.activePane == branchPane && i == m.scheduleCursor {
+				cursor = cursorStyle.Render(">")
+			}
+			state := currentTheme.badge(currentTheme.success, "●", "ENABLED")
+			if !schedule.Enabled {
+				state = currentTheme.badge(currentTheme.muted, "○", "PAUSED")
+			}
+			line := fmt.Sprintf("%s %s %-20s %s", cursor, state, schedule.CronPattern, branchStyle.Render(schedule.Branch))
+			if schedule.Pipeline != "" {
+				line = fmt.Sprintf("%s %s", line, trf("custom: %s", schedule.Pipeline))
+			}
+			line = fmt.Sprintf("%s  %s", line, inactivePaneStyle.Render(trf("updated %s", shortTimestamp(schedule.UpdatedOn))))
+			items = append(items, ansi.Truncate(line, paneWidth-2, "…"))
+		}
+
+		if start > 0 {
+			items[2] = inactivePaneStyle.Render(moreAbove())
+		}
+		if end < len(m.schedules) {
+			items = append(items, inactivePaneStyle.Render(moreBelow()))
+		}
+	}
+
+	content := strings.Join(items, "\n")
+	style := lipgloss.NewStyle().
+		Width(paneWidth).
+		Height(availableHeight).
+		Padding(0, 1).
+		Faint(m.fadingIn())
+
+	return style.Render(content)
+}
@@ -0,0 +1,273 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"bitbucket-cli/internal/bitbucket"
+	"bitbucket-cli/internal/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type searchKind int
+
+const (
+	searchRepo searchKind = iota
+	searchBranch
+	searchPR
+	searchPipeline
+)
+
+type searchResult struct {
+	kind  searchKind
+	index int
+	label string
+}
+
+type searchBranchesLoadedMsg struct {
+	search   int
+	repoSlug string
+	branches []domain.Branch
+	err      error
+}
+
+type searchPullRequestsLoadedMsg struct {
+	search   int
+	repoSlug string
+	prs      []domain.PullRequest
+	err      error
+}
+
+type searchPipelinesLoadedMsg struct {
+	search    int
+	repoSlug  string
+	pipelines []domain.Pipeline
+	err       error
+}
+
+// loadSearchSources loads the lists search number search looks through. The
+// results carry that number, so those of an earlier search, which could
+// still arrive, are dropped.
+func loadSearchSources(ctx context.Context, client bitbucket.BitbucketAPI, search int, repoSlug string) tea.Cmd {
+	return tea.Batch(
+		func() tea.Msg {
+			branches, err := client.ListBranches(ctx, repoSlug)
+			return searchBranchesLoadedMsg{search: search, repoSlug: repoSlug, branches: branches, err: err}
+		},
+		func() tea.Msg {
+			prs, err := client.ListPullRequests(ctx, repoSlug)
+			return searchPullRequestsLoadedMsg{search: search, repoSlug: repoSlug, prs: prs, err: err}
+		},
+		func() tea.Msg {
+			pipelines, err := client.ListPipelines(ctx, repoSlug)
+			return searchPipelinesLoadedMsg{search: search, repoSlug: repoSlug, pipelines: pipelines, err: err}
+		},
+	)
+}
+
+func openSearch(m *AppModel) tea.Cmd {
+	m.searchMode = true
+	m.searchQuery = ""
+	m.searchCursor = 0
+	m.searchBranches = nil
+	m.searchPullRequests = nil
+	m.searchPipelines = nil
+	m.searchPending = 0
+	m.searchSeq++
+
+	if m.selectedRepoSlug == "" {
+		return nil
+	}
+	m.searchPending = 3
+	return loadSearchSources(m.ctx, m.client, m.searchSeq, m.selectedRepoSlug)
+}
+
+func handleSearchKey(m AppModel, msg tea.KeyMsg) (AppModel, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "ctrl+f":
+		m.searchMode = false
+
+	case "up", "ctrl+k":
+		if m.searchCursor > 0 {
+			m.searchCursor--
+		}
+
+	case "down", "ctrl+j":
+		if m.searchCursor < len(m.searchResults())-1 {
+			m.searchCursor++
+		}
+
+	case "enter":
+		results := m.searchResults()
+		if len(results) == 0 {
+			return m, nil
+		}
+		m.searchMode = false
+		return jumpToSearchResult(m, results[m.searchCursor])
+
+	case "backspace":
+		if len(m.searchQuery) > 0 {
+			m.searchQuery = m.searchQuery[:len(m.searchQuery)-1]
+			m.searchCursor = 0
+		}
+
+	default:
+		if len(msg.Runes) > 0 {
+			m.searchQuery += string(msg.Runes)
+			m.searchCursor = 0
+		}
+	}
+
+	return m, nil
+}
+
+func (m AppModel) searchResults() []searchResult {
+	query := strings.ToLower(strings.TrimSpace(m.searchQuery))
+	if query == "" {
+		return nil
+	}
+
+	var results []searchResult
+	for i, repo := range m.repositories {
+		if strings.Contains(strings.ToLower(repo.Name), query) || strings.Contains(strings.ToLower(repo.Slug), query) {
+			results = append(results, searchResult{kind: searchRepo, index: i, label: repo.Name})
+		}
+	}
+	for i, branch := range m.searchBranches {
+		if strings.Contains(strings.ToLower(branch.Name), query) {
+			results = append(results, searchResult{kind: searchBranch, index: i, label: branch.Name})
+		}
+	}
+	for i, pr := range m.searchPullRequests {
+		id := fmt.Sprintf("#%d", pr.ID)
+		if strings.Contains(strings.ToLower(pr.Title), query) ||
+			strings.Contains(strings.ToLower(pr.Author), query) ||
+			strings.Contains(strings.ToLower(pr.SourceBranch), query) ||
+			strings.Contains(id, query) {
+			results = append(results, searchResult{kind: searchPR, index: i, label: fmt.Sprintf("%s %s", id, pr.Title)})
+		}
+	}
+	tracked := m.trackedPipelines(m.searchPipelines)
+	for i, pipeline := range tracked {
+		ref := fmt.Sprintf("#%d", pipeline.BuildNumber)
+		branch := formatPipelineBranch(pipeline.BranchName)
+		if strings.Contains(ref, query) || strings.Contains(strings.ToLower(branch), query) {
+			results = append(results, searchResult{kind: searchPipeline, index: i, label: fmt.Sprintf("%s %s", ref, branch)})
+		}
+	}
+
+	return results
+}
+
+func (m AppModel) trackedPipelines(pipelines []domain.Pipeline) []domain.Pipeline {
+	var tracked []domain.Pipeline
+	for _, pipeline := range pipelines {
+		if m.isTrackedPipelineBranch(pipeline.BranchName) {
+			tracked = append(tracked, pipeline)
+		}
+	}
+	return tracked
+}
+
+func jumpToSearchResult(m AppModel, result searchResult) (AppModel, tea.Cmd) {
+	switch result.kind {
+	case searchRepo:
+		m.repoFilterQuery = ""
+		m.repoCursor = result.index
+		m.activePane = repoPane
+		m.currentView = noSelection
+
+	case searchBranch:
+		m.activePane = branchPane
+		m.currentView = branchesView
+		m.branches = m.searchBranches
+		m.branchFilterQuery = ""
+		m.branchCursor = result.index
+
+	case searchPR:
+		m.activePane = branchPane
+		m.currentView = prView
+		m.pullRequests = m.searchPullRequests
+		m.prFilterQuery = ""
+		m.prCursor = result.index
+
+	case searchPipeline:
+		m.activePane = branchPane
+		m.currentView = pipelinesView
+		m.pipelines = m.searchPipelines
+		m.pipelineFilterQuery = ""
+		m.pipelineCursor = result.index
+		if selectedRunningPipelineUUID(m) != "" {
+			return m, pollPipelineUpdates(m.client)
+		}
+	}
+
+	return m, nil
+}
+
+// searchBadgeWidth lines the result titles up after badges of any length.
+const searchBadgeWidth = 10
+
+func renderSearchBadge(kind searchKind) string {
+	var badge string
+	switch kind {
+	case searchRepo:
+		badge = currentTheme.badge(currentTheme.accent, "", "REPO")
+	case searchBranch:
+		badge = currentTheme.badge(currentTheme.warning, "", "BRANCH")
+	case searchPR:
+		badge = currentTheme.badge(currentTheme.success, "", "PR")
+	case searchPipeline:
+		badge = currentTheme.badge(currentTheme.neutral, "", "PIPELINE")
+	default:
+		return ""
+	}
+	return badge + strings.Repeat(" ", max(searchBadgeWidth-lipgloss.Width(badge), 0))
+}
+
+func (m AppModel) renderSearchOverlay() string {
+	width := m.width - 8
+	if width < 40 {
+		width = 40
+	}
+	height := m.height - 8
+	if height < 5 {
+		height = 5
+	}
+
+	scope := "repositories"
+	if m.selectedRepo != "" {
+		scope = fmt.Sprintf("repositories + %s", m.selectedRepo)
+	}
+
+	items := []string{
+		activePaneStyle.Render(fmt.Sprintf("Search %s", scope)),
+		"",
+		fmt.Sprintf("> %s", m.searchQuery),
+		"",
+	}
+
+	results := m.searchResults()
+	if m.searchPending > 0 {
+		items = append(items, m.spinner.View()+" Loading branches, pull requests and pipelines...")
+	}
+	if strings.TrimSpace(m.searchQuery) == "" {
+		items = append(items, inactivePaneStyle.Render(tr("Type to search")))
+	} else if len(results) == 0 {
+		items = append(items, tr("No matches"))
+	} else {
+		start, end := m.calculateWindow(m.searchCursor, len(results), height-6)
+		for i := start; i < end; i++ {
+			cursor := " "
+			if i == m.searchCursor {
+				cursor = cursorStyle.Render(">")
+			}
+			items = append(items, fmt.Sprintf("%s %s %s", cursor, renderSearchBadge(results[i].kind), results[i].label))
+		}
+	}
+
+	return borderStyle.Width(width).Height(height).Render(strings.Join(items, "\n"))
+}
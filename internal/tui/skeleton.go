@@ -0,0 +1,52 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const fadeInDuration = 250 * time.Millisecond
+
+var skeletonStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("237"))
+
+// skeletonWidths gives placeholder rows an uneven, list-like silhouette.
+var skeletonWidths = []float64{0.72, 0.55, 0.83, 0.64, 0.48, 0.77, 0.6, 0.69}
+
+// renderSkeletonRows returns grey placeholder rows filling a list area of the
+// given size while its data loads.
+func (m AppModel) renderSkeletonRows(width, rows int) []string {
+	if rows < 1 {
+		rows = 1
+	}
+	if width < 10 {
+		width = 10
+	}
+
+	lines := []string{m.spinner.View() + " " + tr("Loading...")}
+	if currentTheme.plain {
+		return lines
+	}
+	for i := 1; i < rows; i++ {
+		barWidth := int(float64(width-4) * skeletonWidths[i%len(skeletonWidths)])
+		if barWidth < 4 {
+			barWidth = 4
+		}
+		lines = append(lines, skeletonStyle.Render("  "+strings.Repeat("▆", barWidth)))
+	}
+	return lines
+}
+
+// finishLoading clears the loading flag and starts the fade-in of the freshly
+// loaded content.
+func (m *AppModel) finishLoading() {
+	m.loading = false
+	m.loadedAt = time.Now()
+}
+
+// fadingIn reports whether content loaded recently enough to still be drawn
+// faint. The spinner tick keeps re-rendering, so the fade ends on its own.
+func (m AppModel) fadingIn() bool {
+	return !m.loadedAt.IsZero() && time.Since(m.loadedAt) < fadeInDuration
+}
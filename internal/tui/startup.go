@@ -0,0 +1,111 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// startViews maps the names accepted by --view to tab root views.
+var startViews = map[string]viewMode{
+	"prs":           prView,
+	"pullrequests":  prView,
+	"pull-requests": prView,
+	"branches":      branchesView,
+	"pipelines":     pipelinesView,
+	"issues":        issuesView,
+	"downloads":     downloadsView,
+	"tags":          tagsView,
+	"deployments":   deploymentsView,
+}
+
+// StartViewNames lists the values accepted by OpenAt, for flag help.
+func StartViewNames() []string {
+	names := make([]string, 0, len(startViews))
+	for name := range startViews {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// OpenAt makes the app start on a tab of repoSlug instead of the repository
+// list or home view. view is one of StartViewNames; empty means pull
+// requests.
+func (m AppModel) OpenAt(repoSlug, view string) (AppModel, error) {
+	mode := prView
+	if view != "" {
+		var ok bool
+		mode, ok = startViews[strings.ToLower(strings.TrimSpace(view))]
+		if !ok {
+			return m, fmt.Errorf("unknown view %q (expected one of %s)", view, strings.Join(StartViewNames(), ", "))
+		}
+	}
+
+	m.selectedRepo = repoSlug
+	m.selectedRepoSlug = repoSlug
+	m.startRepo = repoSlug
+	m.homePending = 0
+	m.startCmd = openTab(&m, tabIndex(mode))
+	return m, nil
+}
+
+// FocusBranch makes the tab opened by OpenAt start with branch selected: the
+// branch itself, or the first pull request or pipeline listed for it.
+func (m AppModel) FocusBranch(branch string) AppModel {
+	m.focusBranch = branch
+	return m
+}
+
+// focusStartBranch moves the cursor of the loaded list to the branch given to
+// FocusBranch. It applies to the first list loaded only.
+func focusStartBranch(m *AppModel) {
+	if m.focusBranch == "" {
+		return
+	}
+	switch m.currentView {
+	case prView:
+		for i, pr := range m.getFilteredPRs() {
+			if pr.SourceBranch == m.focusBranch {
+				m.prCursor = i
+				break
+			}
+		}
+	case branchesView:
+		for i, branch := range m.getFilteredBranches() {
+			if branch.Name == m.focusBranch {
+				m.branchCursor = i
+				break
+			}
+		}
+	case pipelinesView:
+		for i, pipeline := range m.getFilteredPipelines() {
+			if pipeline.BranchName == m.focusBranch {
+				m.pipelineCursor = i
+				break
+			}
+		}
+	}
+	m.focusBranch = ""
+}
+
+// selectStartRepo points the repository cursor at the repository given to
+// OpenAt once it shows up in the loaded list, and reports a typo in its slug
+// once the whole list is in.
+func selectStartRepo(m *AppModel, complete bool) {
+	if m.startRepo == "" {
+		return
+	}
+	for i, repo := range m.repositories {
+		if repo.Slug == m.startRepo {
+			m.repoCursor = i
+			m.selectedRepo = repo.Name
+			m.startRepo = ""
+			return
+		}
+	}
+	if complete {
+		m.message = fmt.Sprintf("Repository %s not found in workspace %s", m.startRepo, m.workspace)
+		m.startRepo = ""
+	}
+}
@@ -0,0 +1,298 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"bitbucket-cli/internal/bitbucket"
+	"bitbucket-cli/internal/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type stepCommandsLoadedMsg struct {
+	stepUUID string
+	steps    []domain.PipelineStep
+	log      string
+	err      error
+}
+
+// loadStepCommands fetches the steps again, for the step's current state,
+// together with its log. A step that has not started has no log yet.
+func loadStepCommands(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug, pipelineUUID, stepUUID string) tea.Cmd {
+	return func() tea.Msg {
+		steps, err := client.ListPipelineSteps(ctx, repoSlug, pipelineUUID)
+		if err != nil {
+			return stepCommandsLoadedMsg{stepUUID: stepUUID, err: err}
+		}
+		log, err := client.GetPipelineStepLog(ctx, repoSlug, pipelineUUID, stepUUID)
+		if errors.Is(err, bitbucket.ErrNotFound) {
+			err = nil
+		}
+		return stepCommandsLoadedMsg{stepUUID: stepUUID, steps: steps, log: log, err: err}
+	}
+}
+
+// openStepCommands lists the script commands of the highlighted step.
+func openStepCommands(m *AppModel) tea.Cmd {
+	step := m.pipelineSteps[m.pipelineStepCursor]
+	if step.UUID == "" {
+		m.message = "Selected step has no UUID"
+		return nil
+	}
+	m.selectedStepName = step.Name
+	if m.selectedStepName == "" {
+		m.selectedStepName = step.UUID
+	}
+	m.currentView = stepCommandsView
+	m.commandsStep = step
+	m.commandLines = locateStepCommands(nil, step.Commands)
+	m.commandCursor = 0
+	ctx := newViewContext(m)
+	m.loading = true
+	m.pipelineStepLog = ""
+	m.pipelineStepLogLines = nil
+	m.logColorLines = nil
+	m.logMarks = nil
+	m.pipelineStepLogCursor = 0
+	m.followStepUUID = ""
+	clearLogSearch(m)
+	return forView(m, loadStepCommands(ctx, m.client, m.selectedRepoSlug, m.selectedPipelineUUID, step.UUID))
+}
+
+func applyStepCommands(m *AppModel, msg stepCommandsLoadedMsg) {
+	if msg.stepUUID != m.commandsStep.UUID {
+		return
+	}
+	m.finishLoading()
+	if msg.err != nil {
+		m.message = fmt.Sprintf("Error loading step commands: %s", describeError(msg.err))
+		return
+	}
+	m.pipelineSteps = msg.steps
+	m.pipelineStepCursor = keepCursor(m.pipelineStepCursor, len(msg.steps))
+	for _, step := range msg.steps {
+		if step.UUID == msg.stepUUID {
+			m.commandsStep = step
+		}
+	}
+	m.pipelineStepLog = msg.log
+	m.pipelineStepLogLines, m.logColorLines = logLines(msg.log)
+	if strings.TrimSpace(msg.log) == "" {
+		m.pipelineStepLogLines, m.logColorLines = nil, nil
+	}
+	m.logMarks = markLogLines(m.pipelineStepLogLines)
+	m.commandLines = locateStepCommands(m.pipelineStepLogLines, m.commandsStep.Commands)
+	m.commandCursor = keepCursor(m.commandCursor, len(m.commandsStep.Commands))
+}
+
+// closeStepCommands goes back to the steps of the pipeline.
+func closeStepCommands(m *AppModel) {
+	m.currentView = pipelineStepsView
+	m.commandsStep = domain.PipelineStep{}
+	m.commandLines = nil
+	m.commandCursor = 0
+	m.pipelineStepLog = ""
+	m.pipelineStepLogLines = nil
+	m.logColorLines = nil
+	m.logMarks = nil
+	m.pipelineStepLogCursor = 0
+}
+
+// openCommandLog shows the step log from the line the highlighted command
+// starts at. Going back returns to the commands.
+func openCommandLog(m *AppModel) {
+	if len(m.commandLines) == 0 {
+		return
+	}
+	line := m.commandLines[m.commandCursor]
+	if line < 0 {
+		m.message = "The command did not run"
+		return
+	}
+	m.currentView = pipelineStepLogView
+	m.pipelineStepLogCursor = line
+	m.logHOffset = 0
+	clearLogSearch(m)
+}
+
+// locateStepCommands finds the line each command starts at in a step log,
+// where Bitbucket echoes it as "+ <command>", or -1 for commands that did not
+// run. Commands run in order, so each is looked for after the previous one.
+func locateStepCommands(lines []string, commands []domain.StepCommand) []int {
+	located := make([]int, len(commands))
+	next := 0
+	for i, command := range commands {
+		located[i] = -1
+		echo := "+ " + strings.TrimSpace(strings.SplitN(strings.TrimSpace(command.Command), "\n", 2)[0])
+		for j := next; j < len(lines); j++ {
+			if strings.HasPrefix(strings.TrimSpace(lines[j]), echo) {
+				located[i] = j
+				next = j + 1
+				break
+			}
+		}
+	}
+	return located
+}
+
+// stepCommandStatus tells how the command at index went. The API has no state
+// per command, so it follows from the log and the state of the step: a step
+// stops at the first failing command, so the commands before the last one
+// that started passed and the last one shares the result of the step.
+func (m AppModel) stepCommandStatus(index int) string {
+	step := m.commandsStep
+	if strings.EqualFold(step.Result, "successful") {
+		return formatPipelineResult(step.Result)
+	}
+	last := -1
+	for i, line := range m.commandLines {
+		if line >= 0 {
+			last = i
+		}
+	}
+	switch {
+	case index < last:
+		return formatPipelineResult("successful")
+	case index == last && !isStepDone(step):
+		return formatPipelineState("in_progress")
+	case index == last:
+		return formatPipelineResult(step.Result)
+	case isStepDone(step):
+		return currentTheme.badge(currentTheme.muted, "○", "NOT RUN")
+	default:
+		return formatPipelineState("pending")
+	}
+}
+
+// commandOutputLines counts the log lines from the command at index to the
+// next command that ran, or to the end of the log.
+func (m AppModel) commandOutputLines(index int) int {
+	start := m.commandLines[index]
+	if start < 0 {
+		return 0
+	}
+	end := len(m.pipelineStepLogLines)
+	for _, line := range m.commandLines[index+1:] {
+		if line >= 0 {
+			end = line
+			break
+		}
+	}
+	return end - start - 1
+}
+
+// commandTimingNote says why the commands have no duration: Bitbucket logs
+// carry no timestamps, so only the step as a whole is timed.
+func (m AppModel) commandTimingNote() string {
+	duration := pipelineDuration(m.commandsStep.StartedOn, m.commandsStep.CompletedOn)
+	if duration == "" {
+		return tr("No duration per command: Bitbucket does not time them")
+	}
+	if !isStepDone(m.commandsStep) {
+		return trf("No duration per command: Bitbucket does not time them; the step has run for %s", duration)
+	}
+	return trf("No duration per command: Bitbucket does not time them; the step took %s", duration)
+}
+
+func (m AppModel) renderStepCommandsPane() string {
+	showRepoPane := m.currentView == noSelection || m.activePane == repoPane
+
+	paneWidth := m.width - 4
+	if showRepoPane {
+		repoPaneWidth := (m.width - 10) / 3
+		if repoPaneWidth < 20 {
+			repoPaneWidth = 20
+		}
+		paneWidth = m.width - repoPaneWidth - 10
+	}
+	if paneWidth < 30 {
+		paneWidth = 30
+	}
+
+	availableHeight := m.height - 6
+	if availableHeight < 5 {
+		availableHeight = 5
+	}
+
+	title := tr("Step Commands")
+	if m.selectedRepo != "" {
+		title = trf("Step Commands (%s)", m.selectedRepo)
+	}
+	if m.selectedPipelineRef != "" {
+		title = fmt.Sprintf("%s %s", title, m.selectedPipelineRef)
+	}
+	if m.selectedStepName != "" {
+		title = fmt.Sprintf("%s - %s", title, m.selectedStepName)
+	}
+	if !showRepoPane {
+		title = trf("%s (esc: back)", title)
+	}
+
+	if m.activePane == branchPane {
+		title = activePaneStyle.Render(title)
+	} else {
+		title = inactivePaneStyle.Render(title)
+	}
+
+	var items []string
+	items = append(items, m.renderRightTabs())
+	items = append(items, title)
+	items = append(items, "")
+
+	commands := m.commandsStep.Commands
+	if m.loading && m.currentView == stepCommandsView {
+		items = append(items, m.renderSkeletonRows(paneWidth, availableHeight-3)...)
+	} else if len(commands) == 0 {
+		items = append(items, tr("No script commands"))
+	} else {
+		commandWidth := paneWidth - 30
+		if commandWidth < 10 {
+			commandWidth = 10
+		}
+		// Two rows are kept for the note on timing below the list.
+		start, end := m.calculateWindow(m.commandCursor, len(commands), availableHeight-5)
+		for i := start; i < end; i++ {
+			command := commands[i]
+			cursor := " "
+			if m.activePane == branchPane && i == m.commandCursor {
+				cursor = cursorStyle.Render(">")
+			}
+
+			name := command.Name
+			if name == "" {
+				name = command.Command
+			}
+			name = strings.Join(strings.Fields(name), " ")
+			line := fmt.Sprintf("%s %s %s", cursor, m.stepCommandStatus(i), lipgloss.NewStyle().MaxWidth(commandWidth).Render(name))
+			if m.commandLines[i] >= 0 {
+				output := trf("(%d lines of output)", m.commandOutputLines(i))
+				if m.commandOutputLines(i) == 1 {
+					output = tr("(1 line of output)")
+				}
+				line = fmt.Sprintf("%s %s", line, inactivePaneStyle.Render(output))
+			}
+			items = append(items, line)
+		}
+
+		if start > 0 {
+			items[2] = inactivePaneStyle.Render(moreAbove())
+		}
+		if end < len(commands) {
+			items = append(items, inactivePaneStyle.Render(moreBelow()))
+		}
+		items = append(items, "", inactivePaneStyle.Render(m.commandTimingNote()))
+	}
+
+	content := strings.Join(items, "\n")
+	style := lipgloss.NewStyle().
+		Width(paneWidth).
+		Height(availableHeight).
+		Padding(0, 1).
+		Faint(m.fadingIn())
+
+	return style.Render(content)
+}
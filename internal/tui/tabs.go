@@ -0,0 +1,281 @@
+package tui
+
+import (
+	"time"
+
+	"bitbucket-cli/internal/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// rightTab describes one tab of the right pane. root is the view the tab
+// opens on, views lists every view (including drill-downs) that keeps the
+// tab highlighted, and open resets the tab's state and starts loading it.
+// state points at the tab's cursor and filter, which are kept while other
+// tabs are shown; filter is nil for tabs without one.
+type rightTab struct {
+	title string
+	root  viewMode
+	views []viewMode
+	open  func(m *AppModel) tea.Cmd
+	state func(m *AppModel) (cursor *int, filter *string)
+}
+
+// tabState is the cursor and filter a tab had when another tab was opened,
+// restored when it opens again on the same repository.
+type tabState struct {
+	repoSlug string
+	cursor   int
+	filter   string
+}
+
+var rightTabs = []rightTab{
+	{
+		title: "Pull Requests",
+		root:  prView,
+		views: []viewMode{prView, prDetailView, prCommitsView, prDiffView, prCommentsView},
+		open: func(m *AppModel) tea.Cmd {
+			m.pullRequests = nil
+			m.prFilterQuery = ""
+			m.prCursor = 0
+			m.openPullRequest = domain.PullRequest{}
+			m.prDiffPR = domain.PullRequest{}
+			m.prDiffLines = nil
+			m.prComments = nil
+			m.prTasks = nil
+			slug := m.selectedRepoSlug
+			// Prefetching only fetches open pull requests.
+			if prs, ok := takePrefetched(m.prefetch.pullRequests, slug); ok && m.pullRequestState() == "OPEN" {
+				return func() tea.Msg { return pullRequestsLoadedMsg{repoSlug: slug, prs: prs} }
+			}
+			m.loading = !showSnapshot(m, m.pullRequestSnapshotName(m.selectedRepoSlug), &m.pullRequests)
+			return loadPullRequests(m.viewCtx, m.client, m.selectedRepoSlug, m.pullRequestState())
+		},
+		state: func(m *AppModel) (*int, *string) { return &m.prCursor, &m.prFilterQuery },
+	},
+	{
+		title: "Branches",
+		root:  branchesView,
+		views: []viewMode{branchesView, branchCommitsView, branchCompareView},
+		open: func(m *AppModel) tea.Cmd {
+			m.branches = nil
+			m.branchFilterQuery = ""
+			m.branchCursor = 0
+			m.compareHead = ""
+			slug := m.selectedRepoSlug
+			if branches, ok := takePrefetched(m.prefetch.branches, slug); ok {
+				return func() tea.Msg { return branchesLoadedMsg{repoSlug: slug, branches: branches} }
+			}
+			m.loading = !showSnapshot(m, m.snapshotName("branches", m.selectedRepoSlug), &m.branches)
+			return loadBranches(m.viewCtx, m.client, m.selectedRepoSlug)
+		},
+		state: func(m *AppModel) (*int, *string) { return &m.branchCursor, &m.branchFilterQuery },
+	},
+	{
+		title: "Pipelines",
+		root:  pipelinesView,
+		views: []viewMode{pipelinesView, pipelineStepsView, pipelineStepLogView, stepCommandsView, artifactsView, schedulesView},
+		open: func(m *AppModel) tea.Cmd {
+			m.pipelines = nil
+			m.pipelineFilterQuery = ""
+			m.pipelineCursor = 0
+			slug := m.selectedRepoSlug
+			if pipelines, ok := takePrefetched(m.prefetch.pipelines, slug); ok {
+				return func() tea.Msg { return pipelinesLoadedMsg{repoSlug: slug, pipelines: pipelines} }
+			}
+			m.loading = !showSnapshot(m, m.snapshotName("pipelines", m.selectedRepoSlug), &m.pipelines)
+			return loadPipelines(m.viewCtx, m.client, m.selectedRepoSlug)
+		},
+		state: func(m *AppModel) (*int, *string) { return &m.pipelineCursor, &m.pipelineFilterQuery },
+	},
+	{
+		title: "Issues",
+		root:  issuesView,
+		views: []viewMode{issuesView, issueDetailView},
+		open: func(m *AppModel) tea.Cmd {
+			m.issues = nil
+			m.issueFilterQuery = ""
+			m.issueCursor = 0
+			m.loading = !showSnapshot(m, m.snapshotName("issues", m.selectedRepoSlug), &m.issues)
+			return loadIssues(m.viewCtx, m.client, m.selectedRepoSlug)
+		},
+		state: func(m *AppModel) (*int, *string) { return &m.issueCursor, &m.issueFilterQuery },
+	},
+	{
+		title: "Downloads",
+		root:  downloadsView,
+		views: []viewMode{downloadsView},
+		open: func(m *AppModel) tea.Cmd {
+			m.downloads = nil
+			m.downloadFilterQuery = ""
+			m.downloadCursor = 0
+			m.loading = !showSnapshot(m, m.snapshotName("downloads", m.selectedRepoSlug), &m.downloads)
+			return loadDownloads(m.viewCtx, m.client, m.selectedRepoSlug)
+		},
+		state: func(m *AppModel) (*int, *string) { return &m.downloadCursor, &m.downloadFilterQuery },
+	},
+	{
+		title: "Tags",
+		root:  tagsView,
+		views: []viewMode{tagsView},
+		open: func(m *AppModel) tea.Cmd {
+			m.tags = nil
+			m.tagFilterQuery = ""
+			m.tagCursor = 0
+			m.loading = !showSnapshot(m, m.snapshotName("tags", m.selectedRepoSlug), &m.tags)
+			return loadTags(m.viewCtx, m.client, m.selectedRepoSlug)
+		},
+		state: func(m *AppModel) (*int, *string) { return &m.tagCursor, &m.tagFilterQuery },
+	},
+	{
+		title: "Deployments",
+		root:  deploymentsView,
+		views: []viewMode{deploymentsView},
+		open: func(m *AppModel) tea.Cmd {
+			m.environments = nil
+			m.environmentCursor = 0
+			m.loading = !showSnapshot(m, m.snapshotName("deployments", m.selectedRepoSlug), &m.environments)
+			return loadDeployments(m.viewCtx, m.client, m.selectedRepoSlug)
+		},
+		state: func(m *AppModel) (*int, *string) { return &m.environmentCursor, nil },
+	},
+}
+
+// tabIndex returns the index of the tab owning view, or -1.
+func tabIndex(view viewMode) int {
+	for i, tab := range rightTabs {
+		for _, v := range tab.views {
+			if v == view {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// isTabRoot reports whether view is the top-level view of a tab, where tab
+// switching is allowed.
+func isTabRoot(view viewMode) bool {
+	for _, tab := range rightTabs {
+		if tab.root == view {
+			return true
+		}
+	}
+	return false
+}
+
+func openTab(m *AppModel, index int) tea.Cmd {
+	if index < 0 || index >= len(rightTabs) || m.selectedRepoSlug == "" {
+		return nil
+	}
+	if current := tabIndex(m.currentView); current >= 0 {
+		saveTabState(m, current)
+	}
+	m.activePane = branchPane
+	m.currentView = rightTabs[index].root
+	m.cachedAt = time.Time{}
+	m.cacheOffline = false
+	newViewContext(m)
+	cmd := rightTabs[index].open(m)
+	restoreTabState(m, index)
+	return tea.Batch(forView(m, cmd), loadLocalBranch(m))
+}
+
+func saveTabState(m *AppModel, index int) {
+	cursor, filter := rightTabs[index].state(m)
+	state := tabState{repoSlug: m.selectedRepoSlug, cursor: *cursor}
+	if filter != nil {
+		state.filter = *filter
+	}
+	if m.tabStates == nil {
+		m.tabStates = make(map[viewMode]tabState)
+	}
+	m.tabStates[rightTabs[index].root] = state
+}
+
+// restoreTabState puts back the cursor and filter a tab had on the selected
+// repository. The reload keeps the cursor where it was, within the new list.
+func restoreTabState(m *AppModel, index int) {
+	state, ok := m.tabStates[rightTabs[index].root]
+	if !ok || state.repoSlug != m.selectedRepoSlug {
+		return
+	}
+	cursor, filter := rightTabs[index].state(m)
+	*cursor = state.cursor
+	if filter != nil {
+		*filter = state.filter
+	}
+	m.quietReload = true
+}
+
+// cycleTab moves delta tabs from the current one, wrapping around.
+func cycleTab(m *AppModel, delta int) tea.Cmd {
+	current := tabIndex(m.currentView)
+	if current < 0 {
+		return nil
+	}
+	next := (current + delta + len(rightTabs)) % len(rightTabs)
+	return openTab(m, next)
+}
+
+// openTabForSelectedRepo selects the highlighted repository and opens the
+// given tab for it.
+func openTabForSelectedRepo(m *AppModel, index int) tea.Cmd {
+	repos := m.getFilteredRepos()
+	if len(repos) == 0 || index < 0 || index >= len(rightTabs) {
+		return nil
+	}
+	repo := repos[m.repoCursor]
+	m.selectedRepo = repo.Name
+	m.selectedRepoSlug = repo.Slug
+	return openTab(m, index)
+}
+
+// selectRepository makes slug the selected repository, for jumps to an item
+// in another repository. The lists of the tabs not reopened by the jump still
+// belong to the previous one, so the pipelines are dropped and load again when
+// their tab is shown.
+func selectRepository(m *AppModel, slug string) {
+	m.selectedRepoSlug = slug
+	m.selectedRepo = slug
+	for _, repo := range m.repositories {
+		if repo.Slug == slug {
+			m.selectedRepo = repo.Name
+		}
+	}
+	m.pipelines = nil
+	m.pipelineCursor = 0
+	m.pipelineFilterQuery = ""
+}
+
+func (m AppModel) renderRightTabs() string {
+	baseTab := lipgloss.NewStyle().Padding(0, 2)
+
+	activeTab := baseTab.
+		Foreground(lipgloss.Color(currentTheme.success)).
+		Reverse(true).
+		Bold(true)
+
+	inactiveTab := baseTab.
+		Foreground(lipgloss.Color(currentTheme.muted))
+
+	active := tabIndex(m.currentView)
+	rendered := make([]string, 0, len(rightTabs))
+	for i, tab := range rightTabs {
+		title := tr(tab.title)
+		if i < 9 {
+			title = string(rune('1'+i)) + " " + title
+		}
+		if i == active && currentTheme.plain {
+			// Without colors the active tab is marked in the text.
+			rendered = append(rendered, baseTab.Render("["+title+"]"))
+		} else if i == active {
+			rendered = append(rendered, activeTab.Render(title))
+		} else {
+			rendered = append(rendered, inactiveTab.Render(title))
+		}
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, rendered...)
+}
@@ -0,0 +1,285 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"bitbucket-cli/internal/bitbucket"
+	"bitbucket-cli/internal/domain"
+	"bitbucket-cli/internal/gitrepo"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+type tagsLoadedMsg struct {
+	repoSlug string
+	tags     []domain.Tag
+	err      error
+}
+
+type tagCreatedMsg struct {
+	repoSlug string
+	tag      domain.Tag
+	target   string
+	err      error
+}
+
+type tagDeletedMsg struct {
+	repoSlug string
+	name     string
+	err      error
+}
+
+func loadTags(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug string) tea.Cmd {
+	return func() tea.Msg {
+		tags, err := client.ListTags(ctx, repoSlug)
+		return tagsLoadedMsg{repoSlug: repoSlug, tags: tags, err: err}
+	}
+}
+
+func createTag(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug, name, target string) tea.Cmd {
+	return func() tea.Msg {
+		tag, err := client.CreateTag(ctx, repoSlug, name, target)
+		return tagCreatedMsg{repoSlug: repoSlug, tag: tag, target: target, err: err}
+	}
+}
+
+func deleteTag(ctx context.Context, client bitbucket.BitbucketAPI, repoSlug, name string) tea.Cmd {
+	return func() tea.Msg {
+		err := client.DeleteTag(ctx, repoSlug, name)
+		return tagDeletedMsg{repoSlug: repoSlug, name: name, err: err}
+	}
+}
+
+// tagSignaturesMsg carries the signature status of the loaded tags that the
+// local clone has.
+type tagSignaturesMsg struct {
+	signatures map[string]gitrepo.Signature
+}
+
+// verifyTagSignatures checks the signatures of tags like
+// verifyCommitSignatures does for commits.
+func verifyTagSignatures(m *AppModel, tags []domain.Tag) tea.Cmd {
+	m.tagSignatures = nil
+	if m.localClone.Root == "" || m.selectedRepoSlug != m.localClone.Repo || len(tags) == 0 {
+		return nil
+	}
+	root := m.localClone.Root
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+	return forView(m, func() tea.Msg {
+		return tagSignaturesMsg{signatures: gitrepo.VerifyTagSignatures(root, names)}
+	})
+}
+
+func (m AppModel) getFilteredTags() []domain.Tag {
+	if m.tagFilterQuery == "" {
+		return m.tags
+	}
+
+	query := strings.ToLower(m.tagFilterQuery)
+	return cachedFilter(m.filters, "tags", query, m.tags, func(tag domain.Tag) bool {
+		return strings.Contains(strings.ToLower(tag.Name), query) || strings.Contains(strings.ToLower(tag.Message), query)
+	})
+}
+
+// startNewTag opens the prompt for a new tag, on the repository's main
+// branch unless another branch or a commit is typed.
+func startNewTag(m *AppModel) {
+	m.newTagStep = "name"
+	m.newTagName = ""
+	m.newTagTarget = ""
+	for _, repo := range m.repositories {
+		if repo.Slug == m.selectedRepoSlug {
+			m.newTagTarget = repo.Mainbranch
+		}
+	}
+}
+
+// newTagPrompt is the help line while a new tag is typed.
+func newTagPrompt(m AppModel) string {
+	if m.newTagStep == "target" {
+		return trf("Tag %s on branch or commit: %s  (esc: cancel, enter: create)", m.newTagName, m.newTagTarget)
+	}
+	return trf("New tag name: %s  (esc: cancel, enter: next)", m.newTagName)
+}
+
+// handleNewTagKey edits the name, then the target of the new tag, and
+// creates it on the second enter.
+func handleNewTagKey(m AppModel, msg tea.KeyMsg) (AppModel, tea.Cmd) {
+	field := &m.newTagName
+	if m.newTagStep == "target" {
+		field = &m.newTagTarget
+	}
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.newTagStep = ""
+	case tea.KeyEnter:
+		if m.newTagStep == "name" {
+			m.newTagName = strings.TrimSpace(m.newTagName)
+			if m.newTagName != "" {
+				m.newTagStep = "target"
+			}
+			return m, nil
+		}
+		m.newTagStep = ""
+		target := strings.TrimSpace(m.newTagTarget)
+		if target == "" {
+			return m, nil
+		}
+		m.message = fmt.Sprintf("Creating tag %s on %s...", m.newTagName, target)
+		return m, startAction(&m, createTag(m.ctx, m.client, m.selectedRepoSlug, m.newTagName, target))
+	case tea.KeyBackspace:
+		if runes := []rune(*field); len(runes) > 0 {
+			*field = string(runes[:len(runes)-1])
+		}
+	case tea.KeySpace:
+		// Tag names cannot contain spaces; commit hashes neither.
+	case tea.KeyRunes:
+		*field += string(msg.Runes)
+	}
+	return m, nil
+}
+
+// addTag adds a created tag to the top of the list, where the newest tags
+// are, and moves the cursor to it when the filter shows it.
+func addTag(m *AppModel, tag domain.Tag) {
+	m.tags = append([]domain.Tag{tag}, m.tags...)
+	for i, shown := range m.getFilteredTags() {
+		if shown.Name == tag.Name {
+			m.tagCursor = i
+		}
+	}
+}
+
+// confirmDeleteTag asks before deleting the highlighted tag.
+func confirmDeleteTag(m *AppModel) {
+	filtered := m.getFilteredTags()
+	if m.tagCursor >= len(filtered) {
+		return
+	}
+	m.deleteTagCandidate = filtered[m.tagCursor].Name
+	m.message = fmt.Sprintf("Delete tag %s? (y/n)", m.deleteTagCandidate)
+}
+
+func handleDeleteTagKey(m AppModel, msg tea.KeyMsg) (AppModel, tea.Cmd) {
+	name := m.deleteTagCandidate
+	m.deleteTagCandidate = ""
+	if msg.String() != "y" {
+		return m, nil
+	}
+	m.message = fmt.Sprintf("Deleting tag %s...", name)
+	return m, startAction(&m, deleteTag(m.ctx, m.client, m.selectedRepoSlug, name))
+}
+
+// removeTag drops a deleted tag from the list.
+func removeTag(m *AppModel, name string) {
+	m.tags = slices.DeleteFunc(m.tags, func(tag domain.Tag) bool {
+		return tag.Name == name
+	})
+	m.tagCursor = keepCursor(m.tagCursor, len(m.getFilteredTags()))
+}
+
+func (m AppModel) renderTagsPane() string {
+	showRepoPane := m.currentView == noSelection || m.activePane == repoPane
+
+	paneWidth := m.width - 4
+	if showRepoPane {
+		repoPaneWidth := (m.width - 10) / 3
+		if repoPaneWidth < 20 {
+			repoPaneWidth = 20
+		}
+		paneWidth = m.width - repoPaneWidth - 10
+	}
+	if paneWidth < 30 {
+		paneWidth = 30
+	}
+
+	availableHeight := m.height - 6
+	if availableHeight < 5 {
+		availableHeight = 5
+	}
+
+	title := tr("Tags")
+	if m.selectedRepo != "" {
+		title = fmt.Sprintf("(%s)", m.selectedRepo)
+	}
+	if m.tagFilterQuery != "" {
+		title = fmt.Sprintf("[/%s]", m.tagFilterQuery)
+	}
+	title = m.withCacheLabel(title)
+	if !showRepoPane {
+		title = trf("%s (esc: back)", title)
+	}
+
+	if m.activePane == branchPane {
+		title = activePaneStyle.Render(title)
+	} else {
+		title = inactivePaneStyle.Render(title)
+	}
+
+	var items []string
+	items = append(items, m.renderRightTabs())
+	items = append(items, title)
+	items = append(items, "")
+
+	if m.loading && m.activePane == branchPane && m.currentView == tagsView {
+		items = append(items, m.renderSkeletonRows(paneWidth, availableHeight-3)...)
+	} else if len(m.tags) == 0 {
+		items = append(items, tr("No tags"))
+	} else {
+		filtered := m.getFilteredTags()
+		if len(filtered) == 0 {
+			items = append(items, tr("No matches"))
+		} else {
+			start, end := m.calculateWindow(m.tagCursor, len(filtered), availableHeight-3)
+
+			hashStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("220"))
+			for i := start; i < end; i++ {
+				tag := filtered[i]
+				cursor := " "
+				if m.activePane == branchPane && i == m.tagCursor {
+					cursor = cursorStyle.Render(">")
+				}
+				hash := tag.Target.Hash
+				if len(hash) > 8 {
+					hash = hash[:8]
+				}
+				date := tag.Date
+				if date == "" {
+					date = tag.Target.Date
+				}
+				line := fmt.Sprintf("%s %s%-30s %s  %s", cursor, currentTheme.icon(iconTag), tag.Name, hashStyle.Render(fmt.Sprintf("%-8s", hash)), inactivePaneStyle.Render(shortTimestamp(date)))
+				if badge := signatureBadge(m.tagSignatures[tag.Name]); badge != "" {
+					line = fmt.Sprintf("%s  %s", line, badge)
+				}
+				if message, _, _ := strings.Cut(tag.Message, "\n"); message != "" {
+					line = fmt.Sprintf("%s  %s", line, message)
+				}
+				items = append(items, ansi.Truncate(line, paneWidth-2, "…"))
+			}
+
+			if start > 0 {
+				items[2] = inactivePaneStyle.Render(moreAbove())
+			}
+			if end < len(filtered) {
+				items = append(items, inactivePaneStyle.Render(moreBelow()))
+			}
+		}
+	}
+
+	content := strings.Join(items, "\n")
+	style := lipgloss.NewStyle().
+		Width(paneWidth).
+		Height(availableHeight).
+		Padding(0, 1).
+		Faint(m.fadingIn())
+
+	return style.Render(content)
+}
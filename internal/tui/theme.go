@@ -0,0 +1,113 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"bitbucket-cli/internal/config"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// theme holds the status colors used for badges and borders. With symbols
+// enabled every badge also carries a glyph so states never rely on color
+// alone; plain is the accessible mode, which spells states out in words.
+type theme struct {
+	success string
+	failure string
+	warning string
+	running string
+	neutral string
+	muted   string
+	// accent marks repository names and heading the section headings.
+	accent  string
+	heading string
+	symbols bool
+	nerd    bool
+	plain   bool
+}
+
+var defaultTheme = theme{
+	success: "42",
+	failure: "196",
+	warning: "214",
+	running: "220",
+	neutral: "99",
+	muted:   "241",
+	accent:  "45",
+	heading: "220",
+}
+
+// colorblindTheme uses a blue/orange palette that stays distinguishable for
+// the common forms of color vision deficiency.
+var colorblindTheme = theme{
+	success: "33",
+	failure: "208",
+	warning: "220",
+	running: "81",
+	neutral: "147",
+	muted:   "245",
+	accent:  "39",
+	heading: "220",
+	symbols: true,
+}
+
+var currentTheme = defaultTheme
+
+func themeFromConfig(cfg config.Config) theme {
+	t := defaultTheme
+	if strings.EqualFold(strings.TrimSpace(cfg.Theme), "colorblind") {
+		t = colorblindTheme
+	}
+
+	switch strings.ToLower(strings.TrimSpace(cfg.BadgeStyle)) {
+	case "symbols", "icons":
+		t.symbols = true
+	case "text":
+		t.symbols = false
+	}
+
+	t.nerd = strings.EqualFold(strings.TrimSpace(cfg.Icons), "nerd")
+
+	if cfg.Accessible {
+		t.plain, t.symbols, t.nerd = true, false, false
+	}
+
+	return t
+}
+
+// nerdGlyphs maps the plain badge symbols to their nerd-font counterparts.
+var nerdGlyphs = map[string]string{
+	"✓": "\uf00c",
+	"✗": "\uf00d",
+	"●": "\uf110",
+	"◐": "\uf017",
+	"■": "\uf04d",
+	"○": "\uf05e",
+}
+
+const (
+	iconRepo        = "\uf401"
+	iconBranch      = "\ue0a0"
+	iconPullRequest = "\uf407"
+	iconTag         = "\uf02b"
+)
+
+// icon returns glyph followed by a space when nerd-font icons are enabled.
+func (t theme) icon(glyph string) string {
+	if !t.nerd {
+		return ""
+	}
+	return glyph + " "
+}
+
+func (t theme) badge(color, symbol, text string) string {
+	text = tr(text)
+	label := fmt.Sprintf("[%s]", text)
+	if glyph, ok := nerdGlyphs[symbol]; ok && t.nerd {
+		label = fmt.Sprintf("[%s %s]", glyph, text)
+	} else if t.symbols && symbol != "" {
+		label = fmt.Sprintf("[%s %s]", symbol, text)
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render(label)
+}
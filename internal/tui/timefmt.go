@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"strings"
+
+	"bitbucket-cli/internal/config"
+)
+
+const (
+	defaultTimestampLayout = "2006-01-02 15:04"
+	twelveHourLayout       = "2006-01-02 03:04 PM"
+)
+
+// timestampLayout is the Go time layout used for timestamps in list rows.
+var timestampLayout = defaultTimestampLayout
+
+var strftimeDirectives = map[byte]string{
+	'Y': "2006",
+	'y': "06",
+	'm': "01",
+	'b': "Jan",
+	'B': "January",
+	'd': "02",
+	'e': "_2",
+	'a': "Mon",
+	'A': "Monday",
+	'H': "15",
+	'I': "03",
+	'M': "04",
+	'S': "05",
+	'p': "PM",
+	'Z': "MST",
+	'z': "-0700",
+	'%': "%",
+}
+
+func timestampLayoutFromConfig(cfg config.Config) string {
+	if format := strings.TrimSpace(cfg.TimeFormat); format != "" {
+		return strftimeToLayout(format)
+	}
+	if strings.TrimSpace(cfg.Clock) == "12" {
+		return twelveHourLayout
+	}
+	return defaultTimestampLayout
+}
+
+// strftimeToLayout converts a strftime-style format such as "%d/%m %H:%M"
+// into a Go time layout. Unknown directives are kept verbatim.
+func strftimeToLayout(format string) string {
+	var b strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' || i == len(format)-1 {
+			b.WriteByte(format[i])
+			continue
+		}
+		if layout, ok := strftimeDirectives[format[i+1]]; ok {
+			b.WriteString(layout)
+		} else {
+			b.WriteString(format[i : i+2])
+		}
+		i++
+	}
+	return b.String()
+}
@@ -0,0 +1,118 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"bitbucket-cli/internal/domain"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// meLabel replaces the signed-in user's name wherever an author or approver
+// is shown.
+const meLabel = "me"
+
+// authorLabel returns "me" for the signed-in user and name otherwise. The API
+// only gives display names in these places, so that is what is compared.
+func authorLabel(user domain.User, name string) string {
+	if user.DisplayName != "" && strings.TrimSpace(name) == user.DisplayName {
+		return meLabel
+	}
+	return name
+}
+
+// userLabel is authorLabel for pull request authors and participants, whose
+// UUID the API gives, so a colleague with the same name is not taken for
+// the signed-in user.
+func userLabel(user domain.User, uuid, name string) string {
+	if user.UUID != "" && uuid == user.UUID {
+		return meLabel
+	}
+	return name
+}
+
+// approverLabels lists who approved pr, the signed-in user as "me".
+func approverLabels(user domain.User, pr domain.PullRequest) []string {
+	var labels []string
+	for _, participant := range pr.Participants {
+		if participant.Approved && strings.TrimSpace(participant.Name) != "" {
+			labels = append(labels, userLabel(user, participant.UUID, strings.TrimSpace(participant.Name)))
+		}
+	}
+	return labels
+}
+
+// avatar renders the initials of name on a background picked from name, the
+// same color its approver label gets, so rows by one person are easy to spot.
+func avatar(name string) string {
+	return lipgloss.NewStyle().
+		Foreground(lipgloss.Color("0")).
+		Background(lipgloss.Color(approverColor(name))).
+		Bold(true).
+		Render(initials(name))
+}
+
+// initials returns two letters for name: the first letters of its first and
+// last words, or the first two letters of a single word.
+func initials(name string) string {
+	var words [][]rune
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		words = append(words, []rune(word))
+	}
+
+	var letters []rune
+	switch {
+	case len(words) == 0:
+		return "??"
+	case len(words) == 1 && len(words[0]) == 1:
+		letters = []rune{words[0][0], ' '}
+	case len(words) == 1:
+		letters = words[0][:2]
+	default:
+		letters = []rune{words[0][0], words[len(words)-1][0]}
+	}
+	return strings.ToUpper(string(letters))
+}
+
+// applyApproval updates pr after the signed-in user approved or unapproved
+// it, keeping the approval count and approver names in step. Whether the user
+// had approved is read from their participant entry, found by UUID, so a
+// colleague's approval under the same name is left alone.
+func applyApproval(pr *domain.PullRequest, user domain.User, approved bool) {
+	name := user.DisplayName
+	if name == "" {
+		name = meLabel
+	}
+	pr.Participants = slices.Clone(pr.Participants)
+	index := slices.IndexFunc(pr.Participants, func(participant domain.Participant) bool {
+		return user.UUID != "" && participant.UUID == user.UUID
+	})
+	wasApproved := index >= 0 && pr.Participants[index].Approved
+	if index >= 0 && strings.TrimSpace(pr.Participants[index].Name) != "" {
+		name = strings.TrimSpace(pr.Participants[index].Name)
+	}
+
+	switch {
+	case approved && !wasApproved:
+		pr.ApproverNames = append(slices.Clone(pr.ApproverNames), name)
+		pr.Approvals++
+	case !approved && wasApproved:
+		if i := slices.Index(pr.ApproverNames, name); i >= 0 {
+			pr.ApproverNames = slices.Delete(slices.Clone(pr.ApproverNames), i, i+1)
+		}
+		if pr.Approvals > 0 {
+			pr.Approvals--
+		}
+	}
+	pr.Approved = pr.Approvals > 0
+
+	if index >= 0 {
+		pr.Participants[index].Approved = approved
+	} else if approved {
+		pr.Participants = append(pr.Participants, domain.Participant{Name: name, UUID: user.UUID, Approved: true})
+	}
+}
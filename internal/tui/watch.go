@@ -0,0 +1,238 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"bitbucket-cli/internal/bitbucket"
+	"bitbucket-cli/internal/config"
+	"bitbucket-cli/internal/domain"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type watchPollTickMsg struct{}
+
+type watchPipelineLoadedMsg struct {
+	key      string
+	repoSlug string
+	pipeline domain.Pipeline
+	err      error
+}
+
+type watchPullRequestLoadedMsg struct {
+	key string
+	pr  domain.PullRequest
+	err error
+}
+
+func watchKey(item config.WatchItem) string {
+	return fmt.Sprintf("%s/%s/%d", item.Kind, item.RepoSlug, item.ID)
+}
+
+func pollWatchList(client bitbucket.BitbucketAPI) tea.Cmd {
+	return tea.Tick(pollInterval(client), func(time.Time) tea.Msg {
+		return watchPollTickMsg{}
+	})
+}
+
+func refreshWatchItem(ctx context.Context, client bitbucket.BitbucketAPI, item config.WatchItem) tea.Cmd {
+	key := watchKey(item)
+	if item.Kind == config.WatchPipeline {
+		return func() tea.Msg {
+			pipeline, err := client.GetPipeline(ctx, item.RepoSlug, item.UUID)
+			return watchPipelineLoadedMsg{key: key, repoSlug: item.RepoSlug, pipeline: pipeline, err: err}
+		}
+	}
+	return func() tea.Msg {
+		pr, err := client.GetPullRequest(ctx, item.RepoSlug, item.ID)
+		return watchPullRequestLoadedMsg{key: key, pr: pr, err: err}
+	}
+}
+
+func refreshWatchList(m AppModel) tea.Cmd {
+	cmds := make([]tea.Cmd, 0, len(m.watchItems))
+	for _, item := range m.watchItems {
+		cmds = append(cmds, refreshWatchItem(bitbucket.WithoutCache(m.ctx), m.client, item))
+	}
+	return tea.Batch(cmds...)
+}
+
+// startWatchPolling refreshes every pinned item and keeps a single poll loop
+// running while anything is pinned.
+func startWatchPolling(m *AppModel) tea.Cmd {
+	if len(m.watchItems) == 0 || m.watchPolling {
+		return nil
+	}
+	m.watchPolling = true
+	return tea.Batch(refreshWatchList(*m), pollWatchList(m.client))
+}
+
+// selectedWatchItem builds the watch entry for the highlighted PR or pipeline.
+func (m AppModel) selectedWatchItem() (config.WatchItem, bool) {
+	if m.activePane != branchPane || m.selectedRepoSlug == "" {
+		return config.WatchItem{}, false
+	}
+
+	switch m.currentView {
+	case prView:
+		filtered := m.getFilteredPRs()
+		if m.prCursor >= len(filtered) {
+			return config.WatchItem{}, false
+		}
+		pr := filtered[m.prCursor]
+		return config.WatchItem{
+			Kind:     config.WatchPullRequest,
+			RepoSlug: m.selectedRepoSlug,
+			ID:       pr.ID,
+			Label:    pr.Title,
+		}, true
+	case pipelinesView:
+		filtered := m.getFilteredPipelines()
+		if m.pipelineCursor >= len(filtered) {
+			return config.WatchItem{}, false
+		}
+		pipeline := filtered[m.pipelineCursor]
+		return config.WatchItem{
+			Kind:     config.WatchPipeline,
+			RepoSlug: m.selectedRepoSlug,
+			ID:       pipeline.BuildNumber,
+			UUID:     pipeline.UUID,
+			Label:    formatPipelineBranch(pipeline.BranchName),
+		}, true
+	}
+	return config.WatchItem{}, false
+}
+
+// toggleWatch pins or unpins the highlighted item and persists the list.
+func toggleWatch(m *AppModel, item config.WatchItem) tea.Cmd {
+	key := watchKey(item)
+	for i, existing := range m.watchItems {
+		if watchKey(existing) == key {
+			m.watchItems = append(m.watchItems[:i:i], m.watchItems[i+1:]...)
+			if m.watchCursor >= len(m.watchItems) && m.watchCursor > 0 {
+				m.watchCursor--
+			}
+			m.message = fmt.Sprintf("Unpinned %s", watchItemRef(item))
+			saveWatchList(m)
+			return nil
+		}
+	}
+
+	m.watchItems = append(m.watchItems, item)
+	m.message = fmt.Sprintf("Pinned %s to watch list", watchItemRef(item))
+	saveWatchList(m)
+	if m.watchPolling {
+		return refreshWatchItem(m.ctx, m.client, item)
+	}
+	return startWatchPolling(m)
+}
+
+func saveWatchList(m *AppModel) {
+	if err := config.SaveWatchList(m.workspace, m.watchItems); err != nil {
+		m.message = fmt.Sprintf("Error saving watch list: %v", err)
+	}
+}
+
+func watchItemRef(item config.WatchItem) string {
+	if item.Kind == config.WatchPipeline {
+		return fmt.Sprintf("%s pipeline #%d", item.RepoSlug, item.ID)
+	}
+	return fmt.Sprintf("%s PR #%d", item.RepoSlug, item.ID)
+}
+
+// openWatchedItem jumps from the dashboard to the pinned item in its repo.
+func openWatchedItem(m *AppModel) tea.Cmd {
+	if m.watchCursor >= len(m.watchItems) {
+		return nil
+	}
+	item := m.watchItems[m.watchCursor]
+	selectRepository(m, item.RepoSlug)
+
+	if item.Kind == config.WatchPipeline {
+		m.activePane = branchPane
+		pipeline, ok := m.watchPipelines[watchKey(item)]
+		if !ok {
+			pipeline = domain.Pipeline{UUID: item.UUID, BuildNumber: item.ID}
+		}
+		return openPipelineSteps(m, item.RepoSlug, pipeline)
+	}
+	m.focusPullRequestID = item.ID
+	return openTab(m, tabIndex(prView))
+}
+
+func (m AppModel) renderWatchPane() string {
+	paneWidth := m.width - 4
+	if paneWidth < 30 {
+		paneWidth = 30
+	}
+
+	availableHeight := m.height - 6
+	if availableHeight < 5 {
+		availableHeight = 5
+	}
+
+	items := []string{activePaneStyle.Render(trf("Watching (%d) (esc: back)", len(m.watchItems))), ""}
+
+	if len(m.watchItems) == 0 {
+		items = append(items, tr("Nothing pinned yet. Press * on a PR or pipeline to watch it."))
+	} else {
+		start, end := m.calculateWindow(m.watchCursor, len(m.watchItems), availableHeight-2)
+		for i := start; i < end; i++ {
+			item := m.watchItems[i]
+			cursor := " "
+			if i == m.watchCursor {
+				cursor = cursorStyle.Render(">")
+			}
+			repo := lipgloss.NewStyle().Foreground(lipgloss.Color("45")).Width(20).Render(item.RepoSlug)
+			items = append(items, fmt.Sprintf("%s %s %s", cursor, repo, m.renderWatchStatus(item)))
+		}
+
+		if start > 0 {
+			items[1] = inactivePaneStyle.Render(moreAbove())
+		}
+		if end < len(m.watchItems) {
+			items = append(items, inactivePaneStyle.Render(moreBelow()))
+		}
+	}
+
+	content := strings.Join(items, "\n")
+	style := lipgloss.NewStyle().
+		Width(paneWidth).
+		Height(availableHeight).
+		Padding(0, 1)
+
+	return style.Render(content)
+}
+
+func (m AppModel) renderWatchStatus(item config.WatchItem) string {
+	key := watchKey(item)
+	if err, ok := m.watchErrors[key]; ok {
+		return fmt.Sprintf("%s %s", watchItemRef(item), messageStyle.Render(describeError(err)))
+	}
+
+	if item.Kind == config.WatchPipeline {
+		pipeline, ok := m.watchPipelines[key]
+		if !ok {
+			return fmt.Sprintf("pipeline #%d %s %s", item.ID, item.Label, m.spinner.View())
+		}
+		line := fmt.Sprintf("pipeline #%d %s %s %s", pipeline.BuildNumber, renderPipelineBranchColumn(pipeline.BranchName), formatPipelineState(pipeline.State), formatPipelineResult(pipeline.Result))
+		if duration := pipelineDuration(pipeline.StartedOn, pipeline.CompletedOn); duration != "" {
+			line = fmt.Sprintf("%s %s", line, duration)
+		}
+		return line
+	}
+
+	pr, ok := m.watchPullRequests[key]
+	if !ok {
+		return fmt.Sprintf("PR #%d %s %s", item.ID, item.Label, m.spinner.View())
+	}
+	line := fmt.Sprintf("PR #%d", pr.ID)
+	if badge := formatPRState(pr.State, pr.Draft); badge != "" {
+		line = fmt.Sprintf("%s %s", line, badge)
+	}
+	return fmt.Sprintf("%s approvals: %d %s", line, pr.Approvals, pr.Title)
+}
@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+
+	"bitbucket-cli/internal/gitrepo"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type worktreeCreatedMsg struct {
+	dir           string
+	pullRequestID int
+	err           error
+}
+
+type worktreeEditedMsg struct {
+	dir string
+	err error
+}
+
+type worktreeRemovedMsg struct {
+	dir string
+	err error
+}
+
+// reviewInWorktree checks the selected PR's source branch out in a temporary
+// worktree of the local clone and opens the editor there. Once the editor
+// exits, the user is asked whether to remove the worktree.
+func reviewInWorktree(m *AppModel) tea.Cmd {
+	filtered := m.getFilteredPRs()
+	if m.prCursor >= len(filtered) {
+		return nil
+	}
+	pr := filtered[m.prCursor]
+	if m.localClone.Root == "" || m.selectedRepoSlug != m.localClone.Repo {
+		m.message = fmt.Sprintf("Start bitbucket-cli inside a clone of %s to review in a worktree", m.selectedRepoSlug)
+		return nil
+	}
+
+	m.message = fmt.Sprintf("Fetching %s into a worktree...", pr.SourceBranch)
+	root := m.localClone.Root
+	return func() tea.Msg {
+		dir, err := os.MkdirTemp("", fmt.Sprintf("bb-pr-%d-*", pr.ID))
+		if err != nil {
+			return worktreeCreatedMsg{pullRequestID: pr.ID, err: err}
+		}
+		if err := gitrepo.AddWorktree(root, pr.SourceBranch, dir); err != nil {
+			_ = os.Remove(dir)
+			return worktreeCreatedMsg{pullRequestID: pr.ID, err: err}
+		}
+		return worktreeCreatedMsg{dir: dir, pullRequestID: pr.ID}
+	}
+}
+
+// editWorktree opens the editor on the worktree directory.
+func editWorktree(editor []string, dir string) tea.Cmd {
+	cmd, err := externalCommand(editor, ".", "nvim", "vi")
+	if err != nil {
+		return func() tea.Msg { return worktreeEditedMsg{dir: dir, err: err} }
+	}
+	cmd.Dir = dir
+	return tea.ExecProcess(cmd, func(execErr error) tea.Msg {
+		return worktreeEditedMsg{dir: dir, err: execErr}
+	})
+}
+
+func removeWorktree(root, dir string) tea.Cmd {
+	return func() tea.Msg {
+		return worktreeRemovedMsg{dir: dir, err: gitrepo.RemoveWorktree(root, dir)}
+	}
+}
+
+// handleWorktreeCleanupKey answers the removal prompt shown after the editor
+// exits: y removes the worktree, any other key keeps it.
+func handleWorktreeCleanupKey(m AppModel, msg tea.KeyMsg) (AppModel, tea.Cmd) {
+	dir := m.worktreeCleanup
+	m.worktreeCleanup = ""
+	if msg.String() == "y" {
+		m.message = "Removing worktree..."
+		return m, removeWorktree(m.localClone.Root, dir)
+	}
+	m.message = fmt.Sprintf("Kept worktree at %s (remove with: git worktree remove %s)", dir, dir)
+	return m, nil
+}
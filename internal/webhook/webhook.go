@@ -0,0 +1,110 @@
+// Package webhook receives Bitbucket webhook deliveries, so the UI can update
+// as soon as something changes instead of waiting for a refresh.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// Kinds of Event, coarse enough to map onto the views they affect.
+const (
+	KindPush        = "push"
+	KindPullRequest = "pullrequest"
+	// KindPipeline covers commit status changes, which is how Bitbucket
+	// Pipelines reports builds to webhooks.
+	KindPipeline = "pipeline"
+	KindOther    = "other"
+)
+
+// maxPayload bounds the request body read; push payloads for large pushes are
+// the biggest and stay well below it.
+const maxPayload = 5 << 20
+
+// Event is a delivery reduced to what changed and where.
+type Event struct {
+	Kind      string
+	Workspace string
+	Repo      string
+}
+
+// Handler accepts webhook POSTs and passes each event to deliver. When secret
+// is set, deliveries must carry a matching X-Hub-Signature.
+func Handler(secret string, deliver func(Event)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "webhooks are POSTed", http.StatusMethodNotAllowed)
+			return
+		}
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxPayload))
+		if err != nil {
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+			return
+		}
+		if secret != "" && !validSignature(secret, r.Header.Get("X-Hub-Signature"), body) {
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+
+		var payload struct {
+			Repository struct {
+				FullName string `json:"full_name"`
+			} `json:"repository"`
+		}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			http.Error(w, "invalid JSON", http.StatusBadRequest)
+			return
+		}
+		workspace, repo, _ := strings.Cut(payload.Repository.FullName, "/")
+		deliver(Event{Kind: eventKind(r.Header.Get("X-Event-Key")), Workspace: workspace, Repo: repo})
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// Listen serves Handler on addr, e.g. ":8088", until the process exits. It
+// returns once the address is bound, so a busy port is reported right away.
+func Listen(addr, secret string, deliver func(Event)) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	server := &http.Server{Handler: Handler(secret, deliver)}
+	go server.Serve(listener)
+	return nil
+}
+
+// eventKind maps an X-Event-Key such as "pullrequest:approved" to a Kind.
+func eventKind(key string) string {
+	switch {
+	case key == "repo:push":
+		return KindPush
+	case strings.HasPrefix(key, "pullrequest:"):
+		return KindPullRequest
+	case strings.HasPrefix(key, "repo:commit_status_"):
+		return KindPipeline
+	default:
+		return KindOther
+	}
+}
+
+// validSignature checks the "sha256=<hex>" HMAC Bitbucket sends for webhooks
+// with a secret.
+func validSignature(secret, header string, body []byte) bool {
+	digest, ok := strings.CutPrefix(header, "sha256=")
+	if !ok {
+		return false
+	}
+	expected, err := hex.DecodeString(digest)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
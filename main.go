@@ -1,16 +1,58 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
+	"bitbucket-cli/internal/bitbucket"
+	"bitbucket-cli/internal/bitbucket/fake"
+	"bitbucket-cli/internal/cli"
 	"bitbucket-cli/internal/config"
+	"bitbucket-cli/internal/gitrepo"
 	"bitbucket-cli/internal/tui"
+	"bitbucket-cli/internal/webhook"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 func main() {
+	inline := flag.Bool("inline", false, "run without the alternate screen so the final view stays in the terminal scrollback")
+	fixtures := flag.String("fixtures", "", "serve API responses from recorded fixtures in `dir` instead of api.bitbucket.org")
+	repo := flag.String("repo", "", "open this repository directly, skipping the repository list")
+	view := flag.String("view", "", "tab to open with --repo: "+strings.Join(tui.StartViewNames(), ", "))
+	profileName := flag.String("profile", "", "use this profile from the config file instead of the default")
+	noGit := flag.Bool("no-git", false, "ignore the git clone in the current directory: do not open its repository or highlight its branch")
+	workspace := flag.String("workspace", "", "open this workspace, with its own profile or the credentials of --profile or the default profile")
+	webhookAddr := flag.String("webhook", "", "listen for Bitbucket webhooks on `addr`, e.g. :8088, and refresh the open view when they arrive")
+	accessible := flag.Bool("accessible", false, "render plain text for screen readers: no color, borders or box drawing (same as accessible = true in the config)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] [[workspace/]repo]\n", filepath.Base(os.Args[0]))
+		fmt.Fprintf(flag.CommandLine.Output(), "       %s [--profile NAME] [--workspace SLUG] <command> [flags]\n", filepath.Base(os.Args[0]))
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() > 0 && cli.IsCommand(flag.Arg(0)) {
+		os.Exit(cli.Run(flag.Args(), cli.Options{Profile: *profileName, Workspace: *workspace}, os.Stdout, os.Stderr))
+	}
+
+	start, err := parseStartTarget(*repo, *view, *workspace, flag.Args())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if *fixtures != "" {
+		runWithFixtures(*fixtures, start, *inline, *accessible, *webhookAddr)
+		return
+	}
+
 	configFile, err := config.LoadConfig()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "failed to load config: %v\n", err)
@@ -18,14 +60,26 @@ func main() {
 		os.Exit(1)
 	}
 
-	var selectedWorkspace string
-	var selectedConfig config.Config
+	// Started inside a clone of a configured workspace, open its repository
+	// on the checked-out branch, and highlight that branch wherever it shows.
+	var clone gitrepo.Clone
+	var inClone bool
+	if !*noGit {
+		clone, inClone = gitrepo.Detect(".")
+	}
+	profileWorkspace := start.workspace
+	if inClone && start.repo == "" && profileWorkspace == "" && *profileName == "" {
+		if _, ok := configFile.ProfileForWorkspace(clone.Workspace); ok {
+			profileWorkspace = clone.Workspace
+		}
+	}
 
-	defaultProfile, err := configFile.GetDefaultProfile()
-	if err == nil {
-		selectedWorkspace = defaultProfile.Workspace
-		selectedConfig = config.FromProfile(defaultProfile)
-	} else {
+	var selectedConfig config.Config
+	profile, err := configFile.ResolveProfile(*profileName, profileWorkspace)
+	switch {
+	case err == nil:
+		selectedConfig = config.FromProfile(profile)
+	case errors.Is(err, config.ErrNoDefaultProfile):
 		m := tui.NewWorkspaceSelector(configFile)
 		p := tea.NewProgram(m)
 		finalModel, err := p.Run()
@@ -40,12 +94,129 @@ func main() {
 			os.Exit(0)
 		}
 
-		selectedWorkspace = model.SelectedConfig().Workspace
 		selectedConfig = model.SelectedConfig()
+		// A workspace without a profile of its own is opened with the
+		// credentials of the chosen profile.
+		if start.workspace != "" {
+			selectedConfig.Workspace = start.workspace
+		}
+	default:
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if *accessible {
+		selectedConfig.Accessible = true
 	}
 
-	app := tui.NewApp(selectedWorkspace, selectedConfig)
-	p := tea.NewProgram(app, tea.WithAltScreen())
+	// A profile using the OAuth authorization code flow is approved in the
+	// browser once; its stored refresh token keeps it signed in after that.
+	if oauth := selectedConfig.OAuth; oauth != nil && bitbucket.NeedsOAuthLogin(*oauth) {
+		if err := bitbucket.LoginOAuth(context.Background(), *oauth, os.Stderr); err != nil {
+			fmt.Fprintf(os.Stderr, "OAuth login failed: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	inWorkspace := inClone && strings.EqualFold(clone.Workspace, selectedConfig.Workspace)
+	if inWorkspace && start.repo == "" {
+		start.repo, start.branch = clone.Repo, clone.Branch
+	}
+
+	app := newApp(selectedConfig.Workspace, selectedConfig, start)
+	if inWorkspace {
+		app = app.WithLocalClone(clone)
+	}
+	runApp(app, *inline, *webhookAddr, selectedConfig.WebhookSecret)
+}
+
+// startTarget is where the app opens, from --workspace/--repo/--view or the
+// positional [workspace/]repo argument.
+type startTarget struct {
+	workspace string
+	repo      string
+	view      string
+	// branch is selected in the opened tab; it is the checked-out branch
+	// when the repository was detected from the current directory.
+	branch string
+}
+
+func parseStartTarget(repo, view, workspace string, args []string) (startTarget, error) {
+	target := startTarget{workspace: workspace, repo: repo, view: view}
+	switch {
+	case len(args) > 1:
+		return target, fmt.Errorf("expected at most one [workspace/]repo argument, got %d", len(args))
+	case len(args) == 1:
+		if repo != "" {
+			return target, fmt.Errorf("give the repository either with --repo or as an argument, not both")
+		}
+		if workspace, slug, ok := strings.Cut(args[0], "/"); ok {
+			target.workspace, target.repo = workspace, slug
+		} else {
+			target.repo = args[0]
+		}
+		if target.repo == "" || strings.Contains(target.repo, "/") {
+			return target, fmt.Errorf("invalid repository %q, expected repo or workspace/repo", args[0])
+		}
+	}
+	return target, nil
+}
+
+// newApp builds the app and opens the start target, if any.
+func newApp(workspace string, cfg config.Config, start startTarget) tui.AppModel {
+	app := tui.NewApp(workspace, cfg)
+	if start.repo == "" {
+		if start.view != "" {
+			fmt.Fprintln(os.Stderr, "--view needs a repository: give --repo or start inside a git clone")
+			os.Exit(2)
+		}
+		return app
+	}
+	app, err := app.OpenAt(start.repo, start.view)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if start.branch != "" {
+		app = app.FocusBranch(start.branch)
+	}
+	return app
+}
+
+// runWithFixtures starts the app against a local fixture server, without
+// reading the config file or talking to Bitbucket.
+func runWithFixtures(dir string, start startTarget, inline, accessible bool, webhookAddr string) {
+	workspace := fake.FixtureWorkspace(dir)
+	if workspace == "" {
+		fmt.Fprintf(os.Stderr, "no single workspace found under %s\n", filepath.Join(dir, "repositories"))
+		os.Exit(1)
+	}
+
+	server := fake.NewFixtureServer(dir)
+	defer server.Close()
+
+	cfg := config.FromProfile(config.Profile{Name: "fixtures", Workspace: workspace, Accessible: accessible}).
+		WithBaseURL(server.URL + fake.APIPrefix)
+	runApp(newApp(workspace, cfg, start), inline, webhookAddr, "")
+}
+
+// runApp runs the app until it quits. With webhookAddr set, webhook
+// deliveries are passed to the app as live updates.
+func runApp(app tui.AppModel, inline bool, webhookAddr, webhookSecret string) {
+	var options []tea.ProgramOption
+	if !inline {
+		options = append(options, tea.WithAltScreen())
+	}
+	p := tea.NewProgram(app, options...)
+	if webhookAddr != "" {
+		err := webhook.Listen(webhookAddr, webhookSecret, func(ev webhook.Event) {
+			p.Send(tui.LiveUpdate(ev.Kind, ev.Workspace, ev.Repo))
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to start webhook listener: %v\n", err)
+			os.Exit(1)
+		}
+	}
 	if _, err := p.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error running app: %v\n", err)
 		os.Exit(1)